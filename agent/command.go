@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+)
+
+// commandLine is a single line of output from a running command
+type commandLine struct {
+	stream string // stdout, stderr or the name of an extra pipe
+	text   string
+}
+
+// extraPipe is an additional output pipe handed to a child process as a file
+// descriptor, e.g. for apt's APT::Status-Fd
+type extraPipe struct {
+	name string
+	r, w *os.File
+}
+
+// newExtraPipe creates a pipe whose lines are reported under name
+func newExtraPipe(name string) (*extraPipe, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	return &extraPipe{name: name, r: r, w: w}, nil
+}
+
+// runCommandLines starts cmd and calls onLine for every line written to
+// stdout or stderr, in the order they arrive. Extra pipes are passed to the
+// child as file descriptors 3, 4, ... in order and their lines are reported
+// the same way. If onLine returns an error the process is killed and that
+// error is returned. Otherwise the result of cmd.Wait is returned.
+func runCommandLines(cmd *exec.Cmd, onLine func(stream, text string) error, extra ...*extraPipe) error {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	for _, p := range extra {
+		cmd.ExtraFiles = append(cmd.ExtraFiles, p.w)
+	}
+
+	err = cmd.Start()
+
+	// The child holds its own copies of the write ends now
+	for _, p := range extra {
+		p.w.Close()
+	}
+	if err != nil {
+		for _, p := range extra {
+			p.r.Close()
+		}
+		return err
+	}
+
+	lines := make(chan commandLine)
+	var wg sync.WaitGroup
+	scan := func(r io.Reader, stream string) {
+		defer wg.Done()
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			lines <- commandLine{stream: stream, text: scanner.Text()}
+		}
+	}
+	wg.Add(2 + len(extra))
+	go scan(stdout, "stdout")
+	go scan(stderr, "stderr")
+	for _, p := range extra {
+		go func(p *extraPipe) {
+			defer p.r.Close()
+			scan(p.r, p.name)
+		}(p)
+	}
+	go func() {
+		wg.Wait()
+		close(lines)
+	}()
+
+	for line := range lines {
+		if err := onLine(line.stream, line.text); err != nil {
+			cmd.Process.Kill()
+			// Drain remaining output so the scanners can exit
+			for range lines {
+			}
+			cmd.Wait()
+			return err
+		}
+	}
+
+	return cmd.Wait()
+}
@@ -0,0 +1,483 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/net/ipv4"
+	"google.golang.org/protobuf/proto"
+
+	pb "pi_agent/proto"
+)
+
+// Device discovery settings
+const (
+	defaultDiscoveryTimeout = 10 * time.Second
+	maxDiscoveryTimeout     = 60 * time.Second
+	minSweepPrefixLen       = 22 // Larger subnets only sweep the /24 around our address
+	sweepInterval           = 2 * time.Millisecond
+	neighborPollInterval    = time.Second
+	procNetARP              = "/proc/net/arp"
+)
+
+var (
+	mdnsGroup = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+	ssdpGroup = &net.UDPAddr{IP: net.IPv4(239, 255, 255, 250), Port: 1900}
+)
+
+// mdnsQueryNames are browsed with one mDNS query; responders add their
+// address records, which give the hostname
+var mdnsQueryNames = []string{
+	"_services._dns-sd._udp.local.",
+	"_workstation._tcp.local.",
+	"_device-info._tcp.local.",
+	"_http._tcp.local.",
+	"_ssh._tcp.local.",
+	"_smb._tcp.local.",
+	"_ipp._tcp.local.",
+	"_printer._tcp.local.",
+	"_googlecast._tcp.local.",
+	"_airplay._tcp.local.",
+	"_hap._tcp.local.",
+}
+
+// deviceSighting is one piece of evidence that a device exists
+type deviceSighting struct {
+	ip          string
+	mac         net.HardwareAddr
+	hostname    string
+	source      string // neighbor, icmp, mdns, ssdp; empty for lookups
+	services    []string
+	description string
+}
+
+// deviceTable remembers devices across discoveries so first_seen means the
+// first time the agent saw a device
+type deviceTable struct {
+	mu      sync.Mutex
+	devices map[string]*pb.DiscoveredDevice // By IP address
+}
+
+func newDeviceTable() *deviceTable {
+	return &deviceTable{devices: make(map[string]*pb.DiscoveredDevice)}
+}
+
+// record merges a sighting seen on iface and returns a copy of the device,
+// and whether anything other than last_seen changed
+func (t *deviceTable) record(s deviceSighting, iface string, now time.Time) (*pb.DiscoveredDevice, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	d, ok := t.devices[s.ip]
+	changed := !ok
+	if !ok {
+		d = &pb.DiscoveredDevice{Ip: s.ip, FirstSeen: now.Unix()}
+		t.devices[s.ip] = d
+	}
+	d.LastSeen = now.Unix()
+
+	if s.mac != nil && s.mac.String() != d.Mac {
+		d.Mac = s.mac.String()
+		d.Vendor = macVendor(s.mac)
+		changed = true
+	}
+	if d.Interface != iface {
+		d.Interface = iface
+		changed = true
+	}
+	// Names a device announces itself win over reverse DNS
+	if s.hostname != "" && s.hostname != d.Hostname && (d.Hostname == "" || s.source == "mdns") {
+		d.Hostname = s.hostname
+		changed = true
+	}
+	if s.source != "" && !slices.Contains(d.Sources, s.source) {
+		d.Sources = append(d.Sources, s.source)
+		changed = true
+	}
+	for _, service := range s.services {
+		if !slices.Contains(d.Services, service) {
+			d.Services = append(d.Services, service)
+			changed = true
+		}
+	}
+	if s.description != "" && s.description != d.Description {
+		d.Description = s.description
+		changed = true
+	}
+	return proto.Clone(d).(*pb.DiscoveredDevice), changed
+}
+
+// discoveryInterface is a local IPv4 address and its subnet
+type discoveryInterface struct {
+	iface  net.Interface
+	addr   net.IP
+	subnet *net.IPNet
+}
+
+// discoveryInterfaces returns the IPv4 subnets of the up, non-loopback
+// interfaces, or of the named interface only
+func discoveryInterfaces(name string) ([]discoveryInterface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	var result []discoveryInterface
+	for _, iface := range ifaces {
+		if name != "" && iface.Name != name {
+			continue
+		}
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipnet, ok := addr.(*net.IPNet)
+			if !ok || ipnet.IP.To4() == nil {
+				continue
+			}
+			result = append(result, discoveryInterface{
+				iface:  iface,
+				addr:   ipnet.IP.To4(),
+				subnet: &net.IPNet{IP: ipnet.IP.Mask(ipnet.Mask).To4(), Mask: ipnet.Mask[len(ipnet.Mask)-4:]},
+			})
+		}
+	}
+	if len(result) == 0 {
+		if name != "" {
+			return nil, fmt.Errorf("interface %s not found or has no IPv4 address", name)
+		}
+		return nil, fmt.Errorf("no interface with an IPv4 address")
+	}
+	return result, nil
+}
+
+// interfaceFor returns the name of the interface whose subnet contains ip
+func interfaceFor(ifaces []discoveryInterface, ip net.IP) string {
+	for _, di := range ifaces {
+		if di.subnet.Contains(ip) {
+			return di.iface.Name
+		}
+	}
+	return ""
+}
+
+// sweepTargets returns the host addresses of the subnets, without our own
+func sweepTargets(ifaces []discoveryInterface) []net.IP {
+	seen := make(map[string]bool)
+	for _, di := range ifaces {
+		seen[di.addr.String()] = true
+	}
+
+	var targets []net.IP
+	for _, di := range ifaces {
+		subnet := di.subnet
+		if ones, _ := subnet.Mask.Size(); ones < minSweepPrefixLen {
+			mask := net.CIDRMask(24, 32)
+			subnet = &net.IPNet{IP: di.addr.Mask(mask), Mask: mask}
+		}
+		if ones, bits := subnet.Mask.Size(); bits-ones < 2 {
+			continue // Point-to-point links
+		}
+		// Skip the network and broadcast addresses
+		for ip := nextIP(subnet.IP); subnet.Contains(nextIP(ip)); ip = nextIP(ip) {
+			if !seen[ip.String()] {
+				seen[ip.String()] = true
+				targets = append(targets, ip)
+			}
+		}
+	}
+	return targets
+}
+
+// sendSighting delivers a sighting unless discovery has ended
+func sendSighting(ctx context.Context, sightings chan<- deviceSighting, s deviceSighting) bool {
+	select {
+	case sightings <- s:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// readARPTable returns the complete entries of the kernel's IPv4 neighbor
+// table
+func readARPTable() []deviceSighting {
+	f, err := os.Open(procNetARP)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var entries []deviceSighting
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // Header
+	for scanner.Scan() {
+		// IP address  HW type  Flags  HW address  Mask  Device
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 {
+			continue
+		}
+		flags, err := strconv.ParseUint(strings.TrimPrefix(fields[2], "0x"), 16, 32)
+		if err != nil || flags&0x2 == 0 {
+			continue // Incomplete: nobody answered
+		}
+		mac, err := net.ParseMAC(fields[3])
+		if err != nil || slices.Equal(mac, make(net.HardwareAddr, len(mac))) {
+			continue
+		}
+		entries = append(entries, deviceSighting{ip: fields[0], mac: mac, source: "neighbor"})
+	}
+	return entries
+}
+
+// pollNeighbors reports the neighbor table now and as the sweep fills it
+func pollNeighbors(ctx context.Context, sightings chan<- deviceSighting) {
+	ticker := time.NewTicker(neighborPollInterval)
+	defer ticker.Stop()
+	for {
+		for _, entry := range readARPTable() {
+			if !sendSighting(ctx, sightings, entry) {
+				return
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sweepICMP pings every target. Each probe also makes the kernel resolve
+// the address with ARP, so hosts that ignore pings still reach the neighbor
+// table. Without ICMP sockets an empty UDP datagram triggers the same ARP.
+func sweepICMP(ctx context.Context, targets []net.IP, sightings chan<- deviceSighting) {
+	conn, err := listenICMP(net.IPv4zero)
+	if err != nil {
+		log.Printf("ICMP sweep unavailable, using UDP to trigger ARP: %v", err)
+		sweepUDP(ctx, targets)
+		return
+	}
+	// Replies are read until discovery ends, after the last probe went out
+	defer conn.Close()
+	go func() {
+		for {
+			reply, err := conn.read(time.Time{})
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				continue
+			}
+			if reply.msgType != ipv4.ICMPTypeEchoReply || (conn.raw && reply.id != conn.id) {
+				continue
+			}
+			if !sendSighting(ctx, sightings, deviceSighting{ip: reply.from.String(), source: "icmp"}) {
+				return
+			}
+		}
+	}()
+
+	for i, ip := range targets {
+		conn.sendEcho(ip, i, 0)
+		select {
+		case <-time.After(sweepInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+	<-ctx.Done()
+}
+
+// sweepUDP sends an empty datagram to the discard port of every target
+func sweepUDP(ctx context.Context, targets []net.IP) {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	for _, ip := range targets {
+		conn.WriteTo(nil, &net.UDPAddr{IP: ip, Port: 9})
+		select {
+		case <-time.After(sweepInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// listenMDNS sends one mDNS browse query on each interface and reports
+// everything that speaks mDNS until ctx ends
+func listenMDNS(ctx context.Context, ifaces []discoveryInterface, sightings chan<- deviceSighting) {
+	conn, err := net.ListenMulticastUDP("udp4", nil, mdnsGroup)
+	if err != nil {
+		log.Printf("mDNS discovery unavailable: %v", err)
+		return
+	}
+	stopClose := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stopClose()
+
+	pc := ipv4.NewPacketConn(conn)
+	for _, di := range ifaces {
+		// Fails harmlessly for interfaces already joined
+		pc.JoinGroup(&di.iface, mdnsGroup)
+	}
+	if query, err := mdnsQuery(); err == nil {
+		for _, di := range ifaces {
+			pc.SetMulticastInterface(&di.iface)
+			conn.WriteToUDP(query, mdnsGroup)
+		}
+	}
+
+	buf := make([]byte, 9000)
+	for {
+		n, src, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		if !sendSighting(ctx, sightings, parseMDNS(buf[:n], src.IP)) {
+			return
+		}
+	}
+}
+
+func mdnsQuery() ([]byte, error) {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{})
+	if err := builder.StartQuestions(); err != nil {
+		return nil, err
+	}
+	for _, name := range mdnsQueryNames {
+		question := dnsmessage.Question{Name: dnsmessage.MustNewName(name), Type: dnsmessage.TypePTR, Class: dnsmessage.ClassINET}
+		if err := builder.Question(question); err != nil {
+			return nil, err
+		}
+	}
+	return builder.Finish()
+}
+
+// parseMDNS extracts the hostname and service types from an mDNS response.
+// Any mDNS packet, even a query, shows the sender is present.
+func parseMDNS(data []byte, src net.IP) deviceSighting {
+	s := deviceSighting{ip: src.String(), source: "mdns"}
+	var msg dnsmessage.Message
+	if err := msg.Unpack(data); err != nil || !msg.Response {
+		return s
+	}
+
+	trim := func(n dnsmessage.Name) string {
+		return strings.TrimSuffix(n.String(), ".")
+	}
+	var srvTarget string
+	for _, r := range append(msg.Answers, msg.Additionals...) {
+		name := trim(r.Header.Name)
+		switch body := r.Body.(type) {
+		case *dnsmessage.AResource:
+			if net.IP(body.A[:]).Equal(src) {
+				s.hostname = name
+			}
+		case *dnsmessage.SRVResource:
+			if srvTarget == "" {
+				srvTarget = trim(body.Target)
+			}
+		case *dnsmessage.PTRResource:
+			switch {
+			case strings.HasSuffix(name, ".arpa"):
+				// Reverse mapping of the responder's address
+				if s.hostname == "" {
+					s.hostname = trim(body.PTR)
+				}
+			case name == "_services._dns-sd._udp.local":
+				s.services = appendService(s.services, trim(body.PTR))
+			default:
+				s.services = appendService(s.services, name)
+			}
+		}
+	}
+	if s.hostname == "" {
+		s.hostname = srvTarget
+	}
+	return s
+}
+
+// appendService adds a service type like "_ipp._tcp.local" as "_ipp._tcp"
+func appendService(services []string, name string) []string {
+	service := strings.TrimSuffix(name, ".local")
+	if !strings.HasPrefix(service, "_") || slices.Contains(services, service) {
+		return services
+	}
+	return append(services, service)
+}
+
+// listenSSDP searches for UPnP devices and listens for their NOTIFY
+// announcements until ctx ends
+func listenSSDP(ctx context.Context, ifaces []discoveryInterface, sightings chan<- deviceSighting) {
+	var conns []*net.UDPConn
+	if notify, err := net.ListenMulticastUDP("udp4", nil, ssdpGroup); err == nil {
+		pc := ipv4.NewPacketConn(notify)
+		for _, di := range ifaces {
+			pc.JoinGroup(&di.iface, ssdpGroup)
+		}
+		conns = append(conns, notify)
+	}
+	// Answers to M-SEARCH come back to the sending socket
+	if search, err := net.ListenUDP("udp4", &net.UDPAddr{}); err == nil {
+		pc := ipv4.NewPacketConn(search)
+		for _, di := range ifaces {
+			pc.SetMulticastInterface(&di.iface)
+			search.WriteToUDP(udpProbePayloads[ssdpGroup.Port], ssdpGroup)
+		}
+		conns = append(conns, search)
+	}
+	if len(conns) == 0 {
+		log.Printf("SSDP discovery unavailable")
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, conn := range conns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, 4096)
+			for {
+				n, src, err := conn.ReadFromUDP(buf)
+				if err != nil {
+					return
+				}
+				if !sendSighting(ctx, sightings, parseSSDP(buf[:n], src.IP)) {
+					return
+				}
+			}
+		}()
+	}
+	<-ctx.Done()
+	for _, conn := range conns {
+		conn.Close()
+	}
+	wg.Wait()
+}
+
+// parseSSDP reads the SERVER header of an M-SEARCH answer or NOTIFY
+func parseSSDP(data []byte, src net.IP) deviceSighting {
+	s := deviceSighting{ip: src.String(), source: "ssdp"}
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if found && strings.EqualFold(strings.TrimSpace(key), "SERVER") {
+			s.description = strings.TrimSpace(value)
+		}
+	}
+	return s
+}
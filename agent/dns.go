@@ -0,0 +1,380 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	pb "pi_agent/proto"
+)
+
+// DNS client settings
+const (
+	dnsTimeout     = 5 * time.Second
+	dnsUDPSize     = 4096 // Advertised with EDNS0
+	resolvConf     = "/etc/resolv.conf"
+	typeCAA        = dnsmessage.Type(257)
+	defaultDNSType = "A"
+)
+
+// dnsRecordTypes are the record types DNSLookup accepts
+var dnsRecordTypes = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"MX":    dnsmessage.TypeMX,
+	"TXT":   dnsmessage.TypeTXT,
+	"NS":    dnsmessage.TypeNS,
+	"SRV":   dnsmessage.TypeSRV,
+	"PTR":   dnsmessage.TypePTR,
+	"SOA":   dnsmessage.TypeSOA,
+	"CAA":   typeCAA,
+}
+
+// dnsRecordTypeName returns the mnemonic for a record type
+func dnsRecordTypeName(t dnsmessage.Type) string {
+	for name, typ := range dnsRecordTypes {
+		if typ == t {
+			return name
+		}
+	}
+	return strings.TrimPrefix(t.String(), "Type")
+}
+
+// dnsRCodeNames are the response codes as dig prints them
+var dnsRCodeNames = map[dnsmessage.RCode]string{
+	dnsmessage.RCodeSuccess:        "NOERROR",
+	dnsmessage.RCodeFormatError:    "FORMERR",
+	dnsmessage.RCodeServerFailure:  "SERVFAIL",
+	dnsmessage.RCodeNameError:      "NXDOMAIN",
+	dnsmessage.RCodeNotImplemented: "NOTIMP",
+	dnsmessage.RCodeRefused:        "REFUSED",
+}
+
+func dnsRCodeName(rcode dnsmessage.RCode) string {
+	if name, ok := dnsRCodeNames[rcode]; ok {
+		return name
+	}
+	return strconv.Itoa(int(rcode))
+}
+
+// resolvConfig holds the parts of /etc/resolv.conf a lookup uses
+type resolvConfig struct {
+	nameserver string // First nameserver
+	search     []string
+	ndots      int
+}
+
+// readResolvConf reads the system resolver configuration
+func readResolvConf() (*resolvConfig, error) {
+	f, err := os.Open(resolvConf)
+	if err != nil {
+		return nil, fmt.Errorf("no resolver given and %s is unreadable: %v", resolvConf, err)
+	}
+	defer f.Close()
+
+	conf := &resolvConfig{ndots: 1}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "nameserver":
+			// Drop an IPv6 zone, which resolv.conf allows
+			if conf.nameserver == "" {
+				conf.nameserver = strings.Split(fields[1], "%")[0]
+			}
+		case "search", "domain":
+			// The last search or domain line wins
+			conf.search = fields[1:]
+			if fields[0] == "domain" {
+				conf.search = fields[1:2]
+			}
+		case "options":
+			for _, option := range fields[1:] {
+				if value, found := strings.CutPrefix(option, "ndots:"); found {
+					if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+						conf.ndots = n
+					}
+				}
+			}
+		}
+	}
+	if conf.nameserver == "" {
+		return nil, fmt.Errorf("no nameserver in %s", resolvConf)
+	}
+	return conf, nil
+}
+
+// searchNames lists the fully qualified names to try for name in the order
+// the system resolver does: names with fewer than ndots dots go through the
+// search list first, others are tried as given first
+func (c *resolvConfig) searchNames(name string) []string {
+	if strings.HasSuffix(name, ".") || len(c.search) == 0 {
+		return []string{dnsQueryName(name, dnsmessage.TypeA)}
+	}
+
+	absolute := name + "."
+	var names []string
+	if strings.Count(name, ".") >= c.ndots {
+		names = append(names, absolute)
+	}
+	for _, domain := range c.search {
+		names = append(names, name+"."+strings.TrimSuffix(domain, ".")+".")
+	}
+	if strings.Count(name, ".") < c.ndots {
+		names = append(names, absolute)
+	}
+	return names
+}
+
+// resolverAddress adds the default port to a resolver address
+func resolverAddress(resolver string) (string, error) {
+	if _, _, err := net.SplitHostPort(resolver); err == nil {
+		return resolver, nil
+	}
+	host := strings.Trim(resolver, "[]")
+	if net.ParseIP(host) == nil {
+		return "", fmt.Errorf("resolver must be an IP address, got %q", resolver)
+	}
+	return net.JoinHostPort(host, "53"), nil
+}
+
+// lookupSystemAddresses answers an A or AAAA lookup through the system
+// resolver, which also reads /etc/hosts, nsswitch.conf and the search list.
+// It does not report TTLs.
+func lookupSystemAddresses(ctx context.Context, response *pb.DNSResponse, name string, qtype dnsmessage.Type) {
+	network, typeName := "ip4", "A"
+	if qtype == dnsmessage.TypeAAAA {
+		network, typeName = "ip6", "AAAA"
+	}
+	response.Server = "system"
+
+	ctx, cancel := context.WithTimeout(ctx, dnsTimeout)
+	defer cancel()
+	startTime := time.Now()
+	ips, err := net.DefaultResolver.LookupIP(ctx, network, name)
+	response.QueryTime = time.Since(startTime).Seconds() * 1000 // Convert to milliseconds
+
+	var dnsErr *net.DNSError
+	switch {
+	case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		response.Rcode = dnsRCodeName(dnsmessage.RCodeNameError)
+		response.Error = fmt.Sprintf("DNS lookup failed: %s", response.Rcode)
+		return
+	case err != nil:
+		response.Error = fmt.Sprintf("DNS lookup failed: %v", err)
+		return
+	}
+
+	response.Rcode = dnsRCodeName(dnsmessage.RCodeSuccess)
+	for _, ip := range ips {
+		response.Addresses = append(response.Addresses, ip.String())
+		response.Records = append(response.Records, &pb.DNSRecord{
+			Name:  strings.TrimSuffix(name, "."),
+			Type:  typeName,
+			Value: ip.String(),
+		})
+	}
+	response.Success = true
+}
+
+// dnsQueryName returns the fully qualified name to query. PTR lookups of an
+// IP address are turned into the in-addr.arpa or ip6.arpa name.
+func dnsQueryName(name string, qtype dnsmessage.Type) string {
+	if ip := net.ParseIP(name); ip != nil && qtype == dnsmessage.TypePTR {
+		if ip4 := ip.To4(); ip4 != nil {
+			return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa.", ip4[3], ip4[2], ip4[1], ip4[0])
+		}
+		var b strings.Builder
+		for i := len(ip) - 1; i >= 0; i-- {
+			fmt.Fprintf(&b, "%x.%x.", ip[i]&0x0f, ip[i]>>4)
+		}
+		return b.String() + "ip6.arpa."
+	}
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+	return name
+}
+
+// queryDNS sends one question to server over UDP, retrying over TCP when the
+// answer is truncated
+func queryDNS(ctx context.Context, server, name string, qtype dnsmessage.Type) (*dnsmessage.Message, error) {
+	qname, err := dnsmessage.NewName(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name %q: %v", name, err)
+	}
+
+	id := uint16(rand.Intn(0x10000))
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, RecursionDesired: true})
+	builder.EnableCompression()
+	question := dnsmessage.Question{Name: qname, Type: qtype, Class: dnsmessage.ClassINET}
+	if err := builder.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := builder.Question(question); err != nil {
+		return nil, err
+	}
+	if err := builder.StartAdditionals(); err != nil {
+		return nil, err
+	}
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(dnsUDPSize, dnsmessage.RCodeSuccess, false); err != nil {
+		return nil, err
+	}
+	if err := builder.OPTResource(opt, dnsmessage.OPTResource{}); err != nil {
+		return nil, err
+	}
+	query, err := builder.Finish()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dnsTimeout)
+	defer cancel()
+
+	msg, err := exchangeDNS(ctx, "udp", server, query, id, question)
+	if err == nil && msg.Truncated {
+		msg, err = exchangeDNS(ctx, "tcp", server, query, id, question)
+	}
+	return msg, err
+}
+
+// exchangeDNS sends a query and waits for the matching response
+func exchangeDNS(ctx context.Context, network, server string, query []byte, id uint16, question dnsmessage.Question) (*dnsmessage.Message, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if network == "tcp" {
+		// TCP messages carry a two byte length prefix
+		framed := make([]byte, 2+len(query))
+		binary.BigEndian.PutUint16(framed, uint16(len(query)))
+		copy(framed[2:], query)
+		if _, err := conn.Write(framed); err != nil {
+			return nil, err
+		}
+		var length [2]byte
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			return nil, err
+		}
+		buf := make([]byte, binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			return nil, err
+		}
+		return parseDNSResponse(buf, id, question)
+	}
+
+	if _, err := conn.Write(query); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 65535)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		// Ignore stray or spoofed datagrams and keep waiting
+		if msg, err := parseDNSResponse(buf[:n], id, question); err == nil {
+			return msg, nil
+		}
+	}
+}
+
+// parseDNSResponse parses a response and checks it answers the query
+func parseDNSResponse(data []byte, id uint16, question dnsmessage.Question) (*dnsmessage.Message, error) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(data); err != nil {
+		return nil, err
+	}
+	if !msg.Response || msg.ID != id {
+		return nil, fmt.Errorf("response does not match query")
+	}
+	if len(msg.Questions) > 0 {
+		q := msg.Questions[0]
+		if q.Type != question.Type || !strings.EqualFold(q.Name.String(), question.Name.String()) {
+			return nil, fmt.Errorf("response is for a different question")
+		}
+	}
+	return &msg, nil
+}
+
+// dnsRecordFromResource formats an answer like dig does. ok is false for
+// resource types that are not reported (OPT, RRSIG, ...).
+func dnsRecordFromResource(r dnsmessage.Resource) (*pb.DNSRecord, bool) {
+	record := &pb.DNSRecord{
+		Name: strings.TrimSuffix(r.Header.Name.String(), "."),
+		Type: dnsRecordTypeName(r.Header.Type),
+		Ttl:  int32(r.Header.TTL),
+	}
+	trim := func(n dnsmessage.Name) string {
+		return strings.TrimSuffix(n.String(), ".")
+	}
+
+	switch body := r.Body.(type) {
+	case *dnsmessage.AResource:
+		record.Value = net.IP(body.A[:]).String()
+	case *dnsmessage.AAAAResource:
+		record.Value = net.IP(body.AAAA[:]).String()
+	case *dnsmessage.CNAMEResource:
+		record.Value = trim(body.CNAME)
+	case *dnsmessage.NSResource:
+		record.Value = trim(body.NS)
+	case *dnsmessage.PTRResource:
+		record.Value = trim(body.PTR)
+	case *dnsmessage.MXResource:
+		record.Value = trim(body.MX)
+		record.Priority = int32(body.Pref)
+	case *dnsmessage.TXTResource:
+		record.Value = strings.Join(body.TXT, "")
+	case *dnsmessage.SRVResource:
+		record.Value = fmt.Sprintf("%d %d %d %s", body.Priority, body.Weight, body.Port, trim(body.Target))
+		record.Priority = int32(body.Priority)
+	case *dnsmessage.SOAResource:
+		record.Value = fmt.Sprintf("%s %s %d %d %d %d %d", trim(body.NS), trim(body.MBox),
+			body.Serial, body.Refresh, body.Retry, body.Expire, body.MinTTL)
+	case *dnsmessage.UnknownResource:
+		if body.Type != typeCAA {
+			return nil, false
+		}
+		value, ok := formatCAA(body.Data)
+		if !ok {
+			return nil, false
+		}
+		record.Value = value
+	default:
+		return nil, false
+	}
+	return record, true
+}
+
+// formatCAA formats CAA record data (RFC 8659) as `0 issue "ca.example"`
+func formatCAA(data []byte) (string, bool) {
+	if len(data) < 2 || len(data) < 2+int(data[1]) {
+		return "", false
+	}
+	flags, tagLen := data[0], int(data[1])
+	tag := string(data[2 : 2+tagLen])
+	value := string(data[2+tagLen:])
+	return fmt.Sprintf("%d %s %q", flags, tag, value), true
+}
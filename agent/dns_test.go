@@ -0,0 +1,304 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"google.golang.org/protobuf/proto"
+
+	pb "pi_agent/proto"
+)
+
+// fakeDNSServer answers queries with canned records on a UDP and a TCP
+// socket sharing one local port
+type fakeDNSServer struct {
+	addr       string
+	mu         sync.Mutex // Guards answers and truncated, which tests fill while it serves
+	answers    map[dnsmessage.Question][]dnsmessage.Resource
+	truncated  map[string]bool // Names whose UDP answer only sets TC
+	tcpQueries atomic.Int32
+}
+
+func startFakeDNSServer(t *testing.T) *fakeDNSServer {
+	t.Helper()
+	var udp net.PacketConn
+	var tcp net.Listener
+	for attempt := 0; tcp == nil; attempt++ {
+		var err error
+		if udp, err = net.ListenPacket("udp", "127.0.0.1:0"); err != nil {
+			t.Fatal(err)
+		}
+		if tcp, err = net.Listen("tcp", udp.LocalAddr().String()); err != nil {
+			udp.Close()
+			if attempt == 10 {
+				t.Fatal(err)
+			}
+		}
+	}
+	t.Cleanup(func() {
+		udp.Close()
+		tcp.Close()
+	})
+
+	s := &fakeDNSServer{
+		addr:      udp.LocalAddr().String(),
+		answers:   make(map[dnsmessage.Question][]dnsmessage.Resource),
+		truncated: make(map[string]bool),
+	}
+	go func() {
+		buf := make([]byte, 65535)
+		for {
+			n, peer, err := udp.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if reply := s.reply(buf[:n], true); reply != nil {
+				udp.WriteTo(reply, peer)
+			}
+		}
+	}()
+	go func() {
+		for {
+			conn, err := tcp.Accept()
+			if err != nil {
+				return
+			}
+			s.tcpQueries.Add(1)
+			var length [2]byte
+			if _, err := io.ReadFull(conn, length[:]); err == nil {
+				query := make([]byte, binary.BigEndian.Uint16(length[:]))
+				if _, err := io.ReadFull(conn, query); err == nil {
+					if reply := s.reply(query, false); reply != nil {
+						framed := binary.BigEndian.AppendUint16(nil, uint16(len(reply)))
+						conn.Write(append(framed, reply...))
+					}
+				}
+			}
+			conn.Close()
+		}
+	}()
+	return s
+}
+
+// add registers an answer; the question is taken from the resource header
+func (s *fakeDNSServer) add(r dnsmessage.Resource) {
+	q := dnsmessage.Question{Name: r.Header.Name, Type: r.Header.Type, Class: dnsmessage.ClassINET}
+	s.mu.Lock()
+	s.answers[q] = append(s.answers[q], r)
+	s.mu.Unlock()
+}
+
+// truncate makes UDP answers for name set only TC, forcing a TCP retry
+func (s *fakeDNSServer) truncate(name string) {
+	s.mu.Lock()
+	s.truncated[name] = true
+	s.mu.Unlock()
+}
+
+func (s *fakeDNSServer) reply(query []byte, udp bool) []byte {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(query); err != nil || len(msg.Questions) != 1 {
+		return nil
+	}
+	q := msg.Questions[0]
+	response := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: msg.ID, Response: true, RecursionDesired: true, RecursionAvailable: true},
+		Questions: msg.Questions,
+	}
+	s.mu.Lock()
+	switch {
+	case udp && s.truncated[q.Name.String()]:
+		response.Truncated = true
+	case s.answers[q] != nil:
+		response.Answers = s.answers[q]
+	default:
+		// CNAME chains: answer with the alias and the target's records
+		alias := dnsmessage.Question{Name: q.Name, Type: dnsmessage.TypeCNAME, Class: q.Class}
+		if cname := s.answers[alias]; cname != nil {
+			target := dnsmessage.Question{Name: cname[0].Body.(*dnsmessage.CNAMEResource).CNAME, Type: q.Type, Class: q.Class}
+			response.Answers = append(append(response.Answers, cname...), s.answers[target]...)
+		} else {
+			response.RCode = dnsmessage.RCodeNameError
+		}
+	}
+	s.mu.Unlock()
+	packed, err := response.Pack()
+	if err != nil {
+		return nil
+	}
+	return packed
+}
+
+func dnsHeader(name string, typ dnsmessage.Type, ttl uint32) dnsmessage.ResourceHeader {
+	return dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Type: typ, Class: dnsmessage.ClassINET, TTL: ttl}
+}
+
+func TestDNSLookup(t *testing.T) {
+	s := startFakeDNSServer(t)
+	name := dnsmessage.MustNewName
+	s.add(dnsmessage.Resource{Header: dnsHeader("example.test.", dnsmessage.TypeA, 300),
+		Body: &dnsmessage.AResource{A: [4]byte{192, 0, 2, 10}}})
+	s.add(dnsmessage.Resource{Header: dnsHeader("example.test.", dnsmessage.TypeAAAA, 3600),
+		Body: &dnsmessage.AAAAResource{AAAA: [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 0x10}}})
+	s.add(dnsmessage.Resource{Header: dnsHeader("www.example.test.", dnsmessage.TypeCNAME, 60),
+		Body: &dnsmessage.CNAMEResource{CNAME: name("example.test.")}})
+	s.add(dnsmessage.Resource{Header: dnsHeader("example.test.", dnsmessage.TypeMX, 1800),
+		Body: &dnsmessage.MXResource{Pref: 10, MX: name("mail.example.test.")}})
+	s.add(dnsmessage.Resource{Header: dnsHeader("example.test.", dnsmessage.TypeMX, 1800),
+		Body: &dnsmessage.MXResource{Pref: 20, MX: name("backup.example.test.")}})
+	s.add(dnsmessage.Resource{Header: dnsHeader("example.test.", dnsmessage.TypeTXT, 120),
+		Body: &dnsmessage.TXTResource{TXT: []string{"v=spf1 ", "mx -all"}}})
+	s.add(dnsmessage.Resource{Header: dnsHeader("example.test.", dnsmessage.TypeNS, 86400),
+		Body: &dnsmessage.NSResource{NS: name("ns1.example.test.")}})
+	s.add(dnsmessage.Resource{Header: dnsHeader("_sip._tcp.example.test.", dnsmessage.TypeSRV, 600),
+		Body: &dnsmessage.SRVResource{Priority: 5, Weight: 60, Port: 5060, Target: name("sip.example.test.")}})
+	s.add(dnsmessage.Resource{Header: dnsHeader("10.2.0.192.in-addr.arpa.", dnsmessage.TypePTR, 900),
+		Body: &dnsmessage.PTRResource{PTR: name("example.test.")}})
+	s.add(dnsmessage.Resource{Header: dnsHeader("0.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", dnsmessage.TypePTR, 900),
+		Body: &dnsmessage.PTRResource{PTR: name("example.test.")}})
+	s.add(dnsmessage.Resource{Header: dnsHeader("example.test.", dnsmessage.TypeSOA, 3600),
+		Body: &dnsmessage.SOAResource{NS: name("ns1.example.test."), MBox: name("hostmaster.example.test."),
+			Serial: 2024061501, Refresh: 7200, Retry: 3600, Expire: 1209600, MinTTL: 300}})
+	s.add(dnsmessage.Resource{Header: dnsHeader("example.test.", typeCAA, 3600),
+		Body: &dnsmessage.UnknownResource{Type: typeCAA, Data: append([]byte{0, 5}, "issueletsencrypt.org"...)}})
+
+	// A truncated UDP answer is fetched again over TCP
+	s.truncate("big.example.test.")
+	var bigAddresses []string
+	var bigRecords []*pb.DNSRecord
+	for i := 1; i <= 40; i++ {
+		s.add(dnsmessage.Resource{Header: dnsHeader("big.example.test.", dnsmessage.TypeA, 30),
+			Body: &dnsmessage.AResource{A: [4]byte{198, 51, 100, byte(i)}}})
+		address := "198.51.100." + strconv.Itoa(i)
+		bigAddresses = append(bigAddresses, address)
+		bigRecords = append(bigRecords, &pb.DNSRecord{Name: "big.example.test", Type: "A", Value: address, Ttl: 30})
+	}
+
+	tests := []struct {
+		hostname, recordType string
+		want                 *pb.DNSResponse
+	}{
+		{"example.test", "", &pb.DNSResponse{
+			Addresses: []string{"192.0.2.10"},
+			Records:   []*pb.DNSRecord{{Name: "example.test", Type: "A", Value: "192.0.2.10", Ttl: 300}},
+		}},
+		{"example.test", "aaaa", &pb.DNSResponse{
+			Addresses: []string{"2001:db8::10"},
+			Records:   []*pb.DNSRecord{{Name: "example.test", Type: "AAAA", Value: "2001:db8::10", Ttl: 3600}},
+		}},
+		{"www.example.test", "A", &pb.DNSResponse{
+			Addresses: []string{"192.0.2.10"},
+			Records: []*pb.DNSRecord{
+				{Name: "www.example.test", Type: "CNAME", Value: "example.test", Ttl: 60},
+				{Name: "example.test", Type: "A", Value: "192.0.2.10", Ttl: 300},
+			},
+		}},
+		{"example.test", "MX", &pb.DNSResponse{
+			Addresses: []string{"mail.example.test", "backup.example.test"},
+			Records: []*pb.DNSRecord{
+				{Name: "example.test", Type: "MX", Value: "mail.example.test", Ttl: 1800, Priority: 10},
+				{Name: "example.test", Type: "MX", Value: "backup.example.test", Ttl: 1800, Priority: 20},
+			},
+		}},
+		{"example.test", "TXT", &pb.DNSResponse{
+			Addresses: []string{"v=spf1 mx -all"},
+			Records:   []*pb.DNSRecord{{Name: "example.test", Type: "TXT", Value: "v=spf1 mx -all", Ttl: 120}},
+		}},
+		{"example.test", "NS", &pb.DNSResponse{
+			Addresses: []string{"ns1.example.test"},
+			Records:   []*pb.DNSRecord{{Name: "example.test", Type: "NS", Value: "ns1.example.test", Ttl: 86400}},
+		}},
+		{"_sip._tcp.example.test", "SRV", &pb.DNSResponse{
+			Addresses: []string{"5 60 5060 sip.example.test"},
+			Records:   []*pb.DNSRecord{{Name: "_sip._tcp.example.test", Type: "SRV", Value: "5 60 5060 sip.example.test", Ttl: 600, Priority: 5}},
+		}},
+		{"192.0.2.10", "PTR", &pb.DNSResponse{
+			Addresses: []string{"example.test"},
+			Records:   []*pb.DNSRecord{{Name: "10.2.0.192.in-addr.arpa", Type: "PTR", Value: "example.test", Ttl: 900}},
+		}},
+		{"2001:db8::10", "PTR", &pb.DNSResponse{
+			Addresses: []string{"example.test"},
+			Records: []*pb.DNSRecord{{Name: "0.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+				Type: "PTR", Value: "example.test", Ttl: 900}},
+		}},
+		{"example.test", "SOA", &pb.DNSResponse{
+			Addresses: []string{"ns1.example.test hostmaster.example.test 2024061501 7200 3600 1209600 300"},
+			Records: []*pb.DNSRecord{{Name: "example.test", Type: "SOA", Ttl: 3600,
+				Value: "ns1.example.test hostmaster.example.test 2024061501 7200 3600 1209600 300"}},
+		}},
+		{"example.test", "CAA", &pb.DNSResponse{
+			Addresses: []string{`0 issue "letsencrypt.org"`},
+			Records:   []*pb.DNSRecord{{Name: "example.test", Type: "CAA", Value: `0 issue "letsencrypt.org"`, Ttl: 3600}},
+		}},
+		{"big.example.test", "A", &pb.DNSResponse{Addresses: bigAddresses, Records: bigRecords}},
+	}
+
+	server := &systemMonitorServer{}
+	for _, tt := range tests {
+		got, err := server.DNSLookup(context.Background(), &pb.DNSRequest{Hostname: tt.hostname, RecordType: tt.recordType, Resolver: s.addr})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := proto.Clone(tt.want).(*pb.DNSResponse)
+		want.Success, want.Hostname, want.Server, want.Rcode = true, tt.hostname, s.addr, "NOERROR"
+		got.QueryTime = 0
+		if !proto.Equal(got, want) {
+			t.Errorf("%s %s:\n got %v\nwant %v", tt.hostname, tt.recordType, got, want)
+		}
+	}
+	if n := s.tcpQueries.Load(); n != 1 {
+		t.Errorf("%d queries over TCP, want 1 for the truncated answer", n)
+	}
+
+	got, _ := server.DNSLookup(context.Background(), &pb.DNSRequest{Hostname: "missing.example.test", Resolver: s.addr})
+	if got.Success || got.Rcode != "NXDOMAIN" || got.Error == "" {
+		t.Errorf("missing name: %v, want a failed NXDOMAIN response", got)
+	}
+}
+
+// Without a resolver, A and AAAA lookups read /etc/hosts like other programs
+func TestDNSLookupSystemResolver(t *testing.T) {
+	got, err := (&systemMonitorServer{}).DNSLookup(context.Background(), &pb.DNSRequest{Hostname: "localhost"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Success || got.Server != "system" || len(got.Addresses) == 0 || got.Addresses[0] != "127.0.0.1" {
+		t.Errorf("localhost: %v, want 127.0.0.1 from the system resolver", got)
+	}
+}
+
+func TestSearchNames(t *testing.T) {
+	conf := &resolvConfig{search: []string{"lan.", "corp.example"}, ndots: 1}
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"printer", []string{"printer.lan.", "printer.corp.example.", "printer."}},
+		{"example.com", []string{"example.com.", "example.com.lan.", "example.com.corp.example."}},
+		{"example.com.", []string{"example.com."}},
+	}
+	for _, tt := range tests {
+		got := conf.searchNames(tt.name)
+		if len(got) != len(tt.want) {
+			t.Errorf("searchNames(%q) = %q, want %q", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("searchNames(%q) = %q, want %q", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+
+	if got := (&resolvConfig{ndots: 1}).searchNames("printer"); len(got) != 1 || got[0] != "printer." {
+		t.Errorf("without a search list: %q, want [printer.]", got)
+	}
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
@@ -12,6 +14,7 @@ import (
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 )
 
 type dockerServiceServer struct {
@@ -154,9 +157,18 @@ func (s *dockerServiceServer) GetContainerLogs(req *pb.LogRequest, stream pb.Doc
 		tail = fmt.Sprintf("%d", req.Tail)
 	}
 
+	// Containers with a TTY write a raw stream without multiplexing headers
+	info, err := s.client.ContainerInspect(stream.Context(), req.ContainerId)
+	if err != nil {
+		return fmt.Errorf("failed to inspect container: %v", err)
+	}
+	tty := info.Config != nil && info.Config.Tty
+
 	opts := container.LogsOptions{
-		ShowStdout: true,
-		ShowStderr: true,
+		ShowStdout: req.Stream != pb.LogStream_LOG_STREAM_STDERR,
+		ShowStderr: req.Stream != pb.LogStream_LOG_STREAM_STDOUT,
+		Since:      req.Since,
+		Until:      req.Until,
 		Follow:     req.Follow,
 		Tail:       tail,
 		Timestamps: true,
@@ -168,66 +180,138 @@ func (s *dockerServiceServer) GetContainerLogs(req *pb.LogRequest, stream pb.Doc
 	}
 	defer logs.Close()
 
-	// Docker log stream format:
-	// [1 byte stream type (1=stdout, 2=stderr)] [3 bytes ignored] [4 bytes payload size (big endian)] [payload]
+	send := func(streamName, line string) error {
+		return stream.Send(parseContainerLogLine(line, streamName, req.ParseJson))
+	}
+	stdout := &logLineWriter{stream: "stdout", send: send}
+	stderr := &logLineWriter{stream: "stderr", send: send}
+
+	if tty {
+		_, err = io.Copy(stdout, logs)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, logs)
+	}
+
+	// Client disconnected or stream was cancelled
+	if stream.Context().Err() != nil {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := stdout.flush(); err != nil {
+		return err
+	}
+	return stderr.flush()
+}
 
-	header := make([]byte, 8)
+// logLineWriter splits demultiplexed log output into lines
+type logLineWriter struct {
+	stream string
+	buf    []byte
+	send   func(stream, line string) error
+}
+
+func (w *logLineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
 	for {
-		// Check for context cancellation
-		select {
-		case <-stream.Context().Done():
-			return nil
-		default:
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
 		}
-
-		// Read header
-		_, err := io.ReadFull(logs, header)
-		if err != nil {
-			if err == io.EOF {
-				return nil
-			}
-			return err
+		line := strings.TrimRight(string(w.buf[:idx]), "\r")
+		w.buf = w.buf[idx+1:]
+		if err := w.send(w.stream, line); err != nil {
+			return 0, err
 		}
+	}
+	return len(p), nil
+}
 
-		// Parse size
-		size := uint32(header[4])<<24 | uint32(header[5])<<16 | uint32(header[6])<<8 | uint32(header[7])
+// flush sends any trailing output that did not end with a newline
+func (w *logLineWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	line := strings.TrimRight(string(w.buf), "\r")
+	w.buf = nil
+	return w.send(w.stream, line)
+}
+
+// parseContainerLogLine converts a timestamped log line into a LogEntry
+func parseContainerLogLine(line, streamName string, parseJSON bool) *pb.LogEntry {
+	var timestamp int64
+	var message string
+
+	parts := strings.SplitN(line, " ", 2)
+	if t, err := time.Parse(time.RFC3339Nano, parts[0]); err == nil && len(parts) == 2 {
+		timestamp = t.Unix()
+		message = parts[1]
+	} else {
+		message = line
+		timestamp = time.Now().Unix()
+	}
 
-		// Read payload
-		payload := make([]byte, size)
-		_, err = io.ReadFull(logs, payload)
-		if err != nil {
-			return err
+	entry := &pb.LogEntry{
+		Timestamp: timestamp,
+		Message:   strings.TrimSpace(message),
+		Level:     "info",
+		Stream:    streamName,
+	}
+
+	if parseJSON {
+		if level, ok := jsonLogLevel(entry.Message); ok {
+			entry.Level = level
 		}
+	}
+
+	return entry
+}
 
-		// Parse timestamp if present
-		logLine := string(payload)
+// jsonLogLevel extracts the level from a structured (JSON) log line
+func jsonLogLevel(message string) (string, bool) {
+	if !strings.HasPrefix(message, "{") {
+		return "", false
+	}
 
-		var timestamp int64
-		var message string
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(message), &fields); err != nil {
+		return "", false
+	}
 
-		parts := strings.SplitN(logLine, " ", 2)
-		if len(parts) == 2 {
-			if t, err := time.Parse(time.RFC3339, parts[0]); err == nil {
-				timestamp = t.Unix()
+	for _, key := range []string{"level", "lvl", "severity", "log.level", "loglevel"} {
+		switch v := fields[key].(type) {
+		case string:
+			return normalizeLogLevel(v), true
+		case float64:
+			// Numeric levels as used by pino/bunyan
+			switch {
+			case v >= 50:
+				return "error", true
+			case v >= 40:
+				return "warning", true
+			case v >= 30:
+				return "info", true
+			default:
+				return "debug", true
 			}
-			message = parts[1]
-		} else {
-			message = logLine
-			timestamp = time.Now().Unix()
-		}
-
-		entry := &pb.LogEntry{
-			Timestamp: timestamp,
-			Message:   strings.TrimSpace(message),
-			Level:     "info",
 		}
+	}
 
-		if header[0] == 2 {
-			entry.Level = "error"
-		}
+	return "", false
+}
 
-		if err := stream.Send(entry); err != nil {
-			return err
-		}
+// normalizeLogLevel maps common level names onto error, warning, info and debug
+func normalizeLogLevel(level string) string {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "error", "err", "fatal", "panic", "critical", "crit", "alert", "emergency", "emerg":
+		return "error"
+	case "warning", "warn":
+		return "warning"
+	case "debug", "trace", "verbose":
+		return "debug"
+	default:
+		return "info"
 	}
 }
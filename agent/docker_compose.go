@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	pb "pi_agent/proto"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// Labels set by docker compose on every container it creates
+const (
+	composeProjectLabel     = "com.docker.compose.project"
+	composeServiceLabel     = "com.docker.compose.service"
+	composeWorkingDirLabel  = "com.docker.compose.project.working_dir"
+	composeConfigFilesLabel = "com.docker.compose.project.config_files"
+)
+
+// ListComposeProjects groups containers by their compose project label
+func (s *dockerServiceServer) ListComposeProjects(ctx context.Context, req *pb.Empty) (*pb.ComposeProjectList, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("docker client not initialized")
+	}
+
+	containers, err := s.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", composeProjectLabel)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %v", err)
+	}
+
+	projects := make(map[string]*pb.ComposeProject)
+	for _, c := range containers {
+		name := c.Labels[composeProjectLabel]
+		project, ok := projects[name]
+		if !ok {
+			project = &pb.ComposeProject{
+				Name:        name,
+				WorkingDir:  c.Labels[composeWorkingDirLabel],
+				ConfigFiles: splitComposeFiles(c.Labels[composeConfigFilesLabel]),
+			}
+			projects[name] = project
+		}
+
+		project.Services = append(project.Services, &pb.ComposeService{
+			Name:          c.Labels[composeServiceLabel],
+			ContainerId:   c.ID,
+			ContainerName: containerName(c.Names),
+			Image:         c.Image,
+			State:         c.State,
+			Status:        c.Status,
+		})
+	}
+
+	var result []*pb.ComposeProject
+	for _, project := range projects {
+		sort.Slice(project.Services, func(i, j int) bool {
+			return project.Services[i].ContainerName < project.Services[j].ContainerName
+		})
+		project.Status = composeProjectStatus(project.Services)
+		result = append(result, project)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return &pb.ComposeProjectList{Projects: result}, nil
+}
+
+// composeProjectStatus summarizes service states as running, partial or exited
+func composeProjectStatus(services []*pb.ComposeService) string {
+	running := 0
+	for _, svc := range services {
+		if svc.State == "running" {
+			running++
+		}
+	}
+
+	switch {
+	case running == 0:
+		return "exited"
+	case running == len(services):
+		return "running"
+	default:
+		return "partial"
+	}
+}
+
+// splitComposeFiles splits the comma separated config_files label
+func splitComposeFiles(label string) []string {
+	var files []string
+	for _, f := range strings.Split(label, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// ManageComposeProject runs a compose command for a project and streams its output
+func (s *dockerServiceServer) ManageComposeProject(req *pb.ComposeCommand, stream pb.DockerService_ManageComposeProjectServer) error {
+	if s.client == nil {
+		return fmt.Errorf("docker client not initialized")
+	}
+	if req.ProjectName == "" {
+		return stream.Send(&pb.ComposeOutput{
+			Completed: true,
+			Error:     "project name is required",
+		})
+	}
+
+	var action []string
+	switch req.Action {
+	case pb.ComposeAction_COMPOSE_UP:
+		action = []string{"up", "-d"}
+		if req.RemoveOrphans {
+			action = append(action, "--remove-orphans")
+		}
+	case pb.ComposeAction_COMPOSE_DOWN:
+		action = []string{"down"}
+	case pb.ComposeAction_COMPOSE_RESTART:
+		action = []string{"restart"}
+	case pb.ComposeAction_COMPOSE_PULL:
+		action = []string{"pull"}
+	case pb.ComposeAction_COMPOSE_ACTION_UNSPECIFIED:
+		return stream.Send(&pb.ComposeOutput{
+			Completed: true,
+			Error:     "action is required",
+		})
+	default:
+		return stream.Send(&pb.ComposeOutput{
+			Completed: true,
+			Error:     "Unknown action",
+		})
+	}
+
+	// Fill in the project location from container labels when not given
+	workingDir := req.WorkingDir
+	configFiles := req.ConfigFiles
+	if workingDir == "" || len(configFiles) == 0 {
+		containers, err := s.client.ContainerList(stream.Context(), container.ListOptions{
+			All:     true,
+			Filters: filters.NewArgs(filters.Arg("label", composeProjectLabel+"="+req.ProjectName)),
+		})
+		if err == nil && len(containers) > 0 {
+			if workingDir == "" {
+				workingDir = containers[0].Labels[composeWorkingDirLabel]
+			}
+			if len(configFiles) == 0 {
+				configFiles = splitComposeFiles(containers[0].Labels[composeConfigFilesLabel])
+			}
+		}
+	}
+	if workingDir == "" && len(configFiles) == 0 {
+		return stream.Send(&pb.ComposeOutput{
+			Completed: true,
+			Error:     fmt.Sprintf("Compose project %s not found; working_dir or config_files required", req.ProjectName),
+		})
+	}
+
+	base, err := composeBinary()
+	if err != nil {
+		return stream.Send(&pb.ComposeOutput{
+			Completed: true,
+			Error:     err.Error(),
+		})
+	}
+
+	args := append([]string{}, base[1:]...)
+	args = append(args, "-p", req.ProjectName)
+	for _, f := range configFiles {
+		args = append(args, "-f", f)
+	}
+	args = append(args, action...)
+
+	cmd := exec.CommandContext(stream.Context(), base[0], args...)
+	cmd.Dir = workingDir
+
+	err = runCommandLines(cmd, func(streamName, line string) error {
+		return stream.Send(&pb.ComposeOutput{
+			Line:   line,
+			Stream: streamName,
+		})
+	})
+	if stream.Context().Err() != nil {
+		return stream.Context().Err()
+	}
+	if err != nil {
+		return stream.Send(&pb.ComposeOutput{
+			Completed: true,
+			Success:   false,
+			Error:     fmt.Sprintf("compose %s failed: %v", action[0], err),
+		})
+	}
+
+	return stream.Send(&pb.ComposeOutput{
+		Line:      fmt.Sprintf("compose %s completed successfully", action[0]),
+		Completed: true,
+		Success:   true,
+	})
+}
+
+// composeBinary returns the command used to invoke compose, preferring the
+// docker compose plugin over the standalone docker-compose binary
+func composeBinary() ([]string, error) {
+	if err := exec.Command("docker", "compose", "version").Run(); err == nil {
+		return []string{"docker", "compose"}, nil
+	}
+	if path, err := exec.LookPath("docker-compose"); err == nil {
+		return []string{path}, nil
+	}
+	return nil, fmt.Errorf("docker compose is not installed")
+}
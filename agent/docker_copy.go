@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	pb "pi_agent/proto"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// containerCopyChunkSize is the size of tar chunks streamed out of a container
+const containerCopyChunkSize = 512 * 1024
+
+// CopyFromContainer streams a file or directory from a container as tar chunks
+func (s *dockerServiceServer) CopyFromContainer(req *pb.ContainerCopyRequest, stream pb.DockerService_CopyFromContainerServer) error {
+	if s.client == nil {
+		return fmt.Errorf("docker client not initialized")
+	}
+
+	reader, stat, err := s.client.CopyFromContainer(stream.Context(), req.ContainerId, req.Path)
+	if err != nil {
+		return stream.Send(&pb.FileChunk{
+			Path:        req.Path,
+			ContainerId: req.ContainerId,
+			Error:       fmt.Sprintf("Failed to copy from container: %v", err),
+		})
+	}
+	defer reader.Close()
+
+	startTime := time.Now()
+	log.Printf("Container copy started: %s:%s (%d bytes)", shortID(req.ContainerId), req.Path, stat.Size)
+
+	// The tar size is not known up front, so TotalSize carries the size of
+	// the source path and the final chunk is marked once the archive ends
+	buffer := make([]byte, containerCopyChunkSize)
+	var offset int64
+	for {
+		n, err := io.ReadFull(reader, buffer)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return fmt.Errorf("read archive error: %w", err)
+		}
+		isFinal := err == io.EOF || err == io.ErrUnexpectedEOF
+
+		chunk := &pb.FileChunk{
+			Path:        req.Path,
+			ContainerId: req.ContainerId,
+			Data:        buffer[:n],
+			Offset:      offset,
+			TotalSize:   stat.Size,
+			IsFinal:     isFinal,
+		}
+		if err := stream.Send(chunk); err != nil {
+			return fmt.Errorf("send chunk error: %w", err)
+		}
+		offset += int64(n)
+
+		if isFinal {
+			break
+		}
+	}
+
+	log.Printf("Container copy complete: %s:%s (%d bytes in %.2fs)", shortID(req.ContainerId), req.Path, offset, time.Since(startTime).Seconds())
+
+	return nil
+}
+
+// CopyToContainer receives tar chunks and extracts them into a container directory
+func (s *dockerServiceServer) CopyToContainer(stream pb.DockerService_CopyToContainerServer) error {
+	if s.client == nil {
+		return fmt.Errorf("docker client not initialized")
+	}
+
+	var (
+		pipeWriter    *io.PipeWriter
+		done          chan error
+		targetPath    string
+		receivedBytes int64
+		startTime     = time.Now()
+	)
+
+	fail := func(err error) error {
+		if pipeWriter != nil {
+			pipeWriter.CloseWithError(err)
+			<-done
+		}
+		return stream.SendAndClose(&pb.FileUploadResponse{
+			Success:      false,
+			Path:         targetPath,
+			BytesWritten: receivedBytes,
+			Error:        err.Error(),
+			Duration:     time.Since(startTime).Seconds(),
+		})
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if pipeWriter != nil {
+				pipeWriter.CloseWithError(err)
+				<-done
+			}
+			return fmt.Errorf("receive chunk error: %w", err)
+		}
+
+		// First chunk: start the archive upload to the Docker daemon
+		if pipeWriter == nil {
+			if chunk.ContainerId == "" || chunk.Path == "" {
+				return fail(fmt.Errorf("container_id and path are required in the first chunk"))
+			}
+			targetPath = chunk.Path
+
+			var pipeReader *io.PipeReader
+			pipeReader, pipeWriter = io.Pipe()
+			done = make(chan error, 1)
+			go func(containerID string) {
+				err := s.client.CopyToContainer(stream.Context(), containerID, targetPath, pipeReader, container.CopyToContainerOptions{})
+				pipeReader.CloseWithError(err)
+				done <- err
+			}(chunk.ContainerId)
+
+			log.Printf("Container upload started: %s:%s (%d bytes)", shortID(chunk.ContainerId), targetPath, chunk.TotalSize)
+		}
+
+		if len(chunk.Data) > 0 {
+			n, err := pipeWriter.Write(chunk.Data)
+			receivedBytes += int64(n)
+			if err != nil {
+				return fail(fmt.Errorf("copy to container failed: %w", err))
+			}
+		}
+
+		if chunk.IsFinal {
+			break
+		}
+	}
+
+	if pipeWriter == nil {
+		return fail(fmt.Errorf("no data received"))
+	}
+
+	pipeWriter.Close()
+	if err := <-done; err != nil {
+		return stream.SendAndClose(&pb.FileUploadResponse{
+			Success:      false,
+			Path:         targetPath,
+			BytesWritten: receivedBytes,
+			Error:        fmt.Sprintf("copy to container failed: %v", err),
+			Duration:     time.Since(startTime).Seconds(),
+		})
+	}
+
+	duration := time.Since(startTime).Seconds()
+	log.Printf("Container upload complete: %s (%d bytes in %.2fs)", targetPath, receivedBytes, duration)
+
+	return stream.SendAndClose(&pb.FileUploadResponse{
+		Success:      true,
+		Path:         targetPath,
+		BytesWritten: receivedBytes,
+		Duration:     duration,
+	})
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	pb "pi_agent/proto"
+)
+
+// secretEnvKeywords mark environment variables whose values are masked
+var secretEnvKeywords = []string{"PASSWORD", "PASSWD", "SECRET", "TOKEN", "KEY", "CREDENTIAL", "AUTH", "PRIVATE"}
+
+// InspectContainer returns detailed configuration and state of a container
+func (s *dockerServiceServer) InspectContainer(ctx context.Context, req *pb.InspectContainerRequest) (*pb.ContainerDetails, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("docker client not initialized")
+	}
+
+	info, err := s.client.ContainerInspect(ctx, req.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container: %v", err)
+	}
+
+	details := &pb.ContainerDetails{
+		Id:           info.ID,
+		Name:         strings.TrimPrefix(info.Name, "/"),
+		ImageId:      info.Image,
+		Created:      info.Created,
+		RestartCount: int32(info.RestartCount),
+		Command:      strings.TrimSpace(info.Path + " " + strings.Join(info.Args, " ")),
+	}
+
+	if info.State != nil {
+		details.State = string(info.State.Status)
+		details.StartedAt = info.State.StartedAt
+		details.FinishedAt = info.State.FinishedAt
+		details.ExitCode = int32(info.State.ExitCode)
+		details.OomKilled = info.State.OOMKilled
+		details.Error = info.State.Error
+
+		if h := info.State.Health; h != nil {
+			health := &pb.ContainerHealth{
+				Status:        string(h.Status),
+				FailingStreak: int32(h.FailingStreak),
+			}
+			for _, result := range h.Log {
+				if result == nil {
+					continue
+				}
+				health.Log = append(health.Log, &pb.HealthCheckResult{
+					Start:    result.Start.Unix(),
+					End:      result.End.Unix(),
+					ExitCode: int32(result.ExitCode),
+					Output:   strings.TrimSpace(result.Output),
+				})
+			}
+			details.Health = health
+		}
+	}
+
+	if info.Config != nil {
+		details.Image = info.Config.Image
+		details.Labels = info.Config.Labels
+		for _, env := range info.Config.Env {
+			if req.MaskSecrets {
+				env = maskSecretEnv(env)
+			}
+			details.Env = append(details.Env, env)
+		}
+	}
+
+	for _, m := range info.Mounts {
+		details.Mounts = append(details.Mounts, &pb.ContainerMount{
+			Type:        string(m.Type),
+			Name:        m.Name,
+			Source:      m.Source,
+			Destination: m.Destination,
+			Mode:        m.Mode,
+			ReadWrite:   m.RW,
+		})
+	}
+
+	if info.NetworkSettings != nil {
+		for name, ep := range info.NetworkSettings.Networks {
+			if ep == nil {
+				continue
+			}
+			details.Networks = append(details.Networks, &pb.ContainerNetwork{
+				Name:        name,
+				IpAddress:   ep.IPAddress,
+				Ipv6Address: ep.GlobalIPv6Address,
+				Gateway:     ep.Gateway,
+				MacAddress:  ep.MacAddress,
+				Aliases:     ep.Aliases,
+			})
+		}
+		sort.Slice(details.Networks, func(i, j int) bool {
+			return details.Networks[i].Name < details.Networks[j].Name
+		})
+	}
+
+	if hc := info.HostConfig; hc != nil {
+		details.RestartPolicy = string(hc.RestartPolicy.Name)
+		details.RestartMaxRetries = int32(hc.RestartPolicy.MaximumRetryCount)
+
+		resources := &pb.ContainerResources{
+			MemoryLimit:       hc.Memory,
+			MemoryReservation: hc.MemoryReservation,
+			MemorySwap:        hc.MemorySwap,
+			Cpus:              float64(hc.NanoCPUs) / 1e9,
+			CpuShares:         hc.CPUShares,
+			CpusetCpus:        hc.CpusetCpus,
+		}
+		if hc.PidsLimit != nil {
+			resources.PidsLimit = *hc.PidsLimit
+		}
+		details.Resources = resources
+	}
+
+	return details, nil
+}
+
+// maskSecretEnv hides the value of a KEY=VALUE pair if the key looks secret
+func maskSecretEnv(env string) string {
+	key, value, found := strings.Cut(env, "=")
+	if !found || value == "" {
+		return env
+	}
+
+	upper := strings.ToUpper(key)
+	for _, keyword := range secretEnvKeywords {
+		if strings.Contains(upper, keyword) {
+			return key + "=********"
+		}
+	}
+	return env
+}
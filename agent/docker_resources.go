@@ -0,0 +1,341 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	pb "pi_agent/proto"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+)
+
+// ==================== Volumes ====================
+
+// ListVolumes returns volumes with their disk usage and attached containers
+func (s *dockerServiceServer) ListVolumes(ctx context.Context, req *pb.Empty) (*pb.VolumeList, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("docker client not initialized")
+	}
+
+	// The disk usage endpoint is the only one that reports volume sizes
+	usage, err := s.client.DiskUsage(ctx, types.DiskUsageOptions{
+		Types: []types.DiskUsageObject{types.VolumeObject},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %v", err)
+	}
+
+	containers, err := s.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %v", err)
+	}
+
+	// Map volume name -> container names
+	attached := make(map[string][]string)
+	for _, c := range containers {
+		for _, m := range c.Mounts {
+			if m.Type == "volume" && m.Name != "" {
+				attached[m.Name] = append(attached[m.Name], containerName(c.Names))
+			}
+		}
+	}
+
+	var result []*pb.VolumeInfo
+	for _, v := range usage.Volumes {
+		if v == nil {
+			continue
+		}
+
+		size := int64(-1)
+		if v.UsageData != nil {
+			size = v.UsageData.Size
+		}
+
+		result = append(result, &pb.VolumeInfo{
+			Name:       v.Name,
+			Driver:     v.Driver,
+			Mountpoint: v.Mountpoint,
+			CreatedAt:  v.CreatedAt,
+			Scope:      v.Scope,
+			Labels:     v.Labels,
+			Size:       size,
+			Containers: attached[v.Name],
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return &pb.VolumeList{Volumes: result}, nil
+}
+
+// CreateVolume creates a new volume
+func (s *dockerServiceServer) CreateVolume(ctx context.Context, req *pb.CreateVolumeRequest) (*pb.ActionStatus, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("docker client not initialized")
+	}
+
+	vol, err := s.client.VolumeCreate(ctx, volume.CreateOptions{
+		Name:       req.Name,
+		Driver:     req.Driver,
+		Labels:     req.Labels,
+		DriverOpts: req.DriverOpts,
+	})
+	if err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to create volume: %v", err),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	return &pb.ActionStatus{
+		Success: true,
+		Message: fmt.Sprintf("Volume %s created successfully", vol.Name),
+	}, nil
+}
+
+// RemoveVolume removes a volume
+func (s *dockerServiceServer) RemoveVolume(ctx context.Context, req *pb.RemoveVolumeRequest) (*pb.ActionStatus, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("docker client not initialized")
+	}
+
+	if err := s.client.VolumeRemove(ctx, req.Name, req.Force); err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to remove volume: %v", err),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	return &pb.ActionStatus{
+		Success: true,
+		Message: fmt.Sprintf("Volume %s removed successfully", req.Name),
+	}, nil
+}
+
+// PruneVolumes removes volumes not used by any container
+func (s *dockerServiceServer) PruneVolumes(ctx context.Context, req *pb.PruneVolumesRequest) (*pb.PruneResponse, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("docker client not initialized")
+	}
+
+	// Since API 1.42 prune only removes anonymous volumes unless all=true
+	args := filters.NewArgs()
+	if req.All {
+		args.Add("all", "true")
+	}
+
+	report, err := s.client.VolumesPrune(ctx, args)
+	if err != nil {
+		return &pb.PruneResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to prune volumes: %v", err),
+		}, nil
+	}
+
+	return &pb.PruneResponse{
+		Success:        true,
+		Deleted:        report.VolumesDeleted,
+		SpaceReclaimed: report.SpaceReclaimed,
+	}, nil
+}
+
+// ==================== Networks ====================
+
+// ListNetworks returns networks with their attached containers
+func (s *dockerServiceServer) ListNetworks(ctx context.Context, req *pb.Empty) (*pb.DockerNetworkList, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("docker client not initialized")
+	}
+
+	networks, err := s.client.NetworkList(ctx, network.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %v", err)
+	}
+
+	// The list endpoint does not include endpoints, so derive them from containers
+	containers, err := s.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %v", err)
+	}
+
+	attached := make(map[string][]string)
+	for _, c := range containers {
+		if c.NetworkSettings == nil {
+			continue
+		}
+		for name, ep := range c.NetworkSettings.Networks {
+			key := name
+			if ep != nil && ep.NetworkID != "" {
+				key = ep.NetworkID
+			}
+			attached[key] = append(attached[key], containerName(c.Names))
+		}
+	}
+
+	var result []*pb.DockerNetworkInfo
+	for _, n := range networks {
+		info := &pb.DockerNetworkInfo{
+			Id:         n.ID,
+			Name:       n.Name,
+			Driver:     n.Driver,
+			Scope:      n.Scope,
+			Internal:   n.Internal,
+			Attachable: n.Attachable,
+			Ipv6:       n.EnableIPv6,
+			Labels:     n.Labels,
+			Created:    n.Created.Unix(),
+		}
+
+		for _, cfg := range n.IPAM.Config {
+			if cfg.Subnet != "" {
+				info.Subnets = append(info.Subnets, cfg.Subnet)
+			}
+			if cfg.Gateway != "" {
+				info.Gateways = append(info.Gateways, cfg.Gateway)
+			}
+		}
+
+		info.Containers = attached[n.ID]
+		if len(info.Containers) == 0 {
+			info.Containers = attached[n.Name]
+		}
+
+		result = append(result, info)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return &pb.DockerNetworkList{Networks: result}, nil
+}
+
+// CreateNetwork creates a new network
+func (s *dockerServiceServer) CreateNetwork(ctx context.Context, req *pb.CreateNetworkRequest) (*pb.ActionStatus, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("docker client not initialized")
+	}
+
+	opts := network.CreateOptions{
+		Driver:     req.Driver,
+		Internal:   req.Internal,
+		Attachable: req.Attachable,
+		Labels:     req.Labels,
+	}
+	if req.Ipv6 {
+		enable := true
+		opts.EnableIPv6 = &enable
+	}
+	if req.Subnet != "" {
+		opts.IPAM = &network.IPAM{
+			Config: []network.IPAMConfig{{Subnet: req.Subnet, Gateway: req.Gateway}},
+		}
+	}
+
+	resp, err := s.client.NetworkCreate(ctx, req.Name, opts)
+	if err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to create network: %v", err),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	return &pb.ActionStatus{
+		Success: true,
+		Message: fmt.Sprintf("Network %s created successfully (%s)", req.Name, shortID(resp.ID)),
+	}, nil
+}
+
+// RemoveNetwork removes a network
+func (s *dockerServiceServer) RemoveNetwork(ctx context.Context, req *pb.DockerNetworkId) (*pb.ActionStatus, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("docker client not initialized")
+	}
+
+	if err := s.client.NetworkRemove(ctx, req.Id); err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to remove network: %v", err),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	return &pb.ActionStatus{
+		Success: true,
+		Message: "Network removed successfully",
+	}, nil
+}
+
+// ConnectNetwork attaches a container to a network
+func (s *dockerServiceServer) ConnectNetwork(ctx context.Context, req *pb.NetworkConnectRequest) (*pb.ActionStatus, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("docker client not initialized")
+	}
+
+	settings := &network.EndpointSettings{
+		Aliases: req.Aliases,
+	}
+	if req.Ipv4Address != "" {
+		settings.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: req.Ipv4Address}
+	}
+
+	if err := s.client.NetworkConnect(ctx, req.NetworkId, req.ContainerId, settings); err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to connect container: %v", err),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	return &pb.ActionStatus{
+		Success: true,
+		Message: "Container connected successfully",
+	}, nil
+}
+
+// DisconnectNetwork detaches a container from a network
+func (s *dockerServiceServer) DisconnectNetwork(ctx context.Context, req *pb.NetworkConnectRequest) (*pb.ActionStatus, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("docker client not initialized")
+	}
+
+	if err := s.client.NetworkDisconnect(ctx, req.NetworkId, req.ContainerId, req.Force); err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to disconnect container: %v", err),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	return &pb.ActionStatus{
+		Success: true,
+		Message: "Container disconnected successfully",
+	}, nil
+}
+
+// containerName returns the primary name of a container without the leading slash
+func containerName(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(names[0], "/")
+}
+
+// shortID truncates a Docker ID to the 12 characters shown by the CLI
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
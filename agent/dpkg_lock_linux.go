@@ -0,0 +1,50 @@
+//go:build linux
+
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// dpkgLockFiles are the locks taken by apt and dpkg, in acquisition order
+var dpkgLockFiles = []string{
+	"/var/lib/dpkg/lock-frontend",
+	"/var/lib/dpkg/lock",
+	"/var/lib/apt/lists/lock",
+	"/var/cache/apt/archives/lock",
+}
+
+// dpkgLockHolder reports the process holding one of the apt/dpkg locks.
+// It returns ok=false when no lock is held. pid is -1 when the holder
+// cannot be determined.
+func dpkgLockHolder() (pid int, name string, ok bool) {
+	for _, path := range dpkgLockFiles {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+
+		// Ask whether a write lock over the whole file would conflict
+		lock := unix.Flock_t{Type: unix.F_WRLCK}
+		err = unix.FcntlFlock(f.Fd(), unix.F_GETLK, &lock)
+		f.Close()
+		if err != nil || lock.Type == unix.F_UNLCK {
+			continue
+		}
+
+		pid = int(lock.Pid)
+		if pid <= 0 {
+			return -1, "", true
+		}
+		if comm, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/comm"); err == nil {
+			name = strings.TrimSpace(string(comm))
+		}
+		return pid, name, true
+	}
+
+	return 0, "", false
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package main
+
+// dpkgLockHolder always reports no lock on platforms without dpkg
+func dpkgLockHolder() (pid int, name string, ok bool) {
+	return 0, "", false
+}
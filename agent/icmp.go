@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+// IANA protocol numbers used to parse ICMP messages
+const (
+	protocolICMP   = 1
+	protocolICMPv6 = 58
+)
+
+// maxPingPayload keeps echo requests within a single IPv4 datagram
+const maxPingPayload = 65507 - 8
+
+// icmpConn sends ICMP echo requests and reads the replies. It prefers
+// unprivileged datagram sockets (Linux net.ipv4.ping_group_range) and falls
+// back to raw sockets, which need root or CAP_NET_RAW.
+type icmpConn struct {
+	conn *icmp.PacketConn
+	v6   bool
+	raw  bool
+	id   int // Echo identifier; datagram sockets have theirs set by the kernel
+}
+
+// icmpReply is an ICMP message received by an icmpConn
+type icmpReply struct {
+	from     net.IP
+	ttl      int // TTL or hop limit of the reply, 0 if unknown
+	msgType  icmp.Type
+	code     int
+	id       int
+	seq      int
+	quoted   []byte // Start of the packet an ICMP error refers to
+	received time.Time
+}
+
+// listenICMP opens an ICMP socket for the address family of dst
+func listenICMP(dst net.IP) (*icmpConn, error) {
+	return openICMP(dst, true)
+}
+
+// listenRawICMP opens a raw ICMP socket, which unlike a datagram socket also
+// receives ICMP errors (time exceeded, unreachable) for other protocols
+func listenRawICMP(dst net.IP) (*icmpConn, error) {
+	return openICMP(dst, false)
+}
+
+func openICMP(dst net.IP, datagram bool) (*icmpConn, error) {
+	v6 := dst.To4() == nil
+	network, rawNetwork, address := "udp4", "ip4:icmp", "0.0.0.0"
+	if v6 {
+		network, rawNetwork, address = "udp6", "ip6:ipv6-icmp", "::"
+	}
+
+	c := &icmpConn{v6: v6, id: rand.Intn(0xffff) + 1}
+	var conn *icmp.PacketConn
+	var err error
+	if datagram {
+		conn, err = icmp.ListenPacket(network, address)
+	}
+	if !datagram || err != nil {
+		conn, err = icmp.ListenPacket(rawNetwork, address)
+		if err != nil {
+			if datagram {
+				return nil, fmt.Errorf("cannot open ICMP socket (needs net.ipv4.ping_group_range or root): %w", err)
+			}
+			return nil, fmt.Errorf("cannot open raw ICMP socket (needs root or CAP_NET_RAW): %w", err)
+		}
+		c.raw = true
+	}
+	c.conn = conn
+
+	// TTL of replies; not supported on every platform
+	if v6 {
+		conn.IPv6PacketConn().SetControlMessage(ipv6.FlagHopLimit, true)
+	} else {
+		conn.IPv4PacketConn().SetControlMessage(ipv4.FlagTTL, true)
+	}
+	return c, nil
+}
+
+func (c *icmpConn) Close() error {
+	return c.conn.Close()
+}
+
+// setTTL sets the TTL (hop limit) of outgoing packets
+func (c *icmpConn) setTTL(ttl int) error {
+	if c.v6 {
+		return c.conn.IPv6PacketConn().SetHopLimit(ttl)
+	}
+	return c.conn.IPv4PacketConn().SetTTL(ttl)
+}
+
+// sendEcho sends an echo request with the given sequence number and payload size
+func (c *icmpConn) sendEcho(dst net.IP, seq, size int) error {
+	var msgType icmp.Type = ipv4.ICMPTypeEcho
+	if c.v6 {
+		msgType = ipv6.ICMPTypeEchoRequest
+	}
+
+	payload := make([]byte, size)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+	msg := icmp.Message{
+		Type: msgType,
+		Body: &icmp.Echo{ID: c.id, Seq: seq & 0xffff, Data: payload},
+	}
+	data, err := msg.Marshal(nil)
+	if err != nil {
+		return err
+	}
+
+	var addr net.Addr = &net.UDPAddr{IP: dst}
+	if c.raw {
+		addr = &net.IPAddr{IP: dst}
+	}
+	_, err = c.conn.WriteTo(data, addr)
+	return err
+}
+
+// read waits until the deadline for the next ICMP message
+func (c *icmpConn) read(deadline time.Time) (*icmpReply, error) {
+	if err := c.conn.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 65536)
+	var n, ttl int
+	var peer net.Addr
+	var err error
+	protocol := protocolICMP
+	if c.v6 {
+		protocol = protocolICMPv6
+		var cm *ipv6.ControlMessage
+		n, cm, peer, err = c.conn.IPv6PacketConn().ReadFrom(buf)
+		if cm != nil {
+			ttl = cm.HopLimit
+		}
+	} else {
+		var cm *ipv4.ControlMessage
+		n, cm, peer, err = c.conn.IPv4PacketConn().ReadFrom(buf)
+		if cm != nil {
+			ttl = cm.TTL
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := parseICMPReply(protocol, buf[:n])
+	if err != nil {
+		return nil, err
+	}
+	reply.ttl, reply.received = ttl, time.Now()
+	switch addr := peer.(type) {
+	case *net.UDPAddr:
+		reply.from = addr.IP
+	case *net.IPAddr:
+		reply.from = addr.IP
+	}
+	return reply, nil
+}
+
+// parseICMPReply decodes an ICMP message without its IP header
+func parseICMPReply(protocol int, data []byte) (*icmpReply, error) {
+	msg, err := icmp.ParseMessage(protocol, data)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &icmpReply{msgType: msg.Type, code: msg.Code}
+	switch body := msg.Body.(type) {
+	case *icmp.Echo:
+		reply.id, reply.seq = body.ID, body.Seq
+	case *icmp.TimeExceeded:
+		reply.quoted = body.Data
+	case *icmp.DstUnreach:
+		reply.quoted = body.Data
+	}
+	return reply, nil
+}
+
+// isEchoReply reports whether the reply answers our echo request seq
+func (c *icmpConn) isEchoReply(reply *icmpReply, seq int) bool {
+	if reply.msgType != ipv4.ICMPTypeEchoReply && reply.msgType != ipv6.ICMPTypeEchoReply {
+		return false
+	}
+	// Datagram sockets only receive their own replies, with the ID rewritten
+	if c.raw && reply.id != c.id {
+		return false
+	}
+	return reply.seq == seq&0xffff
+}
+
+// resolveHost returns the first address of host, preferring IPv4
+func resolveHost(ctx context.Context, host string) (net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip, nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if addr.IP.To4() != nil {
+			return addr.IP, nil
+		}
+	}
+	if len(addrs) > 0 {
+		return addrs[0].IP, nil
+	}
+	return nil, fmt.Errorf("no addresses for %s", host)
+}
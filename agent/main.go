@@ -25,6 +25,8 @@ func main() {
 	version := flag.Bool("version", false, "Print version and exit")
 	port := flag.Int("port", Port, "gRPC server port")
 	host := flag.String("host", "0.0.0.0", "Host address to bind to")
+	dataDir := flag.String("data-dir", "/var/lib/pi-agent", "Directory for persistent agent state")
+	speedTestPort := flag.Int("speedtest-port", 0, "Port of an iperf3-compatible throughput test server to run, e.g. 5201 (0 to disable)")
 	flag.Parse()
 
 	if *version {
@@ -45,7 +47,32 @@ func main() {
 		grpc.WriteBufferSize(1024*1024),    // 1MB write buffer
 		grpc.ReadBufferSize(1024*1024),     // 1MB read buffer
 	)
-	pb.RegisterSystemMonitorServer(grpcServer, &systemMonitorServer{})
+	packages := newPackageManager()
+	log.Printf("Using %s package manager", packages.Name())
+	monitor := &systemMonitorServer{
+		jobs:           newPackageJobQueue(),
+		packages:       packages,
+		dataDir:        *dataDir,
+		lastBootReason: loadLastBootReason(*dataDir),
+		devices:        newDeviceTable(),
+		wifi:           newWifiController(*dataDir),
+		netConfig:      newInterfaceConfigStager(*dataDir),
+	}
+	log.Printf("Last boot: %s", monitor.lastBootReason)
+	monitor.upgrades = newUpgradeScheduler(monitor, *dataDir)
+	monitor.upgrades.start()
+
+	// Start the throughput test server
+	if *speedTestPort > 0 {
+		speedTest, err := newSpeedTestServer(fmt.Sprintf("%s:%d", *host, *speedTestPort))
+		if err != nil {
+			log.Printf("Warning: Failed to start speed test server: %v", err)
+		} else {
+			go speedTest.serve()
+			log.Printf("Speed test server listening on %s", speedTest.listener.Addr())
+		}
+	}
+	pb.RegisterSystemMonitorServer(grpcServer, monitor)
 
 	// Initialize and register Docker service
 	dockerService, err := newDockerService()
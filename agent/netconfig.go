@@ -0,0 +1,397 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+// Timing of interface configuration changes
+const (
+	defaultConfirmTimeout = 2 * time.Minute
+	maxConfirmTimeout     = 30 * time.Minute
+	netConfigApplyDelay   = time.Second      // Lets the RPC reply go out before addresses change
+	netConfigRestoreDelay = 10 * time.Second // Lets NetworkManager or dhcpcd start after a reboot
+)
+
+// pendingInterfaceConfigFile in the data directory holds applied changes
+// waiting for confirmation
+const pendingInterfaceConfigFile = "interface-config-pending.json"
+
+// interfaceConfigurator changes interface addressing through the service
+// that owns the interface
+type interfaceConfigurator interface {
+	// Name returns the backend name (NetworkManager, dhcpcd)
+	Name() string
+
+	// Get returns the configured addressing of iface
+	Get(ctx context.Context, iface string) (*pb.InterfaceConfig, error)
+
+	// Check reports settings the backend cannot express
+	Check(cfg *pb.InterfaceConfig) error
+
+	// Apply configures the interface and returns what Revert needs to
+	// restore its previous configuration
+	Apply(ctx context.Context, cfg *pb.InterfaceConfig) (*interfaceConfigUndo, error)
+
+	// Revert restores the configuration saved by Apply
+	Revert(ctx context.Context, undo *interfaceConfigUndo) error
+}
+
+// interfaceConfigUndo is the previous configuration of an interface, saved
+// in the data directory so the change can be reverted after a restart
+type interfaceConfigUndo struct {
+	Backend    string            `json:"backend"`
+	Interface  string            `json:"interface"`
+	Connection string            `json:"connection,omitempty"` // NetworkManager connection UUID
+	Settings   map[string]string `json:"settings,omitempty"`   // NetworkManager ipv4 and ipv6 settings
+	Path       string            `json:"path,omitempty"`       // dhcpcd.conf
+	Content    []byte            `json:"content,omitempty"`    // Previous dhcpcd.conf
+	Mode       os.FileMode       `json:"mode,omitempty"`
+}
+
+// undoConfigurator returns the backend that saved undo
+func undoConfigurator(undo *interfaceConfigUndo) (interfaceConfigurator, error) {
+	switch undo.Backend {
+	case nmInterfaceConfigurator{}.Name():
+		return nmInterfaceConfigurator{}, nil
+	case dhcpcdConfigurator{}.Name():
+		return dhcpcdConfigurator{path: undo.Path}, nil
+	}
+	return nil, fmt.Errorf("unknown backend %q", undo.Backend)
+}
+
+// newInterfaceConfigurator picks NetworkManager if it manages iface, then
+// dhcpcd (Raspberry Pi OS before Bookworm)
+func newInterfaceConfigurator(iface string) (interfaceConfigurator, error) {
+	if _, err := net.InterfaceByName(iface); err != nil {
+		return nil, fmt.Errorf("interface %s not found", iface)
+	}
+	if nmManagesDevice(iface) {
+		return nmInterfaceConfigurator{}, nil
+	}
+	if _, err := os.Stat(dhcpcdConf); err == nil {
+		return dhcpcdConfigurator{path: dhcpcdConf}, nil
+	}
+	return nil, fmt.Errorf("%s is not managed by NetworkManager and %s does not exist", iface, dhcpcdConf)
+}
+
+// validateInterfaceConfig checks addresses and normalizes them to CIDR and
+// plain IP strings
+func validateInterfaceConfig(cfg *pb.InterfaceConfig) error {
+	var subnets []*net.IPNet
+	normalize := func(addrs []string, v4 bool) ([]string, error) {
+		var result []string
+		for _, addr := range addrs {
+			ip, ipNet, err := net.ParseCIDR(strings.TrimSpace(addr))
+			if err != nil {
+				return nil, fmt.Errorf("invalid address %q; use CIDR notation like 192.168.1.10/24", addr)
+			}
+			if (ip.To4() != nil) != v4 {
+				return nil, fmt.Errorf("%s is in the wrong address family", addr)
+			}
+			ones, _ := ipNet.Mask.Size()
+			result = append(result, fmt.Sprintf("%s/%d", ip, ones))
+			subnets = append(subnets, ipNet)
+		}
+		return result, nil
+	}
+	gateway := func(addr string, v4 bool) (string, error) {
+		if addr = strings.TrimSpace(addr); addr == "" {
+			return "", nil
+		}
+		ip := net.ParseIP(addr)
+		if ip == nil || (ip.To4() != nil) != v4 {
+			return "", fmt.Errorf("invalid gateway %q", addr)
+		}
+		return ip.String(), nil
+	}
+
+	var err error
+	if cfg.Dhcp {
+		if len(cfg.Ipv4Addresses) > 0 || cfg.Ipv4Gateway != "" {
+			return fmt.Errorf("IPv4 addresses and gateway cannot be set with DHCP")
+		}
+	} else if len(cfg.Ipv4Addresses) == 0 {
+		return fmt.Errorf("a static configuration needs at least one IPv4 address")
+	}
+	if cfg.Ipv4Addresses, err = normalize(cfg.Ipv4Addresses, true); err != nil {
+		return err
+	}
+	if cfg.Ipv6Addresses, err = normalize(cfg.Ipv6Addresses, false); err != nil {
+		return err
+	}
+	if cfg.Ipv4Gateway, err = gateway(cfg.Ipv4Gateway, true); err != nil {
+		return err
+	}
+	if cfg.Ipv6Gateway, err = gateway(cfg.Ipv6Gateway, false); err != nil {
+		return err
+	}
+	if cfg.Ipv6Gateway != "" && len(cfg.Ipv6Addresses) == 0 {
+		return fmt.Errorf("an IPv6 gateway needs a static IPv6 address")
+	}
+
+	// A gateway outside every subnet is unreachable and would cut the Pi off
+	if cfg.Ipv4Gateway != "" {
+		onLink := false
+		for _, subnet := range subnets {
+			onLink = onLink || subnet.Contains(net.ParseIP(cfg.Ipv4Gateway))
+		}
+		if !onLink {
+			return fmt.Errorf("gateway %s is not in the subnet of any address", cfg.Ipv4Gateway)
+		}
+	}
+
+	for i, server := range cfg.DnsServers {
+		ip := net.ParseIP(strings.TrimSpace(server))
+		if ip == nil {
+			return fmt.Errorf("invalid DNS server %q", server)
+		}
+		cfg.DnsServers[i] = ip.String()
+	}
+	return nil
+}
+
+// splitDNSServers separates IPv4 and IPv6 servers
+func splitDNSServers(servers []string) (v4, v6 []string) {
+	for _, server := range servers {
+		if net.ParseIP(server).To4() != nil {
+			v4 = append(v4, server)
+		} else {
+			v6 = append(v6, server)
+		}
+	}
+	return v4, v6
+}
+
+// describeInterfaceConfig summarizes a configuration for logs and status
+func describeInterfaceConfig(cfg *pb.InterfaceConfig) string {
+	desc := "DHCP"
+	if !cfg.Dhcp {
+		desc = "static " + strings.Join(cfg.Ipv4Addresses, ", ")
+		if cfg.Ipv4Gateway != "" {
+			desc += " via " + cfg.Ipv4Gateway
+		}
+	}
+	if len(cfg.Ipv6Addresses) > 0 {
+		desc += ", " + strings.Join(cfg.Ipv6Addresses, ", ")
+	}
+	if len(cfg.DnsServers) > 0 {
+		desc += ", DNS " + strings.Join(cfg.DnsServers, " ")
+	}
+	return fmt.Sprintf("%s: %s", cfg.Interface, desc)
+}
+
+// pendingInterfaceConfig is an applied change waiting for confirmation.
+// undo is nil while the change is still being applied.
+type pendingInterfaceConfig struct {
+	description  string
+	configurator interfaceConfigurator
+	undo         *interfaceConfigUndo
+	timer        *time.Timer
+	revertAt     time.Time
+}
+
+// savedInterfaceConfig is a pending change as saved in the data directory
+type savedInterfaceConfig struct {
+	Description string               `json:"description"`
+	RevertAt    int64                `json:"revert_at"`
+	Undo        *interfaceConfigUndo `json:"undo"`
+}
+
+// interfaceConfigStager applies interface changes and reverts them unless
+// they are confirmed in time. Applied changes are saved in the data
+// directory, so one left pending when the agent or the system restarts is
+// still reverted.
+type interfaceConfigStager struct {
+	mu         sync.Mutex
+	path       string
+	pending    map[string]*pendingInterfaceConfig
+	lastChange map[string]string
+}
+
+func newInterfaceConfigStager(dataDir string) *interfaceConfigStager {
+	s := &interfaceConfigStager{
+		path:       filepath.Join(dataDir, pendingInterfaceConfigFile),
+		pending:    make(map[string]*pendingInterfaceConfig),
+		lastChange: make(map[string]string),
+	}
+	s.restore()
+	return s
+}
+
+// restore picks up the changes a previous run left pending. They keep their
+// deadline; those past it are reverted once the network services had time
+// to start.
+func (s *interfaceConfigStager) restore() {
+	var saved map[string]savedInterfaceConfig
+	if err := readJSONFile(s.path, &saved); err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Warning: failed to read pending interface changes: %v", err)
+		}
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for iface, c := range saved {
+		if c.Undo == nil {
+			continue
+		}
+		configurator, err := undoConfigurator(c.Undo)
+		if err != nil {
+			log.Printf("Warning: cannot revert %s: %v", c.Description, err)
+			continue
+		}
+		p := &pendingInterfaceConfig{description: c.Description, configurator: configurator, undo: c.Undo}
+		s.pending[iface] = p
+		delay := max(time.Until(time.Unix(c.RevertAt, 0)), netConfigRestoreDelay)
+		s.schedule(iface, p, delay, "not confirmed in time")
+		log.Printf("Interface config: %s is waiting for confirmation until %s", p.description, p.revertAt.Format("15:04:05"))
+	}
+	s.save()
+}
+
+// confirmTimeout converts the request field; 0 means the default
+func confirmTimeout(seconds int32) time.Duration {
+	if seconds <= 0 {
+		return defaultConfirmTimeout
+	}
+	return min(time.Duration(seconds)*time.Second, maxConfirmTimeout)
+}
+
+// stage applies cfg in the background and starts the revert timer
+func (s *interfaceConfigStager) stage(configurator interfaceConfigurator, cfg *pb.InterfaceConfig, timeout time.Duration) error {
+	iface := cfg.Interface
+	s.mu.Lock()
+	if _, ok := s.pending[iface]; ok {
+		s.mu.Unlock()
+		return fmt.Errorf("a change to %s is waiting for confirmation; confirm or revert it first", iface)
+	}
+	p := &pendingInterfaceConfig{
+		description: describeInterfaceConfig(cfg),
+		revertAt:    time.Now().Add(netConfigApplyDelay + timeout),
+	}
+	s.pending[iface] = p
+	s.mu.Unlock()
+
+	go func() {
+		time.Sleep(netConfigApplyDelay)
+		log.Printf("Interface config: applying %s", p.description)
+		undo, err := configurator.Apply(context.Background(), cfg)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err != nil {
+			delete(s.pending, iface)
+			s.record(iface, fmt.Sprintf("Applying %s failed: %v", p.description, err))
+			return
+		}
+		p.configurator = configurator
+		p.undo = undo
+		s.schedule(iface, p, timeout, fmt.Sprintf("not confirmed within %s", timeout))
+		s.save()
+	}()
+	return nil
+}
+
+// schedule reverts p after delay unless it is confirmed; s.mu must be held
+func (s *interfaceConfigStager) schedule(iface string, p *pendingInterfaceConfig, delay time.Duration, reason string) {
+	p.revertAt = time.Now().Add(delay)
+	p.timer = time.AfterFunc(delay, func() {
+		s.revert(iface, p, reason)
+	})
+}
+
+// confirm keeps the pending change on iface
+func (s *interfaceConfigStager) confirm(iface string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.pending[iface]
+	switch {
+	case !ok:
+		return fmt.Errorf("no change to %s is waiting for confirmation", iface)
+	case p.undo == nil:
+		return fmt.Errorf("the change to %s is still being applied", iface)
+	}
+	p.timer.Stop()
+	delete(s.pending, iface)
+	s.save()
+	s.record(iface, fmt.Sprintf("Confirmed %s", p.description))
+	return nil
+}
+
+// revert undoes the pending change on iface. If p is not nil it is only
+// undone if it is still the pending change, so a timer that fires while the
+// change is being confirmed does nothing.
+func (s *interfaceConfigStager) revert(iface string, p *pendingInterfaceConfig, reason string) error {
+	s.mu.Lock()
+	current, ok := s.pending[iface]
+	switch {
+	case !ok || (p != nil && current != p):
+		s.mu.Unlock()
+		return fmt.Errorf("no change to %s is waiting for confirmation", iface)
+	case current.undo == nil:
+		s.mu.Unlock()
+		return fmt.Errorf("the change to %s is still being applied", iface)
+	}
+	current.timer.Stop()
+	delete(s.pending, iface)
+	s.mu.Unlock()
+
+	err := current.configurator.Revert(context.Background(), current.undo)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.save()
+	if err != nil {
+		s.record(iface, fmt.Sprintf("Reverting %s (%s) failed: %v", current.description, reason, err))
+		return err
+	}
+	s.record(iface, fmt.Sprintf("Reverted %s (%s)", current.description, reason))
+	return nil
+}
+
+// save writes the applied pending changes to the data directory, removing
+// the file when there are none; s.mu must be held
+func (s *interfaceConfigStager) save() {
+	saved := make(map[string]savedInterfaceConfig)
+	for iface, p := range s.pending {
+		if p.undo != nil {
+			saved[iface] = savedInterfaceConfig{Description: p.description, RevertAt: p.revertAt.Unix(), Undo: p.undo}
+		}
+	}
+	var err error
+	if len(saved) > 0 {
+		err = writeJSONFile(s.path, saved)
+	} else if err = os.Remove(s.path); os.IsNotExist(err) {
+		err = nil
+	}
+	if err != nil {
+		log.Printf("Warning: failed to save pending interface changes: %v", err)
+	}
+}
+
+// record logs and keeps the outcome of a change; s.mu must be held
+func (s *interfaceConfigStager) record(iface, result string) {
+	log.Printf("Interface config: %s", result)
+	s.lastChange[iface] = result
+}
+
+// status fills in the pending change and last outcome for cfg.Interface
+func (s *interfaceConfigStager) status(cfg *pb.InterfaceConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p, ok := s.pending[cfg.Interface]; ok {
+		cfg.Pending = true
+		cfg.RevertAt = p.revertAt.Unix()
+	}
+	cfg.LastChange = s.lastChange[cfg.Interface]
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	pb "pi_agent/proto"
+)
+
+const dhcpcdConf = "/etc/dhcpcd.conf"
+
+// dhcpcdStaticOptions are the "static" options Apply manages; others in the
+// interface block are left alone
+var dhcpcdStaticOptions = map[string]bool{
+	"ip_address":          true,
+	"ip6_address":         true,
+	"routers":             true,
+	"domain_name_servers": true,
+}
+
+// dhcpcdConfigurator edits the interface blocks of dhcpcd.conf:
+//
+//	interface eth0
+//	static ip_address=192.168.1.10/24
+//	static routers=192.168.1.1
+//	static domain_name_servers=192.168.1.1 1.1.1.1
+type dhcpcdConfigurator struct {
+	path string
+}
+
+func (dhcpcdConfigurator) Name() string {
+	return "dhcpcd"
+}
+
+// dhcpcdBlock returns the lines [start, end) of the block for iface, which
+// runs to the next interface, ssid or profile line; start is -1 if there is none
+func dhcpcdBlock(lines []string, iface string) (int, int) {
+	start := -1
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "interface", "ssid", "profile":
+			if start >= 0 {
+				return start, i
+			}
+			if fields[0] == "interface" && len(fields) > 1 && fields[1] == iface {
+				start = i
+			}
+		}
+	}
+	return start, len(lines)
+}
+
+// dhcpcdStatic splits "static key=value" into key and value
+func dhcpcdStatic(line string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(line), "static ")
+	if !ok {
+		return "", "", false
+	}
+	key, value, ok := strings.Cut(strings.TrimSpace(rest), "=")
+	return key, strings.TrimSpace(value), ok
+}
+
+func (c dhcpcdConfigurator) Get(ctx context.Context, iface string) (*pb.InterfaceConfig, error) {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &pb.InterfaceConfig{Interface: iface, Dhcp: true}
+	lines := strings.Split(string(data), "\n")
+	start, end := dhcpcdBlock(lines, iface)
+	if start < 0 {
+		return cfg, nil
+	}
+	for _, line := range lines[start+1 : end] {
+		key, value, ok := dhcpcdStatic(line)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "ip_address":
+			cfg.Dhcp = false
+			cfg.Ipv4Addresses = strings.Fields(value)
+		case "ip6_address":
+			cfg.Ipv6Addresses = strings.Fields(value)
+		case "routers":
+			if routers := strings.Fields(value); len(routers) > 0 {
+				cfg.Ipv4Gateway = routers[0]
+			}
+		case "domain_name_servers":
+			cfg.DnsServers = strings.Fields(value)
+		}
+	}
+	return cfg, nil
+}
+
+func (dhcpcdConfigurator) Check(cfg *pb.InterfaceConfig) error {
+	switch {
+	case len(cfg.Ipv4Addresses) > 1, len(cfg.Ipv6Addresses) > 1:
+		return fmt.Errorf("dhcpcd supports one static address per address family")
+	case cfg.Ipv6Gateway != "":
+		return fmt.Errorf("dhcpcd learns the IPv6 gateway from router advertisements; leave it empty")
+	}
+	return nil
+}
+
+// rewriteDhcpcdConf replaces the managed static options for cfg.Interface,
+// adding an interface block at the end if there is none
+func rewriteDhcpcdConf(content string, cfg *pb.InterfaceConfig) string {
+	var static []string
+	if !cfg.Dhcp {
+		static = append(static, "static ip_address="+strings.Join(cfg.Ipv4Addresses, " "))
+		if cfg.Ipv4Gateway != "" {
+			static = append(static, "static routers="+cfg.Ipv4Gateway)
+		}
+	}
+	if len(cfg.Ipv6Addresses) > 0 {
+		static = append(static, "static ip6_address="+strings.Join(cfg.Ipv6Addresses, " "))
+	}
+	if len(cfg.DnsServers) > 0 {
+		static = append(static, "static domain_name_servers="+strings.Join(cfg.DnsServers, " "))
+	}
+
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	start, end := dhcpcdBlock(lines, cfg.Interface)
+	if start < 0 {
+		if len(static) == 0 {
+			return content
+		}
+		block := "interface " + cfg.Interface + "\n" + strings.Join(static, "\n") + "\n"
+		if strings.TrimSpace(content) == "" {
+			return block
+		}
+		return strings.TrimRight(content, "\n") + "\n\n" + block
+	}
+
+	result := append([]string{}, lines[:start+1]...)
+	result = append(result, static...)
+	for _, line := range lines[start+1 : end] {
+		if key, _, ok := dhcpcdStatic(line); ok && dhcpcdStaticOptions[key] {
+			continue
+		}
+		result = append(result, line)
+	}
+	result = append(result, lines[end:]...)
+	return strings.Join(result, "\n") + "\n"
+}
+
+func (c dhcpcdConfigurator) Apply(ctx context.Context, cfg *pb.InterfaceConfig) (*interfaceConfigUndo, error) {
+	info, err := os.Stat(c.path)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, err
+	}
+	undo := &interfaceConfigUndo{
+		Backend:   c.Name(),
+		Interface: cfg.Interface,
+		Path:      c.path,
+		Content:   previous,
+		Mode:      info.Mode().Perm(),
+	}
+
+	if err := writeFileAtomic(c.path, []byte(rewriteDhcpcdConf(string(previous), cfg)), undo.Mode); err != nil {
+		return nil, err
+	}
+	if err := dhcpcdRebind(ctx, cfg.Interface); err != nil {
+		c.Revert(ctx, undo)
+		return nil, err
+	}
+	return undo, nil
+}
+
+func (c dhcpcdConfigurator) Revert(ctx context.Context, undo *interfaceConfigUndo) error {
+	if err := writeFileAtomic(c.path, undo.Content, undo.Mode); err != nil {
+		return err
+	}
+	return dhcpcdRebind(ctx, undo.Interface)
+}
+
+// dhcpcdRebind makes dhcpcd reload its configuration and rebind iface
+func dhcpcdRebind(ctx context.Context, iface string) error {
+	output, err := exec.CommandContext(ctx, "dhcpcd", "--rebind", iface).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return fmt.Errorf("dhcpcd --rebind %s: %s", iface, msg)
+		}
+		return fmt.Errorf("dhcpcd --rebind %s: %v", iface, err)
+	}
+	return nil
+}
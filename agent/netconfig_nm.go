@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pb "pi_agent/proto"
+)
+
+// nmIPSettings are the connection properties Apply changes and revert restores
+var nmIPSettings = []string{
+	"ipv4.method", "ipv4.addresses", "ipv4.gateway", "ipv4.dns", "ipv4.ignore-auto-dns",
+	"ipv6.method", "ipv6.addresses", "ipv6.gateway", "ipv6.dns", "ipv6.ignore-auto-dns",
+}
+
+// nmInterfaceConfigurator edits the connection profile active on an
+// interface and reapplies it
+type nmInterfaceConfigurator struct{}
+
+func (nmInterfaceConfigurator) Name() string {
+	return "NetworkManager"
+}
+
+// nmManagesDevice reports whether NetworkManager is running and manages iface
+func nmManagesDevice(iface string) bool {
+	if !commandExists("nmcli") {
+		return false
+	}
+	output, err := runNmcli(context.Background(), "-t", "-f", "DEVICE,STATE", "device")
+	if err != nil {
+		return false
+	}
+	for _, line := range splitLines(output) {
+		fields := splitNmcliFields(line)
+		if len(fields) == 2 && fields[0] == iface && fields[1] != "unmanaged" {
+			return true
+		}
+	}
+	return false
+}
+
+// nmDeviceConnection returns the UUID of the connection active on iface
+func nmDeviceConnection(ctx context.Context, iface string) (string, error) {
+	output, err := runNmcli(ctx, "-t", "-f", "DEVICE,UUID", "connection", "show", "--active")
+	if err != nil {
+		return "", err
+	}
+	for _, line := range splitLines(output) {
+		if fields := splitNmcliFields(line); len(fields) == 2 && fields[0] == iface {
+			return fields[1], nil
+		}
+	}
+	return "", fmt.Errorf("no active NetworkManager connection on %s", iface)
+}
+
+// nmIPConfig reads the ipv4 and ipv6 settings of a connection. Lists such as
+// addresses are comma separated and unset values print as "--".
+func nmIPConfig(ctx context.Context, uuid string) (map[string]string, error) {
+	output, err := runNmcli(ctx, "-t", "--escape", "no", "-f", "ipv4,ipv6", "connection", "show", "uuid", uuid)
+	if err != nil {
+		return nil, err
+	}
+	settings := make(map[string]string)
+	for _, line := range splitLines(output) {
+		key, value, _ := strings.Cut(line, ":")
+		if value == "--" {
+			value = ""
+		}
+		if strings.HasSuffix(key, ".addresses") || strings.HasSuffix(key, ".dns") {
+			value = strings.Join(nmList(value), ",")
+		}
+		settings[key] = value
+	}
+	return settings, nil
+}
+
+// nmList splits a comma separated setting
+func nmList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (nmInterfaceConfigurator) Get(ctx context.Context, iface string) (*pb.InterfaceConfig, error) {
+	uuid, err := nmDeviceConnection(ctx, iface)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := nmIPConfig(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &pb.InterfaceConfig{
+		Interface:     iface,
+		Dhcp:          settings["ipv4.method"] != "manual",
+		Ipv4Addresses: nmList(settings["ipv4.addresses"]),
+		Ipv4Gateway:   settings["ipv4.gateway"],
+		Ipv6Gateway:   settings["ipv6.gateway"],
+		DnsServers:    append(nmList(settings["ipv4.dns"]), nmList(settings["ipv6.dns"])...),
+	}
+	if settings["ipv6.method"] == "manual" {
+		cfg.Ipv6Addresses = nmList(settings["ipv6.addresses"])
+	}
+	return cfg, nil
+}
+
+func (nmInterfaceConfigurator) Check(cfg *pb.InterfaceConfig) error {
+	return nil
+}
+
+func (c nmInterfaceConfigurator) Apply(ctx context.Context, cfg *pb.InterfaceConfig) (*interfaceConfigUndo, error) {
+	uuid, err := nmDeviceConnection(ctx, cfg.Interface)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := nmIPConfig(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	dns4, dns6 := splitDNSServers(cfg.DnsServers)
+	settings := map[string]string{
+		"ipv4.method":          "auto",
+		"ipv4.addresses":       "",
+		"ipv4.gateway":         "",
+		"ipv4.dns":             strings.Join(dns4, ","),
+		"ipv4.ignore-auto-dns": nmBool(len(cfg.DnsServers) > 0),
+		"ipv6.method":          "auto",
+		"ipv6.addresses":       "",
+		"ipv6.gateway":         "",
+		"ipv6.dns":             strings.Join(dns6, ","),
+		"ipv6.ignore-auto-dns": nmBool(len(cfg.DnsServers) > 0),
+	}
+	if !cfg.Dhcp {
+		settings["ipv4.method"] = "manual"
+		settings["ipv4.addresses"] = strings.Join(cfg.Ipv4Addresses, ",")
+		settings["ipv4.gateway"] = cfg.Ipv4Gateway
+	}
+	// Without static IPv6 addresses keep IPv6 as it was (it may be disabled),
+	// unless it was static
+	if method := previous["ipv6.method"]; method != "" && method != "manual" {
+		settings["ipv6.method"] = method
+	}
+	if len(cfg.Ipv6Addresses) > 0 {
+		settings["ipv6.method"] = "manual"
+		settings["ipv6.addresses"] = strings.Join(cfg.Ipv6Addresses, ",")
+		settings["ipv6.gateway"] = cfg.Ipv6Gateway
+	}
+
+	if method := settings["ipv6.method"]; method == "ignore" || method == "disabled" {
+		delete(settings, "ipv6.dns")
+		delete(settings, "ipv6.ignore-auto-dns")
+	}
+
+	undo := &interfaceConfigUndo{
+		Backend:    c.Name(),
+		Interface:  cfg.Interface,
+		Connection: uuid,
+		Settings:   make(map[string]string),
+	}
+	for _, key := range nmIPSettings {
+		if value, ok := previous[key]; ok {
+			undo.Settings[key] = value
+		}
+	}
+
+	if err := nmModify(ctx, uuid, settings); err != nil {
+		return nil, err
+	}
+	// The profile is changed, so restore it if the device cannot use it
+	if err := nmActivate(ctx, cfg.Interface, uuid); err != nil {
+		c.Revert(ctx, undo)
+		return nil, err
+	}
+	return undo, nil
+}
+
+func (nmInterfaceConfigurator) Revert(ctx context.Context, undo *interfaceConfigUndo) error {
+	if err := nmModify(ctx, undo.Connection, undo.Settings); err != nil {
+		return err
+	}
+	return nmActivate(ctx, undo.Interface, undo.Connection)
+}
+
+// nmModify changes connection settings
+func nmModify(ctx context.Context, uuid string, settings map[string]string) error {
+	args := []string{"connection", "modify", "uuid", uuid}
+	for _, key := range nmIPSettings {
+		if value, ok := settings[key]; ok {
+			args = append(args, key, value)
+		}
+	}
+	_, err := runNmcli(ctx, args...)
+	return err
+}
+
+// nmActivate applies the connection's settings to the device without taking
+// it down where possible
+func nmActivate(ctx context.Context, iface, uuid string) error {
+	if _, err := runNmcli(ctx, "device", "reapply", iface); err != nil {
+		_, err = runNmcli(ctx, "connection", "up", "uuid", uuid)
+		return err
+	}
+	return nil
+}
+
+func nmBool(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
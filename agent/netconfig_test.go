@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+func TestInterfaceConfigStagerRestore(t *testing.T) {
+	dir := t.TempDir()
+	conf := filepath.Join(dir, "dhcpcd.conf")
+	undo := func(iface string) *interfaceConfigUndo {
+		return &interfaceConfigUndo{Backend: "dhcpcd", Interface: iface, Path: conf, Content: []byte("interface " + iface + "\n"), Mode: 0644}
+	}
+	deadline := time.Now().Add(5 * time.Minute).Unix()
+	saved := map[string]savedInterfaceConfig{
+		// Left pending across a reboot that took longer than the timeout
+		"eth0":  {Description: "eth0: static 192.168.1.10/24", RevertAt: time.Now().Add(-time.Hour).Unix(), Undo: undo("eth0")},
+		"wlan0": {Description: "wlan0: DHCP", RevertAt: deadline, Undo: undo("wlan0")},
+		"usb0":  {Description: "usb0: DHCP", RevertAt: deadline, Undo: &interfaceConfigUndo{Backend: "networkd"}},
+	}
+	path := filepath.Join(dir, pendingInterfaceConfigFile)
+	if err := writeJSONFile(path, saved); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newInterfaceConfigStager(dir)
+	status := func(iface string) *pb.InterfaceConfig {
+		cfg := &pb.InterfaceConfig{Interface: iface}
+		s.status(cfg)
+		return cfg
+	}
+	if cfg := status("eth0"); !cfg.Pending || abs64(cfg.RevertAt-time.Now().Add(netConfigRestoreDelay).Unix()) > 1 {
+		t.Errorf("eth0: pending %v, revert at %d; want a revert %s after startup", cfg.Pending, cfg.RevertAt, netConfigRestoreDelay)
+	}
+	if cfg := status("wlan0"); !cfg.Pending || cfg.RevertAt != deadline {
+		t.Errorf("wlan0: pending %v, revert at %d; want the saved deadline %d", cfg.Pending, cfg.RevertAt, deadline)
+	}
+	if cfg := status("usb0"); cfg.Pending {
+		t.Error("usb0: a change from an unknown backend was restored")
+	}
+
+	// Confirming drops a change from the saved state and removes the file
+	// once nothing is pending
+	if err := s.confirm("eth0"); err != nil {
+		t.Fatal(err)
+	}
+	var remaining map[string]savedInterfaceConfig
+	if err := readJSONFile(path, &remaining); err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 || remaining["wlan0"].RevertAt != deadline || string(remaining["wlan0"].Undo.Content) != "interface wlan0\n" {
+		t.Errorf("saved after confirming eth0: %+v", remaining)
+	}
+	if err := s.confirm("wlan0"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("%s still exists with nothing pending", pendingInterfaceConfigFile)
+	}
+	if _, err := os.Stat(conf); !os.IsNotExist(err) {
+		t.Error("dhcpcd.conf was written although no change was reverted")
+	}
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ouiDatabases are full vendor lists installed by distribution packages
+// (ieee-data, hwdata, nmap). The first one found is used.
+var ouiDatabases = []string{
+	"/usr/share/ieee-data/oui.txt",
+	"/usr/share/hwdata/oui.txt",
+	"/usr/share/nmap/nmap-mac-prefixes",
+}
+
+// ouiVendors is the bundled table of vendors commonly found on home and
+// office networks, used when no database is installed
+var ouiVendors = map[string]string{
+	// Raspberry Pi
+	"B827EB": "Raspberry Pi Foundation",
+	"DCA632": "Raspberry Pi Trading",
+	"E45F01": "Raspberry Pi Trading",
+	"D83ADD": "Raspberry Pi Trading",
+	"28CDC1": "Raspberry Pi Trading",
+	"2CCF67": "Raspberry Pi Trading",
+
+	// Virtual machines
+	"525400": "QEMU/KVM",
+	"000C29": "VMware",
+	"005056": "VMware",
+	"000569": "VMware",
+	"080027": "VirtualBox",
+	"00155D": "Microsoft Hyper-V",
+	"00163E": "Xen",
+
+	// Microcontrollers and IoT
+	"18FE34": "Espressif",
+	"5CCF7F": "Espressif",
+	"600194": "Espressif",
+	"240AC4": "Espressif",
+	"30AEA4": "Espressif",
+	"84F3EB": "Espressif",
+	"A4CF12": "Espressif",
+	"246F28": "Espressif",
+	"3C71BF": "Espressif",
+	"ECFABC": "Espressif",
+	"8CAAB5": "Espressif",
+	"A8610A": "Arduino",
+	"001788": "Philips Hue (Signify)",
+	"ECB5FA": "Philips Hue (Signify)",
+
+	// Phones, computers and media devices
+	"000393": "Apple",
+	"000A95": "Apple",
+	"0017F2": "Apple",
+	"001EC2": "Apple",
+	"002500": "Apple",
+	"28CFE9": "Apple",
+	"3C0754": "Apple",
+	"406C8F": "Apple",
+	"60F81D": "Apple",
+	"7CD1C3": "Apple",
+	"ACBC32": "Apple",
+	"F01898": "Apple",
+	"001632": "Samsung",
+	"001D25": "Samsung",
+	"5C0A5B": "Samsung",
+	"001A11": "Google",
+	"3C5AB4": "Google",
+	"546009": "Google",
+	"F4F5D8": "Google",
+	"F4F5E8": "Google",
+	"0C47C9": "Amazon",
+	"40B4CD": "Amazon",
+	"44650D": "Amazon",
+	"6837E9": "Amazon",
+	"74C246": "Amazon",
+	"84D6D0": "Amazon",
+	"F0272D": "Amazon",
+	"FC65DE": "Amazon",
+	"286C07": "Xiaomi",
+	"34CE00": "Xiaomi",
+	"640980": "Xiaomi",
+	"7811DC": "Xiaomi",
+	"00E0FC": "Huawei",
+	"001882": "Huawei",
+	"001C62": "LG Electronics",
+	"000E58": "Sonos",
+	"5CAAFD": "Sonos",
+	"7828CA": "Sonos",
+	"949F3E": "Sonos",
+	"B8E937": "Sonos",
+	"48A6B8": "Sonos",
+	"347E5C": "Sonos",
+	"B0A737": "Roku",
+	"D83134": "Roku",
+	"CC6DA0": "Roku",
+	"AC3A7A": "Roku",
+	"0009BF": "Nintendo",
+	"001F32": "Nintendo",
+	"98B6E9": "Nintendo",
+	"7CBB8A": "Nintendo",
+	"E84ECE": "Nintendo",
+	"00041F": "Sony Interactive Entertainment",
+	"0013A9": "Sony",
+	"00044B": "NVIDIA",
+	"48B02D": "NVIDIA",
+	"001B21": "Intel",
+	"001E67": "Intel",
+	"001517": "Intel",
+	"3C970E": "Intel",
+	"A0369F": "Intel",
+	"00E04C": "Realtek",
+	"001422": "Dell",
+	"00219B": "Dell",
+	"B8AC6F": "Dell",
+	"F8BC12": "Dell",
+	"180373": "Dell",
+	"D4BED9": "Dell",
+	"001F29": "Hewlett Packard",
+	"3CD92B": "Hewlett Packard",
+	"9C8E99": "Hewlett Packard",
+
+	// Network equipment
+	"00000C": "Cisco",
+	"000B86": "Aruba Networks",
+	"000585": "Juniper Networks",
+	"00090F": "Fortinet",
+	"906CAC": "Fortinet",
+	"0418D6": "Ubiquiti",
+	"24A43C": "Ubiquiti",
+	"44D9E7": "Ubiquiti",
+	"687251": "Ubiquiti",
+	"788A20": "Ubiquiti",
+	"802AA8": "Ubiquiti",
+	"B4FBE4": "Ubiquiti",
+	"DC9FDB": "Ubiquiti",
+	"F09FC2": "Ubiquiti",
+	"FCECDA": "Ubiquiti",
+	"7483C2": "Ubiquiti",
+	"E063DA": "Ubiquiti",
+	"000C42": "MikroTik",
+	"4C5E0C": "MikroTik",
+	"6C3B6B": "MikroTik",
+	"B869F4": "MikroTik",
+	"CC2DE0": "MikroTik",
+	"D4CA6D": "MikroTik",
+	"E48D8C": "MikroTik",
+	"2CC81B": "MikroTik",
+	"488F5A": "MikroTik",
+	"64D154": "MikroTik",
+	"744D28": "MikroTik",
+	"DC2C6E": "MikroTik",
+	"50C7BF": "TP-Link",
+	"14CC20": "TP-Link",
+	"60E327": "TP-Link",
+	"C46E1F": "TP-Link",
+	"F4F26D": "TP-Link",
+	"98DED0": "TP-Link",
+	"EC086B": "TP-Link",
+	"A0F3C1": "TP-Link",
+	"B04E26": "TP-Link",
+	"00095B": "Netgear",
+	"00146C": "Netgear",
+	"001B2F": "Netgear",
+	"001E2A": "Netgear",
+	"00223F": "Netgear",
+	"0024B2": "Netgear",
+	"204E7F": "Netgear",
+	"A040A0": "Netgear",
+	"C03F0E": "Netgear",
+	"9C3DCF": "Netgear",
+	"00055D": "D-Link",
+	"000D88": "D-Link",
+	"001195": "D-Link",
+	"001346": "D-Link",
+	"0015E9": "D-Link",
+	"00179A": "D-Link",
+	"00195B": "D-Link",
+	"001B11": "D-Link",
+	"001CF0": "D-Link",
+	"001E58": "D-Link",
+	"002191": "D-Link",
+	"0022B0": "D-Link",
+	"1C7EE5": "D-Link",
+	"28107B": "D-Link",
+	"340804": "D-Link",
+	"5CD998": "D-Link",
+	"84C9B2": "D-Link",
+	"B8A386": "D-Link",
+	"C8BE19": "D-Link",
+	"CCB255": "D-Link",
+	"F07D68": "D-Link",
+	"FC7516": "D-Link",
+	"000625": "Linksys",
+	"000C41": "Linksys",
+	"001217": "Linksys",
+	"0014BF": "Linksys",
+	"001839": "Linksys",
+	"001A70": "Linksys",
+	"001C10": "Linksys",
+	"001D7E": "Linksys",
+	"001EE5": "Linksys",
+	"002129": "Linksys",
+	"00226B": "Linksys",
+	"002369": "Linksys",
+	"00259C": "Linksys",
+	"C0C1C0": "Linksys",
+	"001D60": "ASUSTek",
+	"002618": "ASUSTek",
+	"04D4C4": "ASUSTek",
+	"08606E": "ASUSTek",
+	"1C872C": "ASUSTek",
+	"2C56DC": "ASUSTek",
+	"50465D": "ASUSTek",
+	"AC220B": "ASUSTek",
+	"BCEE7B": "ASUSTek",
+	"F46D04": "ASUSTek",
+	"00040E": "AVM (FRITZ!Box)",
+	"001C4A": "AVM (FRITZ!Box)",
+	"0024FE": "AVM (FRITZ!Box)",
+	"3810D5": "AVM (FRITZ!Box)",
+	"3CA62F": "AVM (FRITZ!Box)",
+	"7CFF4D": "AVM (FRITZ!Box)",
+	"C02506": "AVM (FRITZ!Box)",
+	"E0286D": "AVM (FRITZ!Box)",
+	"444E6D": "AVM (FRITZ!Box)",
+	"2C91AB": "AVM (FRITZ!Box)",
+	"001349": "Zyxel",
+	"00A0C5": "Zyxel",
+
+	// Storage and cameras
+	"001132": "Synology",
+	"00089B": "QNAP",
+	"245EBE": "QNAP",
+	"4419B6": "Hikvision",
+	"BCAD28": "Hikvision",
+	"C056E3": "Hikvision",
+}
+
+var (
+	ouiDatabaseOnce sync.Once
+	ouiDatabase     map[string]string
+)
+
+// loadOUIDatabase reads the first installed vendor list. Lines look like
+// "B8-27-EB   (hex)\t\tRaspberry Pi Foundation" (IEEE) or
+// "B827EB Raspberry Pi Foundation" (nmap).
+func loadOUIDatabase() map[string]string {
+	for _, path := range ouiDatabases {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		vendors := make(map[string]string)
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if prefix, vendor, ok := strings.Cut(line, "(hex)"); ok {
+				prefix = strings.ReplaceAll(strings.TrimSpace(prefix), "-", "")
+				if len(prefix) == 6 {
+					vendors[strings.ToUpper(prefix)] = strings.TrimSpace(vendor)
+				}
+				continue
+			}
+			fields := strings.SplitN(line, " ", 2)
+			if len(fields) == 2 && len(fields[0]) == 6 && !strings.Contains(line, "(base 16)") {
+				vendors[strings.ToUpper(fields[0])] = strings.TrimSpace(fields[1])
+			}
+		}
+		f.Close()
+		if len(vendors) > 0 {
+			return vendors
+		}
+	}
+	return nil
+}
+
+// macVendor returns the vendor of a MAC address, or a note for locally
+// administered addresses (randomized phone MACs, containers, bridges)
+func macVendor(mac net.HardwareAddr) string {
+	if len(mac) < 3 {
+		return ""
+	}
+	prefix := strings.ToUpper(strings.ReplaceAll(mac[:3].String(), ":", ""))
+
+	ouiDatabaseOnce.Do(func() {
+		ouiDatabase = loadOUIDatabase()
+	})
+	if vendor, ok := ouiDatabase[prefix]; ok {
+		return vendor
+	}
+	if vendor, ok := ouiVendors[prefix]; ok {
+		return vendor
+	}
+	if mac[0]&0x02 != 0 {
+		if mac[0] == 0x02 && mac[1] == 0x42 {
+			return "Docker (locally administered)"
+		}
+		return "Locally administered"
+	}
+	return ""
+}
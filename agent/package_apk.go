@@ -0,0 +1,532 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+// apkPackageManager implements packageManager for Alpine Linux
+type apkPackageManager struct{}
+
+// apkInstalledDB is apk's database of installed packages
+const apkInstalledDB = "/lib/apk/db/installed"
+
+func (m *apkPackageManager) Name() string {
+	return "apk"
+}
+
+// ListInstalled reads the installed database directly, since apk list does
+// not report descriptions or sizes
+func (m *apkPackageManager) ListInstalled() ([]*pb.PackageInfo, error) {
+	data, err := os.ReadFile(apkInstalledDB)
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []*pb.PackageInfo
+	for _, record := range parseApkInstalledDB(data) {
+		packages = append(packages, record.info())
+	}
+	return packages, nil
+}
+
+// apkRecord is one package entry of the installed database
+type apkRecord map[string]string
+
+func (r apkRecord) info() *pb.PackageInfo {
+	size, _ := strconv.ParseUint(r["I"], 10, 64)
+	return &pb.PackageInfo{
+		Name:          r["P"],
+		Version:       r["V"],
+		Architecture:  r["A"],
+		Description:   r["T"],
+		Installed:     true,
+		Status:        "installed",
+		InstalledSize: size,
+	}
+}
+
+// parseApkInstalledDB parses the "X:value" records of /lib/apk/db/installed.
+// Records are separated by blank lines; repeated keys (file lists) keep the
+// first value.
+func parseApkInstalledDB(data []byte) []apkRecord {
+	var records []apkRecord
+	record := apkRecord{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if record["P"] != "" {
+				records = append(records, record)
+			}
+			record = apkRecord{}
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		if _, exists := record[key]; !exists {
+			record[key] = value
+		}
+	}
+	if record["P"] != "" {
+		records = append(records, record)
+	}
+	return records
+}
+
+// Search searches the repositories with apk search and marks installed results
+func (m *apkPackageManager) Search(term string, limit int) ([]*pb.PackageInfo, error) {
+	lines, err := commandLines("apk", "search", "-v", term)
+	if err != nil {
+		return nil, err
+	}
+
+	installed := make(map[string]string)
+	if data, err := os.ReadFile(apkInstalledDB); err == nil {
+		for _, record := range parseApkInstalledDB(data) {
+			installed[record["P"]] = record["V"]
+		}
+	}
+
+	var packages []*pb.PackageInfo
+	for _, line := range lines {
+		if len(packages) >= limit {
+			break
+		}
+
+		pkg := parseApkSearchLine(line)
+		if pkg == nil {
+			continue
+		}
+		if version, ok := installed[pkg.Name]; ok {
+			pkg.Installed = true
+			pkg.Status = "installed"
+			pkg.Version = version
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+// parseApkSearchLine parses "name-version - description" lines from apk search -v
+func parseApkSearchLine(line string) *pb.PackageInfo {
+	nameVersion, description, _ := strings.Cut(line, " - ")
+	name, version, ok := splitApkPackage(strings.TrimSpace(nameVersion))
+	if !ok {
+		return nil
+	}
+	return &pb.PackageInfo{
+		Name:        name,
+		Version:     version,
+		Description: strings.TrimSpace(description),
+		Status:      "not-installed",
+	}
+}
+
+// splitApkPackage splits "name-1.2.3-r0" into name and version. apk versions
+// always start with a digit and end with a -rN release suffix.
+func splitApkPackage(s string) (name, version string, ok bool) {
+	rel := strings.LastIndex(s, "-r")
+	if rel <= 0 || rel+2 >= len(s) {
+		return "", "", false
+	}
+	if _, err := strconv.Atoi(s[rel+2:]); err != nil {
+		return "", "", false
+	}
+	start := strings.LastIndex(s[:rel], "-")
+	if start <= 0 || start+1 >= len(s) || s[start+1] < '0' || s[start+1] > '9' {
+		return "", "", false
+	}
+	return s[:start], s[start+1:], true
+}
+
+// Details uses the installed database when possible and apk info otherwise
+func (m *apkPackageManager) Details(name string) (*pb.PackageDetails, error) {
+	if data, err := os.ReadFile(apkInstalledDB); err == nil {
+		for _, record := range parseApkInstalledDB(data) {
+			if record["P"] == name {
+				return record.details(), nil
+			}
+		}
+	}
+
+	output, err := exec.Command("apk", "info", "-a", "--", name).Output()
+	if err != nil {
+		return nil, err
+	}
+	sections := parseApkInfoSections(output)
+	if sections["package"] == "" {
+		return nil, os.ErrNotExist
+	}
+
+	_, version, _ := splitApkPackage(sections["package"])
+	details := &pb.PackageDetails{
+		Name:        name,
+		Version:     version,
+		Description: sections["description"],
+		Homepage:    sections["webpage"],
+		Status:      "not-installed",
+	}
+	details.LongDescription = details.Description
+	details.InstalledSize = parseApkSize(sections["installed size"])
+	return details, nil
+}
+
+func (r apkRecord) details() *pb.PackageDetails {
+	details := &pb.PackageDetails{
+		Name:            r["P"],
+		Version:         r["V"],
+		Architecture:    r["A"],
+		Description:     r["T"],
+		LongDescription: r["T"],
+		Maintainer:      r["m"],
+		Homepage:        r["U"],
+		Source:          r["o"],
+		Installed:       true,
+		Status:          "installed",
+	}
+	details.InstalledSize, _ = strconv.ParseUint(r["I"], 10, 64)
+	return details
+}
+
+// parseApkInfoSections parses apk info output, which is made of blocks like
+// "name-1.0-r0 description:" followed by value lines and a blank line. The
+// values of each block are joined by newlines and the "name-1.0-r0" prefix is
+// stored under "package".
+func parseApkInfoSections(output []byte) map[string]string {
+	sections := make(map[string]string)
+	var key string
+	var values []string
+
+	flush := func() {
+		if key != "" {
+			sections[key] = strings.Join(values, "\n")
+		}
+		key, values = "", nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			flush()
+		case key == "" && strings.HasSuffix(line, ":"):
+			header := strings.TrimSuffix(line, ":")
+			if pkg, field, found := strings.Cut(header, " "); found {
+				sections["package"] = pkg
+				key = field
+			}
+		case key != "":
+			values = append(values, line)
+		}
+	}
+	flush()
+	return sections
+}
+
+// parseApkSize parses sizes such as "924 KiB" printed by apk info
+func parseApkSize(s string) uint64 {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0
+	}
+
+	multiplier := 1.0
+	if len(fields) > 1 {
+		switch strings.ToUpper(fields[1]) {
+		case "KIB", "KB":
+			multiplier = 1024
+		case "MIB", "MB":
+			multiplier = 1024 * 1024
+		case "GIB", "GB":
+			multiplier = 1024 * 1024 * 1024
+		}
+	}
+	return uint64(value * multiplier)
+}
+
+// Dependencies uses apk info -R (depends) and -r (required by)
+func (m *apkPackageManager) Dependencies(name string) (*pb.PackageDependencies, error) {
+	deps := emptyPackageDependencies(name)
+
+	if output, err := exec.Command("apk", "info", "-R", "--", name).Output(); err == nil {
+		for _, dep := range apkSectionLines(output) {
+			deps.Depends = append(deps.Depends, stripVersionConstraint(dep))
+		}
+	}
+
+	if output, err := exec.Command("apk", "info", "-r", "--", name).Output(); err == nil {
+		deps.RequiredBy = append(deps.RequiredBy, apkSectionLines(output)...)
+	}
+
+	return deps, nil
+}
+
+// apkSectionLines returns the value lines of single-field apk info output
+func apkSectionLines(output []byte) []string {
+	for key, value := range parseApkInfoSections(output) {
+		if key != "package" {
+			return splitLines(value)
+		}
+	}
+	return nil
+}
+
+// stripVersionConstraint removes a version constraint such as ">=1.2" or
+// " >= 1.2" from a dependency
+func stripVersionConstraint(dep string) string {
+	if i := strings.IndexAny(dep, "<>=~ "); i > 0 {
+		return dep[:i]
+	}
+	return dep
+}
+
+// Upgradable lists packages from apk list -u
+func (m *apkPackageManager) Upgradable() ([]*pb.UpgradablePackage, error) {
+	lines, err := commandLines("apk", "list", "-u")
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []*pb.UpgradablePackage
+	for _, line := range lines {
+		if pkg := parseApkUpgradableLine(line); pkg != nil {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages, nil
+}
+
+// parseApkUpgradableLine parses a line from "apk list -u"
+// Format: name-newver arch {origin} (license) [upgradable from: name-oldver]
+func parseApkUpgradableLine(line string) *pb.UpgradablePackage {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return nil
+	}
+	name, newVersion, ok := splitApkPackage(fields[0])
+	if !ok {
+		return nil
+	}
+
+	oldVersion := ""
+	if fromIdx := strings.Index(line, "upgradable from: "); fromIdx >= 0 {
+		tail := strings.TrimSuffix(strings.TrimSpace(line[fromIdx+len("upgradable from: "):]), "]")
+		if _, version, ok := splitApkPackage(tail); ok {
+			oldVersion = version
+		}
+	}
+
+	return &pb.UpgradablePackage{
+		Name:           name,
+		CurrentVersion: oldVersion,
+		NewVersion:     newVersion,
+		Architecture:   fields[1],
+	}
+}
+
+// LastRefresh returns the newest cached APKINDEX
+func (m *apkPackageManager) LastRefresh() (time.Time, bool) {
+	matches, _ := filepath.Glob("/var/cache/apk/APKINDEX.*.tar.gz")
+	var newest time.Time
+	for _, path := range matches {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	return newest, !newest.IsZero()
+}
+
+// apkPackageName is an Alpine package name with an optional @repository tag
+// and version constraint (=, ~, <, >, <=, >=)
+var apkPackageName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]*(@[A-Za-z0-9._-]+)?([<>=~]{1,2}[0-9][A-Za-z0-9._+-]*)?$`)
+
+func (m *apkPackageManager) CheckName(name string) error {
+	return checkPackageName(apkPackageName, name)
+}
+
+func (m *apkPackageManager) step(phase string, args ...string) packageStep {
+	// Wait for the database lock instead of failing immediately
+	return packageStep{phase: phase, command: "apk", args: append([]string{"--wait", "120"}, args...)}
+}
+
+func (m *apkPackageManager) RefreshStep() packageStep {
+	return m.step("update", "update")
+}
+
+func (m *apkPackageManager) InstallStep(name string) packageStep {
+	return m.step("install", "add", "--", name)
+}
+
+func (m *apkPackageManager) RemoveStep(name string) packageStep {
+	return m.step("remove", "del", "--", name)
+}
+
+func (m *apkPackageManager) PurgeStep(name string) packageStep {
+	return m.step("purge", "del", "--purge", "--", name)
+}
+
+func (m *apkPackageManager) UpgradeStep(name string) packageStep {
+	if name == "" {
+		return m.step("upgrade", "upgrade")
+	}
+	return m.step("upgrade", "add", "--upgrade", "--", name)
+}
+
+// Simulate runs the operation with apk --simulate. apk does not report
+// per-package sizes up front, so only removals are sized from the database.
+func (m *apkPackageManager) Simulate(op pb.PackageOperation, name string) (*pb.PackageSimulation, error) {
+	step, err := packageOperationStep(m, op, name)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command("apk", append([]string{"--simulate"}, step.args...)...)
+	cmd.Env = append(os.Environ(), "LANG=C")
+	output, runErr := cmd.CombinedOutput()
+
+	sim := parseApkSimulation(output)
+	if runErr != nil {
+		sim.Success = false
+		sim.Error = firstErrorLine(string(output), runErr)
+	}
+
+	if len(sim.Remove) > 0 {
+		if data, err := os.ReadFile(apkInstalledDB); err == nil {
+			sizes := make(map[string]int64)
+			for _, record := range parseApkInstalledDB(data) {
+				sizes[record["P"]], _ = strconv.ParseInt(record["I"], 10, 64)
+			}
+			for _, pkg := range sim.Remove {
+				pkg.SizeDelta = -sizes[pkg.Name]
+				sim.DiskSizeDelta += pkg.SizeDelta
+			}
+		}
+	}
+
+	return sim, nil
+}
+
+// parseApkSimulation parses "(1/3) Installing name (version)" and
+// "(1/1) Upgrading name (old -> new)" progress lines
+func parseApkSimulation(output []byte) *pb.PackageSimulation {
+	sim := &pb.PackageSimulation{Success: true}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "(") {
+			continue
+		}
+		_, rest, found := strings.Cut(line, ") ")
+		if !found {
+			continue
+		}
+
+		fields := strings.Fields(rest)
+		if len(fields) < 2 {
+			continue
+		}
+		pkg := &pb.SimulatedPackage{Name: fields[1]}
+
+		var versions string
+		if open := strings.Index(rest, "("); open >= 0 {
+			versions = strings.TrimSuffix(rest[open+1:], ")")
+		}
+		oldVersion, newVersion, isChange := strings.Cut(versions, " -> ")
+
+		switch fields[0] {
+		case "Installing":
+			pkg.NewVersion = versions
+			sim.Install = append(sim.Install, pkg)
+		case "Upgrading", "Downgrading", "Replacing":
+			if isChange {
+				pkg.CurrentVersion, pkg.NewVersion = oldVersion, newVersion
+			} else {
+				pkg.NewVersion = versions
+			}
+			sim.Upgrade = append(sim.Upgrade, pkg)
+		case "Purging", "Deleting":
+			pkg.CurrentVersion = versions
+			sim.Remove = append(sim.Remove, pkg)
+		}
+	}
+
+	return sim
+}
+
+// apkWorldFile lists the packages explicitly requested, with constraints
+const apkWorldFile = "/etc/apk/world"
+
+// Holds lists packages pinned to an exact version in /etc/apk/world
+func (m *apkPackageManager) Holds() ([]*pb.PackageHold, error) {
+	data, err := os.ReadFile(apkWorldFile)
+	if err != nil {
+		return nil, err
+	}
+	return parseApkWorldHolds(string(data)), nil
+}
+
+// parseApkWorldHolds returns "name=version" entries of the world file
+func parseApkWorldHolds(world string) []*pb.PackageHold {
+	holds := []*pb.PackageHold{}
+	for _, entry := range strings.Fields(world) {
+		i := strings.IndexAny(entry, "=<>~")
+		if i <= 0 || entry[i] != '=' {
+			continue
+		}
+		holds = append(holds, &pb.PackageHold{Name: entry[:i], Version: entry[i+1:]})
+	}
+	return holds
+}
+
+// HoldStep constrains packages to their installed version, or drops the constraint
+func (m *apkPackageManager) HoldStep(names []string, hold bool) (packageStep, error) {
+	if !hold {
+		return m.step("unhold", append([]string{"add", "--"}, names...)...), nil
+	}
+
+	data, err := os.ReadFile(apkInstalledDB)
+	if err != nil {
+		return packageStep{}, err
+	}
+	installed := make(map[string]string)
+	for _, record := range parseApkInstalledDB(data) {
+		installed[record["P"]] = record["V"]
+	}
+
+	args := []string{"add", "--"}
+	for _, name := range names {
+		version, ok := installed[name]
+		if !ok {
+			return packageStep{}, fmt.Errorf("package %s is not installed", name)
+		}
+		args = append(args, name+"="+version)
+	}
+	return m.step("hold", args...), nil
+}
+
+// SecurityUpgradeSteps is not available; Alpine does not mark security updates
+func (m *apkPackageManager) SecurityUpgradeSteps() ([]packageStep, func(), error) {
+	return nil, nil, fmt.Errorf("security-only upgrades are not supported by apk")
+}
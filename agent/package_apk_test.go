@@ -0,0 +1,102 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "pi_agent/proto"
+)
+
+func TestParseApkInstalledDB(t *testing.T) {
+	records := parseApkInstalledDB(fixture(t, "apk-installed"))
+	if len(records) != 3 {
+		t.Fatalf("parsed %d records, want 3", len(records))
+	}
+
+	want := []*pb.PackageInfo{
+		{Name: "busybox", Version: "1.36.1-r15", Architecture: "aarch64", Description: "Size optimized toolbox of many common UNIX utilities", Installed: true, Status: "installed", InstalledSize: 946176},
+		{Name: "musl", Version: "1.2.4_git20230717-r4", Architecture: "aarch64", Description: "the musl c library (libc) implementation", Installed: true, Status: "installed", InstalledSize: 663552},
+		{Name: "py3-pip", Version: "23.3.1-r0", Architecture: "noarch", Description: "Tool for installing and managing Python packages (for python3)", Installed: true, Status: "installed", InstalledSize: 11857920},
+	}
+	for i := range want {
+		if got := records[i].info(); !proto.Equal(got, want[i]) {
+			t.Errorf("record %d = %v, want %v", i, got, want[i])
+		}
+	}
+
+	// Repeated keys such as the file list keep their first value
+	if got := records[0]["R"]; got != "busybox" {
+		t.Errorf("first R of busybox = %q, want busybox", got)
+	}
+	if got := records[2]["D"]; got != "python3 py3-setuptools" {
+		t.Errorf("D of py3-pip = %q", got)
+	}
+}
+
+func TestParseApkSearchLine(t *testing.T) {
+	var got []*pb.PackageInfo
+	for _, line := range fixtureLines(t, "apk-search") {
+		if pkg := parseApkSearchLine(line); pkg != nil {
+			got = append(got, pkg)
+		}
+	}
+
+	want := []*pb.PackageInfo{
+		{Name: "busybox", Version: "1.36.1-r15", Description: "Size optimized toolbox of many common UNIX utilities", Status: "not-installed"},
+		{Name: "busybox-extras", Version: "1.36.1-r15", Description: "Additional binaries of Busybox", Status: "not-installed"},
+		{Name: "libstdc++", Version: "13.2.1_git20231014-r0", Description: "GNU C++ standard runtime library", Status: "not-installed"},
+		{Name: "musl", Version: "1.2.4_git20230717-r4", Description: "the musl c library (libc) implementation", Status: "not-installed"},
+		{Name: "py3-pip", Version: "23.3.1-r0", Description: "Tool for installing and managing Python packages (for python3)", Status: "not-installed"},
+		{Name: "font-noto-cjk", Version: "20201206-r2", Description: "Noto Sans CJK fonts - Pan-CJK typefaces", Status: "not-installed"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parsed %d packages, want %d (warnings skipped)", len(got), len(want))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("package %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSplitApkPackage(t *testing.T) {
+	tests := []struct {
+		in, name, version string
+		ok                bool
+	}{
+		{"busybox-1.36.1-r15", "busybox", "1.36.1-r15", true},
+		{"py3-pip-23.3.1-r0", "py3-pip", "23.3.1-r0", true},
+		{"musl-1.2.4_git20230717-r4", "musl", "1.2.4_git20230717-r4", true},
+		{"libstdc++-13.2.1_git20231014-r0", "libstdc++", "13.2.1_git20231014-r0", true},
+		{"font-noto-cjk-20201206-r2", "font-noto-cjk", "20201206-r2", true},
+		{"linux-rpi-6.6.31-r0", "linux-rpi", "6.6.31-r0", true},
+		{"busybox", "", "", false},
+		{"busybox-r15", "", "", false},
+		{"busybox-1.36.1-rc1", "", "", false},
+		{"name-abc-r0", "", "", false},
+		{"-1.0-r0", "", "", false},
+	}
+	for _, tt := range tests {
+		name, version, ok := splitApkPackage(tt.in)
+		if name != tt.name || version != tt.version || ok != tt.ok {
+			t.Errorf("splitApkPackage(%q) = %q, %q, %v; want %q, %q, %v", tt.in, name, version, ok, tt.name, tt.version, tt.ok)
+		}
+	}
+}
+
+func TestParseApkSimulation(t *testing.T) {
+	want := &pb.PackageSimulation{
+		Success: true,
+		Install: []*pb.SimulatedPackage{
+			{Name: "brotli-libs", NewVersion: "1.1.0-r2"},
+			{Name: "c-ares", NewVersion: "1.34.5-r0"},
+			{Name: "nghttp2-libs", NewVersion: "1.64.0-r0"},
+		},
+		Upgrade: []*pb.SimulatedPackage{{Name: "libcrypto3", CurrentVersion: "3.3.3-r0", NewVersion: "3.3.4-r0"}},
+		Remove:  []*pb.SimulatedPackage{{Name: "nano", CurrentVersion: "8.2-r0"}},
+	}
+	if got := parseApkSimulation(fixture(t, "apk-simulate")); !proto.Equal(got, want) {
+		t.Errorf("simulation:\n got %v\nwant %v", got, want)
+	}
+}
@@ -0,0 +1,542 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+// aptPackageManager implements packageManager for Debian based systems
+type aptPackageManager struct {
+	index aptIndex
+}
+
+// dpkgQueryFormat is the dpkg-query output format parsed by parseDpkgQueryLine
+const dpkgQueryFormat = "${Package}\t${Version}\t${Architecture}\t${Status}\t${binary:Summary}\t${Installed-Size}\t${Section}\n"
+
+func (m *aptPackageManager) Name() string {
+	return "apt"
+}
+
+// ListInstalled lists installed packages with size and section info
+func (m *aptPackageManager) ListInstalled() ([]*pb.PackageInfo, error) {
+	lines, err := commandLines("dpkg-query", "-W", "-f="+dpkgQueryFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []*pb.PackageInfo
+	for _, line := range lines {
+		if pkg := parseDpkgQueryLine(line); pkg != nil {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages, nil
+}
+
+// parseDpkgQueryLine parses one line of dpkg-query output in dpkgQueryFormat.
+// Packages that are not fully installed are skipped.
+func parseDpkgQueryLine(line string) *pb.PackageInfo {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 7 {
+		return nil
+	}
+
+	// Only include installed packages
+	if !strings.Contains(fields[3], "install ok installed") {
+		return nil
+	}
+
+	// Parse installed size (convert KB to bytes)
+	var installedSize uint64
+	if size, err := strconv.ParseUint(fields[5], 10, 64); err == nil {
+		installedSize = size * 1024
+	}
+
+	return &pb.PackageInfo{
+		Name:          fields[0],
+		Version:       fields[1],
+		Architecture:  fields[2],
+		Description:   fields[4],
+		Installed:     true,
+		Status:        "installed",
+		InstalledSize: installedSize,
+		Section:       fields[6],
+	}
+}
+
+// Upgradable lists packages from apt list --upgradable
+func (m *aptPackageManager) Upgradable() ([]*pb.UpgradablePackage, error) {
+	lines, err := commandLines("apt", "list", "--upgradable")
+	if err != nil {
+		return nil, err
+	}
+	return parseAptUpgradable(lines), nil
+}
+
+// parseAptUpgradable parses "apt list --upgradable", skipping its header
+// and the CLI warning
+func parseAptUpgradable(lines []string) []*pb.UpgradablePackage {
+	var packages []*pb.UpgradablePackage
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, "Listing...") || strings.HasPrefix(line, "WARNING:") {
+			continue
+		}
+		if pkg := parseUpgradableLine(line); pkg != nil {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages
+}
+
+// parseUpgradableLine parses a line from "apt list --upgradable"
+// Format: name/source version arch [upgradable from: old_version]
+func parseUpgradableLine(line string) *pb.UpgradablePackage {
+	// Split on "/" to get package name
+	slashIdx := strings.Index(line, "/")
+	if slashIdx < 0 {
+		return nil
+	}
+	name := line[:slashIdx]
+	rest := line[slashIdx+1:]
+
+	// Split remaining by whitespace
+	parts := strings.Fields(rest)
+	if len(parts) < 3 {
+		return nil
+	}
+
+	newVersion := parts[1]
+	arch := parts[2]
+
+	// Extract old version from "[upgradable from: x.y.z]"
+	oldVersion := ""
+	fromIdx := strings.Index(line, "upgradable from: ")
+	if fromIdx >= 0 {
+		tail := line[fromIdx+len("upgradable from: "):]
+		oldVersion = strings.TrimSuffix(strings.TrimSpace(tail), "]")
+	}
+
+	return &pb.UpgradablePackage{
+		Name:           name,
+		CurrentVersion: oldVersion,
+		NewVersion:     newVersion,
+		Architecture:   arch,
+	}
+}
+
+// LastRefresh returns the modification time of the apt lists
+func (m *aptPackageManager) LastRefresh() (time.Time, bool) {
+	if info, err := os.Stat("/var/lib/apt/lists/partial"); err == nil {
+		return info.ModTime(), true
+	}
+	if info, err := os.Stat("/var/cache/apt/pkgcache.bin"); err == nil {
+		return info.ModTime(), true
+	}
+	return time.Time{}, false
+}
+
+// aptPackageName is a Debian package name with an optional architecture,
+// followed by an optional =version or /suite as apt-get accepts
+var aptPackageName = regexp.MustCompile(`^[a-z0-9][a-z0-9+.-]+(:[a-z0-9-]+)?(=[0-9][A-Za-z0-9.+~:-]*|/[A-Za-z0-9.+~_-]+)?$`)
+
+func (m *aptPackageManager) CheckName(name string) error {
+	return checkPackageName(aptPackageName, name)
+}
+
+func (m *aptPackageManager) step(phase string, args ...string) packageStep {
+	return packageStep{phase: phase, command: "apt-get", args: args, apt: true, dpkgLock: true}
+}
+
+func (m *aptPackageManager) RefreshStep() packageStep {
+	return m.step("update", "update")
+}
+
+func (m *aptPackageManager) InstallStep(name string) packageStep {
+	return m.step("install", "install", "-y", "--", name)
+}
+
+func (m *aptPackageManager) RemoveStep(name string) packageStep {
+	return m.step("remove", "remove", "-y", "--", name)
+}
+
+func (m *aptPackageManager) PurgeStep(name string) packageStep {
+	return m.step("purge", "purge", "-y", "--", name)
+}
+
+func (m *aptPackageManager) UpgradeStep(name string) packageStep {
+	if name == "" {
+		return m.step("upgrade", "upgrade", "-y")
+	}
+	return m.step("upgrade", "install", "--only-upgrade", "-y", "--", name)
+}
+
+// aptStatus is a parsed line from apt's APT::Status-Fd output
+type aptStatus struct {
+	kind    string // dlstatus, pmstatus, pmerror, pmconffile
+	pkg     string // Package name (pm*) or file number (dlstatus)
+	percent float64
+	message string
+}
+
+// parseAptStatusLine parses "kind:pkg:percent:message" status lines. Both
+// the package ("libc6:arm64") and the message may contain colons, so the
+// percent is the first numeric field after the package.
+func parseAptStatusLine(line string) (aptStatus, bool) {
+	parts := strings.Split(line, ":")
+	for i := 2; i < len(parts)-1; i++ {
+		percent, err := strconv.ParseFloat(parts[i], 64)
+		if err != nil {
+			continue
+		}
+		return aptStatus{
+			kind:    parts[0],
+			pkg:     strings.Join(parts[1:i], ":"),
+			percent: percent,
+			message: strings.TrimSpace(strings.Join(parts[i+1:], ":")),
+		}, true
+	}
+	return aptStatus{}, false
+}
+
+// aptProgress combines download and install progress into one percentage.
+// When files are downloaded the download phase covers 0-50% and dpkg 50-100%.
+type aptProgress struct {
+	downloaded bool
+	percent    float64
+}
+
+func (p *aptProgress) update(st aptStatus) {
+	var percent float64
+	switch st.kind {
+	case "dlstatus":
+		p.downloaded = true
+		percent = st.percent / 2
+	case "pmstatus":
+		if p.downloaded {
+			percent = 50 + st.percent/2
+		} else {
+			percent = st.percent
+		}
+	default:
+		return
+	}
+
+	// Never move backwards
+	if percent > p.percent {
+		p.percent = percent
+	}
+	if p.percent > 100 {
+		p.percent = 100
+	}
+}
+
+// Simulate runs the operation through apt-get -s and sizes the result from
+// the apt cache and dpkg database
+func (m *aptPackageManager) Simulate(op pb.PackageOperation, name string) (*pb.PackageSimulation, error) {
+	step, err := packageOperationStep(m, op, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without -y apt lists essential removals instead of refusing them
+	args := []string{"-s"}
+	for _, arg := range step.args {
+		if arg != "-y" {
+			args = append(args, arg)
+		}
+	}
+
+	cmd := exec.Command("apt-get", args...)
+	cmd.Env = append(os.Environ(), "DEBIAN_FRONTEND=noninteractive", "LANG=C")
+	output, runErr := cmd.CombinedOutput()
+
+	sim := parseAptSimulation(output)
+	if runErr != nil {
+		sim.Success = false
+		sim.Error = firstErrorLine(string(output), runErr)
+	}
+
+	m.sizeSimulation(sim)
+	return sim, nil
+}
+
+// parseAptSimulation parses apt-get -s output. Inst/Remv/Purg lines give the
+// exact actions; the "The following ..." lists add held back, autoremovable
+// and essential packages, and removals when apt stops before acting.
+func parseAptSimulation(output []byte) *pb.PackageSimulation {
+	sim := &pb.PackageSimulation{Success: true}
+	removed := make(map[string]bool)
+	var removeSection []string
+	section := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.HasPrefix(line, "  ") {
+			for _, pkg := range strings.Fields(line) {
+				pkg = strings.TrimSuffix(pkg, "*") // Marks purges
+				switch section {
+				case "remove":
+					removeSection = append(removeSection, pkg)
+				case "held":
+					sim.HeldBack = append(sim.HeldBack, &pb.SimulatedPackage{Name: pkg})
+				case "autoremovable":
+					sim.Autoremovable = append(sim.Autoremovable, pkg)
+				case "essential":
+					sim.EssentialRemoved = append(sim.EssentialRemoved, pkg)
+				}
+			}
+			continue
+		}
+
+		switch {
+		case strings.Contains(line, "will be REMOVED:"):
+			section = "remove"
+		case strings.Contains(line, "have been kept back:"):
+			section = "held"
+		case strings.Contains(line, "no longer required:"):
+			section = "autoremovable"
+		case strings.Contains(line, "essential packages will be removed"):
+			section = "essential"
+		case strings.HasPrefix(line, "This should NOT be done"):
+			// Part of the essential packages warning
+		default:
+			section = ""
+			if pkg, action := parseAptSimulationAction(line); pkg != nil {
+				switch action {
+				case "Inst":
+					if pkg.CurrentVersion == "" {
+						sim.Install = append(sim.Install, pkg)
+					} else if pkg.CurrentVersion != pkg.NewVersion {
+						sim.Upgrade = append(sim.Upgrade, pkg)
+					}
+				case "Remv", "Purg":
+					if !removed[pkg.Name] {
+						removed[pkg.Name] = true
+						sim.Remove = append(sim.Remove, pkg)
+					}
+				}
+			}
+		}
+	}
+
+	for _, name := range removeSection {
+		if !removed[name] {
+			removed[name] = true
+			sim.Remove = append(sim.Remove, &pb.SimulatedPackage{Name: name})
+		}
+	}
+
+	return sim
+}
+
+// parseAptSimulationAction parses simulated actions such as
+// "Inst name [old] (new Origin [arch])" and "Remv name [old]"
+func parseAptSimulationAction(line string) (*pb.SimulatedPackage, string) {
+	action, rest, found := strings.Cut(line, " ")
+	if !found || (action != "Inst" && action != "Remv" && action != "Purg") {
+		return nil, ""
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return nil, ""
+	}
+	pkg := &pb.SimulatedPackage{Name: fields[0]}
+
+	if open := strings.Index(rest, "["); open >= 0 && (strings.Index(rest, "(") < 0 || open < strings.Index(rest, "(")) {
+		if end := strings.Index(rest[open:], "]"); end > 0 {
+			pkg.CurrentVersion = rest[open+1 : open+end]
+		}
+	}
+
+	if open := strings.Index(rest, "("); open >= 0 {
+		inner := strings.TrimSuffix(rest[open+1:], ")")
+		if parts := strings.Fields(inner); len(parts) > 0 {
+			pkg.NewVersion = parts[0]
+		}
+		if archOpen := strings.LastIndex(inner, "["); archOpen >= 0 {
+			pkg.Architecture = strings.TrimSuffix(inner[archOpen+1:], "]")
+		}
+	}
+
+	return pkg, action
+}
+
+// sizeSimulation fills in download sizes and installed size deltas
+func (m *aptPackageManager) sizeSimulation(sim *pb.PackageSimulation) {
+	// Sizes of the versions that would be installed
+	var targets []string
+	for _, pkg := range append(append([]*pb.SimulatedPackage{}, sim.Install...), sim.Upgrade...) {
+		targets = append(targets, pkg.Name+"="+pkg.NewVersion)
+	}
+	available := make(map[string]aptVersionSize)
+	if len(targets) > 0 {
+		if output, err := exec.Command("apt-cache", append([]string{"show", "--no-all-versions", "--"}, targets...)...).Output(); err == nil {
+			available = parseAptVersionSizes(output)
+		}
+	}
+
+	// Installed versions of packages being replaced, removed or held
+	var current []string
+	for _, list := range [][]*pb.SimulatedPackage{sim.Upgrade, sim.Remove, sim.HeldBack} {
+		for _, pkg := range list {
+			current = append(current, pkg.Name)
+		}
+	}
+	installed := make(map[string]aptVersionSize)
+	if len(current) > 0 {
+		args := append([]string{"-W", "-f=${Package}\t${Version}\t${Installed-Size}\n", "--"}, current...)
+		// dpkg-query exits non-zero if any name is unknown but still prints the rest
+		output, _ := exec.Command("dpkg-query", args...).Output()
+		for _, line := range splitLines(string(output)) {
+			fields := strings.Split(line, "\t")
+			if len(fields) < 3 {
+				continue
+			}
+			size, _ := strconv.ParseInt(fields[2], 10, 64)
+			installed[fields[0]] = aptVersionSize{version: fields[1], installedSize: size * 1024}
+		}
+	}
+
+	for _, pkg := range sim.Install {
+		if size, ok := available[baseName(pkg.Name)+"="+pkg.NewVersion]; ok {
+			pkg.DownloadSize = size.downloadSize
+			pkg.SizeDelta = size.installedSize
+		}
+	}
+	for _, pkg := range sim.Upgrade {
+		if size, ok := available[baseName(pkg.Name)+"="+pkg.NewVersion]; ok {
+			pkg.DownloadSize = size.downloadSize
+			pkg.SizeDelta = size.installedSize - installed[baseName(pkg.Name)].installedSize
+		}
+	}
+	for _, pkg := range sim.Remove {
+		if old, ok := installed[baseName(pkg.Name)]; ok {
+			if pkg.CurrentVersion == "" {
+				pkg.CurrentVersion = old.version
+			}
+			pkg.SizeDelta = -old.installedSize
+		}
+	}
+	for _, pkg := range sim.HeldBack {
+		pkg.CurrentVersion = installed[baseName(pkg.Name)].version
+	}
+
+	for _, list := range [][]*pb.SimulatedPackage{sim.Install, sim.Upgrade, sim.Remove} {
+		for _, pkg := range list {
+			sim.DownloadSize += pkg.DownloadSize
+			sim.DiskSizeDelta += pkg.SizeDelta
+		}
+	}
+}
+
+// aptVersionSize holds the sizes of one package version in bytes
+type aptVersionSize struct {
+	version       string
+	downloadSize  int64
+	installedSize int64
+}
+
+// parseAptVersionSizes parses apt-cache show records into a map keyed by
+// "name=version"
+func parseAptVersionSizes(output []byte) map[string]aptVersionSize {
+	result := make(map[string]aptVersionSize)
+	var name string
+	var size aptVersionSize
+
+	flush := func() {
+		if name != "" && size.version != "" {
+			result[name+"="+size.version] = size
+		}
+		name, size = "", aptVersionSize{}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			flush()
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Package":
+			name = value
+		case "Version":
+			size.version = value
+		case "Size":
+			size.downloadSize, _ = strconv.ParseInt(value, 10, 64)
+		case "Installed-Size":
+			kb, _ := strconv.ParseInt(value, 10, 64)
+			size.installedSize = kb * 1024
+		}
+	}
+	flush()
+	return result
+}
+
+// baseName strips a multiarch ":arch" qualifier from a package name
+func baseName(name string) string {
+	if i := strings.Index(name, ":"); i > 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// Holds lists packages marked with apt-mark hold
+func (m *aptPackageManager) Holds() ([]*pb.PackageHold, error) {
+	names, err := commandLines("apt-mark", "showhold")
+	if err != nil {
+		return nil, err
+	}
+
+	holds := make([]*pb.PackageHold, 0, len(names))
+	if len(names) == 0 {
+		return holds, nil
+	}
+
+	// dpkg-query exits non-zero if any name is unknown but still prints the rest
+	args := append([]string{"-W", "-f=${Package}\t${Version}\n", "--"}, names...)
+	output, _ := exec.Command("dpkg-query", args...).Output()
+	versions := make(map[string]string)
+	for _, line := range splitLines(string(output)) {
+		if name, version, found := strings.Cut(line, "\t"); found {
+			versions[name] = version
+		}
+	}
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		holds = append(holds, &pb.PackageHold{Name: name, Version: versions[baseName(name)]})
+	}
+	return holds, nil
+}
+
+func (m *aptPackageManager) HoldStep(names []string, hold bool) (packageStep, error) {
+	action := "unhold"
+	if hold {
+		action = "hold"
+	}
+	return packageStep{
+		phase:    action,
+		command:  "apt-mark",
+		args:     append([]string{action, "--"}, names...),
+		dpkgLock: true,
+	}, nil
+}
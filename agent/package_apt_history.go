@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+// Log files read for package history; logrotate keeps older copies as
+// <name>.1 and <name>.N.gz
+const (
+	aptHistoryLog = "/var/log/apt/history.log"
+	dpkgLog       = "/var/log/dpkg.log"
+	// dpkg lines further apart than this start a new transaction
+	dpkgTransactionGap = 60 * time.Second
+)
+
+// aptHistoryPackageRe matches one "name:arch (versions)" entry of an
+// Install/Upgrade/Remove line
+var aptHistoryPackageRe = regexp.MustCompile(`([^\s,]+) \(([^)]*)\)`)
+
+// History returns apt transactions plus dpkg actions that did not go through
+// apt (e.g. dpkg -i), oldest first
+func (m *aptPackageManager) History() ([]*pb.PackageTransaction, error) {
+	var transactions []*pb.PackageTransaction
+	for _, path := range rotatedLogFiles(aptHistoryLog) {
+		err := readLogFile(path, func(r io.Reader) {
+			transactions = append(transactions, parseAptHistory(r)...)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var dpkgTransactions []*pb.PackageTransaction
+	for _, path := range rotatedLogFiles(dpkgLog) {
+		err := readLogFile(path, func(r io.Reader) {
+			dpkgTransactions = append(dpkgTransactions, parseDpkgLog(r)...)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, dpkg := range dpkgTransactions {
+		if !overlapsTransaction(dpkg, transactions) {
+			transactions = append(transactions, dpkg)
+		}
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].StartTime < transactions[j].StartTime
+	})
+	return transactions, nil
+}
+
+// rotatedLogFiles returns a log and its rotated copies, oldest first
+func rotatedLogFiles(path string) []string {
+	type rotated struct {
+		path  string
+		index int
+	}
+	var files []rotated
+
+	matches, _ := filepath.Glob(path + "*")
+	for _, match := range matches {
+		suffix := strings.TrimSuffix(strings.TrimPrefix(match, path), ".gz")
+		if suffix == "" {
+			if match == path {
+				files = append(files, rotated{match, 0})
+			}
+			continue
+		}
+		index, err := strconv.Atoi(strings.TrimPrefix(suffix, "."))
+		if err != nil || !strings.HasPrefix(suffix, ".") {
+			continue
+		}
+		files = append(files, rotated{match, index})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].index > files[j].index
+	})
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.path
+	}
+	return paths
+}
+
+// readLogFile opens a plain or gzip-compressed log. Missing files are skipped.
+func readLogFile(path string, read func(io.Reader)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+	read(r)
+	return nil
+}
+
+// parseLogTime parses the local timestamps apt and dpkg write. apt pads the
+// time with two spaces.
+func parseLogTime(value string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02 15:04:05", strings.Join(strings.Fields(value), " "), time.Local)
+}
+
+// parseAptHistory parses the Start-Date ... End-Date stanzas of history.log
+func parseAptHistory(r io.Reader) []*pb.PackageTransaction {
+	var transactions []*pb.PackageTransaction
+	var current *pb.PackageTransaction
+
+	scanner := bufio.NewScanner(r)
+	// A single Install: line lists every package of the run
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), ": ")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		if key == "Start-Date" {
+			current = &pb.PackageTransaction{Source: "apt"}
+			if t, err := parseLogTime(value); err == nil {
+				current.StartTime = t.Unix()
+			}
+			transactions = append(transactions, current)
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		switch key {
+		case "End-Date":
+			if t, err := parseLogTime(value); err == nil {
+				current.EndTime = t.Unix()
+			}
+			current = nil
+		case "Commandline":
+			current.CommandLine = value
+		case "Requested-By":
+			current.RequestedBy = value
+		case "Error":
+			current.Error = value
+		case "Install", "Upgrade", "Downgrade", "Reinstall", "Remove", "Purge":
+			current.Changes = append(current.Changes, parseAptHistoryPackages(strings.ToLower(key), value)...)
+		}
+	}
+	return transactions
+}
+
+// parseAptHistoryPackages parses entries like "foo:arm64 (1.0, automatic)"
+// or "foo:arm64 (1.0, 1.1)" for upgrades and downgrades
+func parseAptHistoryPackages(action, value string) []*pb.PackageChange {
+	var changes []*pb.PackageChange
+	for _, match := range aptHistoryPackageRe.FindAllStringSubmatch(value, -1) {
+		change := &pb.PackageChange{Action: action}
+		change.Name, change.Architecture, _ = strings.Cut(match[1], ":")
+
+		versions := strings.Split(match[2], ", ")
+		if versions[len(versions)-1] == "automatic" {
+			change.Automatic = true
+			versions = versions[:len(versions)-1]
+		}
+
+		switch {
+		case len(versions) >= 2:
+			change.OldVersion, change.NewVersion = versions[0], versions[1]
+		case action == "remove" || action == "purge":
+			change.OldVersion = versions[0]
+		case action == "reinstall":
+			change.OldVersion, change.NewVersion = versions[0], versions[0]
+		default:
+			change.NewVersion = versions[0]
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// parseDpkgLog groups install/upgrade/remove/purge lines of dpkg.log into
+// transactions of activity without long pauses
+func parseDpkgLog(r io.Reader) []*pb.PackageTransaction {
+	var transactions []*pb.PackageTransaction
+	var current *pb.PackageTransaction
+	var last time.Time
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// 2024-01-10 10:00:02 upgrade foo:arm64 1.0 1.1
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		t, err := parseLogTime(fields[0] + " " + fields[1])
+		if err != nil {
+			continue
+		}
+		if current != nil && t.Sub(last) > dpkgTransactionGap {
+			current = nil
+		}
+		last = t
+
+		action := fields[2]
+		if len(fields) != 6 || (action != "install" && action != "upgrade" && action != "remove" && action != "purge") {
+			if current != nil {
+				current.EndTime = t.Unix()
+			}
+			continue
+		}
+
+		if current == nil {
+			current = &pb.PackageTransaction{Source: "dpkg", StartTime: t.Unix()}
+			transactions = append(transactions, current)
+		}
+		current.EndTime = t.Unix()
+
+		change := &pb.PackageChange{Action: action}
+		change.Name, change.Architecture, _ = strings.Cut(fields[3], ":")
+		if fields[4] != "<none>" {
+			change.OldVersion = fields[4]
+		}
+		if fields[5] != "<none>" {
+			change.NewVersion = fields[5]
+		}
+		if action == "upgrade" && change.OldVersion == change.NewVersion {
+			change.Action = "reinstall"
+		}
+		current.Changes = append(current.Changes, change)
+	}
+	return transactions
+}
+
+// overlapsTransaction reports whether a dpkg transaction happened during one
+// of the apt transactions, which already record the same changes
+func overlapsTransaction(dpkg *pb.PackageTransaction, apt []*pb.PackageTransaction) bool {
+	for _, t := range apt {
+		end := t.EndTime
+		if end == 0 {
+			// apt was interrupted before writing End-Date
+			end = t.StartTime + int64(dpkgTransactionGap/time.Second)
+		}
+		if dpkg.StartTime <= end+1 && dpkg.EndTime >= t.StartTime-1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "pi_agent/proto"
+)
+
+// logTime is a local timestamp as apt and dpkg write them
+func logTime(value string) int64 {
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", value, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return t.Unix()
+}
+
+// The fixtures come from history.log and dpkg.log of a Debian 12 system;
+// the long Install: line of the first stanza is cut to six packages
+func TestParseAptHistory(t *testing.T) {
+	got := parseAptHistory(bytes.NewReader(fixture(t, "apt-history.log")))
+
+	automatic := func(name, version string) *pb.PackageChange {
+		return &pb.PackageChange{Action: "install", Name: name, Architecture: "amd64", NewVersion: version, Automatic: true}
+	}
+	want := []*pb.PackageTransaction{
+		{
+			Source:      "apt",
+			StartTime:   logTime("2025-09-27 19:59:53"),
+			EndTime:     logTime("2025-09-27 19:59:55"),
+			CommandLine: "apt-get install -y freeglut3-dev libxcb-cursor0 libmagic1 libmagic-dev libxkbcommon-x11-0 libxcomposite-dev",
+			Changes: []*pb.PackageChange{
+				automatic("libxcb-util1", "0.4.0-1+b1"),
+				automatic("libglu1-mesa-dev", "9.0.2-1.1"),
+				automatic("libglx-dev", "1.6.0-1"),
+				automatic("libegl-dev", "1.6.0-1"),
+				automatic("libgles-dev", "1.6.0-1"),
+				automatic("libglut-dev", "3.4.0-1"),
+			},
+		},
+		{
+			Source:      "apt",
+			StartTime:   logTime("2026-10-19 14:22:31"),
+			EndTime:     logTime("2026-10-19 14:22:31"),
+			CommandLine: "apt-get install -y -o APT::Status-Fd=3 ./pi-agent-fixture-conflict_2.0-1_all.deb",
+			Error:       "Sub-process /usr/bin/dpkg returned an error code (1)",
+			Changes: []*pb.PackageChange{
+				{Action: "install", Name: "pi-agent-fixture-conflict", Architecture: "amd64", NewVersion: "2.0-1"},
+			},
+		},
+		{
+			Source:      "apt",
+			StartTime:   logTime("2026-10-19 14:22:37"),
+			EndTime:     logTime("2026-10-19 14:22:37"),
+			CommandLine: "apt-get remove -y -o APT::Status-Fd=3 pi-agent-fixture-lib:arm64 pi-agent-fixture-lib:amd64",
+			Changes: []*pb.PackageChange{
+				{Action: "remove", Name: "pi-agent-fixture-lib", Architecture: "amd64", OldVersion: "1.0-1"},
+				{Action: "remove", Name: "pi-agent-fixture-lib", Architecture: "arm64", OldVersion: "1.0-1"},
+			},
+		},
+		{
+			Source:      "apt",
+			StartTime:   logTime("2026-10-19 14:27:56"),
+			EndTime:     logTime("2026-10-19 14:27:56"),
+			CommandLine: "apt-get install -y ./demo/pi-agent-demo_1.0-1_all.deb",
+			RequestedBy: "pi (1000)",
+			Changes: []*pb.PackageChange{
+				{Action: "install", Name: "pi-agent-demo", Architecture: "amd64", NewVersion: "1.0-1"},
+			},
+		},
+		{
+			Source:      "apt",
+			StartTime:   logTime("2026-10-19 14:27:59"),
+			EndTime:     logTime("2026-10-19 14:27:59"),
+			CommandLine: "apt-get install -y ./demo/pi-agent-demo_1.1-1_all.deb",
+			Changes: []*pb.PackageChange{
+				{Action: "upgrade", Name: "pi-agent-demo", Architecture: "amd64", OldVersion: "1.0-1", NewVersion: "1.1-1"},
+			},
+		},
+		{
+			Source:      "apt",
+			StartTime:   logTime("2026-10-19 14:28:02"),
+			EndTime:     logTime("2026-10-19 14:28:02"),
+			CommandLine: "apt-get purge -y pi-agent-demo",
+			Changes: []*pb.PackageChange{
+				{Action: "purge", Name: "pi-agent-demo", Architecture: "amd64", OldVersion: "1.1-1"},
+			},
+		},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parsed %d transactions, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("transaction %d:\n got %v\nwant %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestReadRotatedAptHistory(t *testing.T) {
+	var got []*pb.PackageTransaction
+	err := readLogFile(filepath.Join("testdata", "apt-history.log.1.gz"), func(r io.Reader) {
+		got = parseAptHistory(r)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[1].CommandLine != "apt-get install -y nodejs" ||
+		!proto.Equal(got[1].Changes[0], &pb.PackageChange{Action: "install", Name: "nodejs", Architecture: "amd64", NewVersion: "20.19.5-1nodesource1"}) {
+		t.Errorf("gzipped history = %v", got)
+	}
+
+	if err := readLogFile(filepath.Join(t.TempDir(), "history.log.2.gz"), func(io.Reader) {
+		t.Error("read called for a missing file")
+	}); err != nil {
+		t.Errorf("missing file: %v", err)
+	}
+}
+
+// The same package installed, upgraded and purged through apt, then a
+// minute later with dpkg -i and dpkg -P
+func TestParseDpkgLog(t *testing.T) {
+	got := parseDpkgLog(bytes.NewReader(fixture(t, "dpkg.log")))
+
+	changes := []*pb.PackageChange{
+		{Action: "install", Name: "pi-agent-demo", Architecture: "all", NewVersion: "1.0-1"},
+		{Action: "upgrade", Name: "pi-agent-demo", Architecture: "all", OldVersion: "1.0-1", NewVersion: "1.1-1"},
+		{Action: "remove", Name: "pi-agent-demo", Architecture: "all", OldVersion: "1.1-1"},
+		{Action: "purge", Name: "pi-agent-demo", Architecture: "all", OldVersion: "1.1-1"},
+	}
+	want := []*pb.PackageTransaction{
+		{Source: "dpkg", StartTime: logTime("2026-10-19 14:27:56"), EndTime: logTime("2026-10-19 14:28:02"), Changes: changes},
+		{Source: "dpkg", StartTime: logTime("2026-10-19 14:29:07"), EndTime: logTime("2026-10-19 14:29:09"), Changes: changes},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parsed %d transactions, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("transaction %d:\n got %v\nwant %v", i, got[i], want[i])
+		}
+	}
+
+	// The first run went through apt and is already in history.log
+	apt := parseAptHistory(bytes.NewReader(fixture(t, "apt-history.log")))
+	if !overlapsTransaction(got[0], apt) || overlapsTransaction(got[1], apt) {
+		t.Error("only the dpkg run of the apt transactions should overlap")
+	}
+}
+
+func TestRotatedLogFiles(t *testing.T) {
+	dir := t.TempDir()
+	log := filepath.Join(dir, "history.log")
+	for _, name := range []string{"history.log", "history.log.1", "history.log.2.gz", "history.log.10.gz", "history.log.old", "history.logs"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := rotatedLogFiles(log)
+	want := []string{log + ".10.gz", log + ".2.gz", log + ".1", log}
+	if len(got) != len(want) {
+		t.Fatalf("rotated files = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("rotated files = %q, want %q (oldest first)", got, want)
+			break
+		}
+	}
+}
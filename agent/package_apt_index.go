@@ -0,0 +1,659 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+// Files the package index is built from
+const (
+	dpkgStatusFile = "/var/lib/dpkg/status"
+	aptListsDir    = "/var/lib/apt/lists"
+	aptHelper      = "/usr/lib/apt/apt-helper"
+)
+
+// debPackage holds the fields of a dpkg status or Packages stanza that the
+// agent reports
+type debPackage struct {
+	name            string
+	version         string
+	architecture    string
+	status          string // dpkg status, e.g. "install ok installed"
+	summary         string
+	searchText      string // Lowercase name and summary
+	longDescription string
+	descriptionMD5  string
+	installedSize   uint64 // Bytes
+	section         string
+	maintainer      string
+	homepage        string
+	source          string
+	tags            []string
+	depends         []string // Depends and Pre-Depends
+	recommends      []string
+	suggests        []string
+	conflicts       []string    // Conflicts and Breaks
+	release         *aptRelease // Repository of a list record, nil for dpkg status
+}
+
+// installed reports whether dpkg considers the package fully installed,
+// whatever its selection (install, hold, ...)
+func (p *debPackage) installed() bool {
+	return strings.HasSuffix(p.status, " ok installed")
+}
+
+// fileStamp identifies a version of an index source file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// aptIndex is an in-memory copy of the dpkg status database and the apt
+// package lists. Each part is re-read when its files change.
+type aptIndex struct {
+	mu sync.Mutex
+
+	statusStamp fileStamp
+	installed   map[string]*debPackage
+
+	listStamps map[string]fileStamp
+	versions   map[string][]*debPackage // Every list record per package
+
+	prefStamps map[string]fileStamp
+	available  map[string]*debPackage // Candidate version per package
+
+	requiredBy map[string][]string // Reverse Depends/Pre-Depends
+	nativeArch string
+}
+
+// snapshot refreshes stale parts of the index and returns the current maps.
+// The maps are replaced rather than modified, so callers may read them
+// without holding the lock.
+func (x *aptIndex) snapshot() (installed, available map[string]*debPackage, requiredBy map[string][]string, err error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if x.nativeArch == "" {
+		if output, err := exec.Command("dpkg", "--print-architecture").Output(); err == nil {
+			x.nativeArch = strings.TrimSpace(string(output))
+		}
+	}
+
+	changed := false
+	info, err := os.Stat(dpkgStatusFile)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if stamp := (fileStamp{info.ModTime(), info.Size()}); x.installed == nil || stamp != x.statusStamp {
+		installed := make(map[string]*debPackage)
+		if err := readLogFile(dpkgStatusFile, func(r io.Reader) {
+			parseDebControl(r, func(pkg *debPackage) {
+				if pkg.installed() {
+					x.addPreferred(installed, pkg)
+				}
+			})
+		}); err != nil {
+			return nil, nil, nil, err
+		}
+		x.installed, x.statusStamp = installed, stamp
+		changed = true
+	}
+
+	lists, _ := filepath.Glob(filepath.Join(aptListsDir, "*_Packages*"))
+	releaseFiles, _ := filepath.Glob(filepath.Join(aptListsDir, "*Release"))
+	stamps := statFiles(append(lists, releaseFiles...))
+	if x.versions == nil || !sameStamps(stamps, x.listStamps) {
+		releases := make(map[string]*aptRelease, len(releaseFiles))
+		for _, path := range releaseFiles {
+			releases[path] = readAptRelease(path)
+		}
+		versions := make(map[string][]*debPackage)
+		for _, path := range lists {
+			release := listRelease(path, releases)
+			readPackageList(path, func(r io.Reader) {
+				parseDebControl(r, func(pkg *debPackage) {
+					pkg.release = release
+					versions[pkg.name] = append(versions[pkg.name], pkg)
+				})
+			})
+		}
+		x.versions, x.listStamps = versions, stamps
+		changed = true
+	}
+
+	prefFiles, err := aptPreferencesFiles()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if prefStamps := statFiles(prefFiles); changed || !sameStamps(prefStamps, x.prefStamps) {
+		stanzas, err := readAptPreferences()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		x.available = x.candidates(newAptPolicy(stanzas))
+		x.prefStamps = prefStamps
+		x.requiredBy = buildReverseDepends(x.installed, x.available)
+	}
+	return x.installed, x.available, x.requiredBy, nil
+}
+
+// candidates picks each package's candidate among the list records of the
+// native (or "all") architecture, or of foreign ones if it has no native
+// record. Packages without a candidate are left out.
+func (x *aptIndex) candidates(policy *aptPolicy) map[string]*debPackage {
+	available := make(map[string]*debPackage, len(x.versions))
+	for name, records := range x.versions {
+		var native []*debPackage
+		for _, pkg := range records {
+			if x.native(pkg) {
+				native = append(native, pkg)
+			}
+		}
+		if len(native) > 0 {
+			records = native
+		}
+		if pkg := policy.candidate(records, x.installed[name]); pkg != nil {
+			available[name] = pkg
+		}
+	}
+	return available
+}
+
+// native reports whether a package is of the native or "all" architecture
+func (x *aptIndex) native(pkg *debPackage) bool {
+	return pkg.architecture == x.nativeArch || pkg.architecture == "all"
+}
+
+// addPreferred stores pkg unless the map already has a record of the native
+// or "all" architecture for the same name
+func (x *aptIndex) addPreferred(m map[string]*debPackage, pkg *debPackage) {
+	if existing, ok := m[pkg.name]; !ok || (x.native(pkg) && !x.native(existing)) {
+		m[pkg.name] = pkg
+	}
+}
+
+// statFiles stamps the files that exist among paths
+func statFiles(paths []string) map[string]fileStamp {
+	stamps := make(map[string]fileStamp, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			stamps[path] = fileStamp{info.ModTime(), info.Size()}
+		}
+	}
+	return stamps
+}
+
+// sameStamps reports whether two sets of list files are identical
+func sameStamps(a, b map[string]fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, stamp := range a {
+		if b[path] != stamp {
+			return false
+		}
+	}
+	return true
+}
+
+// readPackageList reads an apt list file. Plain and gzip files are read
+// directly; other compressions (lz4, xz, zstd) are decompressed by apt-helper.
+func readPackageList(path string, read func(io.Reader)) {
+	if strings.HasSuffix(path, "_Packages") || strings.HasSuffix(path, ".gz") {
+		readLogFile(path, read)
+		return
+	}
+
+	cmd := exec.Command(aptHelper, "cat-file", path)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return
+	}
+	if err := cmd.Start(); err != nil {
+		return
+	}
+	read(stdout)
+	io.Copy(io.Discard, stdout)
+	cmd.Wait()
+}
+
+// parseDebControl parses deb822 stanzas (dpkg status, Packages, Translation
+// files) and calls fn for each one that names a package
+func parseDebControl(r io.Reader, fn func(*debPackage)) {
+	pkg := &debPackage{}
+	var field string
+	var long strings.Builder
+
+	flush := func() {
+		if pkg.name != "" {
+			pkg.searchText = strings.ToLower(pkg.name + " " + pkg.summary)
+			pkg.longDescription = strings.TrimSpace(long.String())
+			fn(pkg)
+		}
+		pkg = &debPackage{}
+		field = ""
+		long.Reset()
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			flush()
+			continue
+		}
+
+		// Continuation lines only matter for descriptions; " ." is a blank line
+		if line[0] == ' ' || line[0] == '\t' {
+			if field == "description" {
+				if text := strings.TrimSpace(line); text != "." {
+					long.WriteString(text)
+				}
+				long.WriteString("\n")
+			}
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		field = strings.ToLower(key)
+		value = strings.TrimSpace(value)
+
+		switch field {
+		case "package":
+			pkg.name = value
+		case "version":
+			pkg.version = value
+		case "architecture":
+			pkg.architecture = value
+		case "status":
+			pkg.status = value
+		case "description", "description-en":
+			field = "description"
+			pkg.summary = value
+		case "description-md5":
+			pkg.descriptionMD5 = value
+		case "installed-size":
+			if size, err := strconv.ParseUint(value, 10, 64); err == nil {
+				pkg.installedSize = size * 1024 // Convert KB to bytes
+			}
+		case "section":
+			pkg.section = value
+		case "maintainer":
+			pkg.maintainer = value
+		case "homepage":
+			pkg.homepage = value
+		case "source":
+			pkg.source = value
+		case "tag":
+			for _, tag := range strings.Split(value, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					pkg.tags = append(pkg.tags, tag)
+				}
+			}
+		case "depends", "pre-depends":
+			pkg.depends = append(pkg.depends, parseRelationNames(value)...)
+		case "recommends":
+			pkg.recommends = append(pkg.recommends, parseRelationNames(value)...)
+		case "suggests":
+			pkg.suggests = append(pkg.suggests, parseRelationNames(value)...)
+		case "conflicts", "breaks":
+			pkg.conflicts = append(pkg.conflicts, parseRelationNames(value)...)
+		}
+	}
+	flush()
+}
+
+// parseRelationNames returns the package names of a relationship field like
+// "libc6 (>= 2.28), default-mta | mail-transport-agent, python3:any".
+// Alternatives are listed individually.
+func parseRelationNames(value string) []string {
+	var names []string
+	for _, group := range strings.Split(value, ",") {
+		for _, alternative := range strings.Split(group, "|") {
+			name := strings.Fields(alternative)
+			if len(name) == 0 {
+				continue
+			}
+			pkg, _, _ := strings.Cut(name[0], ":")
+			if pkg, _, _ = strings.Cut(pkg, "("); pkg != "" {
+				names = append(names, pkg)
+			}
+		}
+	}
+	return names
+}
+
+// buildReverseDepends maps each package to the packages that depend on it
+func buildReverseDepends(installed, available map[string]*debPackage) map[string][]string {
+	seen := make(map[string]map[string]bool)
+	add := func(pkg *debPackage) {
+		for _, dep := range pkg.depends {
+			if seen[dep] == nil {
+				seen[dep] = make(map[string]bool)
+			}
+			seen[dep][pkg.name] = true
+		}
+	}
+	for _, pkg := range available {
+		add(pkg)
+	}
+	for _, pkg := range installed {
+		add(pkg)
+	}
+
+	requiredBy := make(map[string][]string, len(seen))
+	for dep, names := range seen {
+		list := make([]string, 0, len(names))
+		for name := range names {
+			list = append(list, name)
+		}
+		sort.Strings(list)
+		requiredBy[dep] = list
+	}
+	return requiredBy
+}
+
+// translatedDescription finds the long description of a package in the
+// Translation files, which is where Debian keeps it for packages that are
+// not installed
+func translatedDescription(name, md5 string) string {
+	files, _ := filepath.Glob(filepath.Join(aptListsDir, "*_i18n_Translation-en*"))
+	var found string
+	for _, path := range files {
+		readPackageList(path, func(r io.Reader) {
+			parseDebControl(r, func(pkg *debPackage) {
+				if found == "" && pkg.name == name && (md5 == "" || pkg.descriptionMD5 == md5) {
+					found = pkg.longDescription
+				}
+			})
+		})
+		if found != "" {
+			break
+		}
+	}
+	return found
+}
+
+// firstInstallTime returns when dpkg.log first recorded installing a package
+func firstInstallTime(name string) (time.Time, bool) {
+	var installedAt time.Time
+	readLogFile(dpkgLog, func(r io.Reader) {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) < 4 || fields[2] != "install" {
+				continue
+			}
+			if pkg, _, _ := strings.Cut(fields[3], ":"); pkg != name {
+				continue
+			}
+			if t, err := parseLogTime(fields[0] + " " + fields[1]); err == nil {
+				installedAt = t
+				return
+			}
+		}
+	})
+	return installedAt, !installedAt.IsZero()
+}
+
+// searchMatcher matches packages against a search term. apt-cache search
+// takes a regular expression, but most searches are plain words, which a
+// substring match handles much faster. Terms such as "g++" that are not
+// valid expressions are matched literally.
+func searchMatcher(term string) func(*debPackage) bool {
+	if regexp.QuoteMeta(term) != term {
+		if re, err := regexp.Compile("(?i)" + term); err == nil {
+			return func(pkg *debPackage) bool {
+				return re.MatchString(pkg.name) || re.MatchString(pkg.summary)
+			}
+		}
+	}
+	lowerTerm := strings.ToLower(term)
+	return func(pkg *debPackage) bool {
+		return strings.Contains(pkg.searchText, lowerTerm)
+	}
+}
+
+// Search matches the term against package names and summaries like
+// apt-cache search, serving results from the index
+func (m *aptPackageManager) Search(term string, limit int) ([]*pb.PackageInfo, error) {
+	installed, available, _, err := m.index.snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	match := searchMatcher(term)
+	var names []string
+	for name, pkg := range available {
+		if match(pkg) {
+			names = append(names, name)
+		}
+	}
+	// Locally installed packages that no repository provides
+	for name, pkg := range installed {
+		if _, ok := available[name]; !ok && match(pkg) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var packages []*pb.PackageInfo
+	for _, name := range names {
+		if len(packages) >= limit {
+			break
+		}
+		if pkg, ok := installed[name]; ok {
+			packages = append(packages, &pb.PackageInfo{
+				Name:          name,
+				Version:       pkg.version,
+				Architecture:  pkg.architecture,
+				Description:   pkg.summary,
+				Installed:     true,
+				Status:        "installed",
+				InstalledSize: pkg.installedSize,
+				Section:       pkg.section,
+			})
+			continue
+		}
+		pkg := available[name]
+		packages = append(packages, &pb.PackageInfo{
+			Name:         name,
+			Version:      pkg.version,
+			Architecture: pkg.architecture,
+			Description:  pkg.summary,
+			Status:       "not-installed",
+			Section:      pkg.section,
+		})
+	}
+	return packages, nil
+}
+
+// Details describes the installed version of a package, or the candidate
+// version if it is not installed
+func (m *aptPackageManager) Details(name string) (*pb.PackageDetails, error) {
+	installed, available, _, err := m.index.snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, isInstalled := installed[name]
+	if !isInstalled {
+		if pkg = available[name]; pkg == nil {
+			return nil, fmt.Errorf("no package named %s", name)
+		}
+	}
+
+	details := &pb.PackageDetails{
+		Name:            name,
+		Version:         pkg.version,
+		Architecture:    pkg.architecture,
+		Description:     pkg.summary,
+		LongDescription: pkg.longDescription,
+		Installed:       isInstalled,
+		Status:          "not-installed",
+		InstalledSize:   pkg.installedSize,
+		Maintainer:      pkg.maintainer,
+		Homepage:        pkg.homepage,
+		Section:         pkg.section,
+		Tags:            pkg.tags,
+		Source:          pkg.source,
+	}
+	if details.LongDescription == "" {
+		details.LongDescription = translatedDescription(name, pkg.descriptionMD5)
+	}
+
+	// dpkg status omits some fields the repository lists
+	if candidate := available[name]; candidate != nil {
+		if details.Homepage == "" {
+			details.Homepage = candidate.homepage
+		}
+		if len(details.Tags) == 0 {
+			details.Tags = candidate.tags
+		}
+	}
+
+	if isInstalled {
+		details.Status = "installed"
+		if t, ok := firstInstallTime(name); ok {
+			details.InstallDate = t.Unix()
+		}
+	}
+	return details, nil
+}
+
+// Dependencies lists the relationships of the installed (or candidate)
+// version and the packages that depend on it
+func (m *aptPackageManager) Dependencies(name string) (*pb.PackageDependencies, error) {
+	installed, available, requiredBy, err := m.index.snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	deps := emptyPackageDependencies(name)
+	pkg := installed[name]
+	if pkg == nil {
+		pkg = available[name]
+	}
+	if pkg != nil {
+		deps.Depends = append(deps.Depends, pkg.depends...)
+		deps.Recommends = append(deps.Recommends, pkg.recommends...)
+		deps.Suggests = append(deps.Suggests, pkg.suggests...)
+		deps.Conflicts = append(deps.Conflicts, pkg.conflicts...)
+	}
+	deps.RequiredBy = append(deps.RequiredBy, requiredBy[name]...)
+	return deps, nil
+}
+
+// compareDebianVersions orders two Debian version strings
+// ([epoch:]upstream[-revision]) as dpkg does
+func compareDebianVersions(a, b string) int {
+	epochA, restA := splitEpoch(a)
+	epochB, restB := splitEpoch(b)
+	if epochA != epochB {
+		if epochA < epochB {
+			return -1
+		}
+		return 1
+	}
+
+	upstreamA, revisionA := splitRevision(restA)
+	upstreamB, revisionB := splitRevision(restB)
+	if c := compareVersionPart(upstreamA, upstreamB); c != 0 {
+		return c
+	}
+	return compareVersionPart(revisionA, revisionB)
+}
+
+func splitEpoch(v string) (int, string) {
+	if epoch, rest, found := strings.Cut(v, ":"); found {
+		n, _ := strconv.Atoi(epoch)
+		return n, rest
+	}
+	return 0, v
+}
+
+func splitRevision(v string) (string, string) {
+	if i := strings.LastIndex(v, "-"); i >= 0 {
+		return v[:i], v[i+1:]
+	}
+	return v, ""
+}
+
+// versionCharOrder sorts "~" before everything (even the end of the
+// string), then letters, then other characters
+func versionCharOrder(c byte) int {
+	switch {
+	case c == '~':
+		return -1
+	case c >= '0' && c <= '9':
+		return 0
+	case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		return int(c)
+	default:
+		return int(c) + 256
+	}
+}
+
+// compareVersionPart compares alternating non-digit and digit runs
+func compareVersionPart(a, b string) int {
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+
+	for a != "" || b != "" {
+		// Non-digit prefix, compared character by character
+		for (a != "" && !isDigit(a[0])) || (b != "" && !isDigit(b[0])) {
+			var ca, cb int
+			if a != "" && !isDigit(a[0]) {
+				ca = versionCharOrder(a[0])
+			}
+			if b != "" && !isDigit(b[0]) {
+				cb = versionCharOrder(b[0])
+			}
+			if ca != cb {
+				if ca < cb {
+					return -1
+				}
+				return 1
+			}
+			if a != "" && !isDigit(a[0]) {
+				a = a[1:]
+			}
+			if b != "" && !isDigit(b[0]) {
+				b = b[1:]
+			}
+		}
+
+		// Numeric run, compared by value
+		var na, nb uint64
+		for a != "" && isDigit(a[0]) {
+			na = na*10 + uint64(a[0]-'0')
+			a = a[1:]
+		}
+		for b != "" && isDigit(b[0]) {
+			nb = nb*10 + uint64(b[0]-'0')
+			b = b[1:]
+		}
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSearchMatcher(t *testing.T) {
+	packages := []*debPackage{
+		{name: "g++", summary: "GNU C++ compiler"},
+		{name: "libstdc++6", summary: "GNU Standard C++ Library v3"},
+		{name: "vim", summary: "Vi IMproved - enhanced vi editor"},
+		{name: "python3", summary: "interactive high-level object-oriented language"},
+	}
+	for _, pkg := range packages {
+		pkg.searchText = strings.ToLower(pkg.name + " " + pkg.summary)
+	}
+
+	tests := []struct {
+		term string
+		want []string
+	}{
+		{"vim", []string{"vim"}},
+		{"VIM", []string{"vim"}},
+		{"g++", []string{"g++"}},
+		{"libstdc++", []string{"libstdc++6"}},
+		{"c++", []string{"g++", "libstdc++6"}},
+		{"^py.*3$", []string{"python3"}},
+		{"vi(m|rus)", []string{"vim"}},
+	}
+	for _, tt := range tests {
+		match := searchMatcher(tt.term)
+		var got []string
+		for _, pkg := range packages {
+			if match(pkg) {
+				got = append(got, pkg.name)
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%q matched %q, want %q", tt.term, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q matched %q, want %q", tt.term, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+// requireAptLists skips benchmarks on systems without apt package lists
+func requireAptLists(b *testing.B) {
+	if _, err := exec.LookPath("apt-cache"); err != nil {
+		b.Skip("apt-cache not installed")
+	}
+	if lists, _ := filepath.Glob(filepath.Join(aptListsDir, "*_Packages*")); len(lists) == 0 {
+		b.Skip("no apt package lists")
+	}
+	if _, err := os.Stat(dpkgStatusFile); err != nil {
+		b.Skip("no dpkg status file")
+	}
+}
+
+// The apt-cache variants run the commands Search, Details and Dependencies
+// used before the index: apt-cache plus a dpkg-query per result
+
+func BenchmarkAptSearch(b *testing.B) {
+	requireAptLists(b)
+	m := &aptPackageManager{}
+	if _, err := m.Search("python3", 50); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m.Search("python3", 50)
+		}
+	})
+	b.Run("apt-cache", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			lines, err := commandLines("apt-cache", "search", "python3")
+			if err != nil {
+				b.Fatal(err)
+			}
+			for j, line := range lines {
+				if j == 50 {
+					break
+				}
+				if name, _, found := strings.Cut(line, " - "); found {
+					exec.Command("dpkg-query", "-W", "-f=${Version}\t${Status}", name).Output()
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkAptDetails(b *testing.B) {
+	requireAptLists(b)
+	m := &aptPackageManager{}
+	if _, err := m.Details("bash"); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m.Details("bash")
+		}
+	})
+	b.Run("apt-cache", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			exec.Command("apt-cache", "show", "bash").Output()
+			exec.Command("dpkg-query", "-W", "-f=${db:Status-Status}\t${Installed-Size}", "bash").Output()
+		}
+	})
+}
+
+func BenchmarkAptDependencies(b *testing.B) {
+	requireAptLists(b)
+	m := &aptPackageManager{}
+	if _, err := m.Dependencies("bash"); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m.Dependencies("bash")
+		}
+	})
+	b.Run("apt-cache", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			exec.Command("apt-cache", "depends", "bash").Output()
+			exec.Command("apt-cache", "rdepends", "bash").Output()
+		}
+	})
+}
+
+// BenchmarkAptIndexBuild measures the cold load that the first request after
+// an apt update or dpkg run pays
+func BenchmarkAptIndexBuild(b *testing.B) {
+	requireAptLists(b)
+	for i := 0; i < b.N; i++ {
+		var index aptIndex
+		if _, _, _, err := index.snapshot(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	pb "pi_agent/proto"
+)
+
+// apt preferences locations and the prefix of files written by the agent
+const (
+	aptPreferencesFile    = "/etc/apt/preferences"
+	aptPreferencesDir     = "/etc/apt/preferences.d"
+	managedPinFilePrefix  = "pi-agent-"
+	managedPinExplanation = "Managed by pi-agent"
+)
+
+// aptPreferencesNameRe matches files apt reads from preferences.d
+var aptPreferencesNameRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Pins lists all stanzas from /etc/apt/preferences and preferences.d
+func (m *aptPackageManager) Pins() ([]*pb.PackagePin, error) {
+	pins, err := readAptPreferences()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(pins, func(i, j int) bool {
+		return pins[i].Package < pins[j].Package
+	})
+	return pins, nil
+}
+
+// aptPreferencesFiles lists the preferences files in the order apt reads
+// them: /etc/apt/preferences, then preferences.d by name
+func aptPreferencesFiles() ([]string, error) {
+	files := []string{aptPreferencesFile}
+	entries, err := os.ReadDir(aptPreferencesDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := filepath.Ext(name)
+		// apt only reads files without an extension or ending in .pref
+		if entry.IsDir() || !aptPreferencesNameRe.MatchString(name) || (ext != "" && ext != ".pref") {
+			continue
+		}
+		files = append(files, filepath.Join(aptPreferencesDir, name))
+	}
+	return files, nil
+}
+
+// readAptPreferences returns every stanza in apt's reading order
+func readAptPreferences() ([]*pb.PackagePin, error) {
+	files, err := aptPreferencesFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	pins := []*pb.PackagePin{}
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		managed := strings.HasPrefix(filepath.Base(path), managedPinFilePrefix)
+		for _, pin := range parseAptPreferences(string(data)) {
+			pin.File = path
+			pin.Managed = managed
+			pins = append(pins, pin)
+		}
+	}
+	return pins, nil
+}
+
+// parseAptPreferences parses Package/Pin/Pin-Priority stanzas separated by
+// blank lines. Comment lines and Explanation fields are ignored.
+func parseAptPreferences(data string) []*pb.PackagePin {
+	var pins []*pb.PackagePin
+	pin := &pb.PackagePin{}
+
+	flush := func() {
+		if pin.Package != "" && pin.Pin != "" {
+			pins = append(pins, pin)
+		}
+		pin = &pb.PackagePin{}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			flush()
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "package":
+			pin.Package = value
+		case "pin":
+			pin.Pin = value
+		case "pin-priority":
+			if priority, err := strconv.Atoi(value); err == nil {
+				pin.Priority = int32(priority)
+			}
+		}
+	}
+	flush()
+	return pins
+}
+
+// managedPinPath returns the preferences.d file used for a package's pin
+func managedPinPath(pkg string) string {
+	safe := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, pkg)
+	return filepath.Join(aptPreferencesDir, managedPinFilePrefix+safe+".pref")
+}
+
+// validatePin checks a pin before it is written
+func validatePin(pin *pb.PackagePin) error {
+	if strings.TrimSpace(pin.Package) == "" {
+		return fmt.Errorf("package is required")
+	}
+	if strings.ContainsAny(pin.Package+pin.Pin, "\r\n") {
+		return fmt.Errorf("package and pin must be a single line")
+	}
+
+	kind, value, _ := strings.Cut(strings.TrimSpace(pin.Pin), " ")
+	switch kind {
+	case "version", "release", "origin":
+	default:
+		return fmt.Errorf("pin must start with version, release or origin")
+	}
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("pin %s needs a value", kind)
+	}
+	if pin.Priority == 0 {
+		return fmt.Errorf("pin priority must not be zero")
+	}
+	return nil
+}
+
+// SetPin writes an agent-managed preferences file for the package and checks
+// that apt still parses its configuration, restoring the previous file if not
+func (m *aptPackageManager) SetPin(pin *pb.PackagePin) error {
+	if err := validatePin(pin); err != nil {
+		return err
+	}
+
+	path := managedPinPath(pin.Package)
+	previous, readErr := os.ReadFile(path)
+	baseline := aptPreferencesProblems()
+
+	content := fmt.Sprintf("Explanation: %s\nPackage: %s\nPin: %s\nPin-Priority: %d\n",
+		managedPinExplanation, strings.TrimSpace(pin.Package), strings.TrimSpace(pin.Pin), pin.Priority)
+
+	if err := os.MkdirAll(aptPreferencesDir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return err
+	}
+
+	for problem := range aptPreferencesProblems() {
+		if baseline[problem] {
+			continue
+		}
+		if readErr == nil {
+			os.WriteFile(path, previous, 0644)
+		} else {
+			os.Remove(path)
+		}
+		return fmt.Errorf("apt rejected the pin: %s", problem)
+	}
+	return nil
+}
+
+// ClearPin removes the agent-managed pin for a package
+func (m *aptPackageManager) ClearPin(pkg string) error {
+	path := managedPinPath(pkg)
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no agent-managed pin for %s", pkg)
+		}
+		return err
+	}
+	return nil
+}
+
+// aptPreferencesProblems returns the errors and warnings apt prints while
+// loading its configuration and preferences
+func aptPreferencesProblems() map[string]bool {
+	cmd := exec.Command("apt-cache", "policy")
+	cmd.Env = append(os.Environ(), "LANG=C")
+	output, _ := cmd.CombinedOutput()
+
+	problems := make(map[string]bool)
+	for _, line := range splitLines(string(output)) {
+		if strings.HasPrefix(line, "E:") || strings.HasPrefix(line, "W:") {
+			problems[strings.TrimSpace(line)] = true
+		}
+	}
+	return problems
+}
@@ -0,0 +1,388 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	pb "pi_agent/proto"
+)
+
+// aptRelease describes the repository a package list belongs to, with the
+// properties apt preferences can match
+type aptRelease struct {
+	site         string // Repository host, empty for local files
+	archive      string // a= (Suite)
+	codename     string // n=
+	component    string // c=
+	origin       string // o=
+	label        string // l=
+	version      string // v=
+	architecture string // b=
+
+	notAutomatic         bool
+	butAutomaticUpgrades bool
+}
+
+// dpkgStatusRelease stands for the dpkg status file, which apt lists as the
+// source of installed versions
+var dpkgStatusRelease = &aptRelease{archive: "now"}
+
+// defaultPriority is the priority apt gives a release no pin matches
+func (r *aptRelease) defaultPriority() int {
+	switch {
+	case r == dpkgStatusRelease:
+		return 100
+	case r.notAutomatic && r.butAutomaticUpgrades:
+		return 100
+	case r.notAutomatic:
+		return 1
+	default:
+		return 500
+	}
+}
+
+// readAptRelease reads the fields of a Release or InRelease file. The
+// signature of an InRelease file is not checked; apt did that on download.
+func readAptRelease(path string) *aptRelease {
+	release := &aptRelease{}
+	readLogFile(path, func(r io.Reader) {
+		inHeader := false
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "-----BEGIN PGP SIGNED MESSAGE"):
+				inHeader = true
+				continue
+			case strings.HasPrefix(line, "-----BEGIN PGP SIGNATURE"):
+				return
+			case inHeader:
+				inHeader = line != ""
+				continue
+			case line == "" || line[0] == ' ':
+				continue
+			}
+
+			key, value, found := strings.Cut(line, ":")
+			if !found {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			switch strings.ToLower(key) {
+			case "suite":
+				release.archive = value
+			case "codename":
+				release.codename = value
+			case "origin":
+				release.origin = value
+			case "label":
+				release.label = value
+			case "version":
+				release.version = value
+			case "notautomatic":
+				release.notAutomatic = strings.EqualFold(value, "yes")
+			case "butautomaticupgrades":
+				release.butAutomaticUpgrades = strings.EqualFold(value, "yes")
+			}
+		}
+	})
+	return release
+}
+
+// listRelease returns the release of a package list file, found as the
+// longest Release or InRelease file whose name prefixes the list's. The
+// component and architecture come from the rest of the list's name, e.g.
+// "main_binary-amd64_Packages.lz4".
+func listRelease(listPath string, releases map[string]*aptRelease) *aptRelease {
+	list := filepath.Base(listPath)
+	var prefix string
+	var base *aptRelease
+	for path, release := range releases {
+		name := filepath.Base(path)
+		name = strings.TrimSuffix(strings.TrimSuffix(name, "InRelease"), "Release")
+		if len(name) > len(prefix) && strings.HasPrefix(list, name) {
+			prefix, base = name, release
+		}
+	}
+
+	release := &aptRelease{}
+	if base != nil {
+		*release = *base
+	}
+	site, _, _ := strings.Cut(list, "_")
+	if site, err := url.PathUnescape(site); err == nil {
+		release.site = site
+	}
+	if prefix != "" {
+		rest := strings.TrimPrefix(list, prefix)
+		if component, arch, found := strings.Cut(rest, "_binary-"); found {
+			release.component = strings.ReplaceAll(component, "_", "/")
+			release.architecture, _, _ = strings.Cut(arch, "_")
+		}
+	}
+	return release
+}
+
+// aptPin is a preferences stanza ready for matching
+type aptPin struct {
+	packages []*aptPattern // Nil for "Package: *"
+	sources  []*aptPattern // src: entries
+	kind     string        // version, release or origin
+	version  *aptPattern
+	fields   map[string]*aptPattern // Release keys for release pins
+	priority int
+}
+
+// aptPattern matches a pin value the way apt does: /regex/, a glob, or an
+// exact string
+type aptPattern struct {
+	exact string
+	glob  bool
+	re    *regexp.Regexp
+}
+
+func newAptPattern(value string) *aptPattern {
+	if len(value) > 1 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
+		if re, err := regexp.Compile(value[1 : len(value)-1]); err == nil {
+			return &aptPattern{re: re}
+		}
+	}
+	return &aptPattern{exact: value, glob: strings.ContainsAny(value, "*?[")}
+}
+
+func (p *aptPattern) match(s string) bool {
+	switch {
+	case p.re != nil:
+		return p.re.MatchString(s)
+	case p.glob:
+		ok, _ := filepath.Match(p.exact, s)
+		return ok
+	default:
+		return p.exact == s
+	}
+}
+
+// newAptPin compiles a preferences stanza. Stanzas apt would not understand
+// yield nil.
+func newAptPin(stanza *pb.PackagePin) *aptPin {
+	pin := &aptPin{priority: int(stanza.Priority)}
+	for _, name := range strings.Fields(stanza.Package) {
+		switch {
+		case name == "*":
+		case strings.HasPrefix(name, "src:"):
+			pin.sources = append(pin.sources, newAptPattern(strings.TrimPrefix(name, "src:")))
+		default:
+			pin.packages = append(pin.packages, newAptPattern(name))
+		}
+	}
+
+	kind, value, _ := strings.Cut(strings.TrimSpace(stanza.Pin), " ")
+	value = strings.TrimSpace(value)
+	pin.kind = kind
+	switch kind {
+	case "version":
+		pin.version = newAptPattern(value)
+	case "origin":
+		pin.fields = map[string]*aptPattern{"site": newAptPattern(strings.Trim(value, `"`))}
+	case "release":
+		pin.fields = make(map[string]*aptPattern)
+		for _, term := range strings.Split(value, ",") {
+			key, value, found := strings.Cut(strings.TrimSpace(term), "=")
+			if !found {
+				key, value = "v", key
+			}
+			pin.fields[key] = newAptPattern(value)
+		}
+	default:
+		return nil
+	}
+	return pin
+}
+
+// generic reports whether the pin applies to every package
+func (p *aptPin) generic() bool {
+	return p.packages == nil && p.sources == nil
+}
+
+// appliesTo reports whether a specific pin names the package
+func (p *aptPin) appliesTo(pkg *debPackage) bool {
+	for _, pattern := range p.packages {
+		if pattern.match(pkg.name) {
+			return true
+		}
+	}
+	if len(p.sources) > 0 {
+		source, _, _ := strings.Cut(pkg.source, " ")
+		if source == "" {
+			source = pkg.name
+		}
+		for _, pattern := range p.sources {
+			if pattern.match(source) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchRelease reports whether a release or origin pin selects the release
+func (p *aptPin) matchRelease(r *aptRelease) bool {
+	if p.fields == nil {
+		return false
+	}
+	for key, pattern := range p.fields {
+		value, known := r.field(key)
+		if !known || !pattern.match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// field returns a release property by its pin key
+func (r *aptRelease) field(key string) (string, bool) {
+	switch key {
+	case "site":
+		return r.site, true
+	case "a":
+		return r.archive, true
+	case "n":
+		return r.codename, true
+	case "c":
+		return r.component, true
+	case "o":
+		return r.origin, true
+	case "l":
+		return r.label, true
+	case "v":
+		return r.version, true
+	case "b":
+		return r.architecture, true
+	}
+	return "", false
+}
+
+// aptPolicy picks candidate versions from apt preferences. It follows apt's
+// rules except that APT::Default-Release is not read.
+type aptPolicy struct {
+	generic  []*aptPin
+	specific []*aptPin
+}
+
+func newAptPolicy(stanzas []*pb.PackagePin) *aptPolicy {
+	policy := &aptPolicy{}
+	for _, stanza := range stanzas {
+		pin := newAptPin(stanza)
+		switch {
+		case pin == nil:
+		case pin.generic():
+			// Generic pins select releases; apt ignores generic version pins
+			if pin.kind != "version" {
+				policy.generic = append(policy.generic, pin)
+			}
+		default:
+			policy.specific = append(policy.specific, pin)
+		}
+	}
+	return policy
+}
+
+// releasePriority is the priority of a release: the first generic pin that
+// matches it, or its default
+func (p *aptPolicy) releasePriority(r *aptRelease) int {
+	for _, pin := range p.generic {
+		if pin.matchRelease(r) {
+			return pin.priority
+		}
+	}
+	return r.defaultPriority()
+}
+
+// debVersion is one version of a package and the releases that carry it
+type debVersion struct {
+	pkg      *debPackage
+	releases []*aptRelease
+}
+
+// priority is the first specific pin matching the version, or else the
+// highest priority among its releases
+func (p *aptPolicy) priority(v *debVersion, pins []*aptPin) int {
+	for _, pin := range pins {
+		if pin.kind == "version" {
+			if pin.version.match(v.pkg.version) {
+				return pin.priority
+			}
+			continue
+		}
+		for _, release := range v.releases {
+			if pin.matchRelease(release) {
+				return pin.priority
+			}
+		}
+	}
+
+	best := 0
+	for i, release := range v.releases {
+		if priority := p.releasePriority(release); i == 0 || priority > best {
+			best = priority
+		}
+	}
+	return best
+}
+
+// candidate chooses the version apt would install: going from the highest
+// version down, each one with a strictly higher positive priority replaces
+// the choice, but versions older than the installed one need priority 1000.
+// It returns nil if nothing qualifies or the installed version, which no
+// list carries, wins.
+func (p *aptPolicy) candidate(records []*debPackage, installed *debPackage) *debPackage {
+	var versions []*debVersion
+	byVersion := make(map[string]*debVersion)
+	add := func(pkg *debPackage, release *aptRelease) {
+		v := byVersion[pkg.version]
+		if v == nil {
+			v = &debVersion{pkg: pkg}
+			byVersion[pkg.version] = v
+			versions = append(versions, v)
+		}
+		v.releases = append(v.releases, release)
+	}
+	for _, pkg := range records {
+		add(pkg, pkg.release)
+	}
+	if installed != nil {
+		add(installed, dpkgStatusRelease)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareDebianVersions(versions[i].pkg.version, versions[j].pkg.version) > 0
+	})
+
+	var pins []*aptPin
+	for _, pin := range p.specific {
+		if pin.appliesTo(versions[0].pkg) {
+			pins = append(pins, pin)
+		}
+	}
+
+	var chosen *debVersion
+	chosenPriority := 0
+	for _, v := range versions {
+		priority := p.priority(v, pins)
+		if priority <= chosenPriority {
+			continue
+		}
+		if installed != nil && priority < 1000 && compareDebianVersions(v.pkg.version, installed.version) < 0 {
+			continue
+		}
+		chosen, chosenPriority = v, priority
+	}
+	if chosen == nil || chosen.pkg.release == nil {
+		return nil
+	}
+	return chosen.pkg
+}
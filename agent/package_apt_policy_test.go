@@ -0,0 +1,130 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	pb "pi_agent/proto"
+)
+
+func TestReadAptRelease(t *testing.T) {
+	got := readAptRelease(filepath.Join("testdata", "apt-inrelease"))
+	want := aptRelease{
+		archive:              "bookworm-backports",
+		codename:             "bookworm-backports",
+		origin:               "Debian",
+		label:                "Debian Backports",
+		notAutomatic:         true,
+		butAutomaticUpgrades: true,
+	}
+	if *got != want {
+		t.Errorf("release = %+v, want %+v (signature block ignored)", *got, want)
+	}
+	if priority := got.defaultPriority(); priority != 100 {
+		t.Errorf("default priority = %d, want 100", priority)
+	}
+}
+
+func TestListRelease(t *testing.T) {
+	nodesource := &aptRelease{archive: "nodistro", origin: ". nodistro"}
+	bookworm := &aptRelease{archive: "oldstable", codename: "bookworm"}
+	updates := &aptRelease{archive: "oldstable-updates", codename: "bookworm-updates"}
+	releases := map[string]*aptRelease{
+		"/var/lib/apt/lists/deb.nodesource.com_node%5f20.x_dists_nodistro_InRelease": nodesource,
+		"/var/lib/apt/lists/deb.debian.org_debian_dists_bookworm_InRelease":          bookworm,
+		"/var/lib/apt/lists/deb.debian.org_debian_dists_bookworm-updates_InRelease":  updates,
+	}
+
+	tests := []struct {
+		list string
+		want aptRelease
+	}{
+		{"deb.nodesource.com_node%5f20.x_dists_nodistro_main_binary-amd64_Packages.lz4",
+			aptRelease{site: "deb.nodesource.com", archive: "nodistro", origin: ". nodistro", component: "main", architecture: "amd64"}},
+		{"deb.debian.org_debian_dists_bookworm_non-free-firmware_binary-arm64_Packages",
+			aptRelease{site: "deb.debian.org", archive: "oldstable", codename: "bookworm", component: "non-free-firmware", architecture: "arm64"}},
+		{"deb.debian.org_debian_dists_bookworm-updates_main_binary-amd64_Packages.lz4",
+			aptRelease{site: "deb.debian.org", archive: "oldstable-updates", codename: "bookworm-updates", component: "main", architecture: "amd64"}},
+		{"example.com_repo_Packages", aptRelease{site: "example.com"}},
+	}
+	for _, tt := range tests {
+		if got := listRelease("/var/lib/apt/lists/"+tt.list, releases); *got != tt.want {
+			t.Errorf("%s:\n got %+v\nwant %+v", tt.list, *got, tt.want)
+		}
+	}
+}
+
+func TestAptPolicyCandidate(t *testing.T) {
+	stable := &aptRelease{site: "deb.debian.org", archive: "stable", codename: "bookworm", component: "main"}
+	security := &aptRelease{site: "security.debian.org", archive: "stable-security", codename: "bookworm-security", component: "main"}
+	experimental := &aptRelease{site: "deb.debian.org", archive: "experimental", codename: "rc-buggy", component: "main", notAutomatic: true}
+	vendor := &aptRelease{site: "deb.nodesource.com", archive: "nodistro", component: "main"}
+
+	record := func(name, version string, release *aptRelease) *debPackage {
+		return &debPackage{name: name, version: version, release: release}
+	}
+	nodejs := []*debPackage{
+		record("nodejs", "18.19.0+dfsg-6~deb12u2", stable),
+		record("nodejs", "18.19.0+dfsg-6~deb12u3", security),
+		record("nodejs", "20.19.5-1nodesource1", vendor),
+		record("nodejs", "20.18.0-1nodesource1", vendor),
+		record("nodejs", "22.0.0-1", experimental),
+	}
+	installed := func(version string) *debPackage {
+		return &debPackage{name: "nodejs", version: version}
+	}
+
+	tests := []struct {
+		name      string
+		pins      []*pb.PackagePin
+		installed *debPackage
+		want      string // Empty for no candidate
+	}{
+		{"highest automatic version", nil, nil, "20.19.5-1nodesource1"},
+		{"release pin", []*pb.PackagePin{
+			{Package: "nodejs", Pin: "release n=bookworm", Priority: 600},
+		}, nil, "18.19.0+dfsg-6~deb12u2"},
+		{"first specific pin wins", []*pb.PackagePin{
+			{Package: "nodejs", Pin: "origin deb.nodesource.com", Priority: 600},
+			{Package: "nodejs", Pin: "version 20.18.*", Priority: 1001},
+		}, nil, "20.19.5-1nodesource1"},
+		{"version pin", []*pb.PackagePin{
+			{Package: "nodejs", Pin: "version 20.18.*", Priority: 900},
+		}, nil, "20.18.0-1nodesource1"},
+		{"no downgrade below 1000", []*pb.PackagePin{
+			{Package: "nodejs", Pin: "version 20.18.*", Priority: 900},
+		}, installed("20.19.5-1nodesource1"), "20.19.5-1nodesource1"},
+		{"downgrade at 1000", []*pb.PackagePin{
+			{Package: "nodejs", Pin: "version 20.18.*", Priority: 1000},
+		}, installed("20.19.5-1nodesource1"), "20.18.0-1nodesource1"},
+		{"generic release pin", []*pb.PackagePin{
+			{Package: "*", Pin: "release n=bookworm-security", Priority: 990},
+		}, nil, "18.19.0+dfsg-6~deb12u3"},
+		{"generic version pins ignored", []*pb.PackagePin{
+			{Package: "*", Pin: "version 18.*", Priority: 990},
+		}, nil, "20.19.5-1nodesource1"},
+		{"negative priority excludes", []*pb.PackagePin{
+			{Package: "node*", Pin: "origin deb.nodesource.com", Priority: -1},
+		}, nil, "18.19.0+dfsg-6~deb12u3"},
+		{"release pin with several keys", []*pb.PackagePin{
+			{Package: "/^node/", Pin: "release a=experimental,c=main", Priority: 500},
+		}, nil, "22.0.0-1"},
+		{"source pin", []*pb.PackagePin{
+			{Package: "src:nodejs", Pin: "release a=experimental", Priority: 500},
+		}, nil, "22.0.0-1"},
+		{"local version newer than lists", nil, installed("23.0.0-1"), ""},
+		{"nothing allowed", []*pb.PackagePin{
+			{Package: "*", Pin: "origin *", Priority: -10},
+		}, nil, ""},
+	}
+	for _, tt := range tests {
+		got := newAptPolicy(tt.pins).candidate(nodejs, tt.installed)
+		var version string
+		if got != nil {
+			version = got.version
+		}
+		if version != tt.want {
+			t.Errorf("%s: candidate = %q, want %q", tt.name, version, tt.want)
+		}
+	}
+}
@@ -0,0 +1,680 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+// APT source and keyring locations
+const (
+	aptSourcesFile = "/etc/apt/sources.list"
+	aptSourcesDir  = "/etc/apt/sources.list.d"
+	aptKeyringsDir = "/etc/apt/keyrings"
+	maxSigningKey  = 1024 * 1024
+)
+
+// aptFileNameRe matches names apt accepts for sources.list.d and keyring files
+var aptFileNameRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// sourceFiles returns the source files apt reads, in the order it reads them
+func sourceFiles() []string {
+	files := []string{aptSourcesFile}
+	entries, err := os.ReadDir(aptSourcesDir)
+	if err != nil {
+		return files
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := filepath.Ext(name)
+		if entry.IsDir() || !aptFileNameRe.MatchString(name) || (ext != ".list" && ext != ".sources") {
+			continue
+		}
+		files = append(files, filepath.Join(aptSourcesDir, name))
+	}
+	return files
+}
+
+// Sources lists entries from all source files, including disabled ones
+func (m *aptPackageManager) Sources() ([]*pb.PackageSource, error) {
+	sources := []*pb.PackageSource{}
+	for _, path := range sourceFiles() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		sources = append(sources, parseSourceFile(path, string(data))...)
+	}
+	return sources, nil
+}
+
+// parseSourceFile parses a .list or deb822 .sources file and assigns ids
+func parseSourceFile(path, data string) []*pb.PackageSource {
+	var sources []*pb.PackageSource
+	if strings.HasSuffix(path, ".sources") {
+		for _, para := range splitParagraphs(data) {
+			if src := parseDeb822Source(para); src != nil {
+				sources = append(sources, src)
+			}
+		}
+	} else {
+		for _, line := range strings.Split(data, "\n") {
+			if src := parseSourceListLine(line); src != nil {
+				sources = append(sources, src)
+			}
+		}
+	}
+
+	for i, src := range sources {
+		src.Id = fmt.Sprintf("%s:%d", path, i)
+		src.File = path
+	}
+	return sources
+}
+
+// parseSourceListLine parses a one-line style entry such as
+// "deb [arch=arm64 signed-by=/etc/apt/keyrings/x.gpg] https://repo suite main".
+// Commented out entries are returned as disabled; other lines return nil.
+func parseSourceListLine(line string) *pb.PackageSource {
+	line = strings.TrimSpace(line)
+	enabled := true
+	if strings.HasPrefix(line, "#") {
+		enabled = false
+		line = strings.TrimSpace(strings.TrimLeft(line, "#"))
+	}
+	// Drop trailing comments
+	if i := strings.Index(line, " #"); i >= 0 {
+		line = strings.TrimSpace(line[:i])
+	}
+
+	kind, rest, found := strings.Cut(line, " ")
+	if !found || (kind != "deb" && kind != "deb-src") {
+		return nil
+	}
+
+	src := &pb.PackageSource{
+		Format:  "list",
+		Enabled: enabled,
+		Types:   []string{kind},
+	}
+
+	rest = strings.TrimSpace(rest)
+	if strings.HasPrefix(rest, "[") {
+		end := strings.Index(rest, "]")
+		if end < 0 {
+			return nil
+		}
+		for _, opt := range strings.Fields(rest[1:end]) {
+			key, value, found := strings.Cut(opt, "=")
+			if !found {
+				continue
+			}
+			switch strings.TrimRight(key, "+-") {
+			case "arch":
+				src.Architectures = strings.Split(value, ",")
+			case "signed-by":
+				src.SignedBy = value
+			}
+		}
+		rest = rest[end+1:]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) < 2 {
+		return nil
+	}
+	src.Uris = []string{fields[0]}
+	src.Suites = []string{fields[1]}
+	src.Components = fields[2:]
+	return src
+}
+
+// splitParagraphs splits deb822 data into blank line separated paragraphs
+func splitParagraphs(data string) []string {
+	var paragraphs []string
+	var current []string
+	for _, line := range strings.Split(data, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, strings.Join(current, "\n"))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, strings.Join(current, "\n"))
+	}
+	return paragraphs
+}
+
+// deb822Fields parses the fields of a paragraph, folding continuation lines
+// and skipping comments. Keys are lower-cased.
+func deb822Fields(para string) map[string]string {
+	fields := make(map[string]string)
+	key := ""
+	for _, line := range strings.Split(para, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && key != "" {
+			fields[key] += "\n" + strings.TrimSpace(line)
+			continue
+		}
+		k, v, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(k))
+		fields[key] = strings.TrimSpace(v)
+	}
+	return fields
+}
+
+// parseDeb822Source parses a deb822 stanza; paragraphs without Types are skipped
+func parseDeb822Source(para string) *pb.PackageSource {
+	fields := deb822Fields(para)
+	if fields["types"] == "" {
+		return nil
+	}
+
+	src := &pb.PackageSource{
+		Format:        "deb822",
+		Enabled:       !strings.EqualFold(fields["enabled"], "no"),
+		Types:         strings.Fields(fields["types"]),
+		Uris:          strings.Fields(fields["uris"]),
+		Suites:        strings.Fields(fields["suites"]),
+		Components:    strings.Fields(fields["components"]),
+		Architectures: strings.Fields(fields["architectures"]),
+		SignedBy:      fields["signed-by"],
+	}
+	if strings.Contains(src.SignedBy, "BEGIN PGP PUBLIC KEY BLOCK") {
+		src.SignedBy = "(embedded key)"
+	}
+	return src
+}
+
+// validateSource checks the fields of a new source
+func validateSource(src *pb.PackageSource) error {
+	if src == nil {
+		return fmt.Errorf("source is required")
+	}
+	if len(src.Types) == 0 {
+		src.Types = []string{"deb"}
+	}
+	for _, t := range src.Types {
+		if t != "deb" && t != "deb-src" {
+			return fmt.Errorf("invalid type %q (must be deb or deb-src)", t)
+		}
+	}
+	if len(src.Uris) == 0 {
+		return fmt.Errorf("at least one URI is required")
+	}
+	if len(src.Suites) == 0 {
+		return fmt.Errorf("at least one suite is required")
+	}
+
+	values := append(append(append(append([]string{src.SignedBy}, src.Uris...), src.Suites...), src.Components...), src.Architectures...)
+	for _, v := range values {
+		if strings.ContainsAny(v, " \t\r\n[]#") {
+			return fmt.Errorf("invalid value %q", v)
+		}
+	}
+
+	for _, u := range src.Uris {
+		parsed, err := url.Parse(u)
+		if err != nil || parsed.Scheme == "" {
+			return fmt.Errorf("invalid URI %q", u)
+		}
+	}
+
+	for _, suite := range src.Suites {
+		// Flat repositories use an exact path ending in "/" and no components
+		if strings.HasSuffix(suite, "/") {
+			if len(src.Components) > 0 {
+				return fmt.Errorf("components must be empty for flat repository suite %q", suite)
+			}
+		} else if len(src.Components) == 0 {
+			return fmt.Errorf("components are required for suite %q", suite)
+		}
+	}
+
+	if src.SignedBy != "" {
+		if _, err := os.Stat(src.SignedBy); err != nil {
+			return fmt.Errorf("signing key %s not found", src.SignedBy)
+		}
+	}
+	return nil
+}
+
+// formatSourceList renders a source in one-line style, one line per
+// type, URI and suite combination
+func formatSourceList(src *pb.PackageSource) string {
+	var options []string
+	if len(src.Architectures) > 0 {
+		options = append(options, "arch="+strings.Join(src.Architectures, ","))
+	}
+	if src.SignedBy != "" {
+		options = append(options, "signed-by="+src.SignedBy)
+	}
+	optionStr := ""
+	if len(options) > 0 {
+		optionStr = " [" + strings.Join(options, " ") + "]"
+	}
+
+	var sb strings.Builder
+	for _, t := range src.Types {
+		for _, u := range src.Uris {
+			for _, suite := range src.Suites {
+				sb.WriteString(strings.TrimSpace(fmt.Sprintf("%s%s %s %s %s", t, optionStr, u, suite, strings.Join(src.Components, " "))))
+				sb.WriteString("\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// formatDeb822Source renders a source as a deb822 stanza
+func formatDeb822Source(src *pb.PackageSource) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Types: %s\n", strings.Join(src.Types, " "))
+	fmt.Fprintf(&sb, "URIs: %s\n", strings.Join(src.Uris, " "))
+	fmt.Fprintf(&sb, "Suites: %s\n", strings.Join(src.Suites, " "))
+	if len(src.Components) > 0 {
+		fmt.Fprintf(&sb, "Components: %s\n", strings.Join(src.Components, " "))
+	}
+	if len(src.Architectures) > 0 {
+		fmt.Fprintf(&sb, "Architectures: %s\n", strings.Join(src.Architectures, " "))
+	}
+	if src.SignedBy != "" {
+		fmt.Fprintf(&sb, "Signed-By: %s\n", src.SignedBy)
+	}
+	return sb.String()
+}
+
+// stagedSource is a new source file written to a temporary directory so it
+// can be checked with apt-get update before it is installed
+type stagedSource struct {
+	dir    string
+	path   string
+	target string
+}
+
+// StageSource validates a new source and writes it to a temporary directory.
+// The returned step updates the package lists from that source only.
+func (m *aptPackageManager) StageSource(name string, src *pb.PackageSource, deb822 bool) (*stagedSource, packageStep, error) {
+	if !aptFileNameRe.MatchString(name) {
+		return nil, packageStep{}, fmt.Errorf("invalid source name %q", name)
+	}
+	if err := validateSource(src); err != nil {
+		return nil, packageStep{}, err
+	}
+
+	content := formatSourceList(src)
+	fileName := name + ".list"
+	if deb822 {
+		content = formatDeb822Source(src)
+		fileName = name + ".sources"
+	}
+
+	target := filepath.Join(aptSourcesDir, fileName)
+	for _, ext := range []string{".list", ".sources"} {
+		if _, err := os.Stat(filepath.Join(aptSourcesDir, name+ext)); err == nil {
+			return nil, packageStep{}, fmt.Errorf("source %s already exists", name+ext)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "pi-agent-source-")
+	if err != nil {
+		return nil, packageStep{}, err
+	}
+	staged := &stagedSource{dir: dir, path: filepath.Join(dir, fileName), target: target}
+	parts := filepath.Join(dir, "parts")
+	if err := os.Mkdir(parts, 0755); err != nil {
+		staged.cleanup()
+		return nil, packageStep{}, err
+	}
+	if err := os.WriteFile(staged.path, []byte(content), 0644); err != nil {
+		staged.cleanup()
+		return nil, packageStep{}, err
+	}
+
+	step := m.step("validate", "update",
+		"-o", "Dir::Etc::sourcelist="+staged.path,
+		"-o", "Dir::Etc::sourceparts="+parts,
+		// Keep the lists of all other sources
+		"-o", "APT::Get::List-Cleanup=0",
+	)
+	return staged, step, nil
+}
+
+// commit moves the staged file into sources.list.d
+func (s *stagedSource) commit() error {
+	defer s.cleanup()
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.target, data, 0644)
+}
+
+func (s *stagedSource) cleanup() {
+	os.RemoveAll(s.dir)
+}
+
+// sourceValidationProblem returns the first fetch error in apt-get update
+// output. apt exits 0 when fetching a source fails.
+func sourceValidationProblem(output string) string {
+	for _, line := range splitLines(output) {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Err:") || strings.HasPrefix(line, "E:") {
+			return line
+		}
+	}
+	return ""
+}
+
+// sourceValidationWarnings returns the warnings in apt-get update output,
+// such as a missing Release file date or a key in the legacy trusted.gpg,
+// which do not stop a source from working
+func sourceValidationWarnings(output string) []string {
+	var warnings []string
+	for _, line := range splitLines(output) {
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, "W:") {
+			warnings = append(warnings, line)
+		}
+	}
+	return warnings
+}
+
+// parseSourceID splits "<file>:<index>" and checks the file is a source file
+func parseSourceID(id string) (string, int, error) {
+	i := strings.LastIndex(id, ":")
+	if i <= 0 {
+		return "", 0, fmt.Errorf("invalid source id %q", id)
+	}
+	path := id[:i]
+	index, err := strconv.Atoi(id[i+1:])
+	if err != nil || index < 0 {
+		return "", 0, fmt.Errorf("invalid source id %q", id)
+	}
+
+	for _, file := range sourceFiles() {
+		if file == path {
+			return path, index, nil
+		}
+	}
+	return "", 0, fmt.Errorf("unknown source file %s", path)
+}
+
+// SetSourceEnabled comments or uncomments a one-line entry, or sets the
+// Enabled field of a deb822 stanza
+func (m *aptPackageManager) SetSourceEnabled(id string, enabled bool) error {
+	return editSourceEntry(id, func(entry string, format string) (string, bool) {
+		if format == "deb822" {
+			return setDeb822Enabled(entry, enabled), true
+		}
+		line := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(entry), "#"))
+		if enabled {
+			return line, true
+		}
+		return "# " + line, true
+	})
+}
+
+// RemoveSource deletes an entry, removing the file from sources.list.d if no
+// entries remain
+func (m *aptPackageManager) RemoveSource(id string) error {
+	return editSourceEntry(id, func(entry string, format string) (string, bool) {
+		return "", false
+	})
+}
+
+// editSourceEntry rewrites the entry identified by id. edit returns the
+// replacement text, or false to drop the entry.
+func editSourceEntry(id string, edit func(entry, format string) (string, bool)) error {
+	path, index, err := parseSourceID(id)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var chunks []string
+	isEntry := func(chunk string) bool { return parseSourceListLine(chunk) != nil }
+	separator := "\n"
+	format := "list"
+	if strings.HasSuffix(path, ".sources") {
+		chunks = splitParagraphs(string(data))
+		isEntry = func(chunk string) bool { return parseDeb822Source(chunk) != nil }
+		separator = "\n\n"
+		format = "deb822"
+	} else {
+		chunks = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	}
+
+	var result []string
+	entries, remaining := 0, 0
+	found := false
+	for _, chunk := range chunks {
+		if !isEntry(chunk) {
+			result = append(result, chunk)
+			continue
+		}
+		if entries == index {
+			found = true
+			entries++
+			if replacement, keep := edit(chunk, format); keep {
+				result = append(result, replacement)
+				remaining++
+			}
+			continue
+		}
+		entries++
+		remaining++
+		result = append(result, chunk)
+	}
+	if !found {
+		return fmt.Errorf("source %s not found", id)
+	}
+
+	if remaining == 0 && path != aptSourcesFile {
+		return os.Remove(path)
+	}
+	return os.WriteFile(path, []byte(strings.Join(result, separator)+"\n"), info.Mode().Perm())
+}
+
+// setDeb822Enabled sets or replaces the Enabled field of a stanza
+func setDeb822Enabled(para string, enabled bool) string {
+	value := "yes"
+	if !enabled {
+		value = "no"
+	}
+
+	lines := strings.Split(para, "\n")
+	for i, line := range lines {
+		key, _, found := strings.Cut(line, ":")
+		if found && strings.EqualFold(strings.TrimSpace(key), "enabled") && !strings.HasPrefix(line, "#") {
+			lines[i] = "Enabled: " + value
+			return strings.Join(lines, "\n")
+		}
+	}
+	return para + "\nEnabled: " + value
+}
+
+// ImportSigningKey stores an OpenPGP key in /etc/apt/keyrings, downloading it
+// first if only a URL is given. Armored keys are saved as .asc and binary keys
+// as .gpg, both of which apt reads directly. Returns the keyring path.
+func (m *aptPackageManager) ImportSigningKey(name string, data []byte, keyURL string, replace bool) (string, error) {
+	if !aptFileNameRe.MatchString(name) {
+		return "", fmt.Errorf("invalid key name %q", name)
+	}
+	base := strings.TrimSuffix(strings.TrimSuffix(name, ".gpg"), ".asc")
+	if !replace {
+		for _, ext := range []string{".asc", ".gpg"} {
+			existing := filepath.Join(aptKeyringsDir, base+ext)
+			if _, err := os.Stat(existing); err == nil {
+				return "", fmt.Errorf("%s already exists; set replace to overwrite it", existing)
+			}
+		}
+	}
+
+	if len(data) == 0 {
+		if keyURL == "" {
+			return "", fmt.Errorf("key data or URL is required")
+		}
+		var err error
+		data, err = downloadSigningKey(keyURL)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return writeSigningKey(aptKeyringsDir, base, data)
+}
+
+// writeSigningKey saves a key as dir/base.asc or dir/base.gpg and removes a
+// keyring with the other extension, which apt would otherwise keep trusting
+func writeSigningKey(dir, base string, data []byte) (string, error) {
+	ext, other := "", ""
+	switch {
+	case bytes.Contains(data, []byte("-----BEGIN PGP PUBLIC KEY BLOCK-----")):
+		ext, other = ".asc", ".gpg"
+	case data[0]&0x80 != 0:
+		// Binary OpenPGP packets always have the high bit set
+		ext, other = ".gpg", ".asc"
+	default:
+		return "", fmt.Errorf("data is not an OpenPGP public key")
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, base+ext)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	if err := os.Remove(filepath.Join(dir, base+other)); err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	return path, nil
+}
+
+// downloadSigningKey fetches a key over HTTP(S)
+func downloadSigningKey(keyURL string) ([]byte, error) {
+	parsed, err := url.Parse(keyURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return nil, fmt.Errorf("invalid key URL %q", keyURL)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(keyURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download key: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download key: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSigningKey+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download key: %v", err)
+	}
+	if len(data) > maxSigningKey {
+		return nil, fmt.Errorf("key is larger than %d bytes", maxSigningKey)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("downloaded key is empty")
+	}
+	return data, nil
+}
+
+// isSecuritySuite reports whether a suite carries security updates
+func isSecuritySuite(suite string) bool {
+	return strings.HasSuffix(suite, "-security") || strings.HasSuffix(suite, "/updates")
+}
+
+// SecurityUpgradeSteps updates and upgrades using only the enabled security
+// sources, which limits apt's view of available versions to security fixes
+func (m *aptPackageManager) SecurityUpgradeSteps() ([]packageStep, func(), error) {
+	sources, err := m.Sources()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var stanzas []string
+	for _, src := range sources {
+		if !src.Enabled {
+			continue
+		}
+		var suites []string
+		for _, suite := range src.Suites {
+			if isSecuritySuite(suite) {
+				suites = append(suites, suite)
+			}
+		}
+		if len(suites) == 0 {
+			continue
+		}
+		security := &pb.PackageSource{
+			Types:         src.Types,
+			Uris:          src.Uris,
+			Suites:        suites,
+			Components:    src.Components,
+			Architectures: src.Architectures,
+		}
+		if src.SignedBy != "(embedded key)" {
+			security.SignedBy = src.SignedBy
+		}
+		stanzas = append(stanzas, formatDeb822Source(security))
+	}
+	if len(stanzas) == 0 {
+		return nil, nil, fmt.Errorf("no security sources are configured")
+	}
+
+	dir, err := os.MkdirTemp("", "pi-agent-security-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	path := filepath.Join(dir, "security.sources")
+	parts := filepath.Join(dir, "parts")
+	if err := os.Mkdir(parts, 0755); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(stanzas, "\n")), 0644); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	options := []string{
+		"-o", "Dir::Etc::sourcelist=" + path,
+		"-o", "Dir::Etc::sourceparts=" + parts,
+		"-o", "APT::Get::List-Cleanup=0",
+	}
+	steps := []packageStep{
+		m.step("update", append([]string{"update"}, options...)...),
+		m.step("upgrade", append([]string{"upgrade", "-y"}, options...)...),
+	}
+	return steps, cleanup, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSourceValidation(t *testing.T) {
+	warningsOnly := `Hit:1 http://deb.debian.org/debian bookworm InRelease
+Get:2 https://download.docker.com/linux/debian bookworm InRelease [43.3 kB]
+Fetched 43.3 kB in 1s (52.1 kB/s)
+W: https://download.docker.com/linux/debian/dists/bookworm/InRelease: Key is stored in legacy trusted.gpg keyring (/etc/apt/trusted.gpg), see the DEPRECATION section in apt-key(8) for details.
+W: Release file for https://download.docker.com/linux/debian/dists/bookworm/InRelease is expired (invalid since 2d 3h 2min 13s). Updates for this repository will not be applied.
+`
+	if problem := sourceValidationProblem(warningsOnly); problem != "" {
+		t.Errorf("warnings reported as problem %q", problem)
+	}
+	if got := sourceValidationWarnings(warningsOnly); len(got) != 2 {
+		t.Errorf("warnings = %q, want 2", got)
+	}
+
+	failed := `Hit:1 http://deb.debian.org/debian bookworm InRelease
+Ign:2 https://example.com/debian bookworm InRelease
+Err:3 https://example.com/debian bookworm Release
+  404  Not Found [IP: 93.184.216.34 443]
+E: The repository 'https://example.com/debian bookworm Release' does not have a Release file.
+N: Updating from such a repository can't be done securely, and is therefore disabled by default.
+`
+	if got, want := sourceValidationProblem(failed), "Err:3 https://example.com/debian bookworm Release"; got != want {
+		t.Errorf("problem = %q, want %q", got, want)
+	}
+}
+
+// Replacing a key in the other format must not leave the old keyring behind
+func TestWriteSigningKey(t *testing.T) {
+	dir := t.TempDir()
+	armored := []byte("-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nmQINBGXl\n-----END PGP PUBLIC KEY BLOCK-----\n")
+	binary := []byte{0x99, 0x02, 0x0d, 0x04}
+
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dir, name))
+		return err == nil
+	}
+	steps := []struct {
+		data       []byte
+		want, gone string
+	}{
+		{binary, "docker.gpg", "docker.asc"},
+		{armored, "docker.asc", "docker.gpg"},
+		{binary, "docker.gpg", "docker.asc"},
+	}
+	for i, step := range steps {
+		path, err := writeSigningKey(dir, "docker", step.data)
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if path != filepath.Join(dir, step.want) || !exists(step.want) || exists(step.gone) {
+			t.Errorf("step %d: wrote %s; %s exists %v, %s exists %v", i, path, step.want, exists(step.want), step.gone, exists(step.gone))
+		}
+	}
+
+	if _, err := writeSigningKey(dir, "docker", []byte("not a key")); err == nil {
+		t.Error("plain text accepted as a key")
+	}
+	if !exists("docker.gpg") {
+		t.Error("rejected key removed the existing keyring")
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "pi_agent/proto"
+)
+
+func TestParseDpkgQueryLine(t *testing.T) {
+	var got []*pb.PackageInfo
+	for _, line := range fixtureLines(t, "dpkg-query") {
+		if pkg := parseDpkgQueryLine(line); pkg != nil {
+			got = append(got, pkg)
+		}
+	}
+
+	want := []*pb.PackageInfo{
+		{Name: "bash", Version: "5.2.15-2+b9", Architecture: "amd64", Description: "GNU Bourne Again SHell", Installed: true, Status: "installed", InstalledSize: 7164 * 1024, Section: "shells"},
+		{Name: "ca-certificates", Version: "20230311+deb12u1", Architecture: "all", Description: "Common CA certificates", Installed: true, Status: "installed", InstalledSize: 387 * 1024, Section: "misc"},
+		{Name: "libc6", Version: "2.36-9+deb12u13", Architecture: "amd64", Description: "GNU C Library: Shared libraries", Installed: true, Status: "installed", InstalledSize: 13000 * 1024, Section: "libs"},
+		{Name: "libstdc++6", Version: "12.2.0-14+deb12u1", Architecture: "amd64", Description: "GNU Standard C++ Library v3", Installed: true, Status: "installed", InstalledSize: 2686 * 1024, Section: "libs"},
+		{Name: "tzdata", Version: "2025b-0+deb12u2", Architecture: "all", Description: "time zone and daylight-saving time data", Installed: true, Status: "installed", InstalledSize: 2565 * 1024, Section: "localization"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parsed %d packages, want %d (config-files only packages skipped)", len(got), len(want))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("package %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if pkg := parseDpkgQueryLine("bash\t5.2.15-2+b9\tamd64"); pkg != nil {
+		t.Errorf("short line parsed as %v", pkg)
+	}
+}
+
+// The fixture mixes lines captured from apt 2.6 with the "pkg:arch" names
+// older apt and multiarch installs print
+func TestParseAptStatusLine(t *testing.T) {
+	var got []aptStatus
+	for _, line := range fixtureLines(t, "apt-status-fd") {
+		st, ok := parseAptStatusLine(line)
+		if !ok {
+			t.Errorf("line not parsed: %q", line)
+			continue
+		}
+		got = append(got, st)
+	}
+
+	want := map[int]aptStatus{
+		2:  {kind: "dlstatus", pkg: "1", percent: 50, message: "Retrieving file 1 of 2"},
+		4:  {kind: "pmstatus", pkg: "dpkg-exec", percent: 0, message: "Running dpkg"},
+		6:  {kind: "pmstatus", pkg: "libc6:arm64", percent: 16.6667, message: "Unpacking libc6:arm64 (arm64)"},
+		8:  {kind: "pmconffile", pkg: "/etc/ld.so.conf", percent: 33.3333, message: "'/etc/ld.so.conf' '/etc/ld.so.conf.dpkg-new' 1 1"},
+		10: {kind: "pmstatus", pkg: "pi-agent-fixture-conflict", percent: 50, message: "Unpacking pi-agent-fixture-conflict (all)"},
+		11: {kind: "pmerror", pkg: "/var/cache/apt/archives/pi-agent-fixture-conflict_2.0-1_all.deb", percent: 50,
+			message: "trying to overwrite '/usr/share/pi-agent-fixture/data', which is also in package pi-agent-fixture-lib:arm64 1.0-1"},
+	}
+	for i, w := range want {
+		if i >= len(got) {
+			t.Errorf("line %d missing", i)
+		} else if got[i] != w {
+			t.Errorf("line %d = %+v, want %+v", i, got[i], w)
+		}
+	}
+
+	for _, line := range []string{"", "Reading package lists...", "pmstatus:libc6:arm64", "pmstatus:libc6:arm64:Unpacking"} {
+		if st, ok := parseAptStatusLine(line); ok {
+			t.Errorf("%q parsed as %+v", line, st)
+		}
+	}
+}
+
+func TestAptProgress(t *testing.T) {
+	var progress aptProgress
+	var got []float64
+	for _, line := range fixtureLines(t, "apt-status-fd") {
+		st, _ := parseAptStatusLine(line)
+		progress.update(st)
+		got = append(got, progress.percent)
+	}
+	// Downloads cover 0-50%, dpkg 50-100%; pmconffile and pmerror lines and
+	// the dpkg-exec line restarting at 0% leave the progress unchanged
+	want := []float64{0, 0, 25, 50, 50, 50, 58.33335, 66.66665, 66.66665, 70, 75, 75, 75, 83.33335, 91.66665}
+	if len(got) != len(want) {
+		t.Fatalf("got %d updates, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("progress after line %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	// Without downloads dpkg covers the whole range
+	progress = aptProgress{}
+	progress.update(aptStatus{kind: "pmstatus", pkg: "libc6:arm64", percent: 80})
+	if progress.percent != 80 {
+		t.Errorf("install-only progress = %v, want 80", progress.percent)
+	}
+}
+
+func TestParseAptUpgradable(t *testing.T) {
+	got := parseAptUpgradable(fixtureLines(t, "apt-list-upgradable"))
+	want := []*pb.UpgradablePackage{
+		{Name: "bind9-dnsutils", CurrentVersion: "1:9.18.33-1~deb12u2", NewVersion: "1:9.18.41-1~deb12u1", Architecture: "arm64"},
+		{Name: "chromium", CurrentVersion: "1:141.0.7390.122-1~deb12u1+rpt1", NewVersion: "1:142.0.7444.175-1~deb12u1+rpt1", Architecture: "arm64"},
+		{Name: "libc6", CurrentVersion: "2.36-9+rpt2+deb12u12", NewVersion: "2.36-9+rpt2+deb12u13", Architecture: "arm64"},
+		{Name: "raspi-firmware", CurrentVersion: "1:1.20250430-4~bookworm", NewVersion: "1:1.20250915-1~bookworm", Architecture: "all"},
+		{Name: "tzdata", CurrentVersion: "2025b-0+deb12u1", NewVersion: "2025b-0+deb12u2", Architecture: "all"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parsed %d packages, want %d (warning and Listing... header skipped)", len(got), len(want))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("package %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseAptSimulation(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    *pb.PackageSimulation
+	}{
+		// apt-get -s install --allow-downgrades nginx tzdata=2025b-0+deb12u1 vim-
+		{"apt-get-simulate", &pb.PackageSimulation{
+			Success: true,
+			Install: []*pb.SimulatedPackage{
+				{Name: "nginx-common", NewVersion: "1.22.1-9+deb12u3", Architecture: "all"},
+				{Name: "nginx", NewVersion: "1.22.1-9+deb12u3", Architecture: "amd64"},
+			},
+			Upgrade: []*pb.SimulatedPackage{
+				{Name: "tzdata", CurrentVersion: "2025b-0+deb12u2", NewVersion: "2025b-0+deb12u1", Architecture: "all"},
+			},
+			Remove:        []*pb.SimulatedPackage{{Name: "vim", CurrentVersion: "2:9.0.1378-2+deb12u2"}},
+			Autoremovable: []string{"libsodium23", "vim-common", "vim-runtime", "xxd"},
+		}},
+		// apt-get -s purge coreutils
+		{"apt-get-simulate-essential", &pb.PackageSimulation{
+			Success:          true,
+			Remove:           []*pb.SimulatedPackage{{Name: "coreutils", CurrentVersion: "9.1-1"}},
+			Autoremovable:    []string{"libattr1"},
+			EssentialRemoved: []string{"coreutils"},
+		}},
+	}
+	for _, tt := range tests {
+		if got := parseAptSimulation(fixture(t, tt.fixture)); !proto.Equal(got, tt.want) {
+			t.Errorf("%s:\n got %v\nwant %v", tt.fixture, got, tt.want)
+		}
+	}
+}
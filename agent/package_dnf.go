@@ -0,0 +1,593 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+// dnfPackageManager implements packageManager for Fedora and RHEL based systems
+type dnfPackageManager struct{}
+
+// rpmQueryFormat is the rpm -qa output format parsed by parseRpmQueryLine
+const rpmQueryFormat = "%{NAME}\t%{VERSION}-%{RELEASE}\t%{ARCH}\t%{SIZE}\t%{GROUP}\t%{SUMMARY}\n"
+
+// dnfCheckUpdateAvailable is the exit code of dnf check-update when updates exist
+const dnfCheckUpdateAvailable = 100
+
+func (m *dnfPackageManager) Name() string {
+	return "dnf"
+}
+
+// ListInstalled lists installed packages from the rpm database
+func (m *dnfPackageManager) ListInstalled() ([]*pb.PackageInfo, error) {
+	lines, err := commandLines("rpm", "-qa", "--queryformat", rpmQueryFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []*pb.PackageInfo
+	for _, line := range lines {
+		if pkg := parseRpmQueryLine(line); pkg != nil {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages, nil
+}
+
+// parseRpmQueryLine parses one line of rpm output in rpmQueryFormat
+func parseRpmQueryLine(line string) *pb.PackageInfo {
+	fields := strings.SplitN(line, "\t", 6)
+	if len(fields) < 6 {
+		return nil
+	}
+	// gpg-pubkey entries are imported keys, not packages
+	if fields[0] == "gpg-pubkey" {
+		return nil
+	}
+
+	size, _ := strconv.ParseUint(fields[3], 10, 64)
+	section := fields[4]
+	if section == "Unspecified" {
+		section = ""
+	}
+
+	return &pb.PackageInfo{
+		Name:          fields[0],
+		Version:       fields[1],
+		Architecture:  fields[2],
+		Description:   fields[5],
+		Installed:     true,
+		Status:        "installed",
+		InstalledSize: size,
+		Section:       section,
+	}
+}
+
+// installedVersions maps installed package names to their versions
+func (m *dnfPackageManager) installedVersions() map[string]string {
+	versions := make(map[string]string)
+	if packages, err := m.ListInstalled(); err == nil {
+		for _, pkg := range packages {
+			versions[pkg.Name] = pkg.Version
+		}
+	}
+	return versions
+}
+
+// Search queries the repositories for package names containing term
+func (m *dnfPackageManager) Search(term string, limit int) ([]*pb.PackageInfo, error) {
+	lines, err := commandLines("dnf", "-q", "repoquery", "--latest-limit=1",
+		"--queryformat", "%{name}\t%{evr}\t%{arch}\t%{summary}\n", "*"+term+"*")
+	if err != nil {
+		return nil, err
+	}
+
+	installed := m.installedVersions()
+	seen := make(map[string]bool)
+
+	var packages []*pb.PackageInfo
+	for _, line := range lines {
+		if len(packages) >= limit {
+			break
+		}
+
+		pkg := parseRepoqueryLine(line)
+		if pkg == nil || seen[pkg.Name] {
+			continue
+		}
+		seen[pkg.Name] = true
+
+		if version, ok := installed[pkg.Name]; ok {
+			pkg.Installed = true
+			pkg.Status = "installed"
+			pkg.Version = version
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+// parseRepoqueryLine parses "name\tevr\tarch\tsummary" lines from dnf repoquery
+func parseRepoqueryLine(line string) *pb.PackageInfo {
+	fields := strings.SplitN(line, "\t", 4)
+	if len(fields) < 4 {
+		return nil
+	}
+	return &pb.PackageInfo{
+		Name:         fields[0],
+		Version:      fields[1],
+		Architecture: fields[2],
+		Description:  fields[3],
+		Status:       "not-installed",
+	}
+}
+
+// Details uses dnf info and the rpm install time
+func (m *dnfPackageManager) Details(name string) (*pb.PackageDetails, error) {
+	cmd := exec.Command("dnf", "-q", "info", "--", name)
+	cmd.Env = append(os.Environ(), "LANG=C")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	details := parseDnfInfo(output)
+	if details.Name == "" {
+		return nil, os.ErrNotExist
+	}
+	details.Status = "not-installed"
+
+	installTime, err := exec.Command("rpm", "-q", "--queryformat", "%{INSTALLTIME}", "--", name).Output()
+	if err == nil {
+		details.Installed = true
+		details.Status = "installed"
+		if t, err := strconv.ParseInt(strings.TrimSpace(string(installTime)), 10, 64); err == nil {
+			details.InstallDate = t
+		}
+	}
+
+	return details, nil
+}
+
+// parseDnfInfo parses the first package of dnf info output. Fields are
+// "Key : value" lines; wrapped values continue on lines starting with " : ".
+func parseDnfInfo(output []byte) *pb.PackageDetails {
+	details := &pb.PackageDetails{}
+	var description []string
+	lastKey := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if key == "" {
+			// Continuation of the previous field
+			if lastKey == "Description" {
+				description = append(description, value)
+			}
+			continue
+		}
+		if key == "Name" && details.Name != "" {
+			// dnf lists installed and available versions as separate records
+			break
+		}
+		lastKey = key
+
+		switch key {
+		case "Name":
+			details.Name = value
+		case "Version":
+			details.Version = value
+		case "Release":
+			if details.Version != "" {
+				details.Version += "-" + value
+			}
+		case "Architecture", "Arch":
+			details.Architecture = value
+		case "Size", "Installed size":
+			details.InstalledSize = parseDnfSize(value)
+		case "Source":
+			details.Source = value
+		case "Summary":
+			details.Description = value
+		case "URL":
+			details.Homepage = value
+		case "Packager", "Vendor":
+			if details.Maintainer == "" {
+				details.Maintainer = value
+			}
+		case "Description":
+			description = append(description, value)
+		}
+	}
+
+	details.LongDescription = strings.TrimSpace(strings.Join(description, "\n"))
+	return details
+}
+
+// parseDnfSize parses human readable sizes such as "1.2 M" or "345 k"
+func parseDnfSize(s string) uint64 {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0
+	}
+
+	multiplier := 1.0
+	if len(fields) > 1 {
+		switch strings.ToUpper(strings.TrimSuffix(strings.TrimSuffix(fields[1], "B"), "i")) {
+		case "K":
+			multiplier = 1024
+		case "M":
+			multiplier = 1024 * 1024
+		case "G":
+			multiplier = 1024 * 1024 * 1024
+		}
+	}
+	return uint64(value * multiplier)
+}
+
+// Dependencies uses dnf repoquery for forward and reverse relations
+func (m *dnfPackageManager) Dependencies(name string) (*pb.PackageDependencies, error) {
+	deps := emptyPackageDependencies(name)
+
+	repoquery := func(args ...string) []string {
+		lines, err := commandLines("dnf", append([]string{"-q", "repoquery"}, args...)...)
+		if err != nil {
+			return nil
+		}
+		var result []string
+		for _, line := range lines {
+			result = append(result, stripVersionConstraint(strings.TrimSpace(line)))
+		}
+		return uniqueStrings(result)
+	}
+
+	deps.Depends = append(deps.Depends, repoquery("--requires", "--", name)...)
+	deps.Recommends = append(deps.Recommends, repoquery("--recommends", "--", name)...)
+	deps.Suggests = append(deps.Suggests, repoquery("--suggests", "--", name)...)
+	deps.Conflicts = append(deps.Conflicts, repoquery("--conflicts", "--", name)...)
+	deps.RequiredBy = append(deps.RequiredBy, repoquery("--installed", "--queryformat", "%{name}\n", "--whatrequires", name)...)
+
+	return deps, nil
+}
+
+// uniqueStrings removes duplicates while keeping order
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, v := range values {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// Upgradable lists packages from dnf check-update
+func (m *dnfPackageManager) Upgradable() ([]*pb.UpgradablePackage, error) {
+	cmd := exec.Command("dnf", "-q", "check-update")
+	cmd.Env = append(os.Environ(), "LANG=C")
+	output, err := cmd.Output()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == dnfCheckUpdateAvailable {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	packages := parseDnfCheckUpdate(output)
+	if len(packages) > 0 {
+		installed := m.installedVersions()
+		for _, pkg := range packages {
+			pkg.CurrentVersion = installed[pkg.Name]
+		}
+	}
+	return packages, nil
+}
+
+// parseDnfCheckUpdate parses "name.arch version repo" lines from dnf
+// check-update, stopping at the obsoletes section
+func parseDnfCheckUpdate(output []byte) []*pb.UpgradablePackage {
+	var packages []*pb.UpgradablePackage
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "Obsoleting Packages") {
+			break
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		dot := strings.LastIndex(fields[0], ".")
+		if dot <= 0 {
+			continue
+		}
+
+		packages = append(packages, &pb.UpgradablePackage{
+			Name:         fields[0][:dot],
+			Architecture: fields[0][dot+1:],
+			NewVersion:   fields[1],
+		})
+	}
+	return packages
+}
+
+// LastRefresh returns when dnf last refreshed its metadata cache
+func (m *dnfPackageManager) LastRefresh() (time.Time, bool) {
+	for _, path := range []string{"/var/cache/dnf/last_makecache", "/var/cache/libdnf5", "/var/cache/dnf"} {
+		if info, err := os.Stat(path); err == nil {
+			return info.ModTime(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// dnfPackageName is an RPM package name, optionally extended to a full
+// name-[epoch:]version-release.arch as dnf accepts
+var dnfPackageName = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._+:~^-]*$`)
+
+func (m *dnfPackageManager) CheckName(name string) error {
+	return checkPackageName(dnfPackageName, name)
+}
+
+func (m *dnfPackageManager) step(phase string, args ...string) packageStep {
+	return packageStep{phase: phase, command: "dnf", args: args}
+}
+
+func (m *dnfPackageManager) RefreshStep() packageStep {
+	return m.step("update", "makecache")
+}
+
+func (m *dnfPackageManager) InstallStep(name string) packageStep {
+	return m.step("install", "install", "-y", "--", name)
+}
+
+func (m *dnfPackageManager) RemoveStep(name string) packageStep {
+	return m.step("remove", "remove", "-y", "--", name)
+}
+
+// PurgeStep removes the package; rpm has no separate purge for config files
+func (m *dnfPackageManager) PurgeStep(name string) packageStep {
+	return m.step("purge", "remove", "-y", "--", name)
+}
+
+func (m *dnfPackageManager) UpgradeStep(name string) packageStep {
+	if name == "" {
+		return m.step("upgrade", "upgrade", "-y")
+	}
+	return m.step("upgrade", "upgrade", "-y", "--", name)
+}
+
+// Simulate resolves the transaction with --assumeno and parses the
+// transaction table and summary
+func (m *dnfPackageManager) Simulate(op pb.PackageOperation, name string) (*pb.PackageSimulation, error) {
+	step, err := packageOperationStep(m, op, name)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"--assumeno"}
+	for _, arg := range step.args {
+		if arg != "-y" {
+			args = append(args, arg)
+		}
+	}
+
+	cmd := exec.Command("dnf", args...)
+	cmd.Env = append(os.Environ(), "LANG=C")
+	// dnf exits non-zero when --assumeno aborts the transaction
+	output, runErr := cmd.CombinedOutput()
+
+	sim := parseDnfSimulation(output)
+	if runErr != nil && strings.Contains(strings.ToLower(string(output)), "error:") {
+		sim.Success = false
+		sim.Error = firstErrorLine(string(output), runErr)
+	}
+
+	if len(sim.Upgrade) > 0 {
+		installed := m.installedVersions()
+		for _, pkg := range sim.Upgrade {
+			if pkg.CurrentVersion == "" {
+				pkg.CurrentVersion = installed[pkg.Name]
+			}
+		}
+	}
+
+	return sim, nil
+}
+
+// parseDnfSimulation parses the transaction table printed by dnf (4 and 5)
+// before it asks for confirmation
+func parseDnfSimulation(output []byte) *pb.PackageSimulation {
+	sim := &pb.PackageSimulation{Success: true}
+	section := ""
+	var lastUpgrade *pb.SimulatedPackage
+	var installedSize, freedSize int64
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		// Transaction summary totals
+		switch {
+		case strings.HasPrefix(trimmed, "Total download size:"):
+			sim.DownloadSize = int64(parseDnfSize(strings.TrimPrefix(trimmed, "Total download size:")))
+			continue
+		case strings.HasPrefix(trimmed, "Installed size:"):
+			installedSize = int64(parseDnfSize(strings.TrimPrefix(trimmed, "Installed size:")))
+			continue
+		case strings.HasPrefix(trimmed, "Freed space:"):
+			freedSize = int64(parseDnfSize(strings.TrimPrefix(trimmed, "Freed space:")))
+			continue
+		case strings.Contains(trimmed, "Need to download "):
+			// dnf5: "Total size of inbound packages is 2 MiB. Need to download 2 MiB."
+			value := trimmed[strings.Index(trimmed, "Need to download ")+len("Need to download "):]
+			sim.DownloadSize = int64(parseDnfSize(strings.TrimSuffix(value, ".")))
+			continue
+		case strings.HasPrefix(trimmed, "After this operation,"):
+			// dnf5: "After this operation, 6 MiB extra will be used (...)" or "... 1 MiB freed (...)"
+			value := strings.TrimSpace(strings.TrimPrefix(trimmed, "After this operation,"))
+			size := int64(parseDnfSize(value))
+			if strings.Contains(value, "freed") {
+				size = -size
+			}
+			sim.DiskSizeDelta = size
+			installedSize, freedSize = 0, 0
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") {
+			// Section headers such as "Installing dependencies:"
+			switch {
+			case strings.HasPrefix(trimmed, "("):
+				// dnf4 hints such as "(add '--best --allowerasing' ...):"
+				// belong to the section above
+			case !strings.HasSuffix(trimmed, ":"):
+				section = ""
+			case strings.HasPrefix(trimmed, "Installing"):
+				section = "install"
+			case strings.HasPrefix(trimmed, "Upgrading"), strings.HasPrefix(trimmed, "Downgrading"):
+				section = "upgrade"
+			case strings.HasPrefix(trimmed, "Removing"):
+				section = "remove"
+			case strings.HasPrefix(trimmed, "Skipping packages"):
+				section = "held"
+			default:
+				section = ""
+			}
+			continue
+		}
+
+		fields := strings.Fields(trimmed)
+		if section == "" || len(fields) < 5 {
+			continue
+		}
+
+		// dnf5 lists the replaced version under each upgrade
+		if fields[0] == "replacing" {
+			if lastUpgrade != nil && len(fields) >= 4 {
+				lastUpgrade.CurrentVersion = fields[3]
+			}
+			continue
+		}
+
+		pkg := &pb.SimulatedPackage{
+			Name:         fields[0],
+			Architecture: fields[1],
+		}
+		size := int64(0)
+		if len(fields) >= 6 {
+			size = int64(parseDnfSize(fields[len(fields)-2] + " " + fields[len(fields)-1]))
+		}
+
+		switch section {
+		case "install":
+			pkg.NewVersion = fields[2]
+			pkg.DownloadSize = size
+			sim.Install = append(sim.Install, pkg)
+		case "upgrade":
+			pkg.NewVersion = fields[2]
+			pkg.DownloadSize = size
+			sim.Upgrade = append(sim.Upgrade, pkg)
+			lastUpgrade = pkg
+		case "remove":
+			pkg.CurrentVersion = fields[2]
+			pkg.SizeDelta = -size
+			sim.Remove = append(sim.Remove, pkg)
+		case "held":
+			pkg.NewVersion = fields[2]
+			sim.HeldBack = append(sim.HeldBack, pkg)
+		}
+	}
+
+	if installedSize != 0 || freedSize != 0 {
+		sim.DiskSizeDelta = installedSize - freedSize
+	}
+	return sim
+}
+
+// Holds lists packages locked with the versionlock plugin
+func (m *dnfPackageManager) Holds() ([]*pb.PackageHold, error) {
+	output, err := exec.Command("dnf", "-q", "versionlock", "list").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseDnfVersionlock(output), nil
+}
+
+// parseDnfVersionlock parses dnf4 "name-epoch:version-release.*" lines and
+// dnf5 "Package name: name" / "evr = version" blocks
+func parseDnfVersionlock(output []byte) []*pb.PackageHold {
+	holds := []*pb.PackageHold{}
+	var current *pb.PackageHold
+
+	for _, line := range splitLines(string(output)) {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "Package name:"):
+			current = &pb.PackageHold{Name: strings.TrimSpace(strings.TrimPrefix(line, "Package name:"))}
+			holds = append(holds, current)
+		case strings.HasPrefix(line, "evr ") && current != nil:
+			if _, version, found := strings.Cut(line, "="); found {
+				current.Version = strings.TrimSpace(version)
+			}
+		default:
+			colon := strings.Index(line, ":")
+			if colon <= 0 || strings.Contains(line, " ") {
+				continue
+			}
+			dash := strings.LastIndex(line[:colon], "-")
+			if dash <= 0 {
+				continue
+			}
+			holds = append(holds, &pb.PackageHold{
+				Name:    line[:dash],
+				Version: strings.TrimSuffix(line[colon+1:], ".*"),
+			})
+		}
+	}
+	return holds
+}
+
+func (m *dnfPackageManager) HoldStep(names []string, hold bool) (packageStep, error) {
+	if hold {
+		return m.step("hold", append([]string{"versionlock", "add", "--"}, names...)...), nil
+	}
+	return m.step("unhold", append([]string{"versionlock", "delete", "--"}, names...)...), nil
+}
+
+// SecurityUpgradeSteps refreshes metadata and applies security advisories only
+func (m *dnfPackageManager) SecurityUpgradeSteps() ([]packageStep, func(), error) {
+	steps := []packageStep{
+		m.RefreshStep(),
+		m.step("upgrade", "upgrade", "-y", "--security"),
+	}
+	return steps, func() {}, nil
+}
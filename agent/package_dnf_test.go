@@ -0,0 +1,161 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "pi_agent/proto"
+)
+
+func TestParseRpmQueryLine(t *testing.T) {
+	var got []*pb.PackageInfo
+	for _, line := range fixtureLines(t, "rpm-qa") {
+		if pkg := parseRpmQueryLine(line); pkg != nil {
+			got = append(got, pkg)
+		}
+	}
+
+	want := []*pb.PackageInfo{
+		{Name: "bash", Version: "5.2.26-3.fc40", Architecture: "x86_64", Description: "The GNU Bourne Again shell", Installed: true, Status: "installed", InstalledSize: 8491658},
+		{Name: "kernel-core", Version: "6.8.5-301.fc40", Architecture: "x86_64", Description: "The Linux kernel", Installed: true, Status: "installed", InstalledSize: 68513271},
+		{Name: "tzdata", Version: "2024a-5.fc40", Architecture: "noarch", Description: "Timezone data", Installed: true, Status: "installed", InstalledSize: 1717926, Section: "System Environment/Base"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parsed %d packages, want %d (gpg-pubkey skipped)", len(got), len(want))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("package %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseDnfInfo(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    *pb.PackageDetails
+	}{
+		{"dnf-info", &pb.PackageDetails{
+			Name:          "bash",
+			Version:       "5.2.26-3.fc40",
+			Architecture:  "x86_64",
+			InstalledSize: 8493465, // 8.1 M
+			Source:        "bash-5.2.26-3.fc40.src.rpm",
+			Description:   "The GNU Bourne Again shell",
+			Homepage:      "https://www.gnu.org/software/bash",
+			LongDescription: "The GNU Bourne Again shell (Bash) is a shell or command language\n" +
+				"interpreter that is compatible with the Bourne shell (sh). Bash\n" +
+				"incorporates useful features from the Korn shell (ksh) and the C shell\n" +
+				"(csh). Most sh scripts can be run by bash without modification.",
+		}},
+		{"dnf5-info", &pb.PackageDetails{
+			Name:          "curl",
+			Version:       "8.6.0-10.fc40",
+			Architecture:  "x86_64",
+			InstalledSize: 750489, // 732.9 KiB
+			Source:        "curl-8.6.0-10.fc40.src.rpm",
+			Description:   "A utility for getting files from remote servers (FTP, HTTP, and others)",
+			Homepage:      "https://curl.se/",
+			Maintainer:    "Fedora Project",
+			LongDescription: "curl is a command line tool for transferring data with URL syntax, supporting\n" +
+				"FTP, FTPS, HTTP, HTTPS, SCP, SFTP, TFTP, TELNET, DICT, LDAP, LDAPS, FILE, IMAPS,\n" +
+				"POP3, SMTP and RTSP.",
+		}},
+	}
+	for _, tt := range tests {
+		if got := parseDnfInfo(fixture(t, tt.fixture)); !proto.Equal(got, tt.want) {
+			t.Errorf("%s:\n got %v\nwant %v", tt.fixture, got, tt.want)
+		}
+	}
+}
+
+func TestParseDnfCheckUpdate(t *testing.T) {
+	got := parseDnfCheckUpdate(fixture(t, "dnf-check-update"))
+	want := []*pb.UpgradablePackage{
+		{Name: "bash", Architecture: "x86_64", NewVersion: "5.2.32-1.fc40"},
+		{Name: "kernel-core", Architecture: "x86_64", NewVersion: "6.11.3-200.fc40"},
+		{Name: "NetworkManager-wifi", Architecture: "x86_64", NewVersion: "1:1.46.2-1.fc40"},
+		{Name: "python3-libdnf5", Architecture: "x86_64", NewVersion: "5.2.6.2-1.fc40"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parsed %d packages, want %d (obsoletes skipped)", len(got), len(want))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("package %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseDnfVersionlock(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    []*pb.PackageHold
+	}{
+		{"dnf-versionlock", []*pb.PackageHold{
+			{Name: "kernel-core", Version: "6.8.5-301.fc40"},
+			{Name: "NetworkManager", Version: "1.46.0-1.fc40"},
+		}},
+		{"dnf5-versionlock", []*pb.PackageHold{
+			{Name: "bash", Version: "5.2.26-3.fc40"},
+			{Name: "kernel-core", Version: "6.8.5-301.fc40"},
+		}},
+	}
+	for _, tt := range tests {
+		got := parseDnfVersionlock(fixture(t, tt.fixture))
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: parsed %v, want %v", tt.fixture, got, tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if !proto.Equal(got[i], tt.want[i]) {
+				t.Errorf("%s: hold %d = %v, want %v", tt.fixture, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestParseDnfSimulation(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    *pb.PackageSimulation
+	}{
+		{"dnf-simulate", &pb.PackageSimulation{
+			Success: true,
+			Install: []*pb.SimulatedPackage{
+				{Name: "htop", NewVersion: "3.3.0-3.fc40", Architecture: "x86_64", DownloadSize: 203 * 1024},
+				{Name: "hwloc-libs", NewVersion: "2.10.0-3.fc40", Architecture: "x86_64", DownloadSize: 2202009}, // 2.1 M
+			},
+			Upgrade: []*pb.SimulatedPackage{
+				{Name: "bash", NewVersion: "5.2.32-1.fc40", Architecture: "x86_64", DownloadSize: 1887436}, // 1.8 M
+			},
+			Remove: []*pb.SimulatedPackage{
+				{Name: "nano", CurrentVersion: "7.2-7.fc40", Architecture: "x86_64", SizeDelta: -2936012}, // 2.8 M
+			},
+			HeldBack:      []*pb.SimulatedPackage{{Name: "kernel-core", NewVersion: "6.11.3-200.fc40", Architecture: "x86_64"}},
+			DownloadSize:  4299161,           // 4.1 M
+			DiskSizeDelta: 8283750 - 2936012, // 7.9 M installed, 2.8 M freed
+		}},
+		{"dnf5-simulate", &pb.PackageSimulation{
+			Success: true,
+			Install: []*pb.SimulatedPackage{
+				{Name: "htop", NewVersion: "3.3.0-3.fc41", Architecture: "x86_64", DownloadSize: 501145},         // 489.4 KiB
+				{Name: "hwloc-libs", NewVersion: "2.10.0-5.fc41", Architecture: "x86_64", DownloadSize: 2831155}, // 2.7 MiB
+			},
+			Upgrade: []*pb.SimulatedPackage{
+				{Name: "bash", CurrentVersion: "5.2.26-4.fc41", NewVersion: "5.2.32-1.fc41", Architecture: "x86_64", DownloadSize: 8493465}, // 8.1 MiB
+			},
+			Remove: []*pb.SimulatedPackage{
+				{Name: "nano", CurrentVersion: "8.1-1.fc41", Architecture: "x86_64", SizeDelta: -2936012},
+			},
+			DownloadSize:  3 * 1024 * 1024,
+			DiskSizeDelta: 197 * 1024,
+		}},
+	}
+	for _, tt := range tests {
+		if got := parseDnfSimulation(fixture(t, tt.fixture)); !proto.Equal(got, tt.want) {
+			t.Errorf("%s:\n got %v\nwant %v", tt.fixture, got, tt.want)
+		}
+	}
+}
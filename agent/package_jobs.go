@@ -0,0 +1,611 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+// Limits for the package job queue
+const (
+	maxQueuedPackageJobs   = 32
+	maxFinishedPackageJobs = 50
+	maxPackageJobLogLines  = 5000
+	dpkgLockPollInterval   = 2 * time.Second
+)
+
+var errJobCancelled = errors.New("job cancelled")
+
+// packageStep is a single package manager invocation within a job
+type packageStep struct {
+	phase    string // update, upgrade, install, remove, ...
+	command  string // apt-get, apk, dnf
+	args     []string
+	apt      bool // Report progress over APT::Status-Fd
+	dpkgLock bool // Wait for other holders of the dpkg lock first
+}
+
+// jobLogEntry is one line of job output
+type jobLogEntry struct {
+	phase string
+	log   *pb.PackageOperationLog
+}
+
+// packageJob is a unit of package manager work executed by the job queue
+type packageJob struct {
+	id          string
+	description string
+	steps       []packageStep
+	ctx         context.Context
+	cancel      context.CancelFunc
+	done        chan struct{}
+	closeDone   sync.Once
+
+	mu            sync.Mutex
+	state         pb.JobState
+	err           error
+	cancelled     bool
+	exitCode      int32
+	progress      float64
+	statusMessage string
+	createdAt     time.Time
+	startedAt     time.Time
+	finishedAt    time.Time
+	logs          []jobLogEntry
+	dropped       int           // Log entries discarded from the front
+	changed       chan struct{} // Closed and replaced whenever logs change
+}
+
+// packageJobQueue runs package jobs one at a time so they never race for the dpkg lock
+type packageJobQueue struct {
+	mu      sync.Mutex
+	nextID  int
+	jobs    []*packageJob
+	pending chan *packageJob
+}
+
+func newPackageJobQueue() *packageJobQueue {
+	q := &packageJobQueue{
+		pending: make(chan *packageJob, maxQueuedPackageJobs),
+	}
+	go q.worker()
+	return q
+}
+
+// submit queues a new job made of the given steps
+func (q *packageJobQueue) submit(description string, steps ...packageStep) (*packageJob, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	q.mu.Lock()
+	q.nextID++
+	job := &packageJob{
+		id:            fmt.Sprintf("pkg-%d", q.nextID),
+		description:   description,
+		steps:         steps,
+		ctx:           ctx,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+		state:         pb.JobState_JOB_QUEUED,
+		statusMessage: "Queued",
+		createdAt:     time.Now(),
+		changed:       make(chan struct{}),
+	}
+
+	select {
+	case q.pending <- job:
+	default:
+		q.mu.Unlock()
+		cancel()
+		return nil, fmt.Errorf("too many queued package jobs")
+	}
+
+	q.jobs = append(q.jobs, job)
+	q.pruneLocked()
+	q.mu.Unlock()
+
+	log.Printf("Package job %s queued: %s", job.id, description)
+	return job, nil
+}
+
+// get returns the job with the given ID, or nil
+func (q *packageJobQueue) get(id string) *packageJob {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, job := range q.jobs {
+		if job.id == id {
+			return job
+		}
+	}
+	return nil
+}
+
+// list returns all known jobs in submission order
+func (q *packageJobQueue) list() []*packageJob {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]*packageJob(nil), q.jobs...)
+}
+
+// pruneLocked forgets the oldest finished jobs beyond maxFinishedPackageJobs
+func (q *packageJobQueue) pruneLocked() {
+	finished := 0
+	for _, job := range q.jobs {
+		if job.isDone() {
+			finished++
+		}
+	}
+
+	kept := q.jobs[:0]
+	for _, job := range q.jobs {
+		if finished > maxFinishedPackageJobs && job.isDone() {
+			finished--
+			continue
+		}
+		kept = append(kept, job)
+	}
+	q.jobs = kept
+}
+
+func (q *packageJobQueue) worker() {
+	for job := range q.pending {
+		if job.isDone() {
+			continue
+		}
+		job.run()
+
+		q.mu.Lock()
+		q.pruneLocked()
+		q.mu.Unlock()
+	}
+}
+
+// run executes all steps of the job unless it was cancelled while queued
+func (j *packageJob) run() {
+	j.mu.Lock()
+	if j.state == pb.JobState_JOB_DONE {
+		j.mu.Unlock()
+		return
+	}
+	j.state = pb.JobState_JOB_RUNNING
+	j.startedAt = time.Now()
+	j.statusMessage = "Running"
+	j.mu.Unlock()
+
+	log.Printf("Package job %s started: %s", j.id, j.description)
+
+	for i, step := range j.steps {
+		if step.dpkgLock {
+			if err := j.waitForLock(); err != nil {
+				j.finish(err)
+				return
+			}
+		}
+		if err := j.runStep(i, step); err != nil {
+			j.finish(err)
+			return
+		}
+	}
+
+	j.finish(nil)
+}
+
+// waitForLock blocks while another process holds the apt/dpkg lock
+func (j *packageJob) waitForLock() error {
+	lastHolder := 0
+	for {
+		if j.ctx.Err() != nil {
+			return errJobCancelled
+		}
+
+		pid, name, held := dpkgLockHolder()
+		if !held {
+			if lastHolder != 0 {
+				j.setState(pb.JobState_JOB_RUNNING, "Running")
+			}
+			return nil
+		}
+
+		if pid != lastHolder {
+			holder := "another process"
+			if name != "" {
+				holder = fmt.Sprintf("%s (PID %d)", name, pid)
+			} else if pid > 0 {
+				holder = fmt.Sprintf("PID %d", pid)
+			}
+			msg := fmt.Sprintf("Waiting for dpkg lock held by %s", holder)
+			j.setState(pb.JobState_JOB_WAITING_FOR_LOCK, msg)
+			j.appendLog("lock", &pb.PackageOperationLog{
+				Timestamp: time.Now().Unix(),
+				Level:     "warning",
+				Message:   msg,
+			})
+			lastHolder = pid
+		}
+
+		select {
+		case <-j.ctx.Done():
+			return errJobCancelled
+		case <-time.After(dpkgLockPollInterval):
+		}
+	}
+}
+
+// runStep runs one package manager invocation, streaming output into the job log
+func (j *packageJob) runStep(index int, step packageStep) error {
+	args := step.args
+	var extra []*extraPipe
+	if step.apt {
+		// apt writes machine readable progress to the status fd (fd 3 in the child)
+		status, err := newExtraPipe("status")
+		if err != nil {
+			return err
+		}
+		extra = append(extra, status)
+
+		args = append([]string{
+			"-o", "APT::Status-Fd=3",
+			"-o", "Dpkg::Use-Pty=0",
+			// Covers the window between our lock check and apt taking the lock
+			"-o", "DPkg::Lock::Timeout=120",
+		}, args...)
+	}
+
+	cmd := exec.CommandContext(j.ctx, step.command, args...)
+	cmd.Env = append(os.Environ(), "DEBIAN_FRONTEND=noninteractive", "LANG=C")
+	// Give the package manager a chance to clean up instead of killing it mid-transaction
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = 30 * time.Second
+
+	j.appendLog(step.phase, &pb.PackageOperationLog{
+		Timestamp: time.Now().Unix(),
+		Level:     "info",
+		Message:   step.command + " " + strings.Join(step.args, " "),
+	})
+
+	progress := &aptProgress{}
+	err := runCommandLines(cmd, func(streamName, line string) error {
+		entry := &pb.PackageOperationLog{
+			Timestamp: time.Now().Unix(),
+			Level:     "info",
+			Message:   line,
+		}
+
+		if streamName == "status" {
+			st, ok := parseAptStatusLine(line)
+			if !ok {
+				return nil
+			}
+			entry.Message = st.message
+			if st.kind == "pmerror" {
+				entry.Level = "error"
+			}
+			progress.update(st)
+			j.setProgress((float64(index)*100 + progress.percent) / float64(len(j.steps)))
+		} else {
+			if strings.TrimSpace(line) == "" {
+				return nil
+			}
+			entry.Level = packageLineLevel(line)
+		}
+
+		j.appendLog(step.phase, entry)
+		return nil
+	}, extra...)
+
+	if j.ctx.Err() != nil {
+		return errJobCancelled
+	}
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", step.phase, err)
+	}
+	return nil
+}
+
+// finish marks the job done and emits the final log entry
+func (j *packageJob) finish(err error) {
+	j.mu.Lock()
+	finished := j.finishLocked(err)
+	j.mu.Unlock()
+	if finished {
+		j.release()
+	}
+}
+
+// finishLocked marks the job done unless it already is; j.mu must be held and
+// release called after unlocking when it returns true
+func (j *packageJob) finishLocked(err error) bool {
+	if j.state == pb.JobState_JOB_DONE {
+		return false
+	}
+
+	j.state = pb.JobState_JOB_DONE
+	j.finishedAt = time.Now()
+	j.err = err
+
+	final := &pb.PackageOperationLog{
+		Timestamp: j.finishedAt.Unix(),
+		Completed: true,
+	}
+	phase := "done"
+
+	switch {
+	case err == nil:
+		j.progress = 100
+		j.statusMessage = "Completed successfully"
+		final.Level = "info"
+		final.Message = "Operation completed"
+		final.Success = true
+	case errors.Is(err, errJobCancelled):
+		j.cancelled = true
+		j.exitCode = -1
+		j.statusMessage = "Cancelled"
+		final.Level = "warning"
+		final.Message = "Operation cancelled"
+		phase = "error"
+	default:
+		j.exitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			j.exitCode = int32(exitErr.ExitCode())
+		}
+		j.statusMessage = err.Error()
+		final.Level = "error"
+		final.Message = fmt.Sprintf("Operation failed: %v", err)
+		phase = "error"
+	}
+	final.Progress = j.progress
+	final.ExitCode = j.exitCode
+	// Appended under the same lock so followers never see done without it
+	j.appendLogLocked(phase, final)
+	return true
+}
+
+// release frees the context of a finished job and wakes up waiters
+func (j *packageJob) release() {
+	j.cancel()
+	j.closeDone.Do(func() { close(j.done) })
+
+	log.Printf("Package job %s finished: %s", j.id, j.statusMessage)
+}
+
+// requestCancel cancels a queued or running job
+func (j *packageJob) requestCancel() bool {
+	j.mu.Lock()
+	switch j.state {
+	case pb.JobState_JOB_DONE:
+		j.mu.Unlock()
+		return false
+	case pb.JobState_JOB_QUEUED:
+		// Not started yet; finishing under the lock keeps run from starting
+		// it, and the worker skips finished jobs
+		j.finishLocked(errJobCancelled)
+		j.mu.Unlock()
+		j.release()
+		return true
+	}
+	j.mu.Unlock()
+	j.cancel()
+	return true
+}
+
+func (j *packageJob) isDone() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.state == pb.JobState_JOB_DONE
+}
+
+// setState updates a job that has not finished yet
+func (j *packageJob) setState(state pb.JobState, message string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.state == pb.JobState_JOB_DONE {
+		return
+	}
+	j.state = state
+	j.statusMessage = message
+}
+
+func (j *packageJob) setProgress(progress float64) {
+	j.mu.Lock()
+	if progress > j.progress {
+		j.progress = progress
+	}
+	j.mu.Unlock()
+}
+
+// appendLog records an output line and wakes up followers
+func (j *packageJob) appendLog(phase string, entry *pb.PackageOperationLog) {
+	j.mu.Lock()
+	j.appendLogLocked(phase, entry)
+	j.mu.Unlock()
+}
+
+func (j *packageJob) appendLogLocked(phase string, entry *pb.PackageOperationLog) {
+	entry.JobId = j.id
+	if entry.Progress == 0 {
+		entry.Progress = j.progress
+	}
+	j.logs = append(j.logs, jobLogEntry{phase: phase, log: entry})
+	if len(j.logs) > maxPackageJobLogLines {
+		drop := len(j.logs) - maxPackageJobLogLines
+		j.logs = append([]jobLogEntry(nil), j.logs[drop:]...)
+		j.dropped += drop
+	}
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// follow sends all log entries from the start of the job, then new ones as
+// they arrive, until the job completes or ctx is cancelled
+func (j *packageJob) follow(ctx context.Context, send func(jobLogEntry) error) error {
+	next := 0
+	for {
+		j.mu.Lock()
+		start := next - j.dropped
+		if start < 0 {
+			start = 0
+		}
+		entries := append([]jobLogEntry(nil), j.logs[start:]...)
+		next = j.dropped + len(j.logs)
+		done := j.state == pb.JobState_JOB_DONE
+		changed := j.changed
+		j.mu.Unlock()
+
+		for _, entry := range entries {
+			if err := send(entry); err != nil {
+				return err
+			}
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// output returns the plain command output of the job
+func (j *packageJob) output() string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var sb strings.Builder
+	for _, entry := range j.logs {
+		if entry.log.Completed {
+			continue
+		}
+		sb.WriteString(entry.log.Message)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func (j *packageJob) toProto() *pb.PackageJob {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	job := &pb.PackageJob{
+		Id:            j.id,
+		Description:   j.description,
+		State:         j.state,
+		Success:       j.state == pb.JobState_JOB_DONE && j.err == nil,
+		Cancelled:     j.cancelled,
+		ExitCode:      j.exitCode,
+		Progress:      j.progress,
+		StatusMessage: j.statusMessage,
+		CreatedAt:     j.createdAt.Unix(),
+	}
+	if !j.startedAt.IsZero() {
+		job.StartedAt = j.startedAt.Unix()
+	}
+	if !j.finishedAt.IsZero() {
+		job.FinishedAt = j.finishedAt.Unix()
+	}
+	return job
+}
+
+// runPackageJob queues a job and waits for it to finish
+func (s *systemMonitorServer) runPackageJob(ctx context.Context, description string, steps ...packageStep) (string, error) {
+	job, err := s.jobs.submit(description, steps...)
+	if err != nil {
+		return "", err
+	}
+
+	select {
+	case <-job.done:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	return job.output(), job.err
+}
+
+// streamPackageJob queues a job and follows its output. If the client goes
+// away the job keeps running; it can be reattached with AttachJob using the
+// job ID in each log entry, and only CancelJob stops it.
+func (s *systemMonitorServer) streamPackageJob(ctx context.Context, send func(jobLogEntry) error, description string, steps ...packageStep) error {
+	job, err := s.jobs.submit(description, steps...)
+	if err != nil {
+		return err
+	}
+
+	err = job.follow(ctx, send)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// ListJobs returns queued, running and recently finished package jobs
+func (s *systemMonitorServer) ListJobs(ctx context.Context, req *pb.Empty) (*pb.PackageJobList, error) {
+	var jobs []*pb.PackageJob
+	for _, job := range s.jobs.list() {
+		jobs = append(jobs, job.toProto())
+	}
+	return &pb.PackageJobList{Jobs: jobs}, nil
+}
+
+// GetJob returns a single package job
+func (s *systemMonitorServer) GetJob(ctx context.Context, req *pb.PackageJobId) (*pb.PackageJob, error) {
+	job := s.jobs.get(req.Id)
+	if job == nil {
+		return nil, fmt.Errorf("job %s not found", req.Id)
+	}
+	return job.toProto(), nil
+}
+
+// CancelJob cancels a queued or running package job
+func (s *systemMonitorServer) CancelJob(ctx context.Context, req *pb.PackageJobId) (*pb.ActionStatus, error) {
+	job := s.jobs.get(req.Id)
+	if job == nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Job %s not found", req.Id),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	if !job.requestCancel() {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Job %s has already finished", req.Id),
+			ErrorCode: 2,
+		}, nil
+	}
+
+	return &pb.ActionStatus{
+		Success: true,
+		Message: fmt.Sprintf("Job %s cancelled", req.Id),
+	}, nil
+}
+
+// AttachJob streams a job's output from the beginning and follows it until it completes
+func (s *systemMonitorServer) AttachJob(req *pb.PackageJobId, stream pb.SystemMonitor_AttachJobServer) error {
+	job := s.jobs.get(req.Id)
+	if job == nil {
+		return fmt.Errorf("job %s not found", req.Id)
+	}
+
+	err := job.follow(stream.Context(), func(entry jobLogEntry) error {
+		return stream.Send(entry.log)
+	})
+	if stream.Context().Err() != nil {
+		return nil
+	}
+	return err
+}
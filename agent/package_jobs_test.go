@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+func newTestJob(steps ...packageStep) *packageJob {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &packageJob{
+		id:      "pkg-test",
+		steps:   steps,
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		state:   pb.JobState_JOB_QUEUED,
+		changed: make(chan struct{}),
+	}
+}
+
+// A job cancelled after the worker picked it up must not run or finish twice
+func TestPackageJobCancelledBeforeRun(t *testing.T) {
+	job := newTestJob(packageStep{phase: "install", command: "true"})
+	if !job.requestCancel() {
+		t.Fatal("requestCancel on a queued job returned false")
+	}
+	job.run()
+
+	got := job.toProto()
+	if got.State != pb.JobState_JOB_DONE || !got.Cancelled || got.StartedAt != 0 {
+		t.Errorf("job after run = %v, want cancelled before starting", got)
+	}
+	if job.requestCancel() {
+		t.Error("requestCancel on a finished job returned true")
+	}
+}
+
+func TestPackageJobFinishTwice(t *testing.T) {
+	job := newTestJob()
+	job.finish(nil)
+	job.finish(errJobCancelled)
+
+	select {
+	case <-job.done:
+	default:
+		t.Fatal("done not closed")
+	}
+	if got := job.toProto(); !got.Success || got.Cancelled {
+		t.Errorf("second finish changed the result: %v", got)
+	}
+	job.setState(pb.JobState_JOB_RUNNING, "Running")
+	if got := job.toProto(); got.State != pb.JobState_JOB_DONE {
+		t.Errorf("setState after finish changed state to %v", got.State)
+	}
+}
+
+// A follower leaving must not cancel the job
+func TestStreamPackageJobDetach(t *testing.T) {
+	s := &systemMonitorServer{jobs: newPackageJobQueue()}
+	ctx, cancel := context.WithCancel(context.Background())
+	step := packageStep{phase: "sleep", command: "sleep", args: []string{"0.5"}}
+
+	var jobID string
+	err := s.streamPackageJob(ctx, func(entry jobLogEntry) error {
+		jobID = entry.log.JobId
+		cancel()
+		return nil
+	}, "sleep", step)
+	if err != nil {
+		t.Fatalf("streamPackageJob: %v", err)
+	}
+
+	job := s.jobs.get(jobID)
+	if job == nil {
+		t.Fatalf("job %q not found", jobID)
+	}
+	select {
+	case <-job.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job did not finish")
+	}
+	if got := job.toProto(); !got.Success {
+		t.Errorf("job = %v, want it to finish successfully after the follower left", got)
+	}
+}
+
+// Cancelling a job the worker already started must stop its command rather
+// than mark it done while the command keeps running
+func TestPackageJobCancelRunning(t *testing.T) {
+	job := newTestJob(packageStep{phase: "sleep", command: "sleep", args: []string{"10"}})
+	go job.run()
+	for job.toProto().State == pb.JobState_JOB_QUEUED {
+		time.Sleep(time.Millisecond)
+	}
+	if !job.requestCancel() {
+		t.Fatal("requestCancel on a running job returned false")
+	}
+
+	select {
+	case <-job.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancelled job did not finish")
+	}
+	if got := job.toProto(); !got.Cancelled || got.StartedAt == 0 {
+		t.Errorf("job = %v, want cancelled after starting", got)
+	}
+}
+
+// Whichever of run and requestCancel wins, the job finishes exactly once and
+// a cancelled job never reports success
+func TestPackageJobCancelRace(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		job := newTestJob(packageStep{phase: "sleep", command: "sleep", args: []string{"10"}})
+		go job.run()
+		job.requestCancel()
+
+		select {
+		case <-job.done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("cancelled job did not finish")
+		}
+		if got := job.toProto(); !got.Cancelled || got.Success {
+			t.Fatalf("job = %v, want cancelled", got)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+// packageManager abstracts the distribution's package tooling. Queries run
+// directly; changes are described as packageSteps and executed by the job queue.
+type packageManager interface {
+	// Name returns the backend name (apt, apk, dnf)
+	Name() string
+
+	// ListInstalled returns all installed packages
+	ListInstalled() ([]*pb.PackageInfo, error)
+
+	// Search returns up to limit packages matching term from the repositories
+	Search(term string, limit int) ([]*pb.PackageInfo, error)
+
+	// Details returns detailed information about a package
+	Details(name string) (*pb.PackageDetails, error)
+
+	// Dependencies returns forward and reverse dependencies of a package
+	Dependencies(name string) (*pb.PackageDependencies, error)
+
+	// Upgradable returns packages with a newer version available
+	Upgradable() ([]*pb.UpgradablePackage, error)
+
+	// LastRefresh returns when the package index was last refreshed
+	LastRefresh() (time.Time, bool)
+
+	// CheckName rejects names the tool would not read as a package, such as
+	// ones starting with '-' that it would parse as an option
+	CheckName(name string) error
+
+	// Steps for the job queue
+	RefreshStep() packageStep
+	InstallStep(name string) packageStep
+	RemoveStep(name string) packageStep
+	PurgeStep(name string) packageStep
+	UpgradeStep(name string) packageStep // Empty name upgrades everything
+
+	// Simulate reports what an operation would change without applying it
+	Simulate(op pb.PackageOperation, name string) (*pb.PackageSimulation, error)
+
+	// Holds returns packages held at their installed version
+	Holds() ([]*pb.PackageHold, error)
+
+	// HoldStep holds or releases packages
+	HoldStep(names []string, hold bool) (packageStep, error)
+
+	// SecurityUpgradeSteps returns refresh and upgrade steps limited to
+	// security updates. cleanup must be called once the steps have run.
+	SecurityUpgradeSteps() (steps []packageStep, cleanup func(), err error)
+}
+
+// pinManager is implemented by backends that support pin priorities (apt)
+type pinManager interface {
+	Pins() ([]*pb.PackagePin, error)
+	SetPin(pin *pb.PackagePin) error
+	ClearPin(name string) error
+}
+
+// sourceManager is implemented by backends whose repositories can be managed (apt)
+type sourceManager interface {
+	Sources() ([]*pb.PackageSource, error)
+	StageSource(name string, src *pb.PackageSource, deb822 bool) (*stagedSource, packageStep, error)
+	SetSourceEnabled(id string, enabled bool) error
+	RemoveSource(id string) error
+	ImportSigningKey(name string, data []byte, keyURL string, replace bool) (string, error)
+}
+
+// historyProvider is implemented by backends that can report past package
+// changes (apt)
+type historyProvider interface {
+	History() ([]*pb.PackageTransaction, error)
+}
+
+// newPackageManager selects a backend based on /etc/os-release, falling back
+// to whichever package tool is installed
+func newPackageManager() packageManager {
+	osRelease := readOSRelease()
+	ids := append([]string{osRelease["ID"]}, strings.Fields(osRelease["ID_LIKE"])...)
+
+	for _, id := range ids {
+		switch id {
+		case "debian", "ubuntu", "raspbian":
+			return &aptPackageManager{}
+		case "alpine":
+			return &apkPackageManager{}
+		case "fedora", "rhel", "centos", "rocky", "almalinux":
+			return &dnfPackageManager{}
+		}
+	}
+
+	switch {
+	case commandExists("apt-get"):
+		return &aptPackageManager{}
+	case commandExists("apk"):
+		return &apkPackageManager{}
+	case commandExists("dnf"):
+		return &dnfPackageManager{}
+	}
+
+	log.Printf("Warning: unknown distribution %q, assuming apt", osRelease["ID"])
+	return &aptPackageManager{}
+}
+
+// readOSRelease parses /etc/os-release into a key/value map
+func readOSRelease() map[string]string {
+	data, err := os.ReadFile("/etc/os-release")
+	if err != nil {
+		data, err = os.ReadFile("/usr/lib/os-release")
+		if err != nil {
+			return map[string]string{}
+		}
+	}
+	return parseOSRelease(data)
+}
+
+// parseOSRelease parses KEY=value lines, removing optional quotes
+func parseOSRelease(data []byte) map[string]string {
+	result := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		result[key] = strings.Trim(value, "\"'")
+	}
+	return result
+}
+
+// packageOperationStep returns the job step for a PackageOperation
+func packageOperationStep(m packageManager, op pb.PackageOperation, name string) (packageStep, error) {
+	if name == "" && op != pb.PackageOperation_PACKAGE_UPGRADE {
+		return packageStep{}, fmt.Errorf("package name is required")
+	}
+	if name != "" {
+		if err := m.CheckName(name); err != nil {
+			return packageStep{}, err
+		}
+	}
+
+	switch op {
+	case pb.PackageOperation_PACKAGE_INSTALL:
+		return m.InstallStep(name), nil
+	case pb.PackageOperation_PACKAGE_REMOVE:
+		return m.RemoveStep(name), nil
+	case pb.PackageOperation_PACKAGE_PURGE:
+		return m.PurgeStep(name), nil
+	case pb.PackageOperation_PACKAGE_UPGRADE:
+		return m.UpgradeStep(name), nil
+	default:
+		return packageStep{}, fmt.Errorf("unknown operation")
+	}
+}
+
+// checkPackageName matches name against a backend's package name grammar
+func checkPackageName(grammar *regexp.Regexp, name string) error {
+	if !grammar.MatchString(name) {
+		return fmt.Errorf("invalid package name %q", name)
+	}
+	return nil
+}
+
+// firstErrorLine returns the first error message in package manager output,
+// falling back to err
+func firstErrorLine(output string, err error) string {
+	for _, line := range splitLines(output) {
+		if packageLineLevel(line) == "error" {
+			return strings.TrimSpace(line)
+		}
+	}
+	return err.Error()
+}
+
+func commandExists(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// filterPackages applies a case-insensitive name/description filter
+func filterPackages(packages []*pb.PackageInfo, term string) []*pb.PackageInfo {
+	if term == "" {
+		return packages
+	}
+
+	term = strings.ToLower(term)
+	var result []*pb.PackageInfo
+	for _, pkg := range packages {
+		if strings.Contains(strings.ToLower(pkg.Name), term) ||
+			strings.Contains(strings.ToLower(pkg.Description), term) {
+			result = append(result, pkg)
+		}
+	}
+	return result
+}
+
+// commandLines runs a command and returns its non-empty output lines
+func commandLines(name string, args ...string) ([]string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), "LANG=C")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(string(output)), nil
+}
+
+// splitLines splits output into non-blank lines
+func splitLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimRight(line, "\r"); strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// emptyPackageDependencies returns dependency info with all lists initialized
+func emptyPackageDependencies(name string) *pb.PackageDependencies {
+	return &pb.PackageDependencies{
+		PackageName: name,
+		Depends:     []string{},
+		RequiredBy:  []string{},
+		Recommends:  []string{},
+		Suggests:    []string{},
+		Conflicts:   []string{},
+	}
+}
+
+// packageLineLevel derives a log level from the error and warning prefixes
+// used by apt (E:/W:), apk (ERROR:/WARNING:) and dnf (Error:/Warning:)
+func packageLineLevel(line string) string {
+	switch {
+	case strings.HasPrefix(line, "E:"), strings.HasPrefix(strings.ToLower(line), "error:"):
+		return "error"
+	case strings.HasPrefix(line, "W:"), strings.HasPrefix(strings.ToLower(line), "warning:"):
+		return "warning"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckName(t *testing.T) {
+	tests := []struct {
+		manager packageManager
+		name    string
+		valid   bool
+	}{
+		{&aptPackageManager{}, "vim", true},
+		{&aptPackageManager{}, "libstdc++6", true},
+		{&aptPackageManager{}, "libc6:arm64", true},
+		{&aptPackageManager{}, "nginx=1.22.1-9+deb12u1", true},
+		{&aptPackageManager{}, "python3=3:3.11.2-1", true},
+		{&aptPackageManager{}, "firmware-brcm80211/bookworm-backports", true},
+		{&aptPackageManager{}, "-oDPkg::Pre-Invoke::=touch /tmp/x", false},
+		{&aptPackageManager{}, "--reinstall", false},
+		{&aptPackageManager{}, "Vim", false},
+		{&aptPackageManager{}, "a", false},
+		{&aptPackageManager{}, "vim nano", false},
+		{&aptPackageManager{}, "", false},
+
+		{&apkPackageManager{}, "busybox", true},
+		{&apkPackageManager{}, "py3-pip", true},
+		{&apkPackageManager{}, "nginx=1.24.0-r15", true},
+		{&apkPackageManager{}, "nginx~1.24", true},
+		{&apkPackageManager{}, "nginx>=1.24", true},
+		{&apkPackageManager{}, "mesa-dri-gallium@edge", true},
+		{&apkPackageManager{}, "--allow-untrusted", false},
+		{&apkPackageManager{}, "-X", false},
+		{&apkPackageManager{}, "nginx=", false},
+
+		{&dnfPackageManager{}, "kernel-core", true},
+		{&dnfPackageManager{}, "gcc-c++", true},
+		{&dnfPackageManager{}, "NetworkManager", true},
+		{&dnfPackageManager{}, "bash-0:5.2.26-3.fc40.x86_64", true},
+		{&dnfPackageManager{}, "--setopt=tsflags=noscripts", false},
+		{&dnfPackageManager{}, "-y", false},
+		{&dnfPackageManager{}, "vim*", false},
+	}
+
+	for _, tt := range tests {
+		err := tt.manager.CheckName(tt.name)
+		if (err == nil) != tt.valid {
+			t.Errorf("%s CheckName(%q) = %v, want valid %v", tt.manager.Name(), tt.name, err, tt.valid)
+		}
+	}
+}
+
+// fixture returns the contents of a file in testdata
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// fixtureLines returns the lines of a file in testdata
+func fixtureLines(t *testing.T, name string) []string {
+	t.Helper()
+	return splitLines(string(fixture(t, name)))
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	pb "pi_agent/proto"
+)
+
+// Port scan defaults and limits
+const (
+	defaultScanTimeout     = 1000 * time.Millisecond
+	defaultScanConcurrency = 100
+	maxScanConcurrency     = 1000
+	maxScanHosts           = 256
+	maxScanProbes          = 1 << 18 // A /24 with 1024 ports
+	maxBannerLength        = 256
+)
+
+// defaultScanPorts are scanned when the request names no ports
+var defaultScanPorts = []int{20, 21, 22, 23, 25, 53, 80, 110, 143, 443, 445, 3306, 3389, 5432, 8080, 8443}
+
+// portServices names well-known ports. TCP and UDP share numbers for nearly
+// every service of interest, so one table serves both.
+var portServices = map[int]string{
+	7:     "echo",
+	20:    "ftp-data",
+	21:    "ftp",
+	22:    "ssh",
+	23:    "telnet",
+	25:    "smtp",
+	53:    "dns",
+	67:    "dhcp",
+	68:    "dhcp-client",
+	69:    "tftp",
+	80:    "http",
+	88:    "kerberos",
+	110:   "pop3",
+	111:   "rpcbind",
+	123:   "ntp",
+	135:   "msrpc",
+	137:   "netbios-ns",
+	138:   "netbios-dgm",
+	139:   "netbios-ssn",
+	143:   "imap",
+	161:   "snmp",
+	162:   "snmptrap",
+	179:   "bgp",
+	389:   "ldap",
+	443:   "https",
+	445:   "smb",
+	465:   "smtps",
+	500:   "isakmp",
+	514:   "syslog",
+	515:   "printer",
+	546:   "dhcpv6-client",
+	547:   "dhcpv6",
+	548:   "afp",
+	554:   "rtsp",
+	587:   "submission",
+	631:   "ipp",
+	636:   "ldaps",
+	873:   "rsync",
+	993:   "imaps",
+	995:   "pop3s",
+	1080:  "socks",
+	1194:  "openvpn",
+	1433:  "mssql",
+	1521:  "oracle",
+	1883:  "mqtt",
+	1900:  "ssdp",
+	2049:  "nfs",
+	2375:  "docker",
+	2376:  "docker-tls",
+	3000:  "http-alt",
+	3306:  "mysql",
+	3389:  "rdp",
+	3478:  "stun",
+	4500:  "ipsec-nat-t",
+	5000:  "upnp",
+	5060:  "sip",
+	5353:  "mdns",
+	5432:  "postgresql",
+	5672:  "amqp",
+	5900:  "vnc",
+	6379:  "redis",
+	6443:  "kubernetes",
+	8000:  "http-alt",
+	8008:  "http-alt",
+	8080:  "http-proxy",
+	8443:  "https-alt",
+	8883:  "mqtts",
+	8888:  "http-alt",
+	9000:  "http-alt",
+	9090:  "prometheus",
+	9100:  "node-exporter",
+	11211: "memcached",
+	27017: "mongodb",
+	51820: "wireguard",
+}
+
+// httpPorts get a HEAD request when grabbing banners, as HTTP servers wait
+// for the client to speak first
+var httpPorts = map[int]bool{80: true, 3000: true, 8000: true, 8008: true, 8080: true, 8888: true, 9000: true, 9090: true, 9100: true}
+
+// udpProbePayloads are requests that make common UDP services answer; other
+// ports get an empty datagram
+var udpProbePayloads = map[int][]byte{
+	// Query for the root NS records
+	53: {0x12, 0x34, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0x01},
+	// NTPv3 client request
+	123: append([]byte{0x1b}, make([]byte, 47)...),
+	// SNMPv1 get of sysDescr.0 with community "public"
+	161: {0x30, 0x26, 0x02, 0x01, 0x00, 0x04, 0x06, 'p', 'u', 'b', 'l', 'i', 'c', 0xa0, 0x19, 0x02, 0x01, 0x01,
+		0x02, 0x01, 0x00, 0x02, 0x01, 0x00, 0x30, 0x0e, 0x30, 0x0c, 0x06, 0x08, 0x2b, 0x06, 0x01, 0x02, 0x01, 0x01,
+		0x01, 0x00, 0x05, 0x00},
+	1900: []byte("M-SEARCH * HTTP/1.1\r\nHOST: 239.255.255.250:1900\r\nMAN: \"ssdp:discover\"\r\nMX: 1\r\nST: ssdp:all\r\n\r\n"),
+}
+
+// scanTarget is one address and port to probe
+type scanTarget struct {
+	ip   net.IP
+	port int
+}
+
+// scanHosts returns the addresses named by host: a subnet in CIDR notation
+// or a single name or address
+func scanHosts(ctx context.Context, host string) ([]net.IP, error) {
+	if !strings.Contains(host, "/") {
+		ip, err := resolveHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		return []net.IP{ip}, nil
+	}
+
+	_, subnet, err := net.ParseCIDR(host)
+	if err != nil {
+		return nil, err
+	}
+	ones, bits := subnet.Mask.Size()
+	if bits-ones > 8 {
+		return nil, fmt.Errorf("subnet %s has more than %d addresses", subnet, maxScanHosts)
+	}
+
+	var hosts []net.IP
+	ip := subnet.IP.Mask(subnet.Mask)
+	for ; subnet.Contains(ip); ip = nextIP(ip) {
+		hosts = append(hosts, ip)
+	}
+	// Skip the network and broadcast addresses of IPv4 subnets
+	if bits == 32 && bits-ones >= 2 {
+		hosts = hosts[1 : len(hosts)-1]
+	}
+	return hosts, nil
+}
+
+// nextIP returns the address following ip
+func nextIP(ip net.IP) net.IP {
+	next := append(net.IP(nil), ip...)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+// scanPortList returns the ports of a request: the explicit list, the range, or
+// the common ports
+func scanPortList(req *pb.PortScanRequest) ([]int, error) {
+	if len(req.Ports) > 0 {
+		ports := make([]int, 0, len(req.Ports))
+		seen := make(map[int]bool)
+		for _, port := range req.Ports {
+			if port < 1 || port > 65535 {
+				return nil, fmt.Errorf("invalid port %d", port)
+			}
+			if !seen[int(port)] {
+				seen[int(port)] = true
+				ports = append(ports, int(port))
+			}
+		}
+		return ports, nil
+	}
+
+	if req.StartPort == 0 && req.EndPort == 0 {
+		return defaultScanPorts, nil
+	}
+	start, end := int(req.StartPort), int(req.EndPort)
+	if start == 0 {
+		start = 1
+	}
+	if end == 0 {
+		end = start
+	}
+	if start < 1 || end > 65535 || start > end {
+		return nil, fmt.Errorf("invalid port range %d-%d", req.StartPort, req.EndPort)
+	}
+	ports := make([]int, 0, end-start+1)
+	for port := start; port <= end; port++ {
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+// probeTCP connects to a port and optionally reads what the service sends
+func probeTCP(ctx context.Context, target scanTarget, timeout time.Duration, grabBanner bool) *pb.PortScanResponse {
+	result := scanResult(target, "tcp")
+	dialer := net.Dialer{Timeout: timeout}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(target.ip.String(), strconv.Itoa(target.port)))
+	if err != nil {
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			result.State = "closed"
+			result.Latency = time.Since(start).Seconds() * 1000
+		} else {
+			result.State = "filtered"
+		}
+		return result
+	}
+	defer conn.Close()
+
+	result.State = "open"
+	result.Open = true
+	result.Latency = time.Since(start).Seconds() * 1000
+	if grabBanner {
+		result.Banner = readBanner(conn, target.port, timeout)
+	}
+	return result
+}
+
+// readBanner returns the first line a service sends, asking HTTP servers
+// for their response headers first
+func readBanner(conn net.Conn, port int, timeout time.Duration) string {
+	conn.SetDeadline(time.Now().Add(timeout))
+	if httpPorts[port] {
+		if _, err := conn.Write([]byte("HEAD / HTTP/1.0\r\n\r\n")); err != nil {
+			return ""
+		}
+	}
+	buf := make([]byte, 1024)
+	n, _ := conn.Read(buf)
+	return cleanBanner(buf[:n])
+}
+
+// cleanBanner keeps the first line of data with unprintable bytes replaced,
+// cut to maxBannerLength bytes without splitting a character
+func cleanBanner(data []byte) string {
+	line, _, _ := strings.Cut(string(data), "\n")
+	line = strings.Map(func(r rune) rune {
+		if r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return '.'
+		}
+		return r
+	}, strings.TrimRight(line, "\r"))
+	line = strings.TrimSpace(line)
+	if len(line) > maxBannerLength {
+		end := maxBannerLength
+		for end > 0 && !utf8.RuneStart(line[end]) {
+			end--
+		}
+		line = line[:end]
+	}
+	return line
+}
+
+// probeUDP sends a datagram and waits for an answer. An ICMP port
+// unreachable surfaces as a refused read; silence could mean either an open
+// port that ignored the probe or a firewall.
+func probeUDP(ctx context.Context, target scanTarget, timeout time.Duration) *pb.PortScanResponse {
+	result := scanResult(target, "udp")
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", net.JoinHostPort(target.ip.String(), strconv.Itoa(target.port)))
+	if err != nil {
+		result.State = "filtered"
+		result.Error = err.Error()
+		return result
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	start := time.Now()
+	if _, err := conn.Write(udpProbePayloads[target.port]); err != nil {
+		result.State = "filtered"
+		result.Error = err.Error()
+		return result
+	}
+	buf := make([]byte, 1500)
+	_, err = conn.Read(buf)
+	switch {
+	case err == nil:
+		result.State = "open"
+		result.Open = true
+		result.Latency = time.Since(start).Seconds() * 1000
+	case errors.Is(err, syscall.ECONNREFUSED):
+		result.State = "closed"
+		result.Latency = time.Since(start).Seconds() * 1000
+	default:
+		result.State = "open|filtered"
+	}
+	return result
+}
+
+// scanResult starts the response for a probe
+func scanResult(target scanTarget, protocol string) *pb.PortScanResponse {
+	return &pb.PortScanResponse{
+		Host:     target.ip.String(),
+		Port:     int32(target.port),
+		Protocol: protocol,
+		Service:  portServices[target.port],
+	}
+}
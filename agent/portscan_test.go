@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	pb "pi_agent/proto"
+)
+
+func TestScanPortList(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *pb.PortScanRequest
+		want    []int
+		wantErr bool
+	}{
+		{"defaults", &pb.PortScanRequest{}, defaultScanPorts, false},
+		{"list", &pb.PortScanRequest{Ports: []int32{443, 22, 443, 80}}, []int{443, 22, 80}, false},
+		{"list wins over range", &pb.PortScanRequest{Ports: []int32{22}, StartPort: 1, EndPort: 1024}, []int{22}, false},
+		{"range", &pb.PortScanRequest{StartPort: 8080, EndPort: 8083}, []int{8080, 8081, 8082, 8083}, false},
+		{"start only", &pb.PortScanRequest{StartPort: 8443}, []int{8443}, false},
+		{"end only", &pb.PortScanRequest{EndPort: 3}, []int{1, 2, 3}, false},
+		{"port zero in list", &pb.PortScanRequest{Ports: []int32{22, 0}}, nil, true},
+		{"port too large in list", &pb.PortScanRequest{Ports: []int32{65536}}, nil, true},
+		{"reversed range", &pb.PortScanRequest{StartPort: 100, EndPort: 10}, nil, true},
+		{"range too large", &pb.PortScanRequest{StartPort: 65530, EndPort: 65536}, nil, true},
+		{"negative start", &pb.PortScanRequest{StartPort: -1, EndPort: 10}, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := scanPortList(tt.req)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ports = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScanHosts(t *testing.T) {
+	tests := []struct {
+		host        string
+		count       int
+		first, last string
+	}{
+		{"192.168.1.77/24", 254, "192.168.1.1", "192.168.1.254"},
+		{"10.0.0.4/30", 2, "10.0.0.5", "10.0.0.6"},
+		{"10.0.0.4/31", 2, "10.0.0.4", "10.0.0.5"},
+		{"10.0.0.4/32", 1, "10.0.0.4", "10.0.0.4"},
+		{"2001:db8::/120", 256, "2001:db8::", "2001:db8::ff"},
+		{"192.0.2.9", 1, "192.0.2.9", "192.0.2.9"},
+	}
+	for _, tt := range tests {
+		hosts, err := scanHosts(context.Background(), tt.host)
+		if err != nil {
+			t.Errorf("%s: %v", tt.host, err)
+			continue
+		}
+		if len(hosts) != tt.count || !hosts[0].Equal(net.ParseIP(tt.first)) || !hosts[len(hosts)-1].Equal(net.ParseIP(tt.last)) {
+			t.Errorf("%s: got %d hosts %v-%v, want %d hosts %s-%s",
+				tt.host, len(hosts), hosts[0], hosts[len(hosts)-1], tt.count, tt.first, tt.last)
+		}
+	}
+
+	for _, host := range []string{"10.0.0.0/23", "10.0.0.0/8", "2001:db8::/64", "10.0.0.0/33"} {
+		if hosts, err := scanHosts(context.Background(), host); err == nil {
+			t.Errorf("%s: got %d hosts, want an error", host, len(hosts))
+		}
+	}
+}
+
+func TestCleanBanner(t *testing.T) {
+	tests := []struct {
+		data, want string
+	}{
+		{"SSH-2.0-OpenSSH_9.2p1 Debian-2\r\n", "SSH-2.0-OpenSSH_9.2p1 Debian-2"},
+		{"220 mail.example.test ESMTP Postfix\r\n250 ok\r\n", "220 mail.example.test ESMTP Postfix"},
+		{"HTTP/1.0 200 OK\r\nServer: nginx\r\n\r\n", "HTTP/1.0 200 OK"},
+		{"  \x00\x01binary\xff\tdata\r\n", "..binary..data"},
+		{"café ✓\n", "café ✓"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := cleanBanner([]byte(tt.data)); got != tt.want {
+			t.Errorf("cleanBanner(%q) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+
+	// A multi-byte character across the length limit is dropped whole
+	long := strings.Repeat("a", maxBannerLength-1) + "éé"
+	got := cleanBanner([]byte(long))
+	if want := strings.Repeat("a", maxBannerLength-1); got != want {
+		t.Errorf("long banner: got %d bytes ending %q, want %d bytes", len(got), got[len(got)-3:], len(want))
+	}
+	if !utf8.ValidString(got) {
+		t.Error("long banner is not valid UTF-8")
+	}
+	if got := cleanBanner([]byte(strings.Repeat("b", 1000))); len(got) != maxBannerLength {
+		t.Errorf("ASCII banner: got %d bytes, want %d", len(got), maxBannerLength)
+	}
+}
@@ -79,6 +79,360 @@ func (ServiceAction) EnumDescriptor() ([]byte, []int) {
 	return file_pi_control_proto_rawDescGZIP(), []int{0}
 }
 
+type PackageOperation int32
+
+const (
+	PackageOperation_PACKAGE_INSTALL PackageOperation = 0
+	PackageOperation_PACKAGE_REMOVE  PackageOperation = 1
+	PackageOperation_PACKAGE_UPGRADE PackageOperation = 2 // Upgrade one package, or all if package_name is empty
+	PackageOperation_PACKAGE_PURGE   PackageOperation = 3
+)
+
+// Enum value maps for PackageOperation.
+var (
+	PackageOperation_name = map[int32]string{
+		0: "PACKAGE_INSTALL",
+		1: "PACKAGE_REMOVE",
+		2: "PACKAGE_UPGRADE",
+		3: "PACKAGE_PURGE",
+	}
+	PackageOperation_value = map[string]int32{
+		"PACKAGE_INSTALL": 0,
+		"PACKAGE_REMOVE":  1,
+		"PACKAGE_UPGRADE": 2,
+		"PACKAGE_PURGE":   3,
+	}
+)
+
+func (x PackageOperation) Enum() *PackageOperation {
+	p := new(PackageOperation)
+	*p = x
+	return p
+}
+
+func (x PackageOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PackageOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_pi_control_proto_enumTypes[1].Descriptor()
+}
+
+func (PackageOperation) Type() protoreflect.EnumType {
+	return &file_pi_control_proto_enumTypes[1]
+}
+
+func (x PackageOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PackageOperation.Descriptor instead.
+func (PackageOperation) EnumDescriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{1}
+}
+
+type JobState int32
+
+const (
+	JobState_JOB_QUEUED           JobState = 0
+	JobState_JOB_WAITING_FOR_LOCK JobState = 1 // Another process (e.g. unattended-upgrades) holds the dpkg lock
+	JobState_JOB_RUNNING          JobState = 2
+	JobState_JOB_DONE             JobState = 3
+)
+
+// Enum value maps for JobState.
+var (
+	JobState_name = map[int32]string{
+		0: "JOB_QUEUED",
+		1: "JOB_WAITING_FOR_LOCK",
+		2: "JOB_RUNNING",
+		3: "JOB_DONE",
+	}
+	JobState_value = map[string]int32{
+		"JOB_QUEUED":           0,
+		"JOB_WAITING_FOR_LOCK": 1,
+		"JOB_RUNNING":          2,
+		"JOB_DONE":             3,
+	}
+)
+
+func (x JobState) Enum() *JobState {
+	p := new(JobState)
+	*p = x
+	return p
+}
+
+func (x JobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_pi_control_proto_enumTypes[2].Descriptor()
+}
+
+func (JobState) Type() protoreflect.EnumType {
+	return &file_pi_control_proto_enumTypes[2]
+}
+
+func (x JobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobState.Descriptor instead.
+func (JobState) EnumDescriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{2}
+}
+
+// Transport probed by a port scan
+type PortScanProtocol int32
+
+const (
+	PortScanProtocol_PORT_SCAN_TCP PortScanProtocol = 0 // TCP connect
+	PortScanProtocol_PORT_SCAN_UDP PortScanProtocol = 1 // UDP datagram; silence is reported as open|filtered
+)
+
+// Enum value maps for PortScanProtocol.
+var (
+	PortScanProtocol_name = map[int32]string{
+		0: "PORT_SCAN_TCP",
+		1: "PORT_SCAN_UDP",
+	}
+	PortScanProtocol_value = map[string]int32{
+		"PORT_SCAN_TCP": 0,
+		"PORT_SCAN_UDP": 1,
+	}
+)
+
+func (x PortScanProtocol) Enum() *PortScanProtocol {
+	p := new(PortScanProtocol)
+	*p = x
+	return p
+}
+
+func (x PortScanProtocol) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PortScanProtocol) Descriptor() protoreflect.EnumDescriptor {
+	return file_pi_control_proto_enumTypes[3].Descriptor()
+}
+
+func (PortScanProtocol) Type() protoreflect.EnumType {
+	return &file_pi_control_proto_enumTypes[3]
+}
+
+func (x PortScanProtocol) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PortScanProtocol.Descriptor instead.
+func (PortScanProtocol) EnumDescriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{3}
+}
+
+// Packet type sent by traceroute
+type TracerouteProbe int32
+
+const (
+	TracerouteProbe_TRACEROUTE_UDP  TracerouteProbe = 0 // UDP to high ports, like traceroute(8)
+	TracerouteProbe_TRACEROUTE_ICMP TracerouteProbe = 1 // ICMP echo, like traceroute -I and tracert
+	TracerouteProbe_TRACEROUTE_TCP  TracerouteProbe = 2 // TCP SYN, passes firewalls that drop UDP and ICMP
+)
+
+// Enum value maps for TracerouteProbe.
+var (
+	TracerouteProbe_name = map[int32]string{
+		0: "TRACEROUTE_UDP",
+		1: "TRACEROUTE_ICMP",
+		2: "TRACEROUTE_TCP",
+	}
+	TracerouteProbe_value = map[string]int32{
+		"TRACEROUTE_UDP":  0,
+		"TRACEROUTE_ICMP": 1,
+		"TRACEROUTE_TCP":  2,
+	}
+)
+
+func (x TracerouteProbe) Enum() *TracerouteProbe {
+	p := new(TracerouteProbe)
+	*p = x
+	return p
+}
+
+func (x TracerouteProbe) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TracerouteProbe) Descriptor() protoreflect.EnumDescriptor {
+	return file_pi_control_proto_enumTypes[4].Descriptor()
+}
+
+func (TracerouteProbe) Type() protoreflect.EnumType {
+	return &file_pi_control_proto_enumTypes[4]
+}
+
+func (x TracerouteProbe) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TracerouteProbe.Descriptor instead.
+func (TracerouteProbe) EnumDescriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{4}
+}
+
+type LogStream int32
+
+const (
+	LogStream_LOG_STREAM_ALL    LogStream = 0
+	LogStream_LOG_STREAM_STDOUT LogStream = 1
+	LogStream_LOG_STREAM_STDERR LogStream = 2
+)
+
+// Enum value maps for LogStream.
+var (
+	LogStream_name = map[int32]string{
+		0: "LOG_STREAM_ALL",
+		1: "LOG_STREAM_STDOUT",
+		2: "LOG_STREAM_STDERR",
+	}
+	LogStream_value = map[string]int32{
+		"LOG_STREAM_ALL":    0,
+		"LOG_STREAM_STDOUT": 1,
+		"LOG_STREAM_STDERR": 2,
+	}
+)
+
+func (x LogStream) Enum() *LogStream {
+	p := new(LogStream)
+	*p = x
+	return p
+}
+
+func (x LogStream) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LogStream) Descriptor() protoreflect.EnumDescriptor {
+	return file_pi_control_proto_enumTypes[5].Descriptor()
+}
+
+func (LogStream) Type() protoreflect.EnumType {
+	return &file_pi_control_proto_enumTypes[5]
+}
+
+func (x LogStream) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LogStream.Descriptor instead.
+func (LogStream) EnumDescriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{5}
+}
+
+type ComposeAction int32
+
+const (
+	ComposeAction_COMPOSE_ACTION_UNSPECIFIED ComposeAction = 0 // Rejected, so an unset action does nothing
+	ComposeAction_COMPOSE_UP                 ComposeAction = 1
+	ComposeAction_COMPOSE_DOWN               ComposeAction = 2
+	ComposeAction_COMPOSE_RESTART            ComposeAction = 3
+	ComposeAction_COMPOSE_PULL               ComposeAction = 4
+)
+
+// Enum value maps for ComposeAction.
+var (
+	ComposeAction_name = map[int32]string{
+		0: "COMPOSE_ACTION_UNSPECIFIED",
+		1: "COMPOSE_UP",
+		2: "COMPOSE_DOWN",
+		3: "COMPOSE_RESTART",
+		4: "COMPOSE_PULL",
+	}
+	ComposeAction_value = map[string]int32{
+		"COMPOSE_ACTION_UNSPECIFIED": 0,
+		"COMPOSE_UP":                 1,
+		"COMPOSE_DOWN":               2,
+		"COMPOSE_RESTART":            3,
+		"COMPOSE_PULL":               4,
+	}
+)
+
+func (x ComposeAction) Enum() *ComposeAction {
+	p := new(ComposeAction)
+	*p = x
+	return p
+}
+
+func (x ComposeAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ComposeAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_pi_control_proto_enumTypes[6].Descriptor()
+}
+
+func (ComposeAction) Type() protoreflect.EnumType {
+	return &file_pi_control_proto_enumTypes[6]
+}
+
+func (x ComposeAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ComposeAction.Descriptor instead.
+func (ComposeAction) EnumDescriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{6}
+}
+
+type RebootPolicy int32
+
+const (
+	RebootPolicy_REBOOT_NEVER       RebootPolicy = 0
+	RebootPolicy_REBOOT_IF_REQUIRED RebootPolicy = 1 // Reboot when the upgrade leaves a reboot-required flag
+	RebootPolicy_REBOOT_ALWAYS      RebootPolicy = 2
+)
+
+// Enum value maps for RebootPolicy.
+var (
+	RebootPolicy_name = map[int32]string{
+		0: "REBOOT_NEVER",
+		1: "REBOOT_IF_REQUIRED",
+		2: "REBOOT_ALWAYS",
+	}
+	RebootPolicy_value = map[string]int32{
+		"REBOOT_NEVER":       0,
+		"REBOOT_IF_REQUIRED": 1,
+		"REBOOT_ALWAYS":      2,
+	}
+)
+
+func (x RebootPolicy) Enum() *RebootPolicy {
+	p := new(RebootPolicy)
+	*p = x
+	return p
+}
+
+func (x RebootPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RebootPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_pi_control_proto_enumTypes[7].Descriptor()
+}
+
+func (RebootPolicy) Type() protoreflect.EnumType {
+	return &file_pi_control_proto_enumTypes[7]
+}
+
+func (x RebootPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RebootPolicy.Descriptor instead.
+func (RebootPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{7}
+}
+
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -148,7 +502,9 @@ type LiveStats struct {
 	// Timestamp of this stat snapshot
 	Timestamp int64 `protobuf:"varint,18,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	// Disk I/O stats (bytes per second)
-	DiskIo        []*DiskIOStat `protobuf:"bytes,19,rep,name=disk_io,json=diskIo,proto3" json:"disk_io,omitempty"`
+	DiskIo []*DiskIOStat `protobuf:"bytes,19,rep,name=disk_io,json=diskIo,proto3" json:"disk_io,omitempty"`
+	// Per-interface network stats
+	Interfaces    []*InterfaceStats `protobuf:"bytes,20,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -316,6 +672,13 @@ func (x *LiveStats) GetDiskIo() []*DiskIOStat {
 	return nil
 }
 
+func (x *LiveStats) GetInterfaces() []*InterfaceStats {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
 // Process information
 type ProcessInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -822,6 +1185,7 @@ type LogEntry struct {
 	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
 	Service       string                 `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
 	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Stream        string                 `protobuf:"bytes,5,opt,name=stream,proto3" json:"stream,omitempty"` // stdout, stderr (container logs only)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -884,6 +1248,13 @@ func (x *LogEntry) GetMessage() string {
 	return ""
 }
 
+func (x *LogEntry) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
 // Disk usage information
 type DiskInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1311,29 +1682,27 @@ func (x *NetworkConnection) GetProcessName() string {
 	return ""
 }
 
-// Package management
-type PackageFilter struct {
+type InterfaceConfigRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SearchTerm    string                 `protobuf:"bytes,1,opt,name=search_term,json=searchTerm,proto3" json:"search_term,omitempty"`           // Empty = list all installed
-	InstalledOnly bool                   `protobuf:"varint,2,opt,name=installed_only,json=installedOnly,proto3" json:"installed_only,omitempty"` // If true, only show installed packages
+	Interface     string                 `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PackageFilter) Reset() {
-	*x = PackageFilter{}
+func (x *InterfaceConfigRequest) Reset() {
+	*x = InterfaceConfigRequest{}
 	mi := &file_pi_control_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PackageFilter) String() string {
+func (x *InterfaceConfigRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PackageFilter) ProtoMessage() {}
+func (*InterfaceConfigRequest) ProtoMessage() {}
 
-func (x *PackageFilter) ProtoReflect() protoreflect.Message {
+func (x *InterfaceConfigRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pi_control_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1345,53 +1714,52 @@ func (x *PackageFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PackageFilter.ProtoReflect.Descriptor instead.
-func (*PackageFilter) Descriptor() ([]byte, []int) {
+// Deprecated: Use InterfaceConfigRequest.ProtoReflect.Descriptor instead.
+func (*InterfaceConfigRequest) Descriptor() ([]byte, []int) {
 	return file_pi_control_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *PackageFilter) GetSearchTerm() string {
+func (x *InterfaceConfigRequest) GetInterface() string {
 	if x != nil {
-		return x.SearchTerm
+		return x.Interface
 	}
 	return ""
 }
 
-func (x *PackageFilter) GetInstalledOnly() bool {
-	if x != nil {
-		return x.InstalledOnly
-	}
-	return false
-}
-
-type PackageInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	Architecture  string                 `protobuf:"bytes,3,opt,name=architecture,proto3" json:"architecture,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Installed     bool                   `protobuf:"varint,5,opt,name=installed,proto3" json:"installed,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                     // installed, not-installed, upgradable
-	InstalledSize uint64                 `protobuf:"varint,7,opt,name=installed_size,json=installedSize,proto3" json:"installed_size,omitempty"` // Size in bytes (0 if not installed)
-	Section       string                 `protobuf:"bytes,8,opt,name=section,proto3" json:"section,omitempty"`                                   // Package category (utils, libs, net, etc.)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Addressing of a network interface, as configured in NetworkManager or dhcpcd.conf
+type InterfaceConfig struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Interface      string                 `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"`
+	Dhcp           bool                   `protobuf:"varint,2,opt,name=dhcp,proto3" json:"dhcp,omitempty"`                                       // IPv4 from DHCP; otherwise ipv4_addresses are used
+	Ipv4Addresses  []string               `protobuf:"bytes,3,rep,name=ipv4_addresses,json=ipv4Addresses,proto3" json:"ipv4_addresses,omitempty"` // CIDR, e.g. "192.168.1.10/24"
+	Ipv4Gateway    string                 `protobuf:"bytes,4,opt,name=ipv4_gateway,json=ipv4Gateway,proto3" json:"ipv4_gateway,omitempty"`
+	Ipv6Addresses  []string               `protobuf:"bytes,5,rep,name=ipv6_addresses,json=ipv6Addresses,proto3" json:"ipv6_addresses,omitempty"` // CIDR; empty means automatic (SLAAC/DHCPv6)
+	Ipv6Gateway    string                 `protobuf:"bytes,6,opt,name=ipv6_gateway,json=ipv6Gateway,proto3" json:"ipv6_gateway,omitempty"`
+	DnsServers     []string               `protobuf:"bytes,7,rep,name=dns_servers,json=dnsServers,proto3" json:"dns_servers,omitempty"`              // IPv4 and IPv6; empty uses the servers from DHCP
+	ConfirmTimeout int32                  `protobuf:"varint,8,opt,name=confirm_timeout,json=confirmTimeout,proto3" json:"confirm_timeout,omitempty"` // Set only: seconds to confirm before the change is reverted (default 120)
+	Backend        string                 `protobuf:"bytes,9,opt,name=backend,proto3" json:"backend,omitempty"`                                      // Output only: NetworkManager or dhcpcd
+	Pending        bool                   `protobuf:"varint,10,opt,name=pending,proto3" json:"pending,omitempty"`                                    // Output only: a change is waiting for confirmation
+	RevertAt       int64                  `protobuf:"varint,11,opt,name=revert_at,json=revertAt,proto3" json:"revert_at,omitempty"`                  // Output only: when the pending change is reverted (Unix timestamp)
+	LastChange     string                 `protobuf:"bytes,12,opt,name=last_change,json=lastChange,proto3" json:"last_change,omitempty"`             // Output only: outcome of the last change
+	Error          string                 `protobuf:"bytes,13,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *PackageInfo) Reset() {
-	*x = PackageInfo{}
+func (x *InterfaceConfig) Reset() {
+	*x = InterfaceConfig{}
 	mi := &file_pi_control_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PackageInfo) String() string {
+func (x *InterfaceConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PackageInfo) ProtoMessage() {}
+func (*InterfaceConfig) ProtoMessage() {}
 
-func (x *PackageInfo) ProtoReflect() protoreflect.Message {
+func (x *InterfaceConfig) ProtoReflect() protoreflect.Message {
 	mi := &file_pi_control_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1403,63 +1771,251 @@ func (x *PackageInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PackageInfo.ProtoReflect.Descriptor instead.
-func (*PackageInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use InterfaceConfig.ProtoReflect.Descriptor instead.
+func (*InterfaceConfig) Descriptor() ([]byte, []int) {
 	return file_pi_control_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *PackageInfo) GetName() string {
+func (x *InterfaceConfig) GetInterface() string {
 	if x != nil {
-		return x.Name
+		return x.Interface
 	}
 	return ""
 }
 
-func (x *PackageInfo) GetVersion() string {
+func (x *InterfaceConfig) GetDhcp() bool {
 	if x != nil {
-		return x.Version
+		return x.Dhcp
 	}
-	return ""
+	return false
 }
 
-func (x *PackageInfo) GetArchitecture() string {
+func (x *InterfaceConfig) GetIpv4Addresses() []string {
 	if x != nil {
-		return x.Architecture
+		return x.Ipv4Addresses
 	}
-	return ""
+	return nil
 }
 
-func (x *PackageInfo) GetDescription() string {
+func (x *InterfaceConfig) GetIpv4Gateway() string {
 	if x != nil {
-		return x.Description
+		return x.Ipv4Gateway
 	}
 	return ""
 }
 
-func (x *PackageInfo) GetInstalled() bool {
+func (x *InterfaceConfig) GetIpv6Addresses() []string {
 	if x != nil {
-		return x.Installed
+		return x.Ipv6Addresses
 	}
-	return false
+	return nil
 }
 
-func (x *PackageInfo) GetStatus() string {
+func (x *InterfaceConfig) GetIpv6Gateway() string {
 	if x != nil {
-		return x.Status
+		return x.Ipv6Gateway
 	}
 	return ""
 }
 
-func (x *PackageInfo) GetInstalledSize() uint64 {
+func (x *InterfaceConfig) GetDnsServers() []string {
 	if x != nil {
-		return x.InstalledSize
+		return x.DnsServers
 	}
-	return 0
+	return nil
 }
 
-func (x *PackageInfo) GetSection() string {
+func (x *InterfaceConfig) GetConfirmTimeout() int32 {
 	if x != nil {
-		return x.Section
+		return x.ConfirmTimeout
+	}
+	return 0
+}
+
+func (x *InterfaceConfig) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+func (x *InterfaceConfig) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *InterfaceConfig) GetRevertAt() int64 {
+	if x != nil {
+		return x.RevertAt
+	}
+	return 0
+}
+
+func (x *InterfaceConfig) GetLastChange() string {
+	if x != nil {
+		return x.LastChange
+	}
+	return ""
+}
+
+func (x *InterfaceConfig) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Package management
+type PackageFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SearchTerm    string                 `protobuf:"bytes,1,opt,name=search_term,json=searchTerm,proto3" json:"search_term,omitempty"`           // Empty = list all installed
+	InstalledOnly bool                   `protobuf:"varint,2,opt,name=installed_only,json=installedOnly,proto3" json:"installed_only,omitempty"` // If true, only show installed packages
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageFilter) Reset() {
+	*x = PackageFilter{}
+	mi := &file_pi_control_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageFilter) ProtoMessage() {}
+
+func (x *PackageFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageFilter.ProtoReflect.Descriptor instead.
+func (*PackageFilter) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PackageFilter) GetSearchTerm() string {
+	if x != nil {
+		return x.SearchTerm
+	}
+	return ""
+}
+
+func (x *PackageFilter) GetInstalledOnly() bool {
+	if x != nil {
+		return x.InstalledOnly
+	}
+	return false
+}
+
+type PackageInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Architecture  string                 `protobuf:"bytes,3,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Installed     bool                   `protobuf:"varint,5,opt,name=installed,proto3" json:"installed,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                     // installed, not-installed, upgradable
+	InstalledSize uint64                 `protobuf:"varint,7,opt,name=installed_size,json=installedSize,proto3" json:"installed_size,omitempty"` // Size in bytes (0 if not installed)
+	Section       string                 `protobuf:"bytes,8,opt,name=section,proto3" json:"section,omitempty"`                                   // Package category (utils, libs, net, etc.)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageInfo) Reset() {
+	*x = PackageInfo{}
+	mi := &file_pi_control_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageInfo) ProtoMessage() {}
+
+func (x *PackageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageInfo.ProtoReflect.Descriptor instead.
+func (*PackageInfo) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PackageInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PackageInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PackageInfo) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+func (x *PackageInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PackageInfo) GetInstalled() bool {
+	if x != nil {
+		return x.Installed
+	}
+	return false
+}
+
+func (x *PackageInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PackageInfo) GetInstalledSize() uint64 {
+	if x != nil {
+		return x.InstalledSize
+	}
+	return 0
+}
+
+func (x *PackageInfo) GetSection() string {
+	if x != nil {
+		return x.Section
 	}
 	return ""
 }
@@ -1473,7 +2029,7 @@ type PackageList struct {
 
 func (x *PackageList) Reset() {
 	*x = PackageList{}
-	mi := &file_pi_control_proto_msgTypes[19]
+	mi := &file_pi_control_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1485,7 +2041,7 @@ func (x *PackageList) String() string {
 func (*PackageList) ProtoMessage() {}
 
 func (x *PackageList) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[19]
+	mi := &file_pi_control_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1498,7 +2054,7 @@ func (x *PackageList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PackageList.ProtoReflect.Descriptor instead.
 func (*PackageList) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{19}
+	return file_pi_control_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *PackageList) GetPackages() []*PackageInfo {
@@ -1511,13 +2067,14 @@ func (x *PackageList) GetPackages() []*PackageInfo {
 type PackageCommand struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PackageName   string                 `protobuf:"bytes,1,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
+	Operation     PackageOperation       `protobuf:"varint,2,opt,name=operation,proto3,enum=picontrol.PackageOperation" json:"operation,omitempty"` // Used by StreamPackageOperation (default: install)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PackageCommand) Reset() {
 	*x = PackageCommand{}
-	mi := &file_pi_control_proto_msgTypes[20]
+	mi := &file_pi_control_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1529,7 +2086,7 @@ func (x *PackageCommand) String() string {
 func (*PackageCommand) ProtoMessage() {}
 
 func (x *PackageCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[20]
+	mi := &file_pi_control_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1542,7 +2099,7 @@ func (x *PackageCommand) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PackageCommand.ProtoReflect.Descriptor instead.
 func (*PackageCommand) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{20}
+	return file_pi_control_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *PackageCommand) GetPackageName() string {
@@ -1552,6 +2109,13 @@ func (x *PackageCommand) GetPackageName() string {
 	return ""
 }
 
+func (x *PackageCommand) GetOperation() PackageOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return PackageOperation_PACKAGE_INSTALL
+}
+
 // Request for detailed package information
 type PackageDetailsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1562,7 +2126,7 @@ type PackageDetailsRequest struct {
 
 func (x *PackageDetailsRequest) Reset() {
 	*x = PackageDetailsRequest{}
-	mi := &file_pi_control_proto_msgTypes[21]
+	mi := &file_pi_control_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1574,7 +2138,7 @@ func (x *PackageDetailsRequest) String() string {
 func (*PackageDetailsRequest) ProtoMessage() {}
 
 func (x *PackageDetailsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[21]
+	mi := &file_pi_control_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1587,7 +2151,7 @@ func (x *PackageDetailsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PackageDetailsRequest.ProtoReflect.Descriptor instead.
 func (*PackageDetailsRequest) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{21}
+	return file_pi_control_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *PackageDetailsRequest) GetPackageName() string {
@@ -1622,7 +2186,7 @@ type PackageDetails struct {
 
 func (x *PackageDetails) Reset() {
 	*x = PackageDetails{}
-	mi := &file_pi_control_proto_msgTypes[22]
+	mi := &file_pi_control_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1634,7 +2198,7 @@ func (x *PackageDetails) String() string {
 func (*PackageDetails) ProtoMessage() {}
 
 func (x *PackageDetails) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[22]
+	mi := &file_pi_control_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1647,7 +2211,7 @@ func (x *PackageDetails) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PackageDetails.ProtoReflect.Descriptor instead.
 func (*PackageDetails) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{22}
+	return file_pi_control_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *PackageDetails) GetName() string {
@@ -1777,7 +2341,7 @@ type PackageDependencies struct {
 
 func (x *PackageDependencies) Reset() {
 	*x = PackageDependencies{}
-	mi := &file_pi_control_proto_msgTypes[23]
+	mi := &file_pi_control_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1789,7 +2353,7 @@ func (x *PackageDependencies) String() string {
 func (*PackageDependencies) ProtoMessage() {}
 
 func (x *PackageDependencies) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[23]
+	mi := &file_pi_control_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1802,7 +2366,7 @@ func (x *PackageDependencies) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PackageDependencies.ProtoReflect.Descriptor instead.
 func (*PackageDependencies) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{23}
+	return file_pi_control_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *PackageDependencies) GetPackageName() string {
@@ -1847,34 +2411,38 @@ func (x *PackageDependencies) GetConflicts() []string {
 	return nil
 }
 
-// Log entry for package operations
-type PackageOperationLog struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"` // info, warning, error
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	Progress      float64                `protobuf:"fixed64,4,opt,name=progress,proto3" json:"progress,omitempty"`  // 0-100 percentage
-	Completed     bool                   `protobuf:"varint,5,opt,name=completed,proto3" json:"completed,omitempty"` // True when operation is done
-	Success       bool                   `protobuf:"varint,6,opt,name=success,proto3" json:"success,omitempty"`     // True if operation succeeded
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *PackageOperationLog) Reset() {
-	*x = PackageOperationLog{}
-	mi := &file_pi_control_proto_msgTypes[24]
+// Result of a simulated package operation
+type PackageSimulation struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error            string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Install          []*SimulatedPackage    `protobuf:"bytes,3,rep,name=install,proto3" json:"install,omitempty"`                                           // Newly installed packages
+	Upgrade          []*SimulatedPackage    `protobuf:"bytes,4,rep,name=upgrade,proto3" json:"upgrade,omitempty"`                                           // Upgraded (or downgraded) packages
+	Remove           []*SimulatedPackage    `protobuf:"bytes,5,rep,name=remove,proto3" json:"remove,omitempty"`                                             // Removed packages, including reverse dependencies
+	HeldBack         []*SimulatedPackage    `protobuf:"bytes,6,rep,name=held_back,json=heldBack,proto3" json:"held_back,omitempty"`                         // Upgradable packages that would be kept back
+	Autoremovable    []string               `protobuf:"bytes,7,rep,name=autoremovable,proto3" json:"autoremovable,omitempty"`                               // Packages left unneeded afterwards
+	EssentialRemoved []string               `protobuf:"bytes,8,rep,name=essential_removed,json=essentialRemoved,proto3" json:"essential_removed,omitempty"` // Essential packages the operation would remove
+	DownloadSize     int64                  `protobuf:"varint,9,opt,name=download_size,json=downloadSize,proto3" json:"download_size,omitempty"`            // Bytes to download
+	DiskSizeDelta    int64                  `protobuf:"varint,10,opt,name=disk_size_delta,json=diskSizeDelta,proto3" json:"disk_size_delta,omitempty"`      // Change in installed size in bytes (negative frees space)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PackageSimulation) Reset() {
+	*x = PackageSimulation{}
+	mi := &file_pi_control_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PackageOperationLog) String() string {
+func (x *PackageSimulation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PackageOperationLog) ProtoMessage() {}
+func (*PackageSimulation) ProtoMessage() {}
 
-func (x *PackageOperationLog) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[24]
+func (x *PackageSimulation) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1885,80 +2453,4481 @@ func (x *PackageOperationLog) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PackageOperationLog.ProtoReflect.Descriptor instead.
-func (*PackageOperationLog) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use PackageSimulation.ProtoReflect.Descriptor instead.
+func (*PackageSimulation) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *PackageOperationLog) GetTimestamp() int64 {
+func (x *PackageSimulation) GetSuccess() bool {
 	if x != nil {
-		return x.Timestamp
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-func (x *PackageOperationLog) GetLevel() string {
+func (x *PackageSimulation) GetError() string {
 	if x != nil {
-		return x.Level
+		return x.Error
 	}
 	return ""
 }
 
-func (x *PackageOperationLog) GetMessage() string {
+func (x *PackageSimulation) GetInstall() []*SimulatedPackage {
 	if x != nil {
-		return x.Message
+		return x.Install
 	}
-	return ""
+	return nil
 }
 
-func (x *PackageOperationLog) GetProgress() float64 {
+func (x *PackageSimulation) GetUpgrade() []*SimulatedPackage {
 	if x != nil {
-		return x.Progress
+		return x.Upgrade
 	}
-	return 0
+	return nil
 }
 
-func (x *PackageOperationLog) GetCompleted() bool {
+func (x *PackageSimulation) GetRemove() []*SimulatedPackage {
 	if x != nil {
-		return x.Completed
+		return x.Remove
 	}
-	return false
+	return nil
+}
+
+func (x *PackageSimulation) GetHeldBack() []*SimulatedPackage {
+	if x != nil {
+		return x.HeldBack
+	}
+	return nil
+}
+
+func (x *PackageSimulation) GetAutoremovable() []string {
+	if x != nil {
+		return x.Autoremovable
+	}
+	return nil
+}
+
+func (x *PackageSimulation) GetEssentialRemoved() []string {
+	if x != nil {
+		return x.EssentialRemoved
+	}
+	return nil
+}
+
+func (x *PackageSimulation) GetDownloadSize() int64 {
+	if x != nil {
+		return x.DownloadSize
+	}
+	return 0
+}
+
+func (x *PackageSimulation) GetDiskSizeDelta() int64 {
+	if x != nil {
+		return x.DiskSizeDelta
+	}
+	return 0
+}
+
+// Package affected by a simulated operation
+type SimulatedPackage struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CurrentVersion string                 `protobuf:"bytes,2,opt,name=current_version,json=currentVersion,proto3" json:"current_version,omitempty"` // Empty for new installs
+	NewVersion     string                 `protobuf:"bytes,3,opt,name=new_version,json=newVersion,proto3" json:"new_version,omitempty"`             // Empty for removals
+	Architecture   string                 `protobuf:"bytes,4,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	DownloadSize   int64                  `protobuf:"varint,5,opt,name=download_size,json=downloadSize,proto3" json:"download_size,omitempty"` // Bytes
+	SizeDelta      int64                  `protobuf:"varint,6,opt,name=size_delta,json=sizeDelta,proto3" json:"size_delta,omitempty"`          // Change in installed size in bytes
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SimulatedPackage) Reset() {
+	*x = SimulatedPackage{}
+	mi := &file_pi_control_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulatedPackage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulatedPackage) ProtoMessage() {}
+
+func (x *SimulatedPackage) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulatedPackage.ProtoReflect.Descriptor instead.
+func (*SimulatedPackage) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SimulatedPackage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SimulatedPackage) GetCurrentVersion() string {
+	if x != nil {
+		return x.CurrentVersion
+	}
+	return ""
+}
+
+func (x *SimulatedPackage) GetNewVersion() string {
+	if x != nil {
+		return x.NewVersion
+	}
+	return ""
+}
+
+func (x *SimulatedPackage) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+func (x *SimulatedPackage) GetDownloadSize() int64 {
+	if x != nil {
+		return x.DownloadSize
+	}
+	return 0
+}
+
+func (x *SimulatedPackage) GetSizeDelta() int64 {
+	if x != nil {
+		return x.SizeDelta
+	}
+	return 0
+}
+
+// APT source entry
+type PackageSource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // "<file>:<index>" of the entry within its file
+	File          string                 `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"` // "list" (one-line) or "deb822"
+	Enabled       bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Types         []string               `protobuf:"bytes,5,rep,name=types,proto3" json:"types,omitempty"` // deb, deb-src
+	Uris          []string               `protobuf:"bytes,6,rep,name=uris,proto3" json:"uris,omitempty"`
+	Suites        []string               `protobuf:"bytes,7,rep,name=suites,proto3" json:"suites,omitempty"`
+	Components    []string               `protobuf:"bytes,8,rep,name=components,proto3" json:"components,omitempty"`
+	Architectures []string               `protobuf:"bytes,9,rep,name=architectures,proto3" json:"architectures,omitempty"`
+	SignedBy      string                 `protobuf:"bytes,10,opt,name=signed_by,json=signedBy,proto3" json:"signed_by,omitempty"` // Keyring path
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageSource) Reset() {
+	*x = PackageSource{}
+	mi := &file_pi_control_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageSource) ProtoMessage() {}
+
+func (x *PackageSource) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageSource.ProtoReflect.Descriptor instead.
+func (*PackageSource) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PackageSource) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PackageSource) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *PackageSource) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *PackageSource) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *PackageSource) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *PackageSource) GetUris() []string {
+	if x != nil {
+		return x.Uris
+	}
+	return nil
+}
+
+func (x *PackageSource) GetSuites() []string {
+	if x != nil {
+		return x.Suites
+	}
+	return nil
+}
+
+func (x *PackageSource) GetComponents() []string {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *PackageSource) GetArchitectures() []string {
+	if x != nil {
+		return x.Architectures
+	}
+	return nil
+}
+
+func (x *PackageSource) GetSignedBy() string {
+	if x != nil {
+		return x.SignedBy
+	}
+	return ""
+}
+
+type PackageSourceList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sources       []*PackageSource       `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageSourceList) Reset() {
+	*x = PackageSourceList{}
+	mi := &file_pi_control_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageSourceList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageSourceList) ProtoMessage() {}
+
+func (x *PackageSourceList) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageSourceList.ProtoReflect.Descriptor instead.
+func (*PackageSourceList) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PackageSourceList) GetSources() []*PackageSource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+type AddPackageSourceRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                            // File name in /etc/apt/sources.list.d without extension
+	Source         *PackageSource         `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`                                        // types, uris, suites, components, architectures and signed_by are used
+	Deb822         bool                   `protobuf:"varint,3,opt,name=deb822,proto3" json:"deb822,omitempty"`                                       // Write a .sources file instead of a .list file
+	SkipValidation bool                   `protobuf:"varint,4,opt,name=skip_validation,json=skipValidation,proto3" json:"skip_validation,omitempty"` // Add without running apt-get update first
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AddPackageSourceRequest) Reset() {
+	*x = AddPackageSourceRequest{}
+	mi := &file_pi_control_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPackageSourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPackageSourceRequest) ProtoMessage() {}
+
+func (x *AddPackageSourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPackageSourceRequest.ProtoReflect.Descriptor instead.
+func (*AddPackageSourceRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AddPackageSourceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddPackageSourceRequest) GetSource() *PackageSource {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *AddPackageSourceRequest) GetDeb822() bool {
+	if x != nil {
+		return x.Deb822
+	}
+	return false
+}
+
+func (x *AddPackageSourceRequest) GetSkipValidation() bool {
+	if x != nil {
+		return x.SkipValidation
+	}
+	return false
+}
+
+type PackageSourceToggle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageSourceToggle) Reset() {
+	*x = PackageSourceToggle{}
+	mi := &file_pi_control_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageSourceToggle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageSourceToggle) ProtoMessage() {}
+
+func (x *PackageSourceToggle) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageSourceToggle.ProtoReflect.Descriptor instead.
+func (*PackageSourceToggle) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PackageSourceToggle) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PackageSourceToggle) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type PackageSourceId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageSourceId) Reset() {
+	*x = PackageSourceId{}
+	mi := &file_pi_control_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageSourceId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageSourceId) ProtoMessage() {}
+
+func (x *PackageSourceId) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageSourceId.ProtoReflect.Descriptor instead.
+func (*PackageSourceId) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PackageSourceId) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SigningKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                      // Keyring file name without extension
+	KeyData       []byte                 `protobuf:"bytes,2,opt,name=key_data,json=keyData,proto3" json:"key_data,omitempty"` // ASCII armored or binary OpenPGP key
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`                        // Download the key from here when key_data is empty
+	Replace       bool                   `protobuf:"varint,4,opt,name=replace,proto3" json:"replace,omitempty"`               // Overwrite an existing keyring with the same name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SigningKeyRequest) Reset() {
+	*x = SigningKeyRequest{}
+	mi := &file_pi_control_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SigningKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SigningKeyRequest) ProtoMessage() {}
+
+func (x *SigningKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SigningKeyRequest.ProtoReflect.Descriptor instead.
+func (*SigningKeyRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SigningKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SigningKeyRequest) GetKeyData() []byte {
+	if x != nil {
+		return x.KeyData
+	}
+	return nil
+}
+
+func (x *SigningKeyRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SigningKeyRequest) GetReplace() bool {
+	if x != nil {
+		return x.Replace
+	}
+	return false
+}
+
+type PackageHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`    // Maximum transactions to return (0 = 100)
+	Package       string                 `protobuf:"bytes,2,opt,name=package,proto3" json:"package,omitempty"` // Only transactions touching this package
+	Since         int64                  `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`    // Unix timestamp; only transactions started at or after this
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageHistoryRequest) Reset() {
+	*x = PackageHistoryRequest{}
+	mi := &file_pi_control_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageHistoryRequest) ProtoMessage() {}
+
+func (x *PackageHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageHistoryRequest.ProtoReflect.Descriptor instead.
+func (*PackageHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PackageHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PackageHistoryRequest) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *PackageHistoryRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+// A single package change within a transaction
+type PackageChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Architecture  string                 `protobuf:"bytes,2,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"` // install, upgrade, downgrade, reinstall, remove, purge
+	OldVersion    string                 `protobuf:"bytes,4,opt,name=old_version,json=oldVersion,proto3" json:"old_version,omitempty"`
+	NewVersion    string                 `protobuf:"bytes,5,opt,name=new_version,json=newVersion,proto3" json:"new_version,omitempty"`
+	Automatic     bool                   `protobuf:"varint,6,opt,name=automatic,proto3" json:"automatic,omitempty"` // Installed as a dependency
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageChange) Reset() {
+	*x = PackageChange{}
+	mi := &file_pi_control_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageChange) ProtoMessage() {}
+
+func (x *PackageChange) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageChange.ProtoReflect.Descriptor instead.
+func (*PackageChange) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PackageChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PackageChange) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+func (x *PackageChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *PackageChange) GetOldVersion() string {
+	if x != nil {
+		return x.OldVersion
+	}
+	return ""
+}
+
+func (x *PackageChange) GetNewVersion() string {
+	if x != nil {
+		return x.NewVersion
+	}
+	return ""
+}
+
+func (x *PackageChange) GetAutomatic() bool {
+	if x != nil {
+		return x.Automatic
+	}
+	return false
+}
+
+// One apt run, or a group of dpkg actions not made through apt
+type PackageTransaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     int64                  `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       int64                  `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	CommandLine   string                 `protobuf:"bytes,3,opt,name=command_line,json=commandLine,proto3" json:"command_line,omitempty"`
+	RequestedBy   string                 `protobuf:"bytes,4,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"` // User that ran the command, e.g. "pi (1000)"
+	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`                              // "apt" or "dpkg"
+	Changes       []*PackageChange       `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageTransaction) Reset() {
+	*x = PackageTransaction{}
+	mi := &file_pi_control_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageTransaction) ProtoMessage() {}
+
+func (x *PackageTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageTransaction.ProtoReflect.Descriptor instead.
+func (*PackageTransaction) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PackageTransaction) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *PackageTransaction) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *PackageTransaction) GetCommandLine() string {
+	if x != nil {
+		return x.CommandLine
+	}
+	return ""
+}
+
+func (x *PackageTransaction) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *PackageTransaction) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PackageTransaction) GetChanges() []*PackageChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *PackageTransaction) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type PackageHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*PackageTransaction  `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageHistory) Reset() {
+	*x = PackageHistory{}
+	mi := &file_pi_control_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageHistory) ProtoMessage() {}
+
+func (x *PackageHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageHistory.ProtoReflect.Descriptor instead.
+func (*PackageHistory) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PackageHistory) GetTransactions() []*PackageTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+// Package held at its installed version
+type PackageHold struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"` // Installed (held) version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageHold) Reset() {
+	*x = PackageHold{}
+	mi := &file_pi_control_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageHold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageHold) ProtoMessage() {}
+
+func (x *PackageHold) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageHold.ProtoReflect.Descriptor instead.
+func (*PackageHold) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PackageHold) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PackageHold) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type PackageHoldList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holds         []*PackageHold         `protobuf:"bytes,1,rep,name=holds,proto3" json:"holds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageHoldList) Reset() {
+	*x = PackageHoldList{}
+	mi := &file_pi_control_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageHoldList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageHoldList) ProtoMessage() {}
+
+func (x *PackageHoldList) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageHoldList.ProtoReflect.Descriptor instead.
+func (*PackageHoldList) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PackageHoldList) GetHolds() []*PackageHold {
+	if x != nil {
+		return x.Holds
+	}
+	return nil
+}
+
+type PackageHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PackageNames  []string               `protobuf:"bytes,1,rep,name=package_names,json=packageNames,proto3" json:"package_names,omitempty"`
+	Hold          bool                   `protobuf:"varint,2,opt,name=hold,proto3" json:"hold,omitempty"` // False releases the hold
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageHoldRequest) Reset() {
+	*x = PackageHoldRequest{}
+	mi := &file_pi_control_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageHoldRequest) ProtoMessage() {}
+
+func (x *PackageHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageHoldRequest.ProtoReflect.Descriptor instead.
+func (*PackageHoldRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PackageHoldRequest) GetPackageNames() []string {
+	if x != nil {
+		return x.PackageNames
+	}
+	return nil
+}
+
+func (x *PackageHoldRequest) GetHold() bool {
+	if x != nil {
+		return x.Hold
+	}
+	return false
+}
+
+// apt preferences stanza
+type PackagePin struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Package       string                 `protobuf:"bytes,1,opt,name=package,proto3" json:"package,omitempty"`    // Package name or pattern, e.g. "raspberrypi-kernel" or "linux-image-*"
+	Pin           string                 `protobuf:"bytes,2,opt,name=pin,proto3" json:"pin,omitempty"`            // e.g. "version 1.2.*", "release a=stable", "origin deb.example.com"
+	Priority      int32                  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"` // Pin-Priority; above 1000 allows downgrades, below 0 blocks installation
+	File          string                 `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`          // Preferences file the pin was read from
+	Managed       bool                   `protobuf:"varint,5,opt,name=managed,proto3" json:"managed,omitempty"`   // Created by the agent (can be changed and cleared)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackagePin) Reset() {
+	*x = PackagePin{}
+	mi := &file_pi_control_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackagePin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackagePin) ProtoMessage() {}
+
+func (x *PackagePin) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackagePin.ProtoReflect.Descriptor instead.
+func (*PackagePin) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PackagePin) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *PackagePin) GetPin() string {
+	if x != nil {
+		return x.Pin
+	}
+	return ""
+}
+
+func (x *PackagePin) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *PackagePin) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *PackagePin) GetManaged() bool {
+	if x != nil {
+		return x.Managed
+	}
+	return false
+}
+
+type PackagePinList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pins          []*PackagePin          `protobuf:"bytes,1,rep,name=pins,proto3" json:"pins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackagePinList) Reset() {
+	*x = PackagePinList{}
+	mi := &file_pi_control_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackagePinList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackagePinList) ProtoMessage() {}
+
+func (x *PackagePinList) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackagePinList.ProtoReflect.Descriptor instead.
+func (*PackagePinList) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PackagePinList) GetPins() []*PackagePin {
+	if x != nil {
+		return x.Pins
+	}
+	return nil
+}
+
+// Log entry for package operations
+type PackageOperationLog struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"` // info, warning, error
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Progress      float64                `protobuf:"fixed64,4,opt,name=progress,proto3" json:"progress,omitempty"`                // 0-100 percentage
+	Completed     bool                   `protobuf:"varint,5,opt,name=completed,proto3" json:"completed,omitempty"`               // True when operation is done
+	Success       bool                   `protobuf:"varint,6,opt,name=success,proto3" json:"success,omitempty"`                   // True if operation succeeded
+	ExitCode      int32                  `protobuf:"varint,7,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"` // Exit status of the package manager (set when completed)
+	JobId         string                 `protobuf:"bytes,8,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`           // Package job producing this output
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageOperationLog) Reset() {
+	*x = PackageOperationLog{}
+	mi := &file_pi_control_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageOperationLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageOperationLog) ProtoMessage() {}
+
+func (x *PackageOperationLog) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageOperationLog.ProtoReflect.Descriptor instead.
+func (*PackageOperationLog) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *PackageOperationLog) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *PackageOperationLog) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *PackageOperationLog) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PackageOperationLog) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *PackageOperationLog) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
 }
 
 func (x *PackageOperationLog) GetSuccess() bool {
 	if x != nil {
-		return x.Success
+		return x.Success
+	}
+	return false
+}
+
+func (x *PackageOperationLog) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *PackageOperationLog) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// Package manager job
+type PackageJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"` // e.g. "install htop"
+	State         JobState               `protobuf:"varint,3,opt,name=state,proto3,enum=picontrol.JobState" json:"state,omitempty"`
+	Success       bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"` // Set when done
+	Cancelled     bool                   `protobuf:"varint,5,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Progress      float64                `protobuf:"fixed64,7,opt,name=progress,proto3" json:"progress,omitempty"`                              // 0-100 percentage
+	StatusMessage string                 `protobuf:"bytes,8,opt,name=status_message,json=statusMessage,proto3" json:"status_message,omitempty"` // Latest status, e.g. lock holder while waiting
+	CreatedAt     int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`            // Unix timestamps
+	StartedAt     int64                  `protobuf:"varint,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    int64                  `protobuf:"varint,11,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageJob) Reset() {
+	*x = PackageJob{}
+	mi := &file_pi_control_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageJob) ProtoMessage() {}
+
+func (x *PackageJob) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageJob.ProtoReflect.Descriptor instead.
+func (*PackageJob) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *PackageJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PackageJob) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PackageJob) GetState() JobState {
+	if x != nil {
+		return x.State
+	}
+	return JobState_JOB_QUEUED
+}
+
+func (x *PackageJob) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PackageJob) GetCancelled() bool {
+	if x != nil {
+		return x.Cancelled
+	}
+	return false
+}
+
+func (x *PackageJob) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *PackageJob) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *PackageJob) GetStatusMessage() string {
+	if x != nil {
+		return x.StatusMessage
+	}
+	return ""
+}
+
+func (x *PackageJob) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *PackageJob) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *PackageJob) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+type PackageJobList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*PackageJob          `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageJobList) Reset() {
+	*x = PackageJobList{}
+	mi := &file_pi_control_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageJobList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageJobList) ProtoMessage() {}
+
+func (x *PackageJobList) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageJobList.ProtoReflect.Descriptor instead.
+func (*PackageJobList) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PackageJobList) GetJobs() []*PackageJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type PackageJobId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageJobId) Reset() {
+	*x = PackageJobId{}
+	mi := &file_pi_control_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageJobId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageJobId) ProtoMessage() {}
+
+func (x *PackageJobId) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageJobId.ProtoReflect.Descriptor instead.
+func (*PackageJobId) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *PackageJobId) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Disk I/O statistics
+type DiskIOStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        string                 `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`                            // e.g., "sda", "nvme0n1"
+	ReadBytes     uint64                 `protobuf:"varint,2,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`    // Bytes read per second
+	WriteBytes    uint64                 `protobuf:"varint,3,opt,name=write_bytes,json=writeBytes,proto3" json:"write_bytes,omitempty"` // Bytes written per second
+	ReadCount     uint64                 `protobuf:"varint,4,opt,name=read_count,json=readCount,proto3" json:"read_count,omitempty"`    // Read operations per second
+	WriteCount    uint64                 `protobuf:"varint,5,opt,name=write_count,json=writeCount,proto3" json:"write_count,omitempty"` // Write operations per second
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiskIOStat) Reset() {
+	*x = DiskIOStat{}
+	mi := &file_pi_control_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiskIOStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskIOStat) ProtoMessage() {}
+
+func (x *DiskIOStat) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskIOStat.ProtoReflect.Descriptor instead.
+func (*DiskIOStat) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DiskIOStat) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *DiskIOStat) GetReadBytes() uint64 {
+	if x != nil {
+		return x.ReadBytes
+	}
+	return 0
+}
+
+func (x *DiskIOStat) GetWriteBytes() uint64 {
+	if x != nil {
+		return x.WriteBytes
+	}
+	return 0
+}
+
+func (x *DiskIOStat) GetReadCount() uint64 {
+	if x != nil {
+		return x.ReadCount
+	}
+	return 0
+}
+
+func (x *DiskIOStat) GetWriteCount() uint64 {
+	if x != nil {
+		return x.WriteCount
+	}
+	return 0
+}
+
+type InterfaceStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                             // e.g., "eth0", "wlan0"
+	RxBytes       uint64                 `protobuf:"varint,2,opt,name=rx_bytes,json=rxBytes,proto3" json:"rx_bytes,omitempty"`       // Bytes received per second
+	TxBytes       uint64                 `protobuf:"varint,3,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`       // Bytes sent per second
+	RxPackets     uint64                 `protobuf:"varint,4,opt,name=rx_packets,json=rxPackets,proto3" json:"rx_packets,omitempty"` // Packets received per second
+	TxPackets     uint64                 `protobuf:"varint,5,opt,name=tx_packets,json=txPackets,proto3" json:"tx_packets,omitempty"` // Packets sent per second
+	RxErrors      uint64                 `protobuf:"varint,6,opt,name=rx_errors,json=rxErrors,proto3" json:"rx_errors,omitempty"`    // Receive errors since the interface came up
+	TxErrors      uint64                 `protobuf:"varint,7,opt,name=tx_errors,json=txErrors,proto3" json:"tx_errors,omitempty"`    // Send errors since the interface came up
+	RxDropped     uint64                 `protobuf:"varint,8,opt,name=rx_dropped,json=rxDropped,proto3" json:"rx_dropped,omitempty"` // Received packets dropped since the interface came up
+	TxDropped     uint64                 `protobuf:"varint,9,opt,name=tx_dropped,json=txDropped,proto3" json:"tx_dropped,omitempty"` // Outgoing packets dropped since the interface came up
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterfaceStats) Reset() {
+	*x = InterfaceStats{}
+	mi := &file_pi_control_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterfaceStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceStats) ProtoMessage() {}
+
+func (x *InterfaceStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceStats.ProtoReflect.Descriptor instead.
+func (*InterfaceStats) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *InterfaceStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InterfaceStats) GetRxBytes() uint64 {
+	if x != nil {
+		return x.RxBytes
+	}
+	return 0
+}
+
+func (x *InterfaceStats) GetTxBytes() uint64 {
+	if x != nil {
+		return x.TxBytes
+	}
+	return 0
+}
+
+func (x *InterfaceStats) GetRxPackets() uint64 {
+	if x != nil {
+		return x.RxPackets
+	}
+	return 0
+}
+
+func (x *InterfaceStats) GetTxPackets() uint64 {
+	if x != nil {
+		return x.TxPackets
+	}
+	return 0
+}
+
+func (x *InterfaceStats) GetRxErrors() uint64 {
+	if x != nil {
+		return x.RxErrors
+	}
+	return 0
+}
+
+func (x *InterfaceStats) GetTxErrors() uint64 {
+	if x != nil {
+		return x.TxErrors
+	}
+	return 0
+}
+
+func (x *InterfaceStats) GetRxDropped() uint64 {
+	if x != nil {
+		return x.RxDropped
+	}
+	return 0
+}
+
+func (x *InterfaceStats) GetTxDropped() uint64 {
+	if x != nil {
+		return x.TxDropped
+	}
+	return 0
+}
+
+// Version information
+type VersionInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Version        string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`                    // Agent version string (e.g., "3.1.0")
+	IsRoot         bool                   `protobuf:"varint,2,opt,name=is_root,json=isRoot,proto3" json:"is_root,omitempty"`       // Whether agent is running with root privileges
+	BootTime       int64                  `protobuf:"varint,3,opt,name=boot_time,json=bootTime,proto3" json:"boot_time,omitempty"` // Unix timestamp of the current boot
+	UptimeSeconds  int64                  `protobuf:"varint,4,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	LastBootReason string                 `protobuf:"bytes,5,opt,name=last_boot_reason,json=lastBootReason,proto3" json:"last_boot_reason,omitempty"` // Why the system last restarted, e.g. "reboot requested via agent: kernel update"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VersionInfo) Reset() {
+	*x = VersionInfo{}
+	mi := &file_pi_control_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionInfo) ProtoMessage() {}
+
+func (x *VersionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionInfo.ProtoReflect.Descriptor instead.
+func (*VersionInfo) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *VersionInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *VersionInfo) GetIsRoot() bool {
+	if x != nil {
+		return x.IsRoot
+	}
+	return false
+}
+
+func (x *VersionInfo) GetBootTime() int64 {
+	if x != nil {
+		return x.BootTime
+	}
+	return 0
+}
+
+func (x *VersionInfo) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *VersionInfo) GetLastBootReason() string {
+	if x != nil {
+		return x.LastBootReason
+	}
+	return ""
+}
+
+// Ping request
+type PingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`                                // Hostname or IP address to ping
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`                             // Number of pings (0 = continuous until cancelled)
+	Timeout       int32                  `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`                         // Timeout in seconds per ping (default: 5)
+	PacketSize    int32                  `protobuf:"varint,4,opt,name=packet_size,json=packetSize,proto3" json:"packet_size,omitempty"` // Packet size in bytes (default: 56)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingRequest) Reset() {
+	*x = PingRequest{}
+	mi := &file_pi_control_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingRequest) ProtoMessage() {}
+
+func (x *PingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PingRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *PingRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PingRequest) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *PingRequest) GetPacketSize() int32 {
+	if x != nil {
+		return x.PacketSize
+	}
+	return 0
+}
+
+// Ping response (streamed)
+type PingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`      // Whether ping was successful
+	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`             // Target host
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`                 // Resolved IP address
+	Latency       float64                `protobuf:"fixed64,4,opt,name=latency,proto3" json:"latency,omitempty"`     // Latency in milliseconds
+	Sequence      int32                  `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`    // Sequence number
+	Ttl           int32                  `protobuf:"varint,6,opt,name=ttl,proto3" json:"ttl,omitempty"`              // Time to live
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`           // Error message if failed
+	Finished      bool                   `protobuf:"varint,8,opt,name=finished,proto3" json:"finished,omitempty"`    // True when all pings complete
+	Statistics    *PingStats             `protobuf:"bytes,9,opt,name=statistics,proto3" json:"statistics,omitempty"` // Statistics so far, in every reply; totals in the finished message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingResponse) Reset() {
+	*x = PingResponse{}
+	mi := &file_pi_control_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingResponse) ProtoMessage() {}
+
+func (x *PingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *PingResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PingResponse) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *PingResponse) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *PingResponse) GetLatency() float64 {
+	if x != nil {
+		return x.Latency
+	}
+	return 0
+}
+
+func (x *PingResponse) GetSequence() int32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *PingResponse) GetTtl() int32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (x *PingResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PingResponse) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *PingResponse) GetStatistics() *PingStats {
+	if x != nil {
+		return x.Statistics
+	}
+	return nil
+}
+
+// Ping statistics
+type PingStats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PacketsSent     int32                  `protobuf:"varint,1,opt,name=packets_sent,json=packetsSent,proto3" json:"packets_sent,omitempty"`
+	PacketsReceived int32                  `protobuf:"varint,2,opt,name=packets_received,json=packetsReceived,proto3" json:"packets_received,omitempty"`
+	PacketLoss      float64                `protobuf:"fixed64,3,opt,name=packet_loss,json=packetLoss,proto3" json:"packet_loss,omitempty"` // Percentage
+	MinLatency      float64                `protobuf:"fixed64,4,opt,name=min_latency,json=minLatency,proto3" json:"min_latency,omitempty"` // ms
+	MaxLatency      float64                `protobuf:"fixed64,5,opt,name=max_latency,json=maxLatency,proto3" json:"max_latency,omitempty"` // ms
+	AvgLatency      float64                `protobuf:"fixed64,6,opt,name=avg_latency,json=avgLatency,proto3" json:"avg_latency,omitempty"` // ms
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PingStats) Reset() {
+	*x = PingStats{}
+	mi := &file_pi_control_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingStats) ProtoMessage() {}
+
+func (x *PingStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingStats.ProtoReflect.Descriptor instead.
+func (*PingStats) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *PingStats) GetPacketsSent() int32 {
+	if x != nil {
+		return x.PacketsSent
+	}
+	return 0
+}
+
+func (x *PingStats) GetPacketsReceived() int32 {
+	if x != nil {
+		return x.PacketsReceived
+	}
+	return 0
+}
+
+func (x *PingStats) GetPacketLoss() float64 {
+	if x != nil {
+		return x.PacketLoss
+	}
+	return 0
+}
+
+func (x *PingStats) GetMinLatency() float64 {
+	if x != nil {
+		return x.MinLatency
+	}
+	return 0
+}
+
+func (x *PingStats) GetMaxLatency() float64 {
+	if x != nil {
+		return x.MaxLatency
+	}
+	return 0
+}
+
+func (x *PingStats) GetAvgLatency() float64 {
+	if x != nil {
+		return x.AvgLatency
+	}
+	return 0
+}
+
+// Port scan request
+type PortScanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`                             // Target hostname, IP or subnet in CIDR notation (at most 256 addresses, e.g. 192.168.1.0/24)
+	Ports         []int32                `protobuf:"varint,2,rep,packed,name=ports,proto3" json:"ports,omitempty"`                   // Specific ports to scan (if empty, scan common ports)
+	StartPort     int32                  `protobuf:"varint,3,opt,name=start_port,json=startPort,proto3" json:"start_port,omitempty"` // Start of port range (alternative to ports list)
+	EndPort       int32                  `protobuf:"varint,4,opt,name=end_port,json=endPort,proto3" json:"end_port,omitempty"`       // End of port range
+	Timeout       int32                  `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`                      // Timeout per port in milliseconds (default: 1000)
+	Concurrency   int32                  `protobuf:"varint,6,opt,name=concurrency,proto3" json:"concurrency,omitempty"`              // Probes in flight at once (default: 100, max: 1000)
+	Rate          int32                  `protobuf:"varint,7,opt,name=rate,proto3" json:"rate,omitempty"`                            // Maximum probes started per second (0 = unlimited)
+	Protocol      PortScanProtocol       `protobuf:"varint,8,opt,name=protocol,proto3,enum=picontrol.PortScanProtocol" json:"protocol,omitempty"`
+	GrabBanner    bool                   `protobuf:"varint,9,opt,name=grab_banner,json=grabBanner,proto3" json:"grab_banner,omitempty"` // Read the greeting of open TCP ports
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortScanRequest) Reset() {
+	*x = PortScanRequest{}
+	mi := &file_pi_control_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortScanRequest) ProtoMessage() {}
+
+func (x *PortScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortScanRequest.ProtoReflect.Descriptor instead.
+func (*PortScanRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *PortScanRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *PortScanRequest) GetPorts() []int32 {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *PortScanRequest) GetStartPort() int32 {
+	if x != nil {
+		return x.StartPort
+	}
+	return 0
+}
+
+func (x *PortScanRequest) GetEndPort() int32 {
+	if x != nil {
+		return x.EndPort
+	}
+	return 0
+}
+
+func (x *PortScanRequest) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *PortScanRequest) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *PortScanRequest) GetRate() int32 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *PortScanRequest) GetProtocol() PortScanProtocol {
+	if x != nil {
+		return x.Protocol
+	}
+	return PortScanProtocol_PORT_SCAN_TCP
+}
+
+func (x *PortScanRequest) GetGrabBanner() bool {
+	if x != nil {
+		return x.GrabBanner
+	}
+	return false
+}
+
+// Port scan response (streamed)
+type PortScanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Port          int32                  `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	Open          bool                   `protobuf:"varint,2,opt,name=open,proto3" json:"open,omitempty"`
+	Service       string                 `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"` // Service name if known
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Finished      bool                   `protobuf:"varint,5,opt,name=finished,proto3" json:"finished,omitempty"` // True when scan completes
+	Progress      int32                  `protobuf:"varint,6,opt,name=progress,proto3" json:"progress,omitempty"` // Progress percentage (0-100)
+	Host          string                 `protobuf:"bytes,7,opt,name=host,proto3" json:"host,omitempty"`          // Address probed, for subnet scans
+	Protocol      string                 `protobuf:"bytes,8,opt,name=protocol,proto3" json:"protocol,omitempty"`  // tcp or udp
+	State         string                 `protobuf:"bytes,9,opt,name=state,proto3" json:"state,omitempty"`        // open, closed, filtered or open|filtered
+	Banner        string                 `protobuf:"bytes,10,opt,name=banner,proto3" json:"banner,omitempty"`     // First line sent by the service, with grab_banner
+	Latency       float64                `protobuf:"fixed64,11,opt,name=latency,proto3" json:"latency,omitempty"` // Time to answer in milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortScanResponse) Reset() {
+	*x = PortScanResponse{}
+	mi := &file_pi_control_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortScanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortScanResponse) ProtoMessage() {}
+
+func (x *PortScanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortScanResponse.ProtoReflect.Descriptor instead.
+func (*PortScanResponse) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *PortScanResponse) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *PortScanResponse) GetOpen() bool {
+	if x != nil {
+		return x.Open
+	}
+	return false
+}
+
+func (x *PortScanResponse) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *PortScanResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PortScanResponse) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *PortScanResponse) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *PortScanResponse) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *PortScanResponse) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *PortScanResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *PortScanResponse) GetBanner() string {
+	if x != nil {
+		return x.Banner
+	}
+	return ""
+}
+
+func (x *PortScanResponse) GetLatency() float64 {
+	if x != nil {
+		return x.Latency
+	}
+	return 0
+}
+
+// DNS lookup request
+type DNSRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hostname      string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`                       // Name to look up; for PTR an IP address is also accepted
+	RecordType    string                 `protobuf:"bytes,2,opt,name=record_type,json=recordType,proto3" json:"record_type,omitempty"` // A, AAAA, CNAME, MX, TXT, NS, SRV, PTR, SOA, CAA (default: A)
+	Resolver      string                 `protobuf:"bytes,3,opt,name=resolver,proto3" json:"resolver,omitempty"`                       // Server to query, e.g. "127.0.0.1" or "[fd00::1]:5353" (default: system resolver, which also reads /etc/hosts for A and AAAA)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DNSRequest) Reset() {
+	*x = DNSRequest{}
+	mi := &file_pi_control_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DNSRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSRequest) ProtoMessage() {}
+
+func (x *DNSRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSRequest.ProtoReflect.Descriptor instead.
+func (*DNSRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DNSRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *DNSRequest) GetRecordType() string {
+	if x != nil {
+		return x.RecordType
+	}
+	return ""
+}
+
+func (x *DNSRequest) GetResolver() string {
+	if x != nil {
+		return x.Resolver
+	}
+	return ""
+}
+
+// DNS lookup response
+type DNSResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Hostname      string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Addresses     []string               `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`                    // IP addresses or records
+	Records       []*DNSRecord           `protobuf:"bytes,4,rep,name=records,proto3" json:"records,omitempty"`                        // Detailed records
+	QueryTime     float64                `protobuf:"fixed64,5,opt,name=query_time,json=queryTime,proto3" json:"query_time,omitempty"` // Query time in milliseconds
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Server        string                 `protobuf:"bytes,7,opt,name=server,proto3" json:"server,omitempty"` // Server that answered, host:port, or "system" for A/AAAA lookups without a resolver
+	Rcode         string                 `protobuf:"bytes,8,opt,name=rcode,proto3" json:"rcode,omitempty"`   // Response code, e.g. NOERROR, NXDOMAIN
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DNSResponse) Reset() {
+	*x = DNSResponse{}
+	mi := &file_pi_control_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DNSResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSResponse) ProtoMessage() {}
+
+func (x *DNSResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSResponse.ProtoReflect.Descriptor instead.
+func (*DNSResponse) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *DNSResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DNSResponse) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *DNSResponse) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *DNSResponse) GetRecords() []*DNSRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *DNSResponse) GetQueryTime() float64 {
+	if x != nil {
+		return x.QueryTime
+	}
+	return 0
+}
+
+func (x *DNSResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DNSResponse) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *DNSResponse) GetRcode() string {
+	if x != nil {
+		return x.Rcode
+	}
+	return ""
+}
+
+// DNS record details
+type DNSRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // A, AAAA, MX, etc.
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Ttl           int32                  `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`           // Time to live
+	Priority      int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"` // For MX and SRV records
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`          // Owner name of the record
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DNSRecord) Reset() {
+	*x = DNSRecord{}
+	mi := &file_pi_control_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DNSRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSRecord) ProtoMessage() {}
+
+func (x *DNSRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSRecord.ProtoReflect.Descriptor instead.
+func (*DNSRecord) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *DNSRecord) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DNSRecord) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *DNSRecord) GetTtl() int32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (x *DNSRecord) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *DNSRecord) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Traceroute request
+type TracerouteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	MaxHops       int32                  `protobuf:"varint,2,opt,name=max_hops,json=maxHops,proto3" json:"max_hops,omitempty"` // Maximum number of hops (default: 30)
+	Timeout       int32                  `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`                // Timeout per hop in seconds (default: 5)
+	Probe         TracerouteProbe        `protobuf:"varint,4,opt,name=probe,proto3,enum=picontrol.TracerouteProbe" json:"probe,omitempty"`
+	ProbesPerHop  int32                  `protobuf:"varint,5,opt,name=probes_per_hop,json=probesPerHop,proto3" json:"probes_per_hop,omitempty"` // Default: 3
+	Port          int32                  `protobuf:"varint,6,opt,name=port,proto3" json:"port,omitempty"`                                       // Destination port (UDP: first port, default 33434; TCP: default 80)
+	LookupAsn     bool                   `protobuf:"varint,7,opt,name=lookup_asn,json=lookupAsn,proto3" json:"lookup_asn,omitempty"`            // Look up the AS number of each hop (Team Cymru DNS)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TracerouteRequest) Reset() {
+	*x = TracerouteRequest{}
+	mi := &file_pi_control_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TracerouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracerouteRequest) ProtoMessage() {}
+
+func (x *TracerouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracerouteRequest.ProtoReflect.Descriptor instead.
+func (*TracerouteRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *TracerouteRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *TracerouteRequest) GetMaxHops() int32 {
+	if x != nil {
+		return x.MaxHops
+	}
+	return 0
+}
+
+func (x *TracerouteRequest) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *TracerouteRequest) GetProbe() TracerouteProbe {
+	if x != nil {
+		return x.Probe
+	}
+	return TracerouteProbe_TRACEROUTE_UDP
+}
+
+func (x *TracerouteRequest) GetProbesPerHop() int32 {
+	if x != nil {
+		return x.ProbesPerHop
+	}
+	return 0
+}
+
+func (x *TracerouteRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *TracerouteRequest) GetLookupAsn() bool {
+	if x != nil {
+		return x.LookupAsn
+	}
+	return false
+}
+
+// Traceroute response (streamed)
+type TracerouteResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Hop           int32                    `protobuf:"varint,1,opt,name=hop,proto3" json:"hop,omitempty"`           // Hop number
+	Ip            string                   `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`              // IP address of this hop
+	Hostname      string                   `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`  // Hostname if resolved
+	Latency       float64                  `protobuf:"fixed64,4,opt,name=latency,proto3" json:"latency,omitempty"`  // Latency in milliseconds
+	Timeout       bool                     `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`   // True if hop timed out
+	Finished      bool                     `protobuf:"varint,6,opt,name=finished,proto3" json:"finished,omitempty"` // True when traceroute completes
+	Error         string                   `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Probes        []*TracerouteProbeResult `protobuf:"bytes,8,rep,name=probes,proto3" json:"probes,omitempty"`     // One entry per probe sent
+	Asn           string                   `protobuf:"bytes,9,opt,name=asn,proto3" json:"asn,omitempty"`           // e.g. "AS13335 CLOUDFLARENET - Cloudflare, Inc., US"
+	Reached       bool                     `protobuf:"varint,10,opt,name=reached,proto3" json:"reached,omitempty"` // This hop is the destination
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TracerouteResponse) Reset() {
+	*x = TracerouteResponse{}
+	mi := &file_pi_control_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TracerouteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracerouteResponse) ProtoMessage() {}
+
+func (x *TracerouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracerouteResponse.ProtoReflect.Descriptor instead.
+func (*TracerouteResponse) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *TracerouteResponse) GetHop() int32 {
+	if x != nil {
+		return x.Hop
+	}
+	return 0
+}
+
+func (x *TracerouteResponse) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *TracerouteResponse) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *TracerouteResponse) GetLatency() float64 {
+	if x != nil {
+		return x.Latency
+	}
+	return 0
+}
+
+func (x *TracerouteResponse) GetTimeout() bool {
+	if x != nil {
+		return x.Timeout
+	}
+	return false
+}
+
+func (x *TracerouteResponse) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *TracerouteResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TracerouteResponse) GetProbes() []*TracerouteProbeResult {
+	if x != nil {
+		return x.Probes
+	}
+	return nil
+}
+
+func (x *TracerouteResponse) GetAsn() string {
+	if x != nil {
+		return x.Asn
+	}
+	return ""
+}
+
+func (x *TracerouteResponse) GetReached() bool {
+	if x != nil {
+		return x.Reached
+	}
+	return false
+}
+
+// Reply to a single traceroute probe
+type TracerouteProbeResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`             // Responding address (may differ between probes of one hop)
+	Latency       float64                `protobuf:"fixed64,2,opt,name=latency,proto3" json:"latency,omitempty"` // Milliseconds
+	Timeout       bool                   `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"` // Unreachable code as printed by traceroute, e.g. "!H", "!N", "!X"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TracerouteProbeResult) Reset() {
+	*x = TracerouteProbeResult{}
+	mi := &file_pi_control_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TracerouteProbeResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracerouteProbeResult) ProtoMessage() {}
+
+func (x *TracerouteProbeResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracerouteProbeResult.ProtoReflect.Descriptor instead.
+func (*TracerouteProbeResult) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *TracerouteProbeResult) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *TracerouteProbeResult) GetLatency() float64 {
+	if x != nil {
+		return x.Latency
+	}
+	return 0
+}
+
+func (x *TracerouteProbeResult) GetTimeout() bool {
+	if x != nil {
+		return x.Timeout
+	}
+	return false
+}
+
+func (x *TracerouteProbeResult) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// WiFi information
+type WifiInfo struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Connected         bool                   `protobuf:"varint,1,opt,name=connected,proto3" json:"connected,omitempty"`
+	Ssid              string                 `protobuf:"bytes,2,opt,name=ssid,proto3" json:"ssid,omitempty"`                                            // Network name
+	Bssid             string                 `protobuf:"bytes,3,opt,name=bssid,proto3" json:"bssid,omitempty"`                                          // MAC address of access point
+	SignalStrength    int32                  `protobuf:"varint,4,opt,name=signal_strength,json=signalStrength,proto3" json:"signal_strength,omitempty"` // Signal strength in dBm (-100 to 0)
+	SignalQuality     int32                  `protobuf:"varint,5,opt,name=signal_quality,json=signalQuality,proto3" json:"signal_quality,omitempty"`    // Signal quality percentage (0-100)
+	Frequency         float64                `protobuf:"fixed64,6,opt,name=frequency,proto3" json:"frequency,omitempty"`                                // Frequency in GHz (2.4 or 5.0)
+	Security          string                 `protobuf:"bytes,7,opt,name=security,proto3" json:"security,omitempty"`                                    // WPA2, WPA3, etc.
+	LinkSpeed         float64                `protobuf:"fixed64,8,opt,name=link_speed,json=linkSpeed,proto3" json:"link_speed,omitempty"`               // Link speed in Mbps
+	IpAddress         string                 `protobuf:"bytes,9,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	AvailableNetworks []*WifiNetwork         `protobuf:"bytes,10,rep,name=available_networks,json=availableNetworks,proto3" json:"available_networks,omitempty"` // From the last scan, not a fresh one
+	Interface         string                 `protobuf:"bytes,11,opt,name=interface,proto3" json:"interface,omitempty"`                                          // Wireless interface, e.g. wlan0
+	Channel           int32                  `protobuf:"varint,12,opt,name=channel,proto3" json:"channel,omitempty"`
+	RxLinkSpeed       float64                `protobuf:"fixed64,13,opt,name=rx_link_speed,json=rxLinkSpeed,proto3" json:"rx_link_speed,omitempty"` // Receive bitrate in Mbps
+	Channels          []*WifiChannelStats    `protobuf:"bytes,14,rep,name=channels,proto3" json:"channels,omitempty"`                              // Congestion per channel from the last scan
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *WifiInfo) Reset() {
+	*x = WifiInfo{}
+	mi := &file_pi_control_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WifiInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WifiInfo) ProtoMessage() {}
+
+func (x *WifiInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WifiInfo.ProtoReflect.Descriptor instead.
+func (*WifiInfo) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *WifiInfo) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *WifiInfo) GetSsid() string {
+	if x != nil {
+		return x.Ssid
+	}
+	return ""
+}
+
+func (x *WifiInfo) GetBssid() string {
+	if x != nil {
+		return x.Bssid
+	}
+	return ""
+}
+
+func (x *WifiInfo) GetSignalStrength() int32 {
+	if x != nil {
+		return x.SignalStrength
+	}
+	return 0
+}
+
+func (x *WifiInfo) GetSignalQuality() int32 {
+	if x != nil {
+		return x.SignalQuality
+	}
+	return 0
+}
+
+func (x *WifiInfo) GetFrequency() float64 {
+	if x != nil {
+		return x.Frequency
+	}
+	return 0
+}
+
+func (x *WifiInfo) GetSecurity() string {
+	if x != nil {
+		return x.Security
+	}
+	return ""
+}
+
+func (x *WifiInfo) GetLinkSpeed() float64 {
+	if x != nil {
+		return x.LinkSpeed
+	}
+	return 0
+}
+
+func (x *WifiInfo) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *WifiInfo) GetAvailableNetworks() []*WifiNetwork {
+	if x != nil {
+		return x.AvailableNetworks
+	}
+	return nil
+}
+
+func (x *WifiInfo) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+func (x *WifiInfo) GetChannel() int32 {
+	if x != nil {
+		return x.Channel
+	}
+	return 0
+}
+
+func (x *WifiInfo) GetRxLinkSpeed() float64 {
+	if x != nil {
+		return x.RxLinkSpeed
+	}
+	return 0
+}
+
+func (x *WifiInfo) GetChannels() []*WifiChannelStats {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+// Available WiFi network
+type WifiNetwork struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Ssid           string                 `protobuf:"bytes,1,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	Bssid          string                 `protobuf:"bytes,2,opt,name=bssid,proto3" json:"bssid,omitempty"`
+	SignalStrength int32                  `protobuf:"varint,3,opt,name=signal_strength,json=signalStrength,proto3" json:"signal_strength,omitempty"`
+	SignalQuality  int32                  `protobuf:"varint,4,opt,name=signal_quality,json=signalQuality,proto3" json:"signal_quality,omitempty"`
+	Frequency      float64                `protobuf:"fixed64,5,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	Security       string                 `protobuf:"bytes,6,opt,name=security,proto3" json:"security,omitempty"`
+	Channel        int32                  `protobuf:"varint,7,opt,name=channel,proto3" json:"channel,omitempty"`
+	Connected      bool                   `protobuf:"varint,8,opt,name=connected,proto3" json:"connected,omitempty"`               // This is the access point we are associated with
+	LastSeen       int64                  `protobuf:"varint,9,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"` // Milliseconds since the network was last heard
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WifiNetwork) Reset() {
+	*x = WifiNetwork{}
+	mi := &file_pi_control_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WifiNetwork) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WifiNetwork) ProtoMessage() {}
+
+func (x *WifiNetwork) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WifiNetwork.ProtoReflect.Descriptor instead.
+func (*WifiNetwork) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *WifiNetwork) GetSsid() string {
+	if x != nil {
+		return x.Ssid
+	}
+	return ""
+}
+
+func (x *WifiNetwork) GetBssid() string {
+	if x != nil {
+		return x.Bssid
+	}
+	return ""
+}
+
+func (x *WifiNetwork) GetSignalStrength() int32 {
+	if x != nil {
+		return x.SignalStrength
+	}
+	return 0
+}
+
+func (x *WifiNetwork) GetSignalQuality() int32 {
+	if x != nil {
+		return x.SignalQuality
+	}
+	return 0
+}
+
+func (x *WifiNetwork) GetFrequency() float64 {
+	if x != nil {
+		return x.Frequency
+	}
+	return 0
+}
+
+func (x *WifiNetwork) GetSecurity() string {
+	if x != nil {
+		return x.Security
+	}
+	return ""
+}
+
+func (x *WifiNetwork) GetChannel() int32 {
+	if x != nil {
+		return x.Channel
+	}
+	return 0
+}
+
+func (x *WifiNetwork) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *WifiNetwork) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+// Scan for nearby WiFi networks
+type ScanWifiRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interface     string                 `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"` // Wireless interface (default: first one found)
+	Cached        bool                   `protobuf:"varint,2,opt,name=cached,proto3" json:"cached,omitempty"`      // Return the results of the last scan instead of scanning (no root needed)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanWifiRequest) Reset() {
+	*x = ScanWifiRequest{}
+	mi := &file_pi_control_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanWifiRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanWifiRequest) ProtoMessage() {}
+
+func (x *ScanWifiRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanWifiRequest.ProtoReflect.Descriptor instead.
+func (*ScanWifiRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ScanWifiRequest) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+func (x *ScanWifiRequest) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+type ScanWifiResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Interface     string                 `protobuf:"bytes,2,opt,name=interface,proto3" json:"interface,omitempty"`
+	Networks      []*WifiNetwork         `protobuf:"bytes,3,rep,name=networks,proto3" json:"networks,omitempty"` // Strongest signal first
+	Channels      []*WifiChannelStats    `protobuf:"bytes,4,rep,name=channels,proto3" json:"channels,omitempty"` // Ordered by channel
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanWifiResponse) Reset() {
+	*x = ScanWifiResponse{}
+	mi := &file_pi_control_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanWifiResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanWifiResponse) ProtoMessage() {}
+
+func (x *ScanWifiResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanWifiResponse.ProtoReflect.Descriptor instead.
+func (*ScanWifiResponse) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ScanWifiResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ScanWifiResponse) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+func (x *ScanWifiResponse) GetNetworks() []*WifiNetwork {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+func (x *ScanWifiResponse) GetChannels() []*WifiChannelStats {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *ScanWifiResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// WiFi network saved in NetworkManager or wpa_supplicant
+type SavedWifiNetwork struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ssid          string                 `protobuf:"bytes,1,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`              // NetworkManager connection UUID or wpa_supplicant network id
+	Priority      int32                  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"` // Higher is preferred when several are in range
+	Enabled       bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`   // Connected to automatically
+	Active        bool                   `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
+	Security      string                 `protobuf:"bytes,6,opt,name=security,proto3" json:"security,omitempty"` // Open, WPA2 or WPA3
+	Hidden        bool                   `protobuf:"varint,7,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavedWifiNetwork) Reset() {
+	*x = SavedWifiNetwork{}
+	mi := &file_pi_control_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedWifiNetwork) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedWifiNetwork) ProtoMessage() {}
+
+func (x *SavedWifiNetwork) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedWifiNetwork.ProtoReflect.Descriptor instead.
+func (*SavedWifiNetwork) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *SavedWifiNetwork) GetSsid() string {
+	if x != nil {
+		return x.Ssid
+	}
+	return ""
+}
+
+func (x *SavedWifiNetwork) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SavedWifiNetwork) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *SavedWifiNetwork) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SavedWifiNetwork) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *SavedWifiNetwork) GetSecurity() string {
+	if x != nil {
+		return x.Security
+	}
+	return ""
+}
+
+func (x *SavedWifiNetwork) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+type SavedWifiNetworkList struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Networks       []*SavedWifiNetwork    `protobuf:"bytes,1,rep,name=networks,proto3" json:"networks,omitempty"`
+	Backend        string                 `protobuf:"bytes,2,opt,name=backend,proto3" json:"backend,omitempty"`                                   // NetworkManager or wpa_supplicant
+	ChangePending  bool                   `protobuf:"varint,3,opt,name=change_pending,json=changePending,proto3" json:"change_pending,omitempty"` // A change is being applied or watched for rollback
+	LastChange     string                 `protobuf:"bytes,4,opt,name=last_change,json=lastChange,proto3" json:"last_change,omitempty"`           // Outcome of the last change, e.g. "Connected to Office"
+	LastChangeTime int64                  `protobuf:"varint,5,opt,name=last_change_time,json=lastChangeTime,proto3" json:"last_change_time,omitempty"`
+	Error          string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SavedWifiNetworkList) Reset() {
+	*x = SavedWifiNetworkList{}
+	mi := &file_pi_control_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedWifiNetworkList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedWifiNetworkList) ProtoMessage() {}
+
+func (x *SavedWifiNetworkList) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedWifiNetworkList.ProtoReflect.Descriptor instead.
+func (*SavedWifiNetworkList) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *SavedWifiNetworkList) GetNetworks() []*SavedWifiNetwork {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+func (x *SavedWifiNetworkList) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+func (x *SavedWifiNetworkList) GetChangePending() bool {
+	if x != nil {
+		return x.ChangePending
+	}
+	return false
+}
+
+func (x *SavedWifiNetworkList) GetLastChange() string {
+	if x != nil {
+		return x.LastChange
+	}
+	return ""
+}
+
+func (x *SavedWifiNetworkList) GetLastChangeTime() int64 {
+	if x != nil {
+		return x.LastChangeTime
+	}
+	return 0
+}
+
+func (x *SavedWifiNetworkList) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type AddWifiNetworkRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Ssid            string                 `protobuf:"bytes,1,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	Password        string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // Empty for open networks
+	Security        string                 `protobuf:"bytes,3,opt,name=security,proto3" json:"security,omitempty"` // WPA2 (default with a password), WPA3 or Open
+	Hidden          bool                   `protobuf:"varint,4,opt,name=hidden,proto3" json:"hidden,omitempty"`    // Probe for the SSID since it is not broadcast
+	Priority        int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	Connect         bool                   `protobuf:"varint,6,opt,name=connect,proto3" json:"connect,omitempty"`                                        // Connect now, with rollback; otherwise only save it
+	RollbackTimeout int32                  `protobuf:"varint,7,opt,name=rollback_timeout,json=rollbackTimeout,proto3" json:"rollback_timeout,omitempty"` // Seconds without connectivity before the previous network is restored (default 60, negative disables)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AddWifiNetworkRequest) Reset() {
+	*x = AddWifiNetworkRequest{}
+	mi := &file_pi_control_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddWifiNetworkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWifiNetworkRequest) ProtoMessage() {}
+
+func (x *AddWifiNetworkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWifiNetworkRequest.ProtoReflect.Descriptor instead.
+func (*AddWifiNetworkRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *AddWifiNetworkRequest) GetSsid() string {
+	if x != nil {
+		return x.Ssid
+	}
+	return ""
+}
+
+func (x *AddWifiNetworkRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *AddWifiNetworkRequest) GetSecurity() string {
+	if x != nil {
+		return x.Security
+	}
+	return ""
+}
+
+func (x *AddWifiNetworkRequest) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+func (x *AddWifiNetworkRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *AddWifiNetworkRequest) GetConnect() bool {
+	if x != nil {
+		return x.Connect
+	}
+	return false
+}
+
+func (x *AddWifiNetworkRequest) GetRollbackTimeout() int32 {
+	if x != nil {
+		return x.RollbackTimeout
+	}
+	return 0
+}
+
+// Saved network to connect to, forget or reprioritize
+type WifiNetworkRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Ssid            string                 `protobuf:"bytes,1,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	Priority        int32                  `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`                                      // SetWifiNetworkPriority only
+	RollbackTimeout int32                  `protobuf:"varint,3,opt,name=rollback_timeout,json=rollbackTimeout,proto3" json:"rollback_timeout,omitempty"` // As in AddWifiNetworkRequest; not used by SetWifiNetworkPriority
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WifiNetworkRequest) Reset() {
+	*x = WifiNetworkRequest{}
+	mi := &file_pi_control_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WifiNetworkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WifiNetworkRequest) ProtoMessage() {}
+
+func (x *WifiNetworkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WifiNetworkRequest.ProtoReflect.Descriptor instead.
+func (*WifiNetworkRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *WifiNetworkRequest) GetSsid() string {
+	if x != nil {
+		return x.Ssid
+	}
+	return ""
+}
+
+func (x *WifiNetworkRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *WifiNetworkRequest) GetRollbackTimeout() int32 {
+	if x != nil {
+		return x.RollbackTimeout
+	}
+	return 0
+}
+
+// Congestion on one WiFi channel
+type WifiChannelStats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Channel         int32                  `protobuf:"varint,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Frequency       float64                `protobuf:"fixed64,2,opt,name=frequency,proto3" json:"frequency,omitempty"`                                   // GHz
+	Networks        int32                  `protobuf:"varint,3,opt,name=networks,proto3" json:"networks,omitempty"`                                      // Networks whose primary channel this is
+	StrongestSignal int32                  `protobuf:"varint,4,opt,name=strongest_signal,json=strongestSignal,proto3" json:"strongest_signal,omitempty"` // dBm of the loudest network on the channel
+	Utilization     float64                `protobuf:"fixed64,5,opt,name=utilization,proto3" json:"utilization,omitempty"`                               // Percent of time the channel was busy, from the driver survey; -1 if unknown
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WifiChannelStats) Reset() {
+	*x = WifiChannelStats{}
+	mi := &file_pi_control_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WifiChannelStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WifiChannelStats) ProtoMessage() {}
+
+func (x *WifiChannelStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WifiChannelStats.ProtoReflect.Descriptor instead.
+func (*WifiChannelStats) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *WifiChannelStats) GetChannel() int32 {
+	if x != nil {
+		return x.Channel
+	}
+	return 0
+}
+
+func (x *WifiChannelStats) GetFrequency() float64 {
+	if x != nil {
+		return x.Frequency
+	}
+	return 0
+}
+
+func (x *WifiChannelStats) GetNetworks() int32 {
+	if x != nil {
+		return x.Networks
+	}
+	return 0
+}
+
+func (x *WifiChannelStats) GetStrongestSignal() int32 {
+	if x != nil {
+		return x.StrongestSignal
+	}
+	return 0
+}
+
+func (x *WifiChannelStats) GetUtilization() float64 {
+	if x != nil {
+		return x.Utilization
+	}
+	return 0
+}
+
+// Speed test request
+type SpeedTestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TestDownload  bool                   `protobuf:"varint,1,opt,name=test_download,json=testDownload,proto3" json:"test_download,omitempty"` // Test download speed
+	TestUpload    bool                   `protobuf:"varint,2,opt,name=test_upload,json=testUpload,proto3" json:"test_upload,omitempty"`       // Test upload speed
+	Duration      int32                  `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`                             // Test duration in seconds (default: 10)
+	Server        string                 `protobuf:"bytes,4,opt,name=server,proto3" json:"server,omitempty"`                                  // iperf3 server "host[:port]" (default port 5201) or an http(s):// URL to GET and POST (default: https://speed.cloudflare.com)
+	Parallel      int32                  `protobuf:"varint,5,opt,name=parallel,proto3" json:"parallel,omitempty"`                             // Parallel TCP streams for iperf3 servers (default: 1, max: 8)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpeedTestRequest) Reset() {
+	*x = SpeedTestRequest{}
+	mi := &file_pi_control_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpeedTestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpeedTestRequest) ProtoMessage() {}
+
+func (x *SpeedTestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpeedTestRequest.ProtoReflect.Descriptor instead.
+func (*SpeedTestRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *SpeedTestRequest) GetTestDownload() bool {
+	if x != nil {
+		return x.TestDownload
+	}
+	return false
+}
+
+func (x *SpeedTestRequest) GetTestUpload() bool {
+	if x != nil {
+		return x.TestUpload
+	}
+	return false
+}
+
+func (x *SpeedTestRequest) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *SpeedTestRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *SpeedTestRequest) GetParallel() int32 {
+	if x != nil {
+		return x.Parallel
+	}
+	return 0
+}
+
+// Speed test response (streamed)
+type SpeedTestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         string                 `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`                                        // "connecting", "download", "upload", "complete"
+	DownloadSpeed float64                `protobuf:"fixed64,2,opt,name=download_speed,json=downloadSpeed,proto3" json:"download_speed,omitempty"` // Current download speed in Mbps
+	UploadSpeed   float64                `protobuf:"fixed64,3,opt,name=upload_speed,json=uploadSpeed,proto3" json:"upload_speed,omitempty"`       // Current upload speed in Mbps
+	Progress      float64                `protobuf:"fixed64,4,opt,name=progress,proto3" json:"progress,omitempty"`                                // Progress percentage (0-100)
+	Latency       float64                `protobuf:"fixed64,5,opt,name=latency,proto3" json:"latency,omitempty"`                                  // Server latency in ms
+	Server        string                 `protobuf:"bytes,6,opt,name=server,proto3" json:"server,omitempty"`                                      // Test server address
+	Finished      bool                   `protobuf:"varint,7,opt,name=finished,proto3" json:"finished,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	Jitter        float64                `protobuf:"fixed64,9,opt,name=jitter,proto3" json:"jitter,omitempty"`           // Variation of the connection setup times in ms
+	Retransmits   int64                  `protobuf:"varint,10,opt,name=retransmits,proto3" json:"retransmits,omitempty"` // TCP retransmits by the sender, -1 if unknown
+	Bytes         int64                  `protobuf:"varint,11,opt,name=bytes,proto3" json:"bytes,omitempty"`             // Bytes transferred in this phase
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpeedTestResponse) Reset() {
+	*x = SpeedTestResponse{}
+	mi := &file_pi_control_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpeedTestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpeedTestResponse) ProtoMessage() {}
+
+func (x *SpeedTestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpeedTestResponse.ProtoReflect.Descriptor instead.
+func (*SpeedTestResponse) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *SpeedTestResponse) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *SpeedTestResponse) GetDownloadSpeed() float64 {
+	if x != nil {
+		return x.DownloadSpeed
+	}
+	return 0
+}
+
+func (x *SpeedTestResponse) GetUploadSpeed() float64 {
+	if x != nil {
+		return x.UploadSpeed
+	}
+	return 0
+}
+
+func (x *SpeedTestResponse) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *SpeedTestResponse) GetLatency() float64 {
+	if x != nil {
+		return x.Latency
+	}
+	return 0
+}
+
+func (x *SpeedTestResponse) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *SpeedTestResponse) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *SpeedTestResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SpeedTestResponse) GetJitter() float64 {
+	if x != nil {
+		return x.Jitter
+	}
+	return 0
+}
+
+func (x *SpeedTestResponse) GetRetransmits() int64 {
+	if x != nil {
+		return x.Retransmits
+	}
+	return 0
+}
+
+func (x *SpeedTestResponse) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+// Device discovery request
+type DiscoverDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interface     string                 `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"` // Only search this interface (default: all IPv4 interfaces)
+	Timeout       int32                  `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"`    // Seconds to wait for replies and announcements (default: 10, max: 60)
+	Passive       bool                   `protobuf:"varint,3,opt,name=passive,proto3" json:"passive,omitempty"`    // Skip the ICMP/ARP sweep; only read the neighbor table and listen
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoverDevicesRequest) Reset() {
+	*x = DiscoverDevicesRequest{}
+	mi := &file_pi_control_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoverDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverDevicesRequest) ProtoMessage() {}
+
+func (x *DiscoverDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverDevicesRequest.ProtoReflect.Descriptor instead.
+func (*DiscoverDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *DiscoverDevicesRequest) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+func (x *DiscoverDevicesRequest) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *DiscoverDevicesRequest) GetPassive() bool {
+	if x != nil {
+		return x.Passive
+	}
+	return false
+}
+
+// Device on a local subnet (streamed)
+type DiscoveredDevice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Mac           string                 `protobuf:"bytes,2,opt,name=mac,proto3" json:"mac,omitempty"`
+	Vendor        string                 `protobuf:"bytes,3,opt,name=vendor,proto3" json:"vendor,omitempty"`                         // From the MAC address OUI
+	Hostname      string                 `protobuf:"bytes,4,opt,name=hostname,proto3" json:"hostname,omitempty"`                     // From mDNS or reverse DNS
+	Interface     string                 `protobuf:"bytes,5,opt,name=interface,proto3" json:"interface,omitempty"`                   // Interface the device was seen on
+	FirstSeen     int64                  `protobuf:"varint,6,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"` // Unix timestamp; remembered across discoveries while the agent runs
+	LastSeen      int64                  `protobuf:"varint,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`    // Unix timestamp
+	Sources       []string               `protobuf:"bytes,8,rep,name=sources,proto3" json:"sources,omitempty"`                       // How it was found: neighbor, icmp, mdns, ssdp
+	Services      []string               `protobuf:"bytes,9,rep,name=services,proto3" json:"services,omitempty"`                     // mDNS service types, e.g. _ipp._tcp
+	Description   string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`              // SSDP SERVER header
+	Finished      bool                   `protobuf:"varint,11,opt,name=finished,proto3" json:"finished,omitempty"`                   // True on the last message, which carries no device
+	Error         string                 `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoveredDevice) Reset() {
+	*x = DiscoveredDevice{}
+	mi := &file_pi_control_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoveredDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoveredDevice) ProtoMessage() {}
+
+func (x *DiscoveredDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoveredDevice.ProtoReflect.Descriptor instead.
+func (*DiscoveredDevice) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *DiscoveredDevice) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *DiscoveredDevice) GetMac() string {
+	if x != nil {
+		return x.Mac
+	}
+	return ""
+}
+
+func (x *DiscoveredDevice) GetVendor() string {
+	if x != nil {
+		return x.Vendor
+	}
+	return ""
+}
+
+func (x *DiscoveredDevice) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *DiscoveredDevice) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+func (x *DiscoveredDevice) GetFirstSeen() int64 {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return 0
+}
+
+func (x *DiscoveredDevice) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *DiscoveredDevice) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *DiscoveredDevice) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *DiscoveredDevice) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *DiscoveredDevice) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *DiscoveredDevice) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// File chunk for streaming transfers
+type FileChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                  // Remote file path (relative to agent's working directory)
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                                  // Chunk data (max 256KB recommended)
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`                             // Byte offset in the file
+	TotalSize     int64                  `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`      // Total file size in bytes (set in first chunk)
+	IsFinal       bool                   `protobuf:"varint,5,opt,name=is_final,json=isFinal,proto3" json:"is_final,omitempty"`            // True for the last chunk
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`                                // Error message if something went wrong
+	ContainerId   string                 `protobuf:"bytes,7,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"` // Target container (container copies only, set in first chunk)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileChunk) Reset() {
+	*x = FileChunk{}
+	mi := &file_pi_control_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChunk) ProtoMessage() {}
+
+func (x *FileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChunk.ProtoReflect.Descriptor instead.
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *FileChunk) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *FileChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *FileChunk) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *FileChunk) GetIsFinal() bool {
+	if x != nil {
+		return x.IsFinal
+	}
+	return false
+}
+
+func (x *FileChunk) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *FileChunk) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+// Upload response (after all chunks received)
+type FileUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`                                      // Path where file was written
+	BytesWritten  int64                  `protobuf:"varint,3,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"` // Total bytes written
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                                    // Error message if failed
+	Duration      float64                `protobuf:"fixed64,5,opt,name=duration,proto3" json:"duration,omitempty"`                            // Upload duration in seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileUploadResponse) Reset() {
+	*x = FileUploadResponse{}
+	mi := &file_pi_control_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileUploadResponse) ProtoMessage() {}
+
+func (x *FileUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileUploadResponse.ProtoReflect.Descriptor instead.
+func (*FileUploadResponse) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *FileUploadResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *FileUploadResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileUploadResponse) GetBytesWritten() int64 {
+	if x != nil {
+		return x.BytesWritten
+	}
+	return 0
+}
+
+func (x *FileUploadResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *FileUploadResponse) GetDuration() float64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+// Download request
+type FileDownloadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`      // Remote file path to download
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // Starting byte offset (0 for full file, >0 to resume)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileDownloadRequest) Reset() {
+	*x = FileDownloadRequest{}
+	mi := &file_pi_control_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileDownloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDownloadRequest) ProtoMessage() {}
+
+func (x *FileDownloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDownloadRequest.ProtoReflect.Descriptor instead.
+func (*FileDownloadRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *FileDownloadRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileDownloadRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// Delete request
+type FileDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                   // Remote file or directory path to delete
+	IsDirectory   bool                   `protobuf:"varint,2,opt,name=is_directory,json=isDirectory,proto3" json:"is_directory,omitempty"` // True if deleting a directory (will delete recursively)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileDeleteRequest) Reset() {
+	*x = FileDeleteRequest{}
+	mi := &file_pi_control_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDeleteRequest) ProtoMessage() {}
+
+func (x *FileDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDeleteRequest.ProtoReflect.Descriptor instead.
+func (*FileDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *FileDeleteRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileDeleteRequest) GetIsDirectory() bool {
+	if x != nil {
+		return x.IsDirectory
+	}
+	return false
+}
+
+// Delete response
+type FileDeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`   // Path that was deleted
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"` // Error message if failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileDeleteResponse) Reset() {
+	*x = FileDeleteResponse{}
+	mi := &file_pi_control_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDeleteResponse) ProtoMessage() {}
+
+func (x *FileDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDeleteResponse.ProtoReflect.Descriptor instead.
+func (*FileDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *FileDeleteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *FileDeleteResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileDeleteResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type DockerFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	All           bool                   `protobuf:"varint,1,opt,name=all,proto3" json:"all,omitempty"` // Show all containers (default shows just running)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DockerFilter) Reset() {
+	*x = DockerFilter{}
+	mi := &file_pi_control_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DockerFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DockerFilter) ProtoMessage() {}
+
+func (x *DockerFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DockerFilter.ProtoReflect.Descriptor instead.
+func (*DockerFilter) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *DockerFilter) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type ContainerId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerId) Reset() {
+	*x = ContainerId{}
+	mi := &file_pi_control_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerId) ProtoMessage() {}
+
+func (x *ContainerId) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerId.ProtoReflect.Descriptor instead.
+func (*ContainerId) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ContainerId) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ContainerList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Containers    []*ContainerInfo       `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerList) Reset() {
+	*x = ContainerList{}
+	mi := &file_pi_control_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerList) ProtoMessage() {}
+
+func (x *ContainerList) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerList.ProtoReflect.Descriptor instead.
+func (*ContainerList) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ContainerList) GetContainers() []*ContainerInfo {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+type ContainerInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Names         []string               `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
+	Image         string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	State         string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`   // created, restarting, running, removing, paused, exited, dead
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // Human readable status
+	Created       int64                  `protobuf:"varint,6,opt,name=created,proto3" json:"created,omitempty"`
+	Ports         []string               `protobuf:"bytes,7,rep,name=ports,proto3" json:"ports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerInfo) Reset() {
+	*x = ContainerInfo{}
+	mi := &file_pi_control_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerInfo) ProtoMessage() {}
+
+func (x *ContainerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerInfo.ProtoReflect.Descriptor instead.
+func (*ContainerInfo) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ContainerInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ContainerInfo) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *ContainerInfo) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ContainerInfo) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ContainerInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
 	}
-	return false
+	return ""
 }
 
-// Disk I/O statistics
-type DiskIOStat struct {
+func (x *ContainerInfo) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ContainerInfo) GetPorts() []string {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+type LogRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Device        string                 `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`                            // e.g., "sda", "nvme0n1"
-	ReadBytes     uint64                 `protobuf:"varint,2,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`    // Bytes read per second
-	WriteBytes    uint64                 `protobuf:"varint,3,opt,name=write_bytes,json=writeBytes,proto3" json:"write_bytes,omitempty"` // Bytes written per second
-	ReadCount     uint64                 `protobuf:"varint,4,opt,name=read_count,json=readCount,proto3" json:"read_count,omitempty"`    // Read operations per second
-	WriteCount    uint64                 `protobuf:"varint,5,opt,name=write_count,json=writeCount,proto3" json:"write_count,omitempty"` // Write operations per second
+	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Follow        bool                   `protobuf:"varint,2,opt,name=follow,proto3" json:"follow,omitempty"`                          // Stream logs
+	Tail          int32                  `protobuf:"varint,3,opt,name=tail,proto3" json:"tail,omitempty"`                              // Number of lines to show from the end
+	Since         string                 `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`                             // RFC3339, Unix timestamp or relative duration (e.g. 10m)
+	Until         string                 `protobuf:"bytes,5,opt,name=until,proto3" json:"until,omitempty"`                             // RFC3339, Unix timestamp or relative duration (e.g. 10m)
+	Stream        LogStream              `protobuf:"varint,6,opt,name=stream,proto3,enum=picontrol.LogStream" json:"stream,omitempty"` // Which output streams to include
+	ParseJson     bool                   `protobuf:"varint,7,opt,name=parse_json,json=parseJson,proto3" json:"parse_json,omitempty"`   // Take the level from JSON-formatted log lines
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DiskIOStat) Reset() {
-	*x = DiskIOStat{}
-	mi := &file_pi_control_proto_msgTypes[25]
+func (x *LogRequest) Reset() {
+	*x = LogRequest{}
+	mi := &file_pi_control_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DiskIOStat) String() string {
+func (x *LogRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DiskIOStat) ProtoMessage() {}
+func (*LogRequest) ProtoMessage() {}
 
-func (x *DiskIOStat) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[25]
+func (x *LogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1969,70 +6938,208 @@ func (x *DiskIOStat) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DiskIOStat.ProtoReflect.Descriptor instead.
-func (*DiskIOStat) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use LogRequest.ProtoReflect.Descriptor instead.
+func (*LogRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *DiskIOStat) GetDevice() string {
+func (x *LogRequest) GetContainerId() string {
 	if x != nil {
-		return x.Device
+		return x.ContainerId
 	}
 	return ""
 }
 
-func (x *DiskIOStat) GetReadBytes() uint64 {
+func (x *LogRequest) GetFollow() bool {
 	if x != nil {
-		return x.ReadBytes
+		return x.Follow
 	}
-	return 0
+	return false
 }
 
-func (x *DiskIOStat) GetWriteBytes() uint64 {
+func (x *LogRequest) GetTail() int32 {
 	if x != nil {
-		return x.WriteBytes
+		return x.Tail
 	}
 	return 0
 }
 
-func (x *DiskIOStat) GetReadCount() uint64 {
+func (x *LogRequest) GetSince() string {
 	if x != nil {
-		return x.ReadCount
+		return x.Since
 	}
-	return 0
+	return ""
 }
 
-func (x *DiskIOStat) GetWriteCount() uint64 {
+func (x *LogRequest) GetUntil() string {
 	if x != nil {
-		return x.WriteCount
+		return x.Until
 	}
-	return 0
+	return ""
 }
 
-// Version information
-type VersionInfo struct {
+func (x *LogRequest) GetStream() LogStream {
+	if x != nil {
+		return x.Stream
+	}
+	return LogStream_LOG_STREAM_ALL
+}
+
+func (x *LogRequest) GetParseJson() bool {
+	if x != nil {
+		return x.ParseJson
+	}
+	return false
+}
+
+// Request to copy a path out of a container
+type ContainerCopyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`              // Agent version string (e.g., "3.1.0")
-	IsRoot        bool                   `protobuf:"varint,2,opt,name=is_root,json=isRoot,proto3" json:"is_root,omitempty"` // Whether agent is running with root privileges
+	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"` // File or directory inside the container
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VersionInfo) Reset() {
-	*x = VersionInfo{}
-	mi := &file_pi_control_proto_msgTypes[26]
+func (x *ContainerCopyRequest) Reset() {
+	*x = ContainerCopyRequest{}
+	mi := &file_pi_control_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VersionInfo) String() string {
+func (x *ContainerCopyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VersionInfo) ProtoMessage() {}
+func (*ContainerCopyRequest) ProtoMessage() {}
 
-func (x *VersionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[26]
+func (x *ContainerCopyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerCopyRequest.ProtoReflect.Descriptor instead.
+func (*ContainerCopyRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ContainerCopyRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *ContainerCopyRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type InspectContainerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MaskSecrets   bool                   `protobuf:"varint,2,opt,name=mask_secrets,json=maskSecrets,proto3" json:"mask_secrets,omitempty"` // Replace values of secret-looking env vars with ********
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InspectContainerRequest) Reset() {
+	*x = InspectContainerRequest{}
+	mi := &file_pi_control_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InspectContainerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectContainerRequest) ProtoMessage() {}
+
+func (x *InspectContainerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectContainerRequest.ProtoReflect.Descriptor instead.
+func (*InspectContainerRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *InspectContainerRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *InspectContainerRequest) GetMaskSecrets() bool {
+	if x != nil {
+		return x.MaskSecrets
+	}
+	return false
+}
+
+// Detailed container information (docker inspect)
+type ContainerDetails struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Image             string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	ImageId           string                 `protobuf:"bytes,4,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	Created           string                 `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"` // RFC3339 timestamp
+	State             string                 `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`     // created, running, paused, restarting, exited, dead
+	StartedAt         string                 `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt        string                 `protobuf:"bytes,8,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	ExitCode          int32                  `protobuf:"varint,9,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	OomKilled         bool                   `protobuf:"varint,10,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"` // Killed by the out-of-memory killer
+	Error             string                 `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`                           // Last error reported by the runtime
+	Env               []string               `protobuf:"bytes,12,rep,name=env,proto3" json:"env,omitempty"`                               // KEY=VALUE
+	Mounts            []*ContainerMount      `protobuf:"bytes,13,rep,name=mounts,proto3" json:"mounts,omitempty"`
+	Networks          []*ContainerNetwork    `protobuf:"bytes,14,rep,name=networks,proto3" json:"networks,omitempty"`
+	Labels            map[string]string      `protobuf:"bytes,15,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RestartPolicy     string                 `protobuf:"bytes,16,opt,name=restart_policy,json=restartPolicy,proto3" json:"restart_policy,omitempty"`                // no, always, unless-stopped, on-failure
+	RestartMaxRetries int32                  `protobuf:"varint,17,opt,name=restart_max_retries,json=restartMaxRetries,proto3" json:"restart_max_retries,omitempty"` // For on-failure policy
+	RestartCount      int32                  `protobuf:"varint,18,opt,name=restart_count,json=restartCount,proto3" json:"restart_count,omitempty"`
+	Health            *ContainerHealth       `protobuf:"bytes,19,opt,name=health,proto3" json:"health,omitempty"` // Unset if the container has no health check
+	Resources         *ContainerResources    `protobuf:"bytes,20,opt,name=resources,proto3" json:"resources,omitempty"`
+	Command           string                 `protobuf:"bytes,21,opt,name=command,proto3" json:"command,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ContainerDetails) Reset() {
+	*x = ContainerDetails{}
+	mi := &file_pi_control_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerDetails) ProtoMessage() {}
+
+func (x *ContainerDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2040,54 +7147,188 @@ func (x *VersionInfo) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerDetails.ProtoReflect.Descriptor instead.
+func (*ContainerDetails) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ContainerDetails) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ContainerDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ContainerDetails) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ContainerDetails) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+func (x *ContainerDetails) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *ContainerDetails) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ContainerDetails) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *ContainerDetails) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *ContainerDetails) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *ContainerDetails) GetOomKilled() bool {
+	if x != nil {
+		return x.OomKilled
+	}
+	return false
+}
+
+func (x *ContainerDetails) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ContainerDetails) GetEnv() []string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *ContainerDetails) GetMounts() []*ContainerMount {
+	if x != nil {
+		return x.Mounts
+	}
+	return nil
+}
+
+func (x *ContainerDetails) GetNetworks() []*ContainerNetwork {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+func (x *ContainerDetails) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ContainerDetails) GetRestartPolicy() string {
+	if x != nil {
+		return x.RestartPolicy
+	}
+	return ""
+}
+
+func (x *ContainerDetails) GetRestartMaxRetries() int32 {
+	if x != nil {
+		return x.RestartMaxRetries
+	}
+	return 0
+}
+
+func (x *ContainerDetails) GetRestartCount() int32 {
+	if x != nil {
+		return x.RestartCount
+	}
+	return 0
 }
 
-// Deprecated: Use VersionInfo.ProtoReflect.Descriptor instead.
-func (*VersionInfo) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{26}
+func (x *ContainerDetails) GetHealth() *ContainerHealth {
+	if x != nil {
+		return x.Health
+	}
+	return nil
 }
 
-func (x *VersionInfo) GetVersion() string {
+func (x *ContainerDetails) GetResources() *ContainerResources {
 	if x != nil {
-		return x.Version
+		return x.Resources
 	}
-	return ""
+	return nil
 }
 
-func (x *VersionInfo) GetIsRoot() bool {
+func (x *ContainerDetails) GetCommand() string {
 	if x != nil {
-		return x.IsRoot
+		return x.Command
 	}
-	return false
+	return ""
 }
 
-// Ping request
-type PingRequest struct {
+type ContainerMount struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`                                // Hostname or IP address to ping
-	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`                             // Number of pings (0 = continuous until cancelled)
-	Timeout       int32                  `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`                         // Timeout in seconds per ping (default: 5)
-	PacketSize    int32                  `protobuf:"varint,4,opt,name=packet_size,json=packetSize,proto3" json:"packet_size,omitempty"` // Packet size in bytes (default: 56)
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // bind, volume, tmpfs
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // Volume name
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	Destination   string                 `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`
+	Mode          string                 `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`
+	ReadWrite     bool                   `protobuf:"varint,6,opt,name=read_write,json=readWrite,proto3" json:"read_write,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PingRequest) Reset() {
-	*x = PingRequest{}
-	mi := &file_pi_control_proto_msgTypes[27]
+func (x *ContainerMount) Reset() {
+	*x = ContainerMount{}
+	mi := &file_pi_control_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PingRequest) String() string {
+func (x *ContainerMount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PingRequest) ProtoMessage() {}
+func (*ContainerMount) ProtoMessage() {}
 
-func (x *PingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[27]
+func (x *ContainerMount) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2098,70 +7339,80 @@ func (x *PingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
-func (*PingRequest) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use ContainerMount.ProtoReflect.Descriptor instead.
+func (*ContainerMount) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *PingRequest) GetHost() string {
+func (x *ContainerMount) GetType() string {
 	if x != nil {
-		return x.Host
+		return x.Type
 	}
 	return ""
 }
 
-func (x *PingRequest) GetCount() int32 {
+func (x *ContainerMount) GetName() string {
 	if x != nil {
-		return x.Count
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *PingRequest) GetTimeout() int32 {
+func (x *ContainerMount) GetSource() string {
 	if x != nil {
-		return x.Timeout
+		return x.Source
 	}
-	return 0
+	return ""
 }
 
-func (x *PingRequest) GetPacketSize() int32 {
+func (x *ContainerMount) GetDestination() string {
 	if x != nil {
-		return x.PacketSize
+		return x.Destination
 	}
-	return 0
+	return ""
 }
 
-// Ping response (streamed)
-type PingResponse struct {
+func (x *ContainerMount) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ContainerMount) GetReadWrite() bool {
+	if x != nil {
+		return x.ReadWrite
+	}
+	return false
+}
+
+type ContainerNetwork struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`      // Whether ping was successful
-	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`             // Target host
-	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`                 // Resolved IP address
-	Latency       float64                `protobuf:"fixed64,4,opt,name=latency,proto3" json:"latency,omitempty"`     // Latency in milliseconds
-	Sequence      int32                  `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`    // Sequence number
-	Ttl           int32                  `protobuf:"varint,6,opt,name=ttl,proto3" json:"ttl,omitempty"`              // Time to live
-	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`           // Error message if failed
-	Finished      bool                   `protobuf:"varint,8,opt,name=finished,proto3" json:"finished,omitempty"`    // True when all pings complete
-	Statistics    *PingStats             `protobuf:"bytes,9,opt,name=statistics,proto3" json:"statistics,omitempty"` // Final statistics (only in last message)
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	Ipv6Address   string                 `protobuf:"bytes,3,opt,name=ipv6_address,json=ipv6Address,proto3" json:"ipv6_address,omitempty"`
+	Gateway       string                 `protobuf:"bytes,4,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	MacAddress    string                 `protobuf:"bytes,5,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
+	Aliases       []string               `protobuf:"bytes,6,rep,name=aliases,proto3" json:"aliases,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PingResponse) Reset() {
-	*x = PingResponse{}
-	mi := &file_pi_control_proto_msgTypes[28]
+func (x *ContainerNetwork) Reset() {
+	*x = ContainerNetwork{}
+	mi := &file_pi_control_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PingResponse) String() string {
+func (x *ContainerNetwork) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PingResponse) ProtoMessage() {}
+func (*ContainerNetwork) ProtoMessage() {}
 
-func (x *PingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[28]
+func (x *ContainerNetwork) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2172,102 +7423,138 @@ func (x *PingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
-func (*PingResponse) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use ContainerNetwork.ProtoReflect.Descriptor instead.
+func (*ContainerNetwork) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{88}
 }
 
-func (x *PingResponse) GetSuccess() bool {
+func (x *ContainerNetwork) GetName() string {
 	if x != nil {
-		return x.Success
+		return x.Name
 	}
-	return false
+	return ""
 }
 
-func (x *PingResponse) GetHost() string {
+func (x *ContainerNetwork) GetIpAddress() string {
 	if x != nil {
-		return x.Host
+		return x.IpAddress
 	}
 	return ""
 }
 
-func (x *PingResponse) GetIp() string {
+func (x *ContainerNetwork) GetIpv6Address() string {
 	if x != nil {
-		return x.Ip
+		return x.Ipv6Address
 	}
 	return ""
 }
 
-func (x *PingResponse) GetLatency() float64 {
+func (x *ContainerNetwork) GetGateway() string {
 	if x != nil {
-		return x.Latency
+		return x.Gateway
 	}
-	return 0
+	return ""
 }
 
-func (x *PingResponse) GetSequence() int32 {
+func (x *ContainerNetwork) GetMacAddress() string {
 	if x != nil {
-		return x.Sequence
+		return x.MacAddress
 	}
-	return 0
+	return ""
 }
 
-func (x *PingResponse) GetTtl() int32 {
+func (x *ContainerNetwork) GetAliases() []string {
 	if x != nil {
-		return x.Ttl
+		return x.Aliases
 	}
-	return 0
+	return nil
 }
 
-func (x *PingResponse) GetError() string {
+type ContainerHealth struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // starting, healthy, unhealthy
+	FailingStreak int32                  `protobuf:"varint,2,opt,name=failing_streak,json=failingStreak,proto3" json:"failing_streak,omitempty"`
+	Log           []*HealthCheckResult   `protobuf:"bytes,3,rep,name=log,proto3" json:"log,omitempty"` // Recent results, oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerHealth) Reset() {
+	*x = ContainerHealth{}
+	mi := &file_pi_control_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerHealth) ProtoMessage() {}
+
+func (x *ContainerHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[89]
 	if x != nil {
-		return x.Error
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerHealth.ProtoReflect.Descriptor instead.
+func (*ContainerHealth) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ContainerHealth) GetStatus() string {
+	if x != nil {
+		return x.Status
 	}
 	return ""
 }
 
-func (x *PingResponse) GetFinished() bool {
+func (x *ContainerHealth) GetFailingStreak() int32 {
 	if x != nil {
-		return x.Finished
+		return x.FailingStreak
 	}
-	return false
+	return 0
 }
 
-func (x *PingResponse) GetStatistics() *PingStats {
+func (x *ContainerHealth) GetLog() []*HealthCheckResult {
 	if x != nil {
-		return x.Statistics
+		return x.Log
 	}
 	return nil
 }
 
-// Ping statistics
-type PingStats struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	PacketsSent     int32                  `protobuf:"varint,1,opt,name=packets_sent,json=packetsSent,proto3" json:"packets_sent,omitempty"`
-	PacketsReceived int32                  `protobuf:"varint,2,opt,name=packets_received,json=packetsReceived,proto3" json:"packets_received,omitempty"`
-	PacketLoss      float64                `protobuf:"fixed64,3,opt,name=packet_loss,json=packetLoss,proto3" json:"packet_loss,omitempty"` // Percentage
-	MinLatency      float64                `protobuf:"fixed64,4,opt,name=min_latency,json=minLatency,proto3" json:"min_latency,omitempty"` // ms
-	MaxLatency      float64                `protobuf:"fixed64,5,opt,name=max_latency,json=maxLatency,proto3" json:"max_latency,omitempty"` // ms
-	AvgLatency      float64                `protobuf:"fixed64,6,opt,name=avg_latency,json=avgLatency,proto3" json:"avg_latency,omitempty"` // ms
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type HealthCheckResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         int64                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"` // Unix timestamp
+	End           int64                  `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`     // Unix timestamp
+	ExitCode      int32                  `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Output        string                 `protobuf:"bytes,4,opt,name=output,proto3" json:"output,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PingStats) Reset() {
-	*x = PingStats{}
-	mi := &file_pi_control_proto_msgTypes[29]
+func (x *HealthCheckResult) Reset() {
+	*x = HealthCheckResult{}
+	mi := &file_pi_control_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PingStats) String() string {
+func (x *HealthCheckResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PingStats) ProtoMessage() {}
+func (*HealthCheckResult) ProtoMessage() {}
 
-func (x *PingStats) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[29]
+func (x *HealthCheckResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2278,80 +7565,68 @@ func (x *PingStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PingStats.ProtoReflect.Descriptor instead.
-func (*PingStats) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{29}
-}
-
-func (x *PingStats) GetPacketsSent() int32 {
-	if x != nil {
-		return x.PacketsSent
-	}
-	return 0
-}
-
-func (x *PingStats) GetPacketsReceived() int32 {
-	if x != nil {
-		return x.PacketsReceived
-	}
-	return 0
+// Deprecated: Use HealthCheckResult.ProtoReflect.Descriptor instead.
+func (*HealthCheckResult) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *PingStats) GetPacketLoss() float64 {
+func (x *HealthCheckResult) GetStart() int64 {
 	if x != nil {
-		return x.PacketLoss
+		return x.Start
 	}
 	return 0
 }
 
-func (x *PingStats) GetMinLatency() float64 {
+func (x *HealthCheckResult) GetEnd() int64 {
 	if x != nil {
-		return x.MinLatency
+		return x.End
 	}
 	return 0
 }
 
-func (x *PingStats) GetMaxLatency() float64 {
+func (x *HealthCheckResult) GetExitCode() int32 {
 	if x != nil {
-		return x.MaxLatency
+		return x.ExitCode
 	}
 	return 0
 }
 
-func (x *PingStats) GetAvgLatency() float64 {
+func (x *HealthCheckResult) GetOutput() string {
 	if x != nil {
-		return x.AvgLatency
+		return x.Output
 	}
-	return 0
+	return ""
 }
 
-// Port scan request
-type PortScanRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`                             // Target hostname or IP
-	Ports         []int32                `protobuf:"varint,2,rep,packed,name=ports,proto3" json:"ports,omitempty"`                   // Specific ports to scan (if empty, scan common ports)
-	StartPort     int32                  `protobuf:"varint,3,opt,name=start_port,json=startPort,proto3" json:"start_port,omitempty"` // Start of port range (alternative to ports list)
-	EndPort       int32                  `protobuf:"varint,4,opt,name=end_port,json=endPort,proto3" json:"end_port,omitempty"`       // End of port range
-	Timeout       int32                  `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`                      // Timeout per port in milliseconds (default: 1000)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Resource limits (0 = unlimited)
+type ContainerResources struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	MemoryLimit       int64                  `protobuf:"varint,1,opt,name=memory_limit,json=memoryLimit,proto3" json:"memory_limit,omitempty"`                   // Bytes
+	MemoryReservation int64                  `protobuf:"varint,2,opt,name=memory_reservation,json=memoryReservation,proto3" json:"memory_reservation,omitempty"` // Bytes
+	MemorySwap        int64                  `protobuf:"varint,3,opt,name=memory_swap,json=memorySwap,proto3" json:"memory_swap,omitempty"`                      // Bytes (-1 = unlimited)
+	Cpus              float64                `protobuf:"fixed64,4,opt,name=cpus,proto3" json:"cpus,omitempty"`                                                   // Number of CPUs
+	CpuShares         int64                  `protobuf:"varint,5,opt,name=cpu_shares,json=cpuShares,proto3" json:"cpu_shares,omitempty"`
+	CpusetCpus        string                 `protobuf:"bytes,6,opt,name=cpuset_cpus,json=cpusetCpus,proto3" json:"cpuset_cpus,omitempty"`
+	PidsLimit         int64                  `protobuf:"varint,7,opt,name=pids_limit,json=pidsLimit,proto3" json:"pids_limit,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *PortScanRequest) Reset() {
-	*x = PortScanRequest{}
-	mi := &file_pi_control_proto_msgTypes[30]
+func (x *ContainerResources) Reset() {
+	*x = ContainerResources{}
+	mi := &file_pi_control_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PortScanRequest) String() string {
+func (x *ContainerResources) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PortScanRequest) ProtoMessage() {}
+func (*ContainerResources) ProtoMessage() {}
 
-func (x *PortScanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[30]
+func (x *ContainerResources) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2362,74 +7637,90 @@ func (x *PortScanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PortScanRequest.ProtoReflect.Descriptor instead.
-func (*PortScanRequest) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use ContainerResources.ProtoReflect.Descriptor instead.
+func (*ContainerResources) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{91}
 }
 
-func (x *PortScanRequest) GetHost() string {
+func (x *ContainerResources) GetMemoryLimit() int64 {
 	if x != nil {
-		return x.Host
+		return x.MemoryLimit
 	}
-	return ""
+	return 0
 }
 
-func (x *PortScanRequest) GetPorts() []int32 {
+func (x *ContainerResources) GetMemoryReservation() int64 {
 	if x != nil {
-		return x.Ports
+		return x.MemoryReservation
 	}
-	return nil
+	return 0
 }
 
-func (x *PortScanRequest) GetStartPort() int32 {
+func (x *ContainerResources) GetMemorySwap() int64 {
 	if x != nil {
-		return x.StartPort
+		return x.MemorySwap
 	}
 	return 0
 }
 
-func (x *PortScanRequest) GetEndPort() int32 {
+func (x *ContainerResources) GetCpus() float64 {
 	if x != nil {
-		return x.EndPort
+		return x.Cpus
 	}
 	return 0
 }
 
-func (x *PortScanRequest) GetTimeout() int32 {
+func (x *ContainerResources) GetCpuShares() int64 {
 	if x != nil {
-		return x.Timeout
+		return x.CpuShares
 	}
 	return 0
 }
 
-// Port scan response (streamed)
-type PortScanResponse struct {
+func (x *ContainerResources) GetCpusetCpus() string {
+	if x != nil {
+		return x.CpusetCpus
+	}
+	return ""
+}
+
+func (x *ContainerResources) GetPidsLimit() int64 {
+	if x != nil {
+		return x.PidsLimit
+	}
+	return 0
+}
+
+// Docker volume
+type VolumeInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Port          int32                  `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
-	Open          bool                   `protobuf:"varint,2,opt,name=open,proto3" json:"open,omitempty"`
-	Service       string                 `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"` // Service name if known
-	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
-	Finished      bool                   `protobuf:"varint,5,opt,name=finished,proto3" json:"finished,omitempty"` // True when scan completes
-	Progress      int32                  `protobuf:"varint,6,opt,name=progress,proto3" json:"progress,omitempty"` // Progress percentage (0-100)
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Driver        string                 `protobuf:"bytes,2,opt,name=driver,proto3" json:"driver,omitempty"`
+	Mountpoint    string                 `protobuf:"bytes,3,opt,name=mountpoint,proto3" json:"mountpoint,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Scope         string                 `protobuf:"bytes,5,opt,name=scope,proto3" json:"scope,omitempty"` // local, global
+	Labels        map[string]string      `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Size          int64                  `protobuf:"varint,7,opt,name=size,proto3" json:"size,omitempty"`            // Bytes used (-1 if unknown)
+	Containers    []string               `protobuf:"bytes,8,rep,name=containers,proto3" json:"containers,omitempty"` // Names of containers using this volume
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PortScanResponse) Reset() {
-	*x = PortScanResponse{}
-	mi := &file_pi_control_proto_msgTypes[31]
+func (x *VolumeInfo) Reset() {
+	*x = VolumeInfo{}
+	mi := &file_pi_control_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PortScanResponse) String() string {
+func (x *VolumeInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PortScanResponse) ProtoMessage() {}
+func (*VolumeInfo) ProtoMessage() {}
 
-func (x *PortScanResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[31]
+func (x *VolumeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2440,77 +7731,89 @@ func (x *PortScanResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PortScanResponse.ProtoReflect.Descriptor instead.
-func (*PortScanResponse) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use VolumeInfo.ProtoReflect.Descriptor instead.
+func (*VolumeInfo) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *VolumeInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *PortScanResponse) GetPort() int32 {
+func (x *VolumeInfo) GetDriver() string {
 	if x != nil {
-		return x.Port
+		return x.Driver
 	}
-	return 0
+	return ""
 }
 
-func (x *PortScanResponse) GetOpen() bool {
+func (x *VolumeInfo) GetMountpoint() string {
 	if x != nil {
-		return x.Open
+		return x.Mountpoint
 	}
-	return false
+	return ""
 }
 
-func (x *PortScanResponse) GetService() string {
+func (x *VolumeInfo) GetCreatedAt() string {
 	if x != nil {
-		return x.Service
+		return x.CreatedAt
 	}
 	return ""
 }
 
-func (x *PortScanResponse) GetError() string {
+func (x *VolumeInfo) GetScope() string {
 	if x != nil {
-		return x.Error
+		return x.Scope
 	}
 	return ""
 }
 
-func (x *PortScanResponse) GetFinished() bool {
+func (x *VolumeInfo) GetLabels() map[string]string {
 	if x != nil {
-		return x.Finished
+		return x.Labels
 	}
-	return false
+	return nil
 }
 
-func (x *PortScanResponse) GetProgress() int32 {
+func (x *VolumeInfo) GetSize() int64 {
 	if x != nil {
-		return x.Progress
+		return x.Size
 	}
 	return 0
 }
 
-// DNS lookup request
-type DNSRequest struct {
+func (x *VolumeInfo) GetContainers() []string {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+type VolumeList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Hostname      string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	RecordType    string                 `protobuf:"bytes,2,opt,name=record_type,json=recordType,proto3" json:"record_type,omitempty"` // A, AAAA, MX, TXT, NS, CNAME, etc. (default: A)
+	Volumes       []*VolumeInfo          `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DNSRequest) Reset() {
-	*x = DNSRequest{}
-	mi := &file_pi_control_proto_msgTypes[32]
+func (x *VolumeList) Reset() {
+	*x = VolumeList{}
+	mi := &file_pi_control_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DNSRequest) String() string {
+func (x *VolumeList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DNSRequest) ProtoMessage() {}
+func (*VolumeList) ProtoMessage() {}
 
-func (x *DNSRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[32]
+func (x *VolumeList) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2521,53 +7824,43 @@ func (x *DNSRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DNSRequest.ProtoReflect.Descriptor instead.
-func (*DNSRequest) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{32}
-}
-
-func (x *DNSRequest) GetHostname() string {
-	if x != nil {
-		return x.Hostname
-	}
-	return ""
+// Deprecated: Use VolumeList.ProtoReflect.Descriptor instead.
+func (*VolumeList) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *DNSRequest) GetRecordType() string {
+func (x *VolumeList) GetVolumes() []*VolumeInfo {
 	if x != nil {
-		return x.RecordType
+		return x.Volumes
 	}
-	return ""
+	return nil
 }
 
-// DNS lookup response
-type DNSResponse struct {
+type CreateVolumeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Hostname      string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	Addresses     []string               `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`                    // IP addresses or records
-	Records       []*DNSRecord           `protobuf:"bytes,4,rep,name=records,proto3" json:"records,omitempty"`                        // Detailed records
-	QueryTime     float64                `protobuf:"fixed64,5,opt,name=query_time,json=queryTime,proto3" json:"query_time,omitempty"` // Query time in milliseconds
-	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Driver        string                 `protobuf:"bytes,2,opt,name=driver,proto3" json:"driver,omitempty"` // Default: local
+	Labels        map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DriverOpts    map[string]string      `protobuf:"bytes,4,rep,name=driver_opts,json=driverOpts,proto3" json:"driver_opts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DNSResponse) Reset() {
-	*x = DNSResponse{}
-	mi := &file_pi_control_proto_msgTypes[33]
+func (x *CreateVolumeRequest) Reset() {
+	*x = CreateVolumeRequest{}
+	mi := &file_pi_control_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DNSResponse) String() string {
+func (x *CreateVolumeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DNSResponse) ProtoMessage() {}
+func (*CreateVolumeRequest) ProtoMessage() {}
 
-func (x *DNSResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[33]
+func (x *CreateVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2578,79 +7871,62 @@ func (x *DNSResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DNSResponse.ProtoReflect.Descriptor instead.
-func (*DNSResponse) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use CreateVolumeRequest.ProtoReflect.Descriptor instead.
+func (*CreateVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *DNSResponse) GetSuccess() bool {
+func (x *CreateVolumeRequest) GetName() string {
 	if x != nil {
-		return x.Success
+		return x.Name
 	}
-	return false
+	return ""
 }
 
-func (x *DNSResponse) GetHostname() string {
+func (x *CreateVolumeRequest) GetDriver() string {
 	if x != nil {
-		return x.Hostname
+		return x.Driver
 	}
 	return ""
 }
 
-func (x *DNSResponse) GetAddresses() []string {
+func (x *CreateVolumeRequest) GetLabels() map[string]string {
 	if x != nil {
-		return x.Addresses
+		return x.Labels
 	}
 	return nil
 }
 
-func (x *DNSResponse) GetRecords() []*DNSRecord {
+func (x *CreateVolumeRequest) GetDriverOpts() map[string]string {
 	if x != nil {
-		return x.Records
+		return x.DriverOpts
 	}
 	return nil
 }
 
-func (x *DNSResponse) GetQueryTime() float64 {
-	if x != nil {
-		return x.QueryTime
-	}
-	return 0
-}
-
-func (x *DNSResponse) GetError() string {
-	if x != nil {
-		return x.Error
-	}
-	return ""
-}
-
-// DNS record details
-type DNSRecord struct {
+type RemoveVolumeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // A, AAAA, MX, etc.
-	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	Ttl           int32                  `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`           // Time to live
-	Priority      int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"` // For MX records
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Force         bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DNSRecord) Reset() {
-	*x = DNSRecord{}
-	mi := &file_pi_control_proto_msgTypes[34]
+func (x *RemoveVolumeRequest) Reset() {
+	*x = RemoveVolumeRequest{}
+	mi := &file_pi_control_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DNSRecord) String() string {
+func (x *RemoveVolumeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DNSRecord) ProtoMessage() {}
+func (*RemoveVolumeRequest) ProtoMessage() {}
 
-func (x *DNSRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[34]
+func (x *RemoveVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2661,64 +7937,47 @@ func (x *DNSRecord) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DNSRecord.ProtoReflect.Descriptor instead.
-func (*DNSRecord) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{34}
-}
-
-func (x *DNSRecord) GetType() string {
-	if x != nil {
-		return x.Type
-	}
-	return ""
+// Deprecated: Use RemoveVolumeRequest.ProtoReflect.Descriptor instead.
+func (*RemoveVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *DNSRecord) GetValue() string {
+func (x *RemoveVolumeRequest) GetName() string {
 	if x != nil {
-		return x.Value
+		return x.Name
 	}
 	return ""
 }
 
-func (x *DNSRecord) GetTtl() int32 {
-	if x != nil {
-		return x.Ttl
-	}
-	return 0
-}
-
-func (x *DNSRecord) GetPriority() int32 {
+func (x *RemoveVolumeRequest) GetForce() bool {
 	if x != nil {
-		return x.Priority
+		return x.Force
 	}
-	return 0
+	return false
 }
 
-// Traceroute request
-type TracerouteRequest struct {
+type PruneVolumesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
-	MaxHops       int32                  `protobuf:"varint,2,opt,name=max_hops,json=maxHops,proto3" json:"max_hops,omitempty"` // Maximum number of hops (default: 30)
-	Timeout       int32                  `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`                // Timeout per hop in seconds (default: 5)
+	All           bool                   `protobuf:"varint,1,opt,name=all,proto3" json:"all,omitempty"` // Also remove unused named volumes, not only anonymous ones
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TracerouteRequest) Reset() {
-	*x = TracerouteRequest{}
-	mi := &file_pi_control_proto_msgTypes[35]
+func (x *PruneVolumesRequest) Reset() {
+	*x = PruneVolumesRequest{}
+	mi := &file_pi_control_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TracerouteRequest) String() string {
+func (x *PruneVolumesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TracerouteRequest) ProtoMessage() {}
+func (*PruneVolumesRequest) ProtoMessage() {}
 
-func (x *TracerouteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[35]
+func (x *PruneVolumesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2729,61 +7988,43 @@ func (x *TracerouteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TracerouteRequest.ProtoReflect.Descriptor instead.
-func (*TracerouteRequest) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{35}
-}
-
-func (x *TracerouteRequest) GetHost() string {
-	if x != nil {
-		return x.Host
-	}
-	return ""
-}
-
-func (x *TracerouteRequest) GetMaxHops() int32 {
-	if x != nil {
-		return x.MaxHops
-	}
-	return 0
+// Deprecated: Use PruneVolumesRequest.ProtoReflect.Descriptor instead.
+func (*PruneVolumesRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *TracerouteRequest) GetTimeout() int32 {
+func (x *PruneVolumesRequest) GetAll() bool {
 	if x != nil {
-		return x.Timeout
+		return x.All
 	}
-	return 0
+	return false
 }
 
-// Traceroute response (streamed)
-type TracerouteResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Hop           int32                  `protobuf:"varint,1,opt,name=hop,proto3" json:"hop,omitempty"`           // Hop number
-	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`              // IP address of this hop
-	Hostname      string                 `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`  // Hostname if resolved
-	Latency       float64                `protobuf:"fixed64,4,opt,name=latency,proto3" json:"latency,omitempty"`  // Latency in milliseconds
-	Timeout       bool                   `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`   // True if hop timed out
-	Finished      bool                   `protobuf:"varint,6,opt,name=finished,proto3" json:"finished,omitempty"` // True when traceroute completes
-	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type PruneResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Deleted        []string               `protobuf:"bytes,2,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	SpaceReclaimed uint64                 `protobuf:"varint,3,opt,name=space_reclaimed,json=spaceReclaimed,proto3" json:"space_reclaimed,omitempty"` // Bytes
+	Error          string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *TracerouteResponse) Reset() {
-	*x = TracerouteResponse{}
-	mi := &file_pi_control_proto_msgTypes[36]
+func (x *PruneResponse) Reset() {
+	*x = PruneResponse{}
+	mi := &file_pi_control_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TracerouteResponse) String() string {
+func (x *PruneResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TracerouteResponse) ProtoMessage() {}
+func (*PruneResponse) ProtoMessage() {}
 
-func (x *TracerouteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[36]
+func (x *PruneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2794,92 +8035,73 @@ func (x *TracerouteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TracerouteResponse.ProtoReflect.Descriptor instead.
-func (*TracerouteResponse) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{36}
-}
-
-func (x *TracerouteResponse) GetHop() int32 {
-	if x != nil {
-		return x.Hop
-	}
-	return 0
+// Deprecated: Use PruneResponse.ProtoReflect.Descriptor instead.
+func (*PruneResponse) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *TracerouteResponse) GetIp() string {
+func (x *PruneResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Ip
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *TracerouteResponse) GetHostname() string {
+func (x *PruneResponse) GetDeleted() []string {
 	if x != nil {
-		return x.Hostname
+		return x.Deleted
 	}
-	return ""
+	return nil
 }
 
-func (x *TracerouteResponse) GetLatency() float64 {
+func (x *PruneResponse) GetSpaceReclaimed() uint64 {
 	if x != nil {
-		return x.Latency
+		return x.SpaceReclaimed
 	}
 	return 0
 }
 
-func (x *TracerouteResponse) GetTimeout() bool {
-	if x != nil {
-		return x.Timeout
-	}
-	return false
-}
-
-func (x *TracerouteResponse) GetFinished() bool {
-	if x != nil {
-		return x.Finished
-	}
-	return false
-}
-
-func (x *TracerouteResponse) GetError() string {
+func (x *PruneResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-// WiFi information
-type WifiInfo struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Connected         bool                   `protobuf:"varint,1,opt,name=connected,proto3" json:"connected,omitempty"`
-	Ssid              string                 `protobuf:"bytes,2,opt,name=ssid,proto3" json:"ssid,omitempty"`                                            // Network name
-	Bssid             string                 `protobuf:"bytes,3,opt,name=bssid,proto3" json:"bssid,omitempty"`                                          // MAC address of access point
-	SignalStrength    int32                  `protobuf:"varint,4,opt,name=signal_strength,json=signalStrength,proto3" json:"signal_strength,omitempty"` // Signal strength in dBm (-100 to 0)
-	SignalQuality     int32                  `protobuf:"varint,5,opt,name=signal_quality,json=signalQuality,proto3" json:"signal_quality,omitempty"`    // Signal quality percentage (0-100)
-	Frequency         float64                `protobuf:"fixed64,6,opt,name=frequency,proto3" json:"frequency,omitempty"`                                // Frequency in GHz (2.4 or 5.0)
-	Security          string                 `protobuf:"bytes,7,opt,name=security,proto3" json:"security,omitempty"`                                    // WPA2, WPA3, etc.
-	LinkSpeed         float64                `protobuf:"fixed64,8,opt,name=link_speed,json=linkSpeed,proto3" json:"link_speed,omitempty"`               // Link speed in Mbps
-	IpAddress         string                 `protobuf:"bytes,9,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
-	AvailableNetworks []*WifiNetwork         `protobuf:"bytes,10,rep,name=available_networks,json=availableNetworks,proto3" json:"available_networks,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// Docker network
+type DockerNetworkInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Driver        string                 `protobuf:"bytes,3,opt,name=driver,proto3" json:"driver,omitempty"` // bridge, host, macvlan, overlay, none
+	Scope         string                 `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`
+	Internal      bool                   `protobuf:"varint,5,opt,name=internal,proto3" json:"internal,omitempty"`
+	Attachable    bool                   `protobuf:"varint,6,opt,name=attachable,proto3" json:"attachable,omitempty"`
+	Ipv6          bool                   `protobuf:"varint,7,opt,name=ipv6,proto3" json:"ipv6,omitempty"`
+	Subnets       []string               `protobuf:"bytes,8,rep,name=subnets,proto3" json:"subnets,omitempty"`
+	Gateways      []string               `protobuf:"bytes,9,rep,name=gateways,proto3" json:"gateways,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Containers    []string               `protobuf:"bytes,11,rep,name=containers,proto3" json:"containers,omitempty"` // Names of attached containers
+	Created       int64                  `protobuf:"varint,12,opt,name=created,proto3" json:"created,omitempty"`      // Unix timestamp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WifiInfo) Reset() {
-	*x = WifiInfo{}
-	mi := &file_pi_control_proto_msgTypes[37]
+func (x *DockerNetworkInfo) Reset() {
+	*x = DockerNetworkInfo{}
+	mi := &file_pi_control_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WifiInfo) String() string {
+func (x *DockerNetworkInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WifiInfo) ProtoMessage() {}
+func (*DockerNetworkInfo) ProtoMessage() {}
 
-func (x *WifiInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[37]
+func (x *DockerNetworkInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2890,109 +8112,117 @@ func (x *WifiInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WifiInfo.ProtoReflect.Descriptor instead.
-func (*WifiInfo) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use DockerNetworkInfo.ProtoReflect.Descriptor instead.
+func (*DockerNetworkInfo) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *WifiInfo) GetConnected() bool {
+func (x *DockerNetworkInfo) GetId() string {
 	if x != nil {
-		return x.Connected
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DockerNetworkInfo) GetName() string {
+	if x != nil {
+		return x.Name
 	}
-	return false
+	return ""
 }
 
-func (x *WifiInfo) GetSsid() string {
+func (x *DockerNetworkInfo) GetDriver() string {
 	if x != nil {
-		return x.Ssid
+		return x.Driver
 	}
 	return ""
 }
 
-func (x *WifiInfo) GetBssid() string {
+func (x *DockerNetworkInfo) GetScope() string {
 	if x != nil {
-		return x.Bssid
+		return x.Scope
 	}
 	return ""
 }
 
-func (x *WifiInfo) GetSignalStrength() int32 {
+func (x *DockerNetworkInfo) GetInternal() bool {
 	if x != nil {
-		return x.SignalStrength
+		return x.Internal
 	}
-	return 0
+	return false
 }
 
-func (x *WifiInfo) GetSignalQuality() int32 {
+func (x *DockerNetworkInfo) GetAttachable() bool {
 	if x != nil {
-		return x.SignalQuality
+		return x.Attachable
 	}
-	return 0
+	return false
 }
 
-func (x *WifiInfo) GetFrequency() float64 {
+func (x *DockerNetworkInfo) GetIpv6() bool {
 	if x != nil {
-		return x.Frequency
+		return x.Ipv6
 	}
-	return 0
+	return false
 }
 
-func (x *WifiInfo) GetSecurity() string {
+func (x *DockerNetworkInfo) GetSubnets() []string {
 	if x != nil {
-		return x.Security
+		return x.Subnets
 	}
-	return ""
+	return nil
 }
 
-func (x *WifiInfo) GetLinkSpeed() float64 {
+func (x *DockerNetworkInfo) GetGateways() []string {
 	if x != nil {
-		return x.LinkSpeed
+		return x.Gateways
 	}
-	return 0
+	return nil
 }
 
-func (x *WifiInfo) GetIpAddress() string {
+func (x *DockerNetworkInfo) GetLabels() map[string]string {
 	if x != nil {
-		return x.IpAddress
+		return x.Labels
 	}
-	return ""
+	return nil
 }
 
-func (x *WifiInfo) GetAvailableNetworks() []*WifiNetwork {
+func (x *DockerNetworkInfo) GetContainers() []string {
 	if x != nil {
-		return x.AvailableNetworks
+		return x.Containers
 	}
 	return nil
 }
 
-// Available WiFi network
-type WifiNetwork struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Ssid           string                 `protobuf:"bytes,1,opt,name=ssid,proto3" json:"ssid,omitempty"`
-	Bssid          string                 `protobuf:"bytes,2,opt,name=bssid,proto3" json:"bssid,omitempty"`
-	SignalStrength int32                  `protobuf:"varint,3,opt,name=signal_strength,json=signalStrength,proto3" json:"signal_strength,omitempty"`
-	SignalQuality  int32                  `protobuf:"varint,4,opt,name=signal_quality,json=signalQuality,proto3" json:"signal_quality,omitempty"`
-	Frequency      float64                `protobuf:"fixed64,5,opt,name=frequency,proto3" json:"frequency,omitempty"`
-	Security       string                 `protobuf:"bytes,6,opt,name=security,proto3" json:"security,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *DockerNetworkInfo) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
 }
 
-func (x *WifiNetwork) Reset() {
-	*x = WifiNetwork{}
-	mi := &file_pi_control_proto_msgTypes[38]
+type DockerNetworkList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Networks      []*DockerNetworkInfo   `protobuf:"bytes,1,rep,name=networks,proto3" json:"networks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DockerNetworkList) Reset() {
+	*x = DockerNetworkList{}
+	mi := &file_pi_control_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WifiNetwork) String() string {
+func (x *DockerNetworkList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WifiNetwork) ProtoMessage() {}
+func (*DockerNetworkList) ProtoMessage() {}
 
-func (x *WifiNetwork) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[38]
+func (x *DockerNetworkList) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3003,78 +8233,91 @@ func (x *WifiNetwork) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WifiNetwork.ProtoReflect.Descriptor instead.
-func (*WifiNetwork) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use DockerNetworkList.ProtoReflect.Descriptor instead.
+func (*DockerNetworkList) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{99}
 }
 
-func (x *WifiNetwork) GetSsid() string {
+func (x *DockerNetworkList) GetNetworks() []*DockerNetworkInfo {
 	if x != nil {
-		return x.Ssid
+		return x.Networks
 	}
-	return ""
+	return nil
 }
 
-func (x *WifiNetwork) GetBssid() string {
-	if x != nil {
-		return x.Bssid
-	}
-	return ""
+type DockerNetworkId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Network ID or name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WifiNetwork) GetSignalStrength() int32 {
-	if x != nil {
-		return x.SignalStrength
-	}
-	return 0
+func (x *DockerNetworkId) Reset() {
+	*x = DockerNetworkId{}
+	mi := &file_pi_control_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *WifiNetwork) GetSignalQuality() int32 {
-	if x != nil {
-		return x.SignalQuality
-	}
-	return 0
+func (x *DockerNetworkId) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *WifiNetwork) GetFrequency() float64 {
+func (*DockerNetworkId) ProtoMessage() {}
+
+func (x *DockerNetworkId) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[100]
 	if x != nil {
-		return x.Frequency
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *WifiNetwork) GetSecurity() string {
+// Deprecated: Use DockerNetworkId.ProtoReflect.Descriptor instead.
+func (*DockerNetworkId) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *DockerNetworkId) GetId() string {
 	if x != nil {
-		return x.Security
+		return x.Id
 	}
 	return ""
 }
 
-// Speed test request
-type SpeedTestRequest struct {
+type CreateNetworkRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TestDownload  bool                   `protobuf:"varint,1,opt,name=test_download,json=testDownload,proto3" json:"test_download,omitempty"` // Test download speed
-	TestUpload    bool                   `protobuf:"varint,2,opt,name=test_upload,json=testUpload,proto3" json:"test_upload,omitempty"`       // Test upload speed
-	Duration      int32                  `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`                             // Test duration in seconds (default: 10)
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Driver        string                 `protobuf:"bytes,2,opt,name=driver,proto3" json:"driver,omitempty"` // Default: bridge
+	Subnet        string                 `protobuf:"bytes,3,opt,name=subnet,proto3" json:"subnet,omitempty"` // e.g. 172.30.0.0/16
+	Gateway       string                 `protobuf:"bytes,4,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	Internal      bool                   `protobuf:"varint,5,opt,name=internal,proto3" json:"internal,omitempty"`
+	Attachable    bool                   `protobuf:"varint,6,opt,name=attachable,proto3" json:"attachable,omitempty"`
+	Ipv6          bool                   `protobuf:"varint,7,opt,name=ipv6,proto3" json:"ipv6,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SpeedTestRequest) Reset() {
-	*x = SpeedTestRequest{}
-	mi := &file_pi_control_proto_msgTypes[39]
+func (x *CreateNetworkRequest) Reset() {
+	*x = CreateNetworkRequest{}
+	mi := &file_pi_control_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SpeedTestRequest) String() string {
+func (x *CreateNetworkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpeedTestRequest) ProtoMessage() {}
+func (*CreateNetworkRequest) ProtoMessage() {}
 
-func (x *SpeedTestRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[39]
+func (x *CreateNetworkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3085,62 +8328,93 @@ func (x *SpeedTestRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpeedTestRequest.ProtoReflect.Descriptor instead.
-func (*SpeedTestRequest) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use CreateNetworkRequest.ProtoReflect.Descriptor instead.
+func (*CreateNetworkRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{101}
 }
 
-func (x *SpeedTestRequest) GetTestDownload() bool {
+func (x *CreateNetworkRequest) GetName() string {
 	if x != nil {
-		return x.TestDownload
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateNetworkRequest) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *CreateNetworkRequest) GetSubnet() string {
+	if x != nil {
+		return x.Subnet
+	}
+	return ""
+}
+
+func (x *CreateNetworkRequest) GetGateway() string {
+	if x != nil {
+		return x.Gateway
+	}
+	return ""
+}
+
+func (x *CreateNetworkRequest) GetInternal() bool {
+	if x != nil {
+		return x.Internal
 	}
 	return false
 }
 
-func (x *SpeedTestRequest) GetTestUpload() bool {
+func (x *CreateNetworkRequest) GetAttachable() bool {
 	if x != nil {
-		return x.TestUpload
+		return x.Attachable
 	}
 	return false
 }
 
-func (x *SpeedTestRequest) GetDuration() int32 {
+func (x *CreateNetworkRequest) GetIpv6() bool {
 	if x != nil {
-		return x.Duration
+		return x.Ipv6
 	}
-	return 0
+	return false
 }
 
-// Speed test response (streamed)
-type SpeedTestResponse struct {
+func (x *CreateNetworkRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type NetworkConnectRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Phase         string                 `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`                                        // "connecting", "download", "upload", "complete"
-	DownloadSpeed float64                `protobuf:"fixed64,2,opt,name=download_speed,json=downloadSpeed,proto3" json:"download_speed,omitempty"` // Current download speed in Mbps
-	UploadSpeed   float64                `protobuf:"fixed64,3,opt,name=upload_speed,json=uploadSpeed,proto3" json:"upload_speed,omitempty"`       // Current upload speed in Mbps
-	Progress      float64                `protobuf:"fixed64,4,opt,name=progress,proto3" json:"progress,omitempty"`                                // Progress percentage (0-100)
-	Latency       float64                `protobuf:"fixed64,5,opt,name=latency,proto3" json:"latency,omitempty"`                                  // Server latency in ms
-	Server        string                 `protobuf:"bytes,6,opt,name=server,proto3" json:"server,omitempty"`                                      // Test server location
-	Finished      bool                   `protobuf:"varint,7,opt,name=finished,proto3" json:"finished,omitempty"`
-	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	NetworkId     string                 `protobuf:"bytes,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	ContainerId   string                 `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Aliases       []string               `protobuf:"bytes,3,rep,name=aliases,proto3" json:"aliases,omitempty"`                            // Connect only
+	Ipv4Address   string                 `protobuf:"bytes,4,opt,name=ipv4_address,json=ipv4Address,proto3" json:"ipv4_address,omitempty"` // Connect only, static IP
+	Force         bool                   `protobuf:"varint,5,opt,name=force,proto3" json:"force,omitempty"`                               // Disconnect only
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SpeedTestResponse) Reset() {
-	*x = SpeedTestResponse{}
-	mi := &file_pi_control_proto_msgTypes[40]
+func (x *NetworkConnectRequest) Reset() {
+	*x = NetworkConnectRequest{}
+	mi := &file_pi_control_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SpeedTestResponse) String() string {
+func (x *NetworkConnectRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpeedTestResponse) ProtoMessage() {}
+func (*NetworkConnectRequest) ProtoMessage() {}
 
-func (x *SpeedTestResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[40]
+func (x *NetworkConnectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3151,95 +8425,73 @@ func (x *SpeedTestResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpeedTestResponse.ProtoReflect.Descriptor instead.
-func (*SpeedTestResponse) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use NetworkConnectRequest.ProtoReflect.Descriptor instead.
+func (*NetworkConnectRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{102}
 }
 
-func (x *SpeedTestResponse) GetPhase() string {
+func (x *NetworkConnectRequest) GetNetworkId() string {
 	if x != nil {
-		return x.Phase
+		return x.NetworkId
 	}
 	return ""
 }
 
-func (x *SpeedTestResponse) GetDownloadSpeed() float64 {
-	if x != nil {
-		return x.DownloadSpeed
-	}
-	return 0
-}
-
-func (x *SpeedTestResponse) GetUploadSpeed() float64 {
-	if x != nil {
-		return x.UploadSpeed
-	}
-	return 0
-}
-
-func (x *SpeedTestResponse) GetProgress() float64 {
+func (x *NetworkConnectRequest) GetContainerId() string {
 	if x != nil {
-		return x.Progress
+		return x.ContainerId
 	}
-	return 0
+	return ""
 }
 
-func (x *SpeedTestResponse) GetLatency() float64 {
+func (x *NetworkConnectRequest) GetAliases() []string {
 	if x != nil {
-		return x.Latency
+		return x.Aliases
 	}
-	return 0
+	return nil
 }
 
-func (x *SpeedTestResponse) GetServer() string {
+func (x *NetworkConnectRequest) GetIpv4Address() string {
 	if x != nil {
-		return x.Server
+		return x.Ipv4Address
 	}
 	return ""
 }
 
-func (x *SpeedTestResponse) GetFinished() bool {
+func (x *NetworkConnectRequest) GetForce() bool {
 	if x != nil {
-		return x.Finished
+		return x.Force
 	}
 	return false
 }
 
-func (x *SpeedTestResponse) GetError() string {
-	if x != nil {
-		return x.Error
-	}
-	return ""
-}
-
-// File chunk for streaming transfers
-type FileChunk struct {
+// Docker Compose project (containers sharing com.docker.compose.project)
+type ComposeProject struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                             // Remote file path (relative to agent's working directory)
-	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                             // Chunk data (max 256KB recommended)
-	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`                        // Byte offset in the file
-	TotalSize     int64                  `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"` // Total file size in bytes (set in first chunk)
-	IsFinal       bool                   `protobuf:"varint,5,opt,name=is_final,json=isFinal,proto3" json:"is_final,omitempty"`       // True for the last chunk
-	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`                           // Error message if something went wrong
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	WorkingDir    string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`    // Project directory on the Pi
+	ConfigFiles   []string               `protobuf:"bytes,3,rep,name=config_files,json=configFiles,proto3" json:"config_files,omitempty"` // Compose files (view/edit via file RPCs)
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                              // running, partial, exited
+	Services      []*ComposeService      `protobuf:"bytes,5,rep,name=services,proto3" json:"services,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileChunk) Reset() {
-	*x = FileChunk{}
-	mi := &file_pi_control_proto_msgTypes[41]
+func (x *ComposeProject) Reset() {
+	*x = ComposeProject{}
+	mi := &file_pi_control_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileChunk) String() string {
+func (x *ComposeProject) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileChunk) ProtoMessage() {}
+func (*ComposeProject) ProtoMessage() {}
 
-func (x *FileChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[41]
+func (x *ComposeProject) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3250,80 +8502,74 @@ func (x *FileChunk) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileChunk.ProtoReflect.Descriptor instead.
-func (*FileChunk) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use ComposeProject.ProtoReflect.Descriptor instead.
+func (*ComposeProject) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{103}
 }
 
-func (x *FileChunk) GetPath() string {
+func (x *ComposeProject) GetName() string {
 	if x != nil {
-		return x.Path
+		return x.Name
 	}
 	return ""
 }
 
-func (x *FileChunk) GetData() []byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-func (x *FileChunk) GetOffset() int64 {
+func (x *ComposeProject) GetWorkingDir() string {
 	if x != nil {
-		return x.Offset
+		return x.WorkingDir
 	}
-	return 0
+	return ""
 }
 
-func (x *FileChunk) GetTotalSize() int64 {
+func (x *ComposeProject) GetConfigFiles() []string {
 	if x != nil {
-		return x.TotalSize
+		return x.ConfigFiles
 	}
-	return 0
+	return nil
 }
 
-func (x *FileChunk) GetIsFinal() bool {
+func (x *ComposeProject) GetStatus() string {
 	if x != nil {
-		return x.IsFinal
+		return x.Status
 	}
-	return false
+	return ""
 }
 
-func (x *FileChunk) GetError() string {
+func (x *ComposeProject) GetServices() []*ComposeService {
 	if x != nil {
-		return x.Error
+		return x.Services
 	}
-	return ""
+	return nil
 }
 
-// Upload response (after all chunks received)
-type FileUploadResponse struct {
+// Service within a compose project
+type ComposeService struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`                                      // Path where file was written
-	BytesWritten  int64                  `protobuf:"varint,3,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"` // Total bytes written
-	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                                    // Error message if failed
-	Duration      float64                `protobuf:"fixed64,5,opt,name=duration,proto3" json:"duration,omitempty"`                            // Upload duration in seconds
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ContainerId   string                 `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	ContainerName string                 `protobuf:"bytes,3,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	Image         string                 `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	State         string                 `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`   // created, running, exited, etc.
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // Human readable status
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileUploadResponse) Reset() {
-	*x = FileUploadResponse{}
-	mi := &file_pi_control_proto_msgTypes[42]
+func (x *ComposeService) Reset() {
+	*x = ComposeService{}
+	mi := &file_pi_control_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileUploadResponse) String() string {
+func (x *ComposeService) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileUploadResponse) ProtoMessage() {}
+func (*ComposeService) ProtoMessage() {}
 
-func (x *FileUploadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[42]
+func (x *ComposeService) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3334,70 +8580,75 @@ func (x *FileUploadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileUploadResponse.ProtoReflect.Descriptor instead.
-func (*FileUploadResponse) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use ComposeService.ProtoReflect.Descriptor instead.
+func (*ComposeService) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{104}
 }
 
-func (x *FileUploadResponse) GetSuccess() bool {
+func (x *ComposeService) GetName() string {
 	if x != nil {
-		return x.Success
+		return x.Name
 	}
-	return false
+	return ""
 }
 
-func (x *FileUploadResponse) GetPath() string {
+func (x *ComposeService) GetContainerId() string {
 	if x != nil {
-		return x.Path
+		return x.ContainerId
 	}
 	return ""
 }
 
-func (x *FileUploadResponse) GetBytesWritten() int64 {
+func (x *ComposeService) GetContainerName() string {
 	if x != nil {
-		return x.BytesWritten
+		return x.ContainerName
 	}
-	return 0
+	return ""
 }
 
-func (x *FileUploadResponse) GetError() string {
+func (x *ComposeService) GetImage() string {
 	if x != nil {
-		return x.Error
+		return x.Image
 	}
 	return ""
 }
 
-func (x *FileUploadResponse) GetDuration() float64 {
+func (x *ComposeService) GetState() string {
 	if x != nil {
-		return x.Duration
+		return x.State
 	}
-	return 0
+	return ""
 }
 
-// Download request
-type FileDownloadRequest struct {
+func (x *ComposeService) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ComposeProjectList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`      // Remote file path to download
-	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // Starting byte offset (0 for full file, >0 to resume)
+	Projects      []*ComposeProject      `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileDownloadRequest) Reset() {
-	*x = FileDownloadRequest{}
-	mi := &file_pi_control_proto_msgTypes[43]
+func (x *ComposeProjectList) Reset() {
+	*x = ComposeProjectList{}
+	mi := &file_pi_control_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileDownloadRequest) String() string {
+func (x *ComposeProjectList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileDownloadRequest) ProtoMessage() {}
+func (*ComposeProjectList) ProtoMessage() {}
 
-func (x *FileDownloadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[43]
+func (x *ComposeProjectList) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3408,49 +8659,44 @@ func (x *FileDownloadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileDownloadRequest.ProtoReflect.Descriptor instead.
-func (*FileDownloadRequest) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{43}
-}
-
-func (x *FileDownloadRequest) GetPath() string {
-	if x != nil {
-		return x.Path
-	}
-	return ""
+// Deprecated: Use ComposeProjectList.ProtoReflect.Descriptor instead.
+func (*ComposeProjectList) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *FileDownloadRequest) GetOffset() int64 {
+func (x *ComposeProjectList) GetProjects() []*ComposeProject {
 	if x != nil {
-		return x.Offset
+		return x.Projects
 	}
-	return 0
+	return nil
 }
 
-// Delete request
-type FileDeleteRequest struct {
+type ComposeCommand struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                   // Remote file or directory path to delete
-	IsDirectory   bool                   `protobuf:"varint,2,opt,name=is_directory,json=isDirectory,proto3" json:"is_directory,omitempty"` // True if deleting a directory (will delete recursively)
+	ProjectName   string                 `protobuf:"bytes,1,opt,name=project_name,json=projectName,proto3" json:"project_name,omitempty"`
+	Action        ComposeAction          `protobuf:"varint,2,opt,name=action,proto3,enum=picontrol.ComposeAction" json:"action,omitempty"`
+	WorkingDir    string                 `protobuf:"bytes,3,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`           // Optional, defaults to the project's label
+	ConfigFiles   []string               `protobuf:"bytes,4,rep,name=config_files,json=configFiles,proto3" json:"config_files,omitempty"`        // Optional, defaults to the project's label
+	RemoveOrphans bool                   `protobuf:"varint,5,opt,name=remove_orphans,json=removeOrphans,proto3" json:"remove_orphans,omitempty"` // COMPOSE_UP: remove containers no longer in the compose file
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileDeleteRequest) Reset() {
-	*x = FileDeleteRequest{}
-	mi := &file_pi_control_proto_msgTypes[44]
+func (x *ComposeCommand) Reset() {
+	*x = ComposeCommand{}
+	mi := &file_pi_control_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileDeleteRequest) String() string {
+func (x *ComposeCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileDeleteRequest) ProtoMessage() {}
+func (*ComposeCommand) ProtoMessage() {}
 
-func (x *FileDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[44]
+func (x *ComposeCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3461,50 +8707,73 @@ func (x *FileDeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileDeleteRequest.ProtoReflect.Descriptor instead.
-func (*FileDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{44}
+// Deprecated: Use ComposeCommand.ProtoReflect.Descriptor instead.
+func (*ComposeCommand) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{106}
 }
 
-func (x *FileDeleteRequest) GetPath() string {
+func (x *ComposeCommand) GetProjectName() string {
 	if x != nil {
-		return x.Path
+		return x.ProjectName
 	}
 	return ""
 }
 
-func (x *FileDeleteRequest) GetIsDirectory() bool {
+func (x *ComposeCommand) GetAction() ComposeAction {
 	if x != nil {
-		return x.IsDirectory
+		return x.Action
+	}
+	return ComposeAction_COMPOSE_ACTION_UNSPECIFIED
+}
+
+func (x *ComposeCommand) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *ComposeCommand) GetConfigFiles() []string {
+	if x != nil {
+		return x.ConfigFiles
+	}
+	return nil
+}
+
+func (x *ComposeCommand) GetRemoveOrphans() bool {
+	if x != nil {
+		return x.RemoveOrphans
 	}
 	return false
 }
 
-// Delete response
-type FileDeleteResponse struct {
+// Output line from a compose operation (streamed)
+type ComposeOutput struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`   // Path that was deleted
-	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"` // Error message if failed
+	Line          string                 `protobuf:"bytes,1,opt,name=line,proto3" json:"line,omitempty"`
+	Stream        string                 `protobuf:"bytes,2,opt,name=stream,proto3" json:"stream,omitempty"`        // stdout, stderr
+	Completed     bool                   `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"` // True when the operation is done
+	Success       bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`     // True if the operation succeeded
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileDeleteResponse) Reset() {
-	*x = FileDeleteResponse{}
-	mi := &file_pi_control_proto_msgTypes[45]
+func (x *ComposeOutput) Reset() {
+	*x = ComposeOutput{}
+	mi := &file_pi_control_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileDeleteResponse) String() string {
+func (x *ComposeOutput) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileDeleteResponse) ProtoMessage() {}
+func (*ComposeOutput) ProtoMessage() {}
 
-func (x *FileDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[45]
+func (x *ComposeOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3515,54 +8784,76 @@ func (x *FileDeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileDeleteResponse.ProtoReflect.Descriptor instead.
-func (*FileDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{45}
+// Deprecated: Use ComposeOutput.ProtoReflect.Descriptor instead.
+func (*ComposeOutput) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{107}
 }
 
-func (x *FileDeleteResponse) GetSuccess() bool {
+func (x *ComposeOutput) GetLine() string {
 	if x != nil {
-		return x.Success
+		return x.Line
 	}
-	return false
+	return ""
 }
 
-func (x *FileDeleteResponse) GetPath() string {
+func (x *ComposeOutput) GetStream() string {
 	if x != nil {
-		return x.Path
+		return x.Stream
 	}
 	return ""
 }
 
-func (x *FileDeleteResponse) GetError() string {
+func (x *ComposeOutput) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *ComposeOutput) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ComposeOutput) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-type DockerFilter struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	All           bool                   `protobuf:"varint,1,opt,name=all,proto3" json:"all,omitempty"` // Show all containers (default shows just running)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type SystemUpdateStatus struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	OsName                 string                 `protobuf:"bytes,1,opt,name=os_name,json=osName,proto3" json:"os_name,omitempty"`                             // e.g. "Debian GNU/Linux 12 (bookworm)"
+	KernelVersion          string                 `protobuf:"bytes,2,opt,name=kernel_version,json=kernelVersion,proto3" json:"kernel_version,omitempty"`        // e.g. "6.1.0-rpi7-rpi-v8"
+	Architecture           string                 `protobuf:"bytes,3,opt,name=architecture,proto3" json:"architecture,omitempty"`                               // e.g. "aarch64"
+	UpgradableCount        int32                  `protobuf:"varint,4,opt,name=upgradable_count,json=upgradableCount,proto3" json:"upgradable_count,omitempty"` // Number of packages that can be upgraded (excluding held packages)
+	UpgradablePackages     []*UpgradablePackage   `protobuf:"bytes,5,rep,name=upgradable_packages,json=upgradablePackages,proto3" json:"upgradable_packages,omitempty"`
+	LastUpdate             string                 `protobuf:"bytes,6,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`                                       // Timestamp of last apt update
+	Uptime                 string                 `protobuf:"bytes,7,opt,name=uptime,proto3" json:"uptime,omitempty"`                                                                 // System uptime string
+	RebootRequired         bool                   `protobuf:"varint,8,opt,name=reboot_required,json=rebootRequired,proto3" json:"reboot_required,omitempty"`                          // Set when /var/run/reboot-required exists
+	RebootRequiredPackages []string               `protobuf:"bytes,9,rep,name=reboot_required_packages,json=rebootRequiredPackages,proto3" json:"reboot_required_packages,omitempty"` // Packages listed in reboot-required.pkgs
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *DockerFilter) Reset() {
-	*x = DockerFilter{}
-	mi := &file_pi_control_proto_msgTypes[46]
+func (x *SystemUpdateStatus) Reset() {
+	*x = SystemUpdateStatus{}
+	mi := &file_pi_control_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DockerFilter) String() string {
+func (x *SystemUpdateStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DockerFilter) ProtoMessage() {}
+func (*SystemUpdateStatus) ProtoMessage() {}
 
-func (x *DockerFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[46]
+func (x *SystemUpdateStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3573,84 +8864,98 @@ func (x *DockerFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DockerFilter.ProtoReflect.Descriptor instead.
-func (*DockerFilter) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{46}
+// Deprecated: Use SystemUpdateStatus.ProtoReflect.Descriptor instead.
+func (*SystemUpdateStatus) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{108}
 }
 
-func (x *DockerFilter) GetAll() bool {
+func (x *SystemUpdateStatus) GetOsName() string {
 	if x != nil {
-		return x.All
+		return x.OsName
 	}
-	return false
+	return ""
 }
 
-type ContainerId struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SystemUpdateStatus) GetKernelVersion() string {
+	if x != nil {
+		return x.KernelVersion
+	}
+	return ""
 }
 
-func (x *ContainerId) Reset() {
-	*x = ContainerId{}
-	mi := &file_pi_control_proto_msgTypes[47]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *SystemUpdateStatus) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
 }
 
-func (x *ContainerId) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SystemUpdateStatus) GetUpgradableCount() int32 {
+	if x != nil {
+		return x.UpgradableCount
+	}
+	return 0
 }
 
-func (*ContainerId) ProtoMessage() {}
-
-func (x *ContainerId) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[47]
+func (x *SystemUpdateStatus) GetUpgradablePackages() []*UpgradablePackage {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.UpgradablePackages
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ContainerId.ProtoReflect.Descriptor instead.
-func (*ContainerId) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{47}
+func (x *SystemUpdateStatus) GetLastUpdate() string {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return ""
 }
 
-func (x *ContainerId) GetId() string {
+func (x *SystemUpdateStatus) GetUptime() string {
 	if x != nil {
-		return x.Id
+		return x.Uptime
 	}
 	return ""
 }
 
-type ContainerList struct {
+func (x *SystemUpdateStatus) GetRebootRequired() bool {
+	if x != nil {
+		return x.RebootRequired
+	}
+	return false
+}
+
+func (x *SystemUpdateStatus) GetRebootRequiredPackages() []string {
+	if x != nil {
+		return x.RebootRequiredPackages
+	}
+	return nil
+}
+
+// Delayed reboot or shutdown request
+type PowerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Containers    []*ContainerInfo       `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty"`
+	DelayMinutes  int32                  `protobuf:"varint,1,opt,name=delay_minutes,json=delayMinutes,proto3" json:"delay_minutes,omitempty"` // 0 = immediately
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                                // Broadcast to logged-in users (wall)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ContainerList) Reset() {
-	*x = ContainerList{}
-	mi := &file_pi_control_proto_msgTypes[48]
+func (x *PowerRequest) Reset() {
+	*x = PowerRequest{}
+	mi := &file_pi_control_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ContainerList) String() string {
+func (x *PowerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ContainerList) ProtoMessage() {}
+func (*PowerRequest) ProtoMessage() {}
 
-func (x *ContainerList) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[48]
+func (x *PowerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3661,46 +8966,51 @@ func (x *ContainerList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ContainerList.ProtoReflect.Descriptor instead.
-func (*ContainerList) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{48}
+// Deprecated: Use PowerRequest.ProtoReflect.Descriptor instead.
+func (*PowerRequest) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{109}
 }
 
-func (x *ContainerList) GetContainers() []*ContainerInfo {
+func (x *PowerRequest) GetDelayMinutes() int32 {
 	if x != nil {
-		return x.Containers
+		return x.DelayMinutes
 	}
-	return nil
+	return 0
 }
 
-type ContainerInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Names         []string               `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
-	Image         string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
-	State         string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`   // created, restarting, running, removing, paused, exited, dead
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // Human readable status
-	Created       int64                  `protobuf:"varint,6,opt,name=created,proto3" json:"created,omitempty"`
-	Ports         []string               `protobuf:"bytes,7,rep,name=ports,proto3" json:"ports,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *PowerRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type UpgradablePackage struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CurrentVersion string                 `protobuf:"bytes,2,opt,name=current_version,json=currentVersion,proto3" json:"current_version,omitempty"`
+	NewVersion     string                 `protobuf:"bytes,3,opt,name=new_version,json=newVersion,proto3" json:"new_version,omitempty"`
+	Architecture   string                 `protobuf:"bytes,4,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	Held           bool                   `protobuf:"varint,5,opt,name=held,proto3" json:"held,omitempty"` // Held packages are not upgraded or counted in upgradable_count
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *ContainerInfo) Reset() {
-	*x = ContainerInfo{}
-	mi := &file_pi_control_proto_msgTypes[49]
+func (x *UpgradablePackage) Reset() {
+	*x = UpgradablePackage{}
+	mi := &file_pi_control_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ContainerInfo) String() string {
+func (x *UpgradablePackage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ContainerInfo) ProtoMessage() {}
+func (*UpgradablePackage) ProtoMessage() {}
 
-func (x *ContainerInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[49]
+func (x *UpgradablePackage) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3711,84 +9021,75 @@ func (x *ContainerInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ContainerInfo.ProtoReflect.Descriptor instead.
-func (*ContainerInfo) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{49}
+// Deprecated: Use UpgradablePackage.ProtoReflect.Descriptor instead.
+func (*UpgradablePackage) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{110}
 }
 
-func (x *ContainerInfo) GetId() string {
+func (x *UpgradablePackage) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ContainerInfo) GetNames() []string {
-	if x != nil {
-		return x.Names
-	}
-	return nil
-}
-
-func (x *ContainerInfo) GetImage() string {
+func (x *UpgradablePackage) GetCurrentVersion() string {
 	if x != nil {
-		return x.Image
+		return x.CurrentVersion
 	}
 	return ""
 }
 
-func (x *ContainerInfo) GetState() string {
+func (x *UpgradablePackage) GetNewVersion() string {
 	if x != nil {
-		return x.State
+		return x.NewVersion
 	}
 	return ""
 }
 
-func (x *ContainerInfo) GetStatus() string {
+func (x *UpgradablePackage) GetArchitecture() string {
 	if x != nil {
-		return x.Status
+		return x.Architecture
 	}
 	return ""
 }
 
-func (x *ContainerInfo) GetCreated() int64 {
-	if x != nil {
-		return x.Created
-	}
-	return 0
-}
-
-func (x *ContainerInfo) GetPorts() []string {
+func (x *UpgradablePackage) GetHeld() bool {
 	if x != nil {
-		return x.Ports
+		return x.Held
 	}
-	return nil
+	return false
 }
 
-type LogRequest struct {
+// Unattended upgrade schedule, evaluated in the Pi's local time
+type UpgradePolicy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
-	Follow        bool                   `protobuf:"varint,2,opt,name=follow,proto3" json:"follow,omitempty"` // Stream logs
-	Tail          int32                  `protobuf:"varint,3,opt,name=tail,proto3" json:"tail,omitempty"`     // Number of lines to show from the end
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Days          []int32                `protobuf:"varint,2,rep,packed,name=days,proto3" json:"days,omitempty"`                                 // Days of the week the window opens (0 = Sunday); empty means every day
+	WindowStart   string                 `protobuf:"bytes,3,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`        // "HH:MM"
+	WindowMinutes int32                  `protobuf:"varint,4,opt,name=window_minutes,json=windowMinutes,proto3" json:"window_minutes,omitempty"` // Window length (default 120); runs only start inside the window
+	SecurityOnly  bool                   `protobuf:"varint,5,opt,name=security_only,json=securityOnly,proto3" json:"security_only,omitempty"`    // Only install security updates
+	Reboot        RebootPolicy           `protobuf:"varint,6,opt,name=reboot,proto3,enum=picontrol.RebootPolicy" json:"reboot,omitempty"`
+	NextRun       int64                  `protobuf:"varint,7,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"` // Output only: start of the next window (Unix timestamp, 0 if disabled)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogRequest) Reset() {
-	*x = LogRequest{}
-	mi := &file_pi_control_proto_msgTypes[50]
+func (x *UpgradePolicy) Reset() {
+	*x = UpgradePolicy{}
+	mi := &file_pi_control_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogRequest) String() string {
+func (x *UpgradePolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogRequest) ProtoMessage() {}
+func (*UpgradePolicy) ProtoMessage() {}
 
-func (x *LogRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[50]
+func (x *UpgradePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3799,60 +9100,95 @@ func (x *LogRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogRequest.ProtoReflect.Descriptor instead.
-func (*LogRequest) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{50}
+// Deprecated: Use UpgradePolicy.ProtoReflect.Descriptor instead.
+func (*UpgradePolicy) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{111}
 }
 
-func (x *LogRequest) GetContainerId() string {
+func (x *UpgradePolicy) GetEnabled() bool {
 	if x != nil {
-		return x.ContainerId
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *UpgradePolicy) GetDays() []int32 {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *UpgradePolicy) GetWindowStart() string {
+	if x != nil {
+		return x.WindowStart
 	}
 	return ""
 }
 
-func (x *LogRequest) GetFollow() bool {
+func (x *UpgradePolicy) GetWindowMinutes() int32 {
 	if x != nil {
-		return x.Follow
+		return x.WindowMinutes
+	}
+	return 0
+}
+
+func (x *UpgradePolicy) GetSecurityOnly() bool {
+	if x != nil {
+		return x.SecurityOnly
 	}
 	return false
 }
 
-func (x *LogRequest) GetTail() int32 {
+func (x *UpgradePolicy) GetReboot() RebootPolicy {
 	if x != nil {
-		return x.Tail
+		return x.Reboot
+	}
+	return RebootPolicy_REBOOT_NEVER
+}
+
+func (x *UpgradePolicy) GetNextRun() int64 {
+	if x != nil {
+		return x.NextRun
 	}
 	return 0
 }
 
-type SystemUpdateStatus struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	OsName             string                 `protobuf:"bytes,1,opt,name=os_name,json=osName,proto3" json:"os_name,omitempty"`                             // e.g. "Debian GNU/Linux 12 (bookworm)"
-	KernelVersion      string                 `protobuf:"bytes,2,opt,name=kernel_version,json=kernelVersion,proto3" json:"kernel_version,omitempty"`        // e.g. "6.1.0-rpi7-rpi-v8"
-	Architecture       string                 `protobuf:"bytes,3,opt,name=architecture,proto3" json:"architecture,omitempty"`                               // e.g. "aarch64"
-	UpgradableCount    int32                  `protobuf:"varint,4,opt,name=upgradable_count,json=upgradableCount,proto3" json:"upgradable_count,omitempty"` // Number of packages that can be upgraded
-	UpgradablePackages []*UpgradablePackage   `protobuf:"bytes,5,rep,name=upgradable_packages,json=upgradablePackages,proto3" json:"upgradable_packages,omitempty"`
-	LastUpdate         string                 `protobuf:"bytes,6,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"` // Timestamp of last apt update
-	Uptime             string                 `protobuf:"bytes,7,opt,name=uptime,proto3" json:"uptime,omitempty"`                           // System uptime string
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+// Record of one upgrade run
+type UpgradeRun struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Trigger         string                 `protobuf:"bytes,2,opt,name=trigger,proto3" json:"trigger,omitempty"`                       // "schedule" or "manual"
+	StartedAt       int64                  `protobuf:"varint,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"` // Unix timestamps
+	FinishedAt      int64                  `protobuf:"varint,4,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Success         bool                   `protobuf:"varint,5,opt,name=success,proto3" json:"success,omitempty"`
+	Error           string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	SecurityOnly    bool                   `protobuf:"varint,7,opt,name=security_only,json=securityOnly,proto3" json:"security_only,omitempty"`
+	Upgraded        []*UpgradablePackage   `protobuf:"bytes,8,rep,name=upgraded,proto3" json:"upgraded,omitempty"` // Packages upgraded by the run
+	Held            []*UpgradablePackage   `protobuf:"bytes,9,rep,name=held,proto3" json:"held,omitempty"`         // Upgradable packages skipped because they are held
+	RebootRequired  bool                   `protobuf:"varint,10,opt,name=reboot_required,json=rebootRequired,proto3" json:"reboot_required,omitempty"`
+	RebootScheduled bool                   `protobuf:"varint,11,opt,name=reboot_scheduled,json=rebootScheduled,proto3" json:"reboot_scheduled,omitempty"`
+	Output          string                 `protobuf:"bytes,12,opt,name=output,proto3" json:"output,omitempty"` // Package manager output (truncated)
+	JobId           string                 `protobuf:"bytes,13,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *SystemUpdateStatus) Reset() {
-	*x = SystemUpdateStatus{}
-	mi := &file_pi_control_proto_msgTypes[51]
+func (x *UpgradeRun) Reset() {
+	*x = UpgradeRun{}
+	mi := &file_pi_control_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SystemUpdateStatus) String() string {
+func (x *UpgradeRun) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SystemUpdateStatus) ProtoMessage() {}
+func (*UpgradeRun) ProtoMessage() {}
 
-func (x *SystemUpdateStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[51]
+func (x *UpgradeRun) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3863,126 +9199,144 @@ func (x *SystemUpdateStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SystemUpdateStatus.ProtoReflect.Descriptor instead.
-func (*SystemUpdateStatus) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{51}
+// Deprecated: Use UpgradeRun.ProtoReflect.Descriptor instead.
+func (*UpgradeRun) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{112}
 }
 
-func (x *SystemUpdateStatus) GetOsName() string {
+func (x *UpgradeRun) GetId() string {
 	if x != nil {
-		return x.OsName
+		return x.Id
 	}
 	return ""
 }
 
-func (x *SystemUpdateStatus) GetKernelVersion() string {
+func (x *UpgradeRun) GetTrigger() string {
 	if x != nil {
-		return x.KernelVersion
+		return x.Trigger
 	}
 	return ""
 }
 
-func (x *SystemUpdateStatus) GetArchitecture() string {
+func (x *UpgradeRun) GetStartedAt() int64 {
 	if x != nil {
-		return x.Architecture
+		return x.StartedAt
 	}
-	return ""
+	return 0
 }
 
-func (x *SystemUpdateStatus) GetUpgradableCount() int32 {
+func (x *UpgradeRun) GetFinishedAt() int64 {
 	if x != nil {
-		return x.UpgradableCount
+		return x.FinishedAt
 	}
 	return 0
 }
 
-func (x *SystemUpdateStatus) GetUpgradablePackages() []*UpgradablePackage {
+func (x *UpgradeRun) GetSuccess() bool {
 	if x != nil {
-		return x.UpgradablePackages
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-func (x *SystemUpdateStatus) GetLastUpdate() string {
+func (x *UpgradeRun) GetError() string {
 	if x != nil {
-		return x.LastUpdate
+		return x.Error
 	}
 	return ""
 }
 
-func (x *SystemUpdateStatus) GetUptime() string {
+func (x *UpgradeRun) GetSecurityOnly() bool {
 	if x != nil {
-		return x.Uptime
+		return x.SecurityOnly
 	}
-	return ""
-}
-
-type UpgradablePackage struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	CurrentVersion string                 `protobuf:"bytes,2,opt,name=current_version,json=currentVersion,proto3" json:"current_version,omitempty"`
-	NewVersion     string                 `protobuf:"bytes,3,opt,name=new_version,json=newVersion,proto3" json:"new_version,omitempty"`
-	Architecture   string                 `protobuf:"bytes,4,opt,name=architecture,proto3" json:"architecture,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	return false
 }
 
-func (x *UpgradablePackage) Reset() {
-	*x = UpgradablePackage{}
-	mi := &file_pi_control_proto_msgTypes[52]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *UpgradeRun) GetUpgraded() []*UpgradablePackage {
+	if x != nil {
+		return x.Upgraded
+	}
+	return nil
 }
 
-func (x *UpgradablePackage) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UpgradeRun) GetHeld() []*UpgradablePackage {
+	if x != nil {
+		return x.Held
+	}
+	return nil
 }
 
-func (*UpgradablePackage) ProtoMessage() {}
-
-func (x *UpgradablePackage) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[52]
+func (x *UpgradeRun) GetRebootRequired() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.RebootRequired
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use UpgradablePackage.ProtoReflect.Descriptor instead.
-func (*UpgradablePackage) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{52}
+func (x *UpgradeRun) GetRebootScheduled() bool {
+	if x != nil {
+		return x.RebootScheduled
+	}
+	return false
 }
 
-func (x *UpgradablePackage) GetName() string {
+func (x *UpgradeRun) GetOutput() string {
 	if x != nil {
-		return x.Name
+		return x.Output
 	}
 	return ""
 }
 
-func (x *UpgradablePackage) GetCurrentVersion() string {
+func (x *UpgradeRun) GetJobId() string {
 	if x != nil {
-		return x.CurrentVersion
+		return x.JobId
 	}
 	return ""
 }
 
-func (x *UpgradablePackage) GetNewVersion() string {
+type UpgradeRunList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Runs          []*UpgradeRun          `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpgradeRunList) Reset() {
+	*x = UpgradeRunList{}
+	mi := &file_pi_control_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpgradeRunList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeRunList) ProtoMessage() {}
+
+func (x *UpgradeRunList) ProtoReflect() protoreflect.Message {
+	mi := &file_pi_control_proto_msgTypes[113]
 	if x != nil {
-		return x.NewVersion
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UpgradablePackage) GetArchitecture() string {
+// Deprecated: Use UpgradeRunList.ProtoReflect.Descriptor instead.
+func (*UpgradeRunList) Descriptor() ([]byte, []int) {
+	return file_pi_control_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *UpgradeRunList) GetRuns() []*UpgradeRun {
 	if x != nil {
-		return x.Architecture
+		return x.Runs
 	}
-	return ""
+	return nil
 }
 
 type UpgradeProgress struct {
@@ -3992,13 +9346,14 @@ type UpgradeProgress struct {
 	Percent       int32                  `protobuf:"varint,3,opt,name=percent,proto3" json:"percent,omitempty"`                         // Progress percentage (0-100) if available
 	IsComplete    bool                   `protobuf:"varint,4,opt,name=is_complete,json=isComplete,proto3" json:"is_complete,omitempty"` // Whether the entire operation is done
 	Success       bool                   `protobuf:"varint,5,opt,name=success,proto3" json:"success,omitempty"`                         // Whether it completed successfully
+	JobId         string                 `protobuf:"bytes,6,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`                 // Package job producing this output
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpgradeProgress) Reset() {
 	*x = UpgradeProgress{}
-	mi := &file_pi_control_proto_msgTypes[53]
+	mi := &file_pi_control_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4010,7 +9365,7 @@ func (x *UpgradeProgress) String() string {
 func (*UpgradeProgress) ProtoMessage() {}
 
 func (x *UpgradeProgress) ProtoReflect() protoreflect.Message {
-	mi := &file_pi_control_proto_msgTypes[53]
+	mi := &file_pi_control_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4023,7 +9378,7 @@ func (x *UpgradeProgress) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpgradeProgress.ProtoReflect.Descriptor instead.
 func (*UpgradeProgress) Descriptor() ([]byte, []int) {
-	return file_pi_control_proto_rawDescGZIP(), []int{53}
+	return file_pi_control_proto_rawDescGZIP(), []int{114}
 }
 
 func (x *UpgradeProgress) GetLine() string {
@@ -4061,12 +9416,19 @@ func (x *UpgradeProgress) GetSuccess() bool {
 	return false
 }
 
+func (x *UpgradeProgress) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
 var File_pi_control_proto protoreflect.FileDescriptor
 
 const file_pi_control_proto_rawDesc = "" +
 	"\n" +
 	"\x10pi_control.proto\x12\tpicontrol\"\a\n" +
-	"\x05Empty\"\xf6\x04\n" +
+	"\x05Empty\"\xb1\x05\n" +
 	"\tLiveStats\x12\x1b\n" +
 	"\tcpu_usage\x18\x01 \x01(\x01R\bcpuUsage\x12 \n" +
 	"\fcpu_per_core\x18\x02 \x03(\x01R\n" +
@@ -4091,7 +9453,10 @@ const file_pi_control_proto_rawDesc = "" +
 	"\x0enet_bytes_recv\x18\x10 \x01(\x04R\fnetBytesRecv\x12;\n" +
 	"\rtop_processes\x18\x11 \x03(\v2\x16.picontrol.ProcessInfoR\ftopProcesses\x12\x1c\n" +
 	"\ttimestamp\x18\x12 \x01(\x03R\ttimestamp\x12.\n" +
-	"\adisk_io\x18\x13 \x03(\v2\x15.picontrol.DiskIOStatR\x06diskIo\"\x80\x02\n" +
+	"\adisk_io\x18\x13 \x03(\v2\x15.picontrol.DiskIOStatR\x06diskIo\x129\n" +
+	"\n" +
+	"interfaces\x18\x14 \x03(\v2\x19.picontrol.InterfaceStatsR\n" +
+	"interfaces\"\x80\x02\n" +
 	"\vProcessInfo\x12\x10\n" +
 	"\x03pid\x18\x01 \x01(\x05R\x03pid\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
@@ -4127,12 +9492,13 @@ const file_pi_control_proto_rawDesc = "" +
 	"\x06levels\x18\x01 \x03(\tR\x06levels\x12\x18\n" +
 	"\aservice\x18\x02 \x01(\tR\aservice\x12\x1d\n" +
 	"\n" +
-	"tail_lines\x18\x03 \x01(\x05R\ttailLines\"r\n" +
+	"tail_lines\x18\x03 \x01(\x05R\ttailLines\"\x8a\x01\n" +
 	"\bLogEntry\x12\x1c\n" +
 	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x14\n" +
 	"\x05level\x18\x02 \x01(\tR\x05level\x12\x18\n" +
 	"\aservice\x18\x03 \x01(\tR\aservice\x12\x18\n" +
-	"\amessage\x18\x04 \x01(\tR\amessage\"D\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x16\n" +
+	"\x06stream\x18\x05 \x01(\tR\x06stream\"D\n" +
 	"\bDiskInfo\x128\n" +
 	"\n" +
 	"partitions\x18\x01 \x03(\v2\x18.picontrol.DiskPartitionR\n" +
@@ -4179,7 +9545,26 @@ const file_pi_control_proto_rawDesc = "" +
 	"remotePort\x12\x16\n" +
 	"\x06status\x18\x06 \x01(\tR\x06status\x12\x10\n" +
 	"\x03pid\x18\a \x01(\x05R\x03pid\x12!\n" +
-	"\fprocess_name\x18\b \x01(\tR\vprocessName\"W\n" +
+	"\fprocess_name\x18\b \x01(\tR\vprocessName\"6\n" +
+	"\x16InterfaceConfigRequest\x12\x1c\n" +
+	"\tinterface\x18\x01 \x01(\tR\tinterface\"\xa9\x03\n" +
+	"\x0fInterfaceConfig\x12\x1c\n" +
+	"\tinterface\x18\x01 \x01(\tR\tinterface\x12\x12\n" +
+	"\x04dhcp\x18\x02 \x01(\bR\x04dhcp\x12%\n" +
+	"\x0eipv4_addresses\x18\x03 \x03(\tR\ripv4Addresses\x12!\n" +
+	"\fipv4_gateway\x18\x04 \x01(\tR\vipv4Gateway\x12%\n" +
+	"\x0eipv6_addresses\x18\x05 \x03(\tR\ripv6Addresses\x12!\n" +
+	"\fipv6_gateway\x18\x06 \x01(\tR\vipv6Gateway\x12\x1f\n" +
+	"\vdns_servers\x18\a \x03(\tR\n" +
+	"dnsServers\x12'\n" +
+	"\x0fconfirm_timeout\x18\b \x01(\x05R\x0econfirmTimeout\x12\x18\n" +
+	"\abackend\x18\t \x01(\tR\abackend\x12\x18\n" +
+	"\apending\x18\n" +
+	" \x01(\bR\apending\x12\x1b\n" +
+	"\trevert_at\x18\v \x01(\x03R\brevertAt\x12\x1f\n" +
+	"\vlast_change\x18\f \x01(\tR\n" +
+	"lastChange\x12\x14\n" +
+	"\x05error\x18\r \x01(\tR\x05error\"W\n" +
 	"\rPackageFilter\x12\x1f\n" +
 	"\vsearch_term\x18\x01 \x01(\tR\n" +
 	"searchTerm\x12%\n" +
@@ -4194,9 +9579,10 @@ const file_pi_control_proto_rawDesc = "" +
 	"\x0einstalled_size\x18\a \x01(\x04R\rinstalledSize\x12\x18\n" +
 	"\asection\x18\b \x01(\tR\asection\"A\n" +
 	"\vPackageList\x122\n" +
-	"\bpackages\x18\x01 \x03(\v2\x16.picontrol.PackageInfoR\bpackages\"3\n" +
+	"\bpackages\x18\x01 \x03(\v2\x16.picontrol.PackageInfoR\bpackages\"n\n" +
 	"\x0ePackageCommand\x12!\n" +
-	"\fpackage_name\x18\x01 \x01(\tR\vpackageName\":\n" +
+	"\fpackage_name\x18\x01 \x01(\tR\vpackageName\x129\n" +
+	"\toperation\x18\x02 \x01(\x0e2\x1b.picontrol.PackageOperationR\toperation\":\n" +
 	"\x15PackageDetailsRequest\x12!\n" +
 	"\fpackage_name\x18\x01 \x01(\tR\vpackageName\"\xe7\x03\n" +
 	"\x0ePackageDetails\x12\x12\n" +
@@ -4228,14 +9614,130 @@ const file_pi_control_proto_rawDesc = "" +
 	"recommends\x18\x04 \x03(\tR\n" +
 	"recommends\x12\x1a\n" +
 	"\bsuggests\x18\x05 \x03(\tR\bsuggests\x12\x1c\n" +
-	"\tconflicts\x18\x06 \x03(\tR\tconflicts\"\xb7\x01\n" +
+	"\tconflicts\x18\x06 \x03(\tR\tconflicts\"\xc0\x03\n" +
+	"\x11PackageSimulation\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x125\n" +
+	"\ainstall\x18\x03 \x03(\v2\x1b.picontrol.SimulatedPackageR\ainstall\x125\n" +
+	"\aupgrade\x18\x04 \x03(\v2\x1b.picontrol.SimulatedPackageR\aupgrade\x123\n" +
+	"\x06remove\x18\x05 \x03(\v2\x1b.picontrol.SimulatedPackageR\x06remove\x128\n" +
+	"\theld_back\x18\x06 \x03(\v2\x1b.picontrol.SimulatedPackageR\bheldBack\x12$\n" +
+	"\rautoremovable\x18\a \x03(\tR\rautoremovable\x12+\n" +
+	"\x11essential_removed\x18\b \x03(\tR\x10essentialRemoved\x12#\n" +
+	"\rdownload_size\x18\t \x01(\x03R\fdownloadSize\x12&\n" +
+	"\x0fdisk_size_delta\x18\n" +
+	" \x01(\x03R\rdiskSizeDelta\"\xd8\x01\n" +
+	"\x10SimulatedPackage\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12'\n" +
+	"\x0fcurrent_version\x18\x02 \x01(\tR\x0ecurrentVersion\x12\x1f\n" +
+	"\vnew_version\x18\x03 \x01(\tR\n" +
+	"newVersion\x12\"\n" +
+	"\farchitecture\x18\x04 \x01(\tR\farchitecture\x12#\n" +
+	"\rdownload_size\x18\x05 \x01(\x03R\fdownloadSize\x12\x1d\n" +
+	"\n" +
+	"size_delta\x18\x06 \x01(\x03R\tsizeDelta\"\x8a\x02\n" +
+	"\rPackageSource\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04file\x18\x02 \x01(\tR\x04file\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\x12\x14\n" +
+	"\x05types\x18\x05 \x03(\tR\x05types\x12\x12\n" +
+	"\x04uris\x18\x06 \x03(\tR\x04uris\x12\x16\n" +
+	"\x06suites\x18\a \x03(\tR\x06suites\x12\x1e\n" +
+	"\n" +
+	"components\x18\b \x03(\tR\n" +
+	"components\x12$\n" +
+	"\rarchitectures\x18\t \x03(\tR\rarchitectures\x12\x1b\n" +
+	"\tsigned_by\x18\n" +
+	" \x01(\tR\bsignedBy\"G\n" +
+	"\x11PackageSourceList\x122\n" +
+	"\asources\x18\x01 \x03(\v2\x18.picontrol.PackageSourceR\asources\"\xa0\x01\n" +
+	"\x17AddPackageSourceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x120\n" +
+	"\x06source\x18\x02 \x01(\v2\x18.picontrol.PackageSourceR\x06source\x12\x16\n" +
+	"\x06deb822\x18\x03 \x01(\bR\x06deb822\x12'\n" +
+	"\x0fskip_validation\x18\x04 \x01(\bR\x0eskipValidation\"?\n" +
+	"\x13PackageSourceToggle\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"!\n" +
+	"\x0fPackageSourceId\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"n\n" +
+	"\x11SigningKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\bkey_data\x18\x02 \x01(\fR\akeyData\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x18\n" +
+	"\areplace\x18\x04 \x01(\bR\areplace\"]\n" +
+	"\x15PackageHistoryRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x18\n" +
+	"\apackage\x18\x02 \x01(\tR\apackage\x12\x14\n" +
+	"\x05since\x18\x03 \x01(\x03R\x05since\"\xbf\x01\n" +
+	"\rPackageChange\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\"\n" +
+	"\farchitecture\x18\x02 \x01(\tR\farchitecture\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1f\n" +
+	"\vold_version\x18\x04 \x01(\tR\n" +
+	"oldVersion\x12\x1f\n" +
+	"\vnew_version\x18\x05 \x01(\tR\n" +
+	"newVersion\x12\x1c\n" +
+	"\tautomatic\x18\x06 \x01(\bR\tautomatic\"\xf6\x01\n" +
+	"\x12PackageTransaction\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x02 \x01(\x03R\aendTime\x12!\n" +
+	"\fcommand_line\x18\x03 \x01(\tR\vcommandLine\x12!\n" +
+	"\frequested_by\x18\x04 \x01(\tR\vrequestedBy\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\x122\n" +
+	"\achanges\x18\x06 \x03(\v2\x18.picontrol.PackageChangeR\achanges\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"S\n" +
+	"\x0ePackageHistory\x12A\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1d.picontrol.PackageTransactionR\ftransactions\";\n" +
+	"\vPackageHold\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"?\n" +
+	"\x0fPackageHoldList\x12,\n" +
+	"\x05holds\x18\x01 \x03(\v2\x16.picontrol.PackageHoldR\x05holds\"M\n" +
+	"\x12PackageHoldRequest\x12#\n" +
+	"\rpackage_names\x18\x01 \x03(\tR\fpackageNames\x12\x12\n" +
+	"\x04hold\x18\x02 \x01(\bR\x04hold\"\x82\x01\n" +
+	"\n" +
+	"PackagePin\x12\x18\n" +
+	"\apackage\x18\x01 \x01(\tR\apackage\x12\x10\n" +
+	"\x03pin\x18\x02 \x01(\tR\x03pin\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x05R\bpriority\x12\x12\n" +
+	"\x04file\x18\x04 \x01(\tR\x04file\x12\x18\n" +
+	"\amanaged\x18\x05 \x01(\bR\amanaged\";\n" +
+	"\x0ePackagePinList\x12)\n" +
+	"\x04pins\x18\x01 \x03(\v2\x15.picontrol.PackagePinR\x04pins\"\xeb\x01\n" +
 	"\x13PackageOperationLog\x12\x1c\n" +
 	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x14\n" +
 	"\x05level\x18\x02 \x01(\tR\x05level\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1a\n" +
 	"\bprogress\x18\x04 \x01(\x01R\bprogress\x12\x1c\n" +
 	"\tcompleted\x18\x05 \x01(\bR\tcompleted\x12\x18\n" +
-	"\asuccess\x18\x06 \x01(\bR\asuccess\"\xa4\x01\n" +
+	"\asuccess\x18\x06 \x01(\bR\asuccess\x12\x1b\n" +
+	"\texit_code\x18\a \x01(\x05R\bexitCode\x12\x15\n" +
+	"\x06job_id\x18\b \x01(\tR\x05jobId\"\xe0\x02\n" +
+	"\n" +
+	"PackageJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12)\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x13.picontrol.JobStateR\x05state\x12\x18\n" +
+	"\asuccess\x18\x04 \x01(\bR\asuccess\x12\x1c\n" +
+	"\tcancelled\x18\x05 \x01(\bR\tcancelled\x12\x1b\n" +
+	"\texit_code\x18\x06 \x01(\x05R\bexitCode\x12\x1a\n" +
+	"\bprogress\x18\a \x01(\x01R\bprogress\x12%\n" +
+	"\x0estatus_message\x18\b \x01(\tR\rstatusMessage\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\n" +
+	" \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\v \x01(\x03R\n" +
+	"finishedAt\";\n" +
+	"\x0ePackageJobList\x12)\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x15.picontrol.PackageJobR\x04jobs\"\x1e\n" +
+	"\fPackageJobId\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa4\x01\n" +
 	"\n" +
 	"DiskIOStat\x12\x16\n" +
 	"\x06device\x18\x01 \x01(\tR\x06device\x12\x1d\n" +
@@ -4246,10 +9748,27 @@ const file_pi_control_proto_rawDesc = "" +
 	"\n" +
 	"read_count\x18\x04 \x01(\x04R\treadCount\x12\x1f\n" +
 	"\vwrite_count\x18\x05 \x01(\x04R\n" +
-	"writeCount\"@\n" +
+	"writeCount\"\x90\x02\n" +
+	"\x0eInterfaceStats\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\brx_bytes\x18\x02 \x01(\x04R\arxBytes\x12\x19\n" +
+	"\btx_bytes\x18\x03 \x01(\x04R\atxBytes\x12\x1d\n" +
+	"\n" +
+	"rx_packets\x18\x04 \x01(\x04R\trxPackets\x12\x1d\n" +
+	"\n" +
+	"tx_packets\x18\x05 \x01(\x04R\ttxPackets\x12\x1b\n" +
+	"\trx_errors\x18\x06 \x01(\x04R\brxErrors\x12\x1b\n" +
+	"\ttx_errors\x18\a \x01(\x04R\btxErrors\x12\x1d\n" +
+	"\n" +
+	"rx_dropped\x18\b \x01(\x04R\trxDropped\x12\x1d\n" +
+	"\n" +
+	"tx_dropped\x18\t \x01(\x04R\ttxDropped\"\xae\x01\n" +
 	"\vVersionInfo\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\tR\aversion\x12\x17\n" +
-	"\ais_root\x18\x02 \x01(\bR\x06isRoot\"r\n" +
+	"\ais_root\x18\x02 \x01(\bR\x06isRoot\x12\x1b\n" +
+	"\tboot_time\x18\x03 \x01(\x03R\bbootTime\x12%\n" +
+	"\x0euptime_seconds\x18\x04 \x01(\x03R\ruptimeSeconds\x12(\n" +
+	"\x10last_boot_reason\x18\x05 \x01(\tR\x0elastBootReason\"r\n" +
 	"\vPingRequest\x12\x12\n" +
 	"\x04host\x18\x01 \x01(\tR\x04host\x12\x14\n" +
 	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x18\n" +
@@ -4278,26 +9797,38 @@ const file_pi_control_proto_rawDesc = "" +
 	"\vmax_latency\x18\x05 \x01(\x01R\n" +
 	"maxLatency\x12\x1f\n" +
 	"\vavg_latency\x18\x06 \x01(\x01R\n" +
-	"avgLatency\"\x8f\x01\n" +
+	"avgLatency\"\x9f\x02\n" +
 	"\x0fPortScanRequest\x12\x12\n" +
 	"\x04host\x18\x01 \x01(\tR\x04host\x12\x14\n" +
 	"\x05ports\x18\x02 \x03(\x05R\x05ports\x12\x1d\n" +
 	"\n" +
 	"start_port\x18\x03 \x01(\x05R\tstartPort\x12\x19\n" +
 	"\bend_port\x18\x04 \x01(\x05R\aendPort\x12\x18\n" +
-	"\atimeout\x18\x05 \x01(\x05R\atimeout\"\xa2\x01\n" +
+	"\atimeout\x18\x05 \x01(\x05R\atimeout\x12 \n" +
+	"\vconcurrency\x18\x06 \x01(\x05R\vconcurrency\x12\x12\n" +
+	"\x04rate\x18\a \x01(\x05R\x04rate\x127\n" +
+	"\bprotocol\x18\b \x01(\x0e2\x1b.picontrol.PortScanProtocolR\bprotocol\x12\x1f\n" +
+	"\vgrab_banner\x18\t \x01(\bR\n" +
+	"grabBanner\"\x9a\x02\n" +
 	"\x10PortScanResponse\x12\x12\n" +
 	"\x04port\x18\x01 \x01(\x05R\x04port\x12\x12\n" +
 	"\x04open\x18\x02 \x01(\bR\x04open\x12\x18\n" +
 	"\aservice\x18\x03 \x01(\tR\aservice\x12\x14\n" +
 	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1a\n" +
 	"\bfinished\x18\x05 \x01(\bR\bfinished\x12\x1a\n" +
-	"\bprogress\x18\x06 \x01(\x05R\bprogress\"I\n" +
+	"\bprogress\x18\x06 \x01(\x05R\bprogress\x12\x12\n" +
+	"\x04host\x18\a \x01(\tR\x04host\x12\x1a\n" +
+	"\bprotocol\x18\b \x01(\tR\bprotocol\x12\x14\n" +
+	"\x05state\x18\t \x01(\tR\x05state\x12\x16\n" +
+	"\x06banner\x18\n" +
+	" \x01(\tR\x06banner\x12\x18\n" +
+	"\alatency\x18\v \x01(\x01R\alatency\"e\n" +
 	"\n" +
 	"DNSRequest\x12\x1a\n" +
 	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x1f\n" +
 	"\vrecord_type\x18\x02 \x01(\tR\n" +
-	"recordType\"\xc6\x01\n" +
+	"recordType\x12\x1a\n" +
+	"\bresolver\x18\x03 \x01(\tR\bresolver\"\xf4\x01\n" +
 	"\vDNSResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1a\n" +
 	"\bhostname\x18\x02 \x01(\tR\bhostname\x12\x1c\n" +
@@ -4305,16 +9836,24 @@ const file_pi_control_proto_rawDesc = "" +
 	"\arecords\x18\x04 \x03(\v2\x14.picontrol.DNSRecordR\arecords\x12\x1d\n" +
 	"\n" +
 	"query_time\x18\x05 \x01(\x01R\tqueryTime\x12\x14\n" +
-	"\x05error\x18\x06 \x01(\tR\x05error\"c\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x16\n" +
+	"\x06server\x18\a \x01(\tR\x06server\x12\x14\n" +
+	"\x05rcode\x18\b \x01(\tR\x05rcode\"w\n" +
 	"\tDNSRecord\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value\x12\x10\n" +
 	"\x03ttl\x18\x03 \x01(\x05R\x03ttl\x12\x1a\n" +
-	"\bpriority\x18\x04 \x01(\x05R\bpriority\"\\\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\"\xe7\x01\n" +
 	"\x11TracerouteRequest\x12\x12\n" +
 	"\x04host\x18\x01 \x01(\tR\x04host\x12\x19\n" +
 	"\bmax_hops\x18\x02 \x01(\x05R\amaxHops\x12\x18\n" +
-	"\atimeout\x18\x03 \x01(\x05R\atimeout\"\xb8\x01\n" +
+	"\atimeout\x18\x03 \x01(\x05R\atimeout\x120\n" +
+	"\x05probe\x18\x04 \x01(\x0e2\x1a.picontrol.TracerouteProbeR\x05probe\x12$\n" +
+	"\x0eprobes_per_hop\x18\x05 \x01(\x05R\fprobesPerHop\x12\x12\n" +
+	"\x04port\x18\x06 \x01(\x05R\x04port\x12\x1d\n" +
+	"\n" +
+	"lookup_asn\x18\a \x01(\bR\tlookupAsn\"\x9e\x02\n" +
 	"\x12TracerouteResponse\x12\x10\n" +
 	"\x03hop\x18\x01 \x01(\x05R\x03hop\x12\x0e\n" +
 	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x1a\n" +
@@ -4322,7 +9861,16 @@ const file_pi_control_proto_rawDesc = "" +
 	"\alatency\x18\x04 \x01(\x01R\alatency\x12\x18\n" +
 	"\atimeout\x18\x05 \x01(\bR\atimeout\x12\x1a\n" +
 	"\bfinished\x18\x06 \x01(\bR\bfinished\x12\x14\n" +
-	"\x05error\x18\a \x01(\tR\x05error\"\xe1\x02\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x128\n" +
+	"\x06probes\x18\b \x03(\v2 .picontrol.TracerouteProbeResultR\x06probes\x12\x10\n" +
+	"\x03asn\x18\t \x01(\tR\x03asn\x12\x18\n" +
+	"\areached\x18\n" +
+	" \x01(\bR\areached\"o\n" +
+	"\x15TracerouteProbeResult\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12\x18\n" +
+	"\alatency\x18\x02 \x01(\x01R\alatency\x12\x18\n" +
+	"\atimeout\x18\x03 \x01(\bR\atimeout\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\"\xf6\x03\n" +
 	"\bWifiInfo\x12\x1c\n" +
 	"\tconnected\x18\x01 \x01(\bR\tconnected\x12\x12\n" +
 	"\x04ssid\x18\x02 \x01(\tR\x04ssid\x12\x14\n" +
@@ -4336,19 +9884,71 @@ const file_pi_control_proto_rawDesc = "" +
 	"\n" +
 	"ip_address\x18\t \x01(\tR\tipAddress\x12E\n" +
 	"\x12available_networks\x18\n" +
-	" \x03(\v2\x16.picontrol.WifiNetworkR\x11availableNetworks\"\xc1\x01\n" +
+	" \x03(\v2\x16.picontrol.WifiNetworkR\x11availableNetworks\x12\x1c\n" +
+	"\tinterface\x18\v \x01(\tR\tinterface\x12\x18\n" +
+	"\achannel\x18\f \x01(\x05R\achannel\x12\"\n" +
+	"\rrx_link_speed\x18\r \x01(\x01R\vrxLinkSpeed\x127\n" +
+	"\bchannels\x18\x0e \x03(\v2\x1b.picontrol.WifiChannelStatsR\bchannels\"\x96\x02\n" +
 	"\vWifiNetwork\x12\x12\n" +
 	"\x04ssid\x18\x01 \x01(\tR\x04ssid\x12\x14\n" +
 	"\x05bssid\x18\x02 \x01(\tR\x05bssid\x12'\n" +
 	"\x0fsignal_strength\x18\x03 \x01(\x05R\x0esignalStrength\x12%\n" +
 	"\x0esignal_quality\x18\x04 \x01(\x05R\rsignalQuality\x12\x1c\n" +
 	"\tfrequency\x18\x05 \x01(\x01R\tfrequency\x12\x1a\n" +
-	"\bsecurity\x18\x06 \x01(\tR\bsecurity\"t\n" +
+	"\bsecurity\x18\x06 \x01(\tR\bsecurity\x12\x18\n" +
+	"\achannel\x18\a \x01(\x05R\achannel\x12\x1c\n" +
+	"\tconnected\x18\b \x01(\bR\tconnected\x12\x1b\n" +
+	"\tlast_seen\x18\t \x01(\x03R\blastSeen\"G\n" +
+	"\x0fScanWifiRequest\x12\x1c\n" +
+	"\tinterface\x18\x01 \x01(\tR\tinterface\x12\x16\n" +
+	"\x06cached\x18\x02 \x01(\bR\x06cached\"\xcd\x01\n" +
+	"\x10ScanWifiResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1c\n" +
+	"\tinterface\x18\x02 \x01(\tR\tinterface\x122\n" +
+	"\bnetworks\x18\x03 \x03(\v2\x16.picontrol.WifiNetworkR\bnetworks\x127\n" +
+	"\bchannels\x18\x04 \x03(\v2\x1b.picontrol.WifiChannelStatsR\bchannels\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xb8\x01\n" +
+	"\x10SavedWifiNetwork\x12\x12\n" +
+	"\x04ssid\x18\x01 \x01(\tR\x04ssid\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x05R\bpriority\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\x12\x16\n" +
+	"\x06active\x18\x05 \x01(\bR\x06active\x12\x1a\n" +
+	"\bsecurity\x18\x06 \x01(\tR\bsecurity\x12\x16\n" +
+	"\x06hidden\x18\a \x01(\bR\x06hidden\"\xf1\x01\n" +
+	"\x14SavedWifiNetworkList\x127\n" +
+	"\bnetworks\x18\x01 \x03(\v2\x1b.picontrol.SavedWifiNetworkR\bnetworks\x12\x18\n" +
+	"\abackend\x18\x02 \x01(\tR\abackend\x12%\n" +
+	"\x0echange_pending\x18\x03 \x01(\bR\rchangePending\x12\x1f\n" +
+	"\vlast_change\x18\x04 \x01(\tR\n" +
+	"lastChange\x12(\n" +
+	"\x10last_change_time\x18\x05 \x01(\x03R\x0elastChangeTime\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\xdc\x01\n" +
+	"\x15AddWifiNetworkRequest\x12\x12\n" +
+	"\x04ssid\x18\x01 \x01(\tR\x04ssid\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1a\n" +
+	"\bsecurity\x18\x03 \x01(\tR\bsecurity\x12\x16\n" +
+	"\x06hidden\x18\x04 \x01(\bR\x06hidden\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x12\x18\n" +
+	"\aconnect\x18\x06 \x01(\bR\aconnect\x12)\n" +
+	"\x10rollback_timeout\x18\a \x01(\x05R\x0frollbackTimeout\"o\n" +
+	"\x12WifiNetworkRequest\x12\x12\n" +
+	"\x04ssid\x18\x01 \x01(\tR\x04ssid\x12\x1a\n" +
+	"\bpriority\x18\x02 \x01(\x05R\bpriority\x12)\n" +
+	"\x10rollback_timeout\x18\x03 \x01(\x05R\x0frollbackTimeout\"\xb3\x01\n" +
+	"\x10WifiChannelStats\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\x05R\achannel\x12\x1c\n" +
+	"\tfrequency\x18\x02 \x01(\x01R\tfrequency\x12\x1a\n" +
+	"\bnetworks\x18\x03 \x01(\x05R\bnetworks\x12)\n" +
+	"\x10strongest_signal\x18\x04 \x01(\x05R\x0fstrongestSignal\x12 \n" +
+	"\vutilization\x18\x05 \x01(\x01R\vutilization\"\xa8\x01\n" +
 	"\x10SpeedTestRequest\x12#\n" +
 	"\rtest_download\x18\x01 \x01(\bR\ftestDownload\x12\x1f\n" +
 	"\vtest_upload\x18\x02 \x01(\bR\n" +
 	"testUpload\x12\x1a\n" +
-	"\bduration\x18\x03 \x01(\x05R\bduration\"\xf3\x01\n" +
+	"\bduration\x18\x03 \x01(\x05R\bduration\x12\x16\n" +
+	"\x06server\x18\x04 \x01(\tR\x06server\x12\x1a\n" +
+	"\bparallel\x18\x05 \x01(\x05R\bparallel\"\xc3\x02\n" +
 	"\x11SpeedTestResponse\x12\x14\n" +
 	"\x05phase\x18\x01 \x01(\tR\x05phase\x12%\n" +
 	"\x0edownload_speed\x18\x02 \x01(\x01R\rdownloadSpeed\x12!\n" +
@@ -4357,7 +9957,30 @@ const file_pi_control_proto_rawDesc = "" +
 	"\alatency\x18\x05 \x01(\x01R\alatency\x12\x16\n" +
 	"\x06server\x18\x06 \x01(\tR\x06server\x12\x1a\n" +
 	"\bfinished\x18\a \x01(\bR\bfinished\x12\x14\n" +
-	"\x05error\x18\b \x01(\tR\x05error\"\x9b\x01\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x12\x16\n" +
+	"\x06jitter\x18\t \x01(\x01R\x06jitter\x12 \n" +
+	"\vretransmits\x18\n" +
+	" \x01(\x03R\vretransmits\x12\x14\n" +
+	"\x05bytes\x18\v \x01(\x03R\x05bytes\"j\n" +
+	"\x16DiscoverDevicesRequest\x12\x1c\n" +
+	"\tinterface\x18\x01 \x01(\tR\tinterface\x12\x18\n" +
+	"\atimeout\x18\x02 \x01(\x05R\atimeout\x12\x18\n" +
+	"\apassive\x18\x03 \x01(\bR\apassive\"\xcc\x02\n" +
+	"\x10DiscoveredDevice\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12\x10\n" +
+	"\x03mac\x18\x02 \x01(\tR\x03mac\x12\x16\n" +
+	"\x06vendor\x18\x03 \x01(\tR\x06vendor\x12\x1a\n" +
+	"\bhostname\x18\x04 \x01(\tR\bhostname\x12\x1c\n" +
+	"\tinterface\x18\x05 \x01(\tR\tinterface\x12\x1d\n" +
+	"\n" +
+	"first_seen\x18\x06 \x01(\x03R\tfirstSeen\x12\x1b\n" +
+	"\tlast_seen\x18\a \x01(\x03R\blastSeen\x12\x18\n" +
+	"\asources\x18\b \x03(\tR\asources\x12\x1a\n" +
+	"\bservices\x18\t \x03(\tR\bservices\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\x12\x1a\n" +
+	"\bfinished\x18\v \x01(\bR\bfinished\x12\x14\n" +
+	"\x05error\x18\f \x01(\tR\x05error\"\xbe\x01\n" +
 	"\tFileChunk\x12\x12\n" +
 	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
 	"\x04data\x18\x02 \x01(\fR\x04data\x12\x16\n" +
@@ -4365,7 +9988,8 @@ const file_pi_control_proto_rawDesc = "" +
 	"\n" +
 	"total_size\x18\x04 \x01(\x03R\ttotalSize\x12\x19\n" +
 	"\bis_final\x18\x05 \x01(\bR\aisFinal\x12\x14\n" +
-	"\x05error\x18\x06 \x01(\tR\x05error\"\x99\x01\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12!\n" +
+	"\fcontainer_id\x18\a \x01(\tR\vcontainerId\"\x99\x01\n" +
 	"\x12FileUploadResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x12\n" +
 	"\x04path\x18\x02 \x01(\tR\x04path\x12#\n" +
@@ -4397,12 +10021,208 @@ const file_pi_control_proto_rawDesc = "" +
 	"\x05state\x18\x04 \x01(\tR\x05state\x12\x16\n" +
 	"\x06status\x18\x05 \x01(\tR\x06status\x12\x18\n" +
 	"\acreated\x18\x06 \x01(\x03R\acreated\x12\x14\n" +
-	"\x05ports\x18\a \x03(\tR\x05ports\"[\n" +
+	"\x05ports\x18\a \x03(\tR\x05ports\"\xd4\x01\n" +
 	"\n" +
 	"LogRequest\x12!\n" +
 	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\x12\x16\n" +
 	"\x06follow\x18\x02 \x01(\bR\x06follow\x12\x12\n" +
-	"\x04tail\x18\x03 \x01(\x05R\x04tail\"\xab\x02\n" +
+	"\x04tail\x18\x03 \x01(\x05R\x04tail\x12\x14\n" +
+	"\x05since\x18\x04 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x05 \x01(\tR\x05until\x12,\n" +
+	"\x06stream\x18\x06 \x01(\x0e2\x14.picontrol.LogStreamR\x06stream\x12\x1d\n" +
+	"\n" +
+	"parse_json\x18\a \x01(\bR\tparseJson\"M\n" +
+	"\x14ContainerCopyRequest\x12!\n" +
+	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\"L\n" +
+	"\x17InspectContainerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fmask_secrets\x18\x02 \x01(\bR\vmaskSecrets\"\xaa\x06\n" +
+	"\x10ContainerDetails\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12\x19\n" +
+	"\bimage_id\x18\x04 \x01(\tR\aimageId\x12\x18\n" +
+	"\acreated\x18\x05 \x01(\tR\acreated\x12\x14\n" +
+	"\x05state\x18\x06 \x01(\tR\x05state\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\a \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\b \x01(\tR\n" +
+	"finishedAt\x12\x1b\n" +
+	"\texit_code\x18\t \x01(\x05R\bexitCode\x12\x1d\n" +
+	"\n" +
+	"oom_killed\x18\n" +
+	" \x01(\bR\toomKilled\x12\x14\n" +
+	"\x05error\x18\v \x01(\tR\x05error\x12\x10\n" +
+	"\x03env\x18\f \x03(\tR\x03env\x121\n" +
+	"\x06mounts\x18\r \x03(\v2\x19.picontrol.ContainerMountR\x06mounts\x127\n" +
+	"\bnetworks\x18\x0e \x03(\v2\x1b.picontrol.ContainerNetworkR\bnetworks\x12?\n" +
+	"\x06labels\x18\x0f \x03(\v2'.picontrol.ContainerDetails.LabelsEntryR\x06labels\x12%\n" +
+	"\x0erestart_policy\x18\x10 \x01(\tR\rrestartPolicy\x12.\n" +
+	"\x13restart_max_retries\x18\x11 \x01(\x05R\x11restartMaxRetries\x12#\n" +
+	"\rrestart_count\x18\x12 \x01(\x05R\frestartCount\x122\n" +
+	"\x06health\x18\x13 \x01(\v2\x1a.picontrol.ContainerHealthR\x06health\x12;\n" +
+	"\tresources\x18\x14 \x01(\v2\x1d.picontrol.ContainerResourcesR\tresources\x12\x18\n" +
+	"\acommand\x18\x15 \x01(\tR\acommand\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa5\x01\n" +
+	"\x0eContainerMount\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12 \n" +
+	"\vdestination\x18\x04 \x01(\tR\vdestination\x12\x12\n" +
+	"\x04mode\x18\x05 \x01(\tR\x04mode\x12\x1d\n" +
+	"\n" +
+	"read_write\x18\x06 \x01(\bR\treadWrite\"\xbd\x01\n" +
+	"\x10ContainerNetwork\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x02 \x01(\tR\tipAddress\x12!\n" +
+	"\fipv6_address\x18\x03 \x01(\tR\vipv6Address\x12\x18\n" +
+	"\agateway\x18\x04 \x01(\tR\agateway\x12\x1f\n" +
+	"\vmac_address\x18\x05 \x01(\tR\n" +
+	"macAddress\x12\x18\n" +
+	"\aaliases\x18\x06 \x03(\tR\aaliases\"\x80\x01\n" +
+	"\x0fContainerHealth\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12%\n" +
+	"\x0efailing_streak\x18\x02 \x01(\x05R\rfailingStreak\x12.\n" +
+	"\x03log\x18\x03 \x03(\v2\x1c.picontrol.HealthCheckResultR\x03log\"p\n" +
+	"\x11HealthCheckResult\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x03R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x03R\x03end\x12\x1b\n" +
+	"\texit_code\x18\x03 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06output\x18\x04 \x01(\tR\x06output\"\xfa\x01\n" +
+	"\x12ContainerResources\x12!\n" +
+	"\fmemory_limit\x18\x01 \x01(\x03R\vmemoryLimit\x12-\n" +
+	"\x12memory_reservation\x18\x02 \x01(\x03R\x11memoryReservation\x12\x1f\n" +
+	"\vmemory_swap\x18\x03 \x01(\x03R\n" +
+	"memorySwap\x12\x12\n" +
+	"\x04cpus\x18\x04 \x01(\x01R\x04cpus\x12\x1d\n" +
+	"\n" +
+	"cpu_shares\x18\x05 \x01(\x03R\tcpuShares\x12\x1f\n" +
+	"\vcpuset_cpus\x18\x06 \x01(\tR\n" +
+	"cpusetCpus\x12\x1d\n" +
+	"\n" +
+	"pids_limit\x18\a \x01(\x03R\tpidsLimit\"\xb7\x02\n" +
+	"\n" +
+	"VolumeInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06driver\x18\x02 \x01(\tR\x06driver\x12\x1e\n" +
+	"\n" +
+	"mountpoint\x18\x03 \x01(\tR\n" +
+	"mountpoint\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x14\n" +
+	"\x05scope\x18\x05 \x01(\tR\x05scope\x129\n" +
+	"\x06labels\x18\x06 \x03(\v2!.picontrol.VolumeInfo.LabelsEntryR\x06labels\x12\x12\n" +
+	"\x04size\x18\a \x01(\x03R\x04size\x12\x1e\n" +
+	"\n" +
+	"containers\x18\b \x03(\tR\n" +
+	"containers\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"=\n" +
+	"\n" +
+	"VolumeList\x12/\n" +
+	"\avolumes\x18\x01 \x03(\v2\x15.picontrol.VolumeInfoR\avolumes\"\xd0\x02\n" +
+	"\x13CreateVolumeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06driver\x18\x02 \x01(\tR\x06driver\x12B\n" +
+	"\x06labels\x18\x03 \x03(\v2*.picontrol.CreateVolumeRequest.LabelsEntryR\x06labels\x12O\n" +
+	"\vdriver_opts\x18\x04 \x03(\v2..picontrol.CreateVolumeRequest.DriverOptsEntryR\n" +
+	"driverOpts\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a=\n" +
+	"\x0fDriverOptsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"?\n" +
+	"\x13RemoveVolumeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"'\n" +
+	"\x13PruneVolumesRequest\x12\x10\n" +
+	"\x03all\x18\x01 \x01(\bR\x03all\"\x82\x01\n" +
+	"\rPruneResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\adeleted\x18\x02 \x03(\tR\adeleted\x12'\n" +
+	"\x0fspace_reclaimed\x18\x03 \x01(\x04R\x0espaceReclaimed\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xa2\x03\n" +
+	"\x11DockerNetworkInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06driver\x18\x03 \x01(\tR\x06driver\x12\x14\n" +
+	"\x05scope\x18\x04 \x01(\tR\x05scope\x12\x1a\n" +
+	"\binternal\x18\x05 \x01(\bR\binternal\x12\x1e\n" +
+	"\n" +
+	"attachable\x18\x06 \x01(\bR\n" +
+	"attachable\x12\x12\n" +
+	"\x04ipv6\x18\a \x01(\bR\x04ipv6\x12\x18\n" +
+	"\asubnets\x18\b \x03(\tR\asubnets\x12\x1a\n" +
+	"\bgateways\x18\t \x03(\tR\bgateways\x12@\n" +
+	"\x06labels\x18\n" +
+	" \x03(\v2(.picontrol.DockerNetworkInfo.LabelsEntryR\x06labels\x12\x1e\n" +
+	"\n" +
+	"containers\x18\v \x03(\tR\n" +
+	"containers\x12\x18\n" +
+	"\acreated\x18\f \x01(\x03R\acreated\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"M\n" +
+	"\x11DockerNetworkList\x128\n" +
+	"\bnetworks\x18\x01 \x03(\v2\x1c.picontrol.DockerNetworkInfoR\bnetworks\"!\n" +
+	"\x0fDockerNetworkId\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xc4\x02\n" +
+	"\x14CreateNetworkRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06driver\x18\x02 \x01(\tR\x06driver\x12\x16\n" +
+	"\x06subnet\x18\x03 \x01(\tR\x06subnet\x12\x18\n" +
+	"\agateway\x18\x04 \x01(\tR\agateway\x12\x1a\n" +
+	"\binternal\x18\x05 \x01(\bR\binternal\x12\x1e\n" +
+	"\n" +
+	"attachable\x18\x06 \x01(\bR\n" +
+	"attachable\x12\x12\n" +
+	"\x04ipv6\x18\a \x01(\bR\x04ipv6\x12C\n" +
+	"\x06labels\x18\b \x03(\v2+.picontrol.CreateNetworkRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xac\x01\n" +
+	"\x15NetworkConnectRequest\x12\x1d\n" +
+	"\n" +
+	"network_id\x18\x01 \x01(\tR\tnetworkId\x12!\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12\x18\n" +
+	"\aaliases\x18\x03 \x03(\tR\aaliases\x12!\n" +
+	"\fipv4_address\x18\x04 \x01(\tR\vipv4Address\x12\x14\n" +
+	"\x05force\x18\x05 \x01(\bR\x05force\"\xb7\x01\n" +
+	"\x0eComposeProject\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vworking_dir\x18\x02 \x01(\tR\n" +
+	"workingDir\x12!\n" +
+	"\fconfig_files\x18\x03 \x03(\tR\vconfigFiles\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x125\n" +
+	"\bservices\x18\x05 \x03(\v2\x19.picontrol.ComposeServiceR\bservices\"\xb2\x01\n" +
+	"\x0eComposeService\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12%\n" +
+	"\x0econtainer_name\x18\x03 \x01(\tR\rcontainerName\x12\x14\n" +
+	"\x05image\x18\x04 \x01(\tR\x05image\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\"K\n" +
+	"\x12ComposeProjectList\x125\n" +
+	"\bprojects\x18\x01 \x03(\v2\x19.picontrol.ComposeProjectR\bprojects\"\xd0\x01\n" +
+	"\x0eComposeCommand\x12!\n" +
+	"\fproject_name\x18\x01 \x01(\tR\vprojectName\x120\n" +
+	"\x06action\x18\x02 \x01(\x0e2\x18.picontrol.ComposeActionR\x06action\x12\x1f\n" +
+	"\vworking_dir\x18\x03 \x01(\tR\n" +
+	"workingDir\x12!\n" +
+	"\fconfig_files\x18\x04 \x03(\tR\vconfigFiles\x12%\n" +
+	"\x0eremove_orphans\x18\x05 \x01(\bR\rremoveOrphans\"\x89\x01\n" +
+	"\rComposeOutput\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\tR\x04line\x12\x16\n" +
+	"\x06stream\x18\x02 \x01(\tR\x06stream\x12\x1c\n" +
+	"\tcompleted\x18\x03 \x01(\bR\tcompleted\x12\x18\n" +
+	"\asuccess\x18\x04 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\x8e\x03\n" +
 	"\x12SystemUpdateStatus\x12\x17\n" +
 	"\aos_name\x18\x01 \x01(\tR\x06osName\x12%\n" +
 	"\x0ekernel_version\x18\x02 \x01(\tR\rkernelVersion\x12\"\n" +
@@ -4411,20 +10231,55 @@ const file_pi_control_proto_rawDesc = "" +
 	"\x13upgradable_packages\x18\x05 \x03(\v2\x1c.picontrol.UpgradablePackageR\x12upgradablePackages\x12\x1f\n" +
 	"\vlast_update\x18\x06 \x01(\tR\n" +
 	"lastUpdate\x12\x16\n" +
-	"\x06uptime\x18\a \x01(\tR\x06uptime\"\x95\x01\n" +
+	"\x06uptime\x18\a \x01(\tR\x06uptime\x12'\n" +
+	"\x0freboot_required\x18\b \x01(\bR\x0erebootRequired\x128\n" +
+	"\x18reboot_required_packages\x18\t \x03(\tR\x16rebootRequiredPackages\"M\n" +
+	"\fPowerRequest\x12#\n" +
+	"\rdelay_minutes\x18\x01 \x01(\x05R\fdelayMinutes\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xa9\x01\n" +
 	"\x11UpgradablePackage\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12'\n" +
 	"\x0fcurrent_version\x18\x02 \x01(\tR\x0ecurrentVersion\x12\x1f\n" +
 	"\vnew_version\x18\x03 \x01(\tR\n" +
 	"newVersion\x12\"\n" +
-	"\farchitecture\x18\x04 \x01(\tR\farchitecture\"\x90\x01\n" +
+	"\farchitecture\x18\x04 \x01(\tR\farchitecture\x12\x12\n" +
+	"\x04held\x18\x05 \x01(\bR\x04held\"\xf8\x01\n" +
+	"\rUpgradePolicy\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x12\n" +
+	"\x04days\x18\x02 \x03(\x05R\x04days\x12!\n" +
+	"\fwindow_start\x18\x03 \x01(\tR\vwindowStart\x12%\n" +
+	"\x0ewindow_minutes\x18\x04 \x01(\x05R\rwindowMinutes\x12#\n" +
+	"\rsecurity_only\x18\x05 \x01(\bR\fsecurityOnly\x12/\n" +
+	"\x06reboot\x18\x06 \x01(\x0e2\x17.picontrol.RebootPolicyR\x06reboot\x12\x19\n" +
+	"\bnext_run\x18\a \x01(\x03R\anextRun\"\xba\x03\n" +
+	"\n" +
+	"UpgradeRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\atrigger\x18\x02 \x01(\tR\atrigger\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x04 \x01(\x03R\n" +
+	"finishedAt\x12\x18\n" +
+	"\asuccess\x18\x05 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12#\n" +
+	"\rsecurity_only\x18\a \x01(\bR\fsecurityOnly\x128\n" +
+	"\bupgraded\x18\b \x03(\v2\x1c.picontrol.UpgradablePackageR\bupgraded\x120\n" +
+	"\x04held\x18\t \x03(\v2\x1c.picontrol.UpgradablePackageR\x04held\x12'\n" +
+	"\x0freboot_required\x18\n" +
+	" \x01(\bR\x0erebootRequired\x12)\n" +
+	"\x10reboot_scheduled\x18\v \x01(\bR\x0frebootScheduled\x12\x16\n" +
+	"\x06output\x18\f \x01(\tR\x06output\x12\x15\n" +
+	"\x06job_id\x18\r \x01(\tR\x05jobId\";\n" +
+	"\x0eUpgradeRunList\x12)\n" +
+	"\x04runs\x18\x01 \x03(\v2\x15.picontrol.UpgradeRunR\x04runs\"\xa7\x01\n" +
 	"\x0fUpgradeProgress\x12\x12\n" +
 	"\x04line\x18\x01 \x01(\tR\x04line\x12\x14\n" +
 	"\x05phase\x18\x02 \x01(\tR\x05phase\x12\x18\n" +
 	"\apercent\x18\x03 \x01(\x05R\apercent\x12\x1f\n" +
 	"\vis_complete\x18\x04 \x01(\bR\n" +
 	"isComplete\x12\x18\n" +
-	"\asuccess\x18\x05 \x01(\bR\asuccess*V\n" +
+	"\asuccess\x18\x05 \x01(\bR\asuccess\x12\x15\n" +
+	"\x06job_id\x18\x06 \x01(\tR\x05jobId*V\n" +
 	"\rServiceAction\x12\t\n" +
 	"\x05START\x10\x00\x12\b\n" +
 	"\x04STOP\x10\x01\x12\v\n" +
@@ -4433,7 +10288,40 @@ const file_pi_control_proto_rawDesc = "" +
 	"\x06ENABLE\x10\x03\x12\v\n" +
 	"\aDISABLE\x10\x04\x12\n" +
 	"\n" +
-	"\x06RELOAD\x10\x052\x86\x11\n" +
+	"\x06RELOAD\x10\x05*c\n" +
+	"\x10PackageOperation\x12\x13\n" +
+	"\x0fPACKAGE_INSTALL\x10\x00\x12\x12\n" +
+	"\x0ePACKAGE_REMOVE\x10\x01\x12\x13\n" +
+	"\x0fPACKAGE_UPGRADE\x10\x02\x12\x11\n" +
+	"\rPACKAGE_PURGE\x10\x03*S\n" +
+	"\bJobState\x12\x0e\n" +
+	"\n" +
+	"JOB_QUEUED\x10\x00\x12\x18\n" +
+	"\x14JOB_WAITING_FOR_LOCK\x10\x01\x12\x0f\n" +
+	"\vJOB_RUNNING\x10\x02\x12\f\n" +
+	"\bJOB_DONE\x10\x03*8\n" +
+	"\x10PortScanProtocol\x12\x11\n" +
+	"\rPORT_SCAN_TCP\x10\x00\x12\x11\n" +
+	"\rPORT_SCAN_UDP\x10\x01*N\n" +
+	"\x0fTracerouteProbe\x12\x12\n" +
+	"\x0eTRACEROUTE_UDP\x10\x00\x12\x13\n" +
+	"\x0fTRACEROUTE_ICMP\x10\x01\x12\x12\n" +
+	"\x0eTRACEROUTE_TCP\x10\x02*M\n" +
+	"\tLogStream\x12\x12\n" +
+	"\x0eLOG_STREAM_ALL\x10\x00\x12\x15\n" +
+	"\x11LOG_STREAM_STDOUT\x10\x01\x12\x15\n" +
+	"\x11LOG_STREAM_STDERR\x10\x02*x\n" +
+	"\rComposeAction\x12\x1e\n" +
+	"\x1aCOMPOSE_ACTION_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"COMPOSE_UP\x10\x01\x12\x10\n" +
+	"\fCOMPOSE_DOWN\x10\x02\x12\x13\n" +
+	"\x0fCOMPOSE_RESTART\x10\x03\x12\x10\n" +
+	"\fCOMPOSE_PULL\x10\x04*K\n" +
+	"\fRebootPolicy\x12\x10\n" +
+	"\fREBOOT_NEVER\x10\x00\x12\x16\n" +
+	"\x12REBOOT_IF_REQUIRED\x10\x01\x12\x11\n" +
+	"\rREBOOT_ALWAYS\x10\x022\x9c$\n" +
 	"\rSystemMonitor\x127\n" +
 	"\vStreamStats\x12\x10.picontrol.Empty\x1a\x14.picontrol.LiveStats0\x01\x129\n" +
 	"\rListProcesses\x12\x10.picontrol.Empty\x1a\x16.picontrol.ProcessList\x12<\n" +
@@ -4446,38 +10334,87 @@ const file_pi_control_proto_rawDesc = "" +
 	"StreamLogs\x12\x14.picontrol.LogFilter\x1a\x13.picontrol.LogEntry0\x01\x124\n" +
 	"\vGetDiskInfo\x12\x10.picontrol.Empty\x1a\x13.picontrol.DiskInfo\x12:\n" +
 	"\x0eGetNetworkInfo\x12\x10.picontrol.Empty\x1a\x16.picontrol.NetworkInfo\x12K\n" +
-	"\x15GetNetworkConnections\x12\x10.picontrol.Empty\x1a .picontrol.NetworkConnectionList\x12@\n" +
+	"\x15GetNetworkConnections\x12\x10.picontrol.Empty\x1a .picontrol.NetworkConnectionList\x12S\n" +
+	"\x12GetInterfaceConfig\x12!.picontrol.InterfaceConfigRequest\x1a\x1a.picontrol.InterfaceConfig\x12I\n" +
+	"\x12SetInterfaceConfig\x12\x1a.picontrol.InterfaceConfig\x1a\x17.picontrol.ActionStatus\x12T\n" +
+	"\x16ConfirmInterfaceConfig\x12!.picontrol.InterfaceConfigRequest\x1a\x17.picontrol.ActionStatus\x12S\n" +
+	"\x15RevertInterfaceConfig\x12!.picontrol.InterfaceConfigRequest\x1a\x17.picontrol.ActionStatus\x12@\n" +
 	"\fListPackages\x12\x18.picontrol.PackageFilter\x1a\x16.picontrol.PackageList\x12D\n" +
 	"\x0eInstallPackage\x12\x19.picontrol.PackageCommand\x1a\x17.picontrol.ActionStatus\x12C\n" +
 	"\rRemovePackage\x12\x19.picontrol.PackageCommand\x1a\x17.picontrol.ActionStatus\x12C\n" +
 	"\rUpdatePackage\x12\x19.picontrol.PackageCommand\x1a\x17.picontrol.ActionStatus\x12>\n" +
 	"\x11UpdatePackageList\x12\x10.picontrol.Empty\x1a\x17.picontrol.ActionStatus\x12<\n" +
-	"\x0fUpgradePackages\x12\x10.picontrol.Empty\x1a\x17.picontrol.ActionStatus\x126\n" +
+	"\x0fUpgradePackages\x12\x10.picontrol.Empty\x1a\x17.picontrol.ActionStatus\x12D\n" +
+	"\x12ListPackageSources\x12\x10.picontrol.Empty\x1a\x1c.picontrol.PackageSourceList\x12O\n" +
+	"\x10AddPackageSource\x12\".picontrol.AddPackageSourceRequest\x1a\x17.picontrol.ActionStatus\x12R\n" +
+	"\x17SetPackageSourceEnabled\x12\x1e.picontrol.PackageSourceToggle\x1a\x17.picontrol.ActionStatus\x12J\n" +
+	"\x13RemovePackageSource\x12\x1a.picontrol.PackageSourceId\x1a\x17.picontrol.ActionStatus\x12I\n" +
+	"\x10ImportSigningKey\x12\x1c.picontrol.SigningKeyRequest\x1a\x17.picontrol.ActionStatus\x12P\n" +
+	"\x11GetPackageHistory\x12 .picontrol.PackageHistoryRequest\x1a\x19.picontrol.PackageHistory\x126\n" +
 	"\n" +
 	"GetVersion\x12\x10.picontrol.Empty\x1a\x16.picontrol.VersionInfo\x12P\n" +
 	"\x11GetPackageDetails\x12 .picontrol.PackageDetailsRequest\x1a\x19.picontrol.PackageDetails\x12Z\n" +
 	"\x16GetPackageDependencies\x12 .picontrol.PackageDetailsRequest\x1a\x1e.picontrol.PackageDependencies\x12U\n" +
-	"\x16StreamPackageOperation\x12\x19.picontrol.PackageCommand\x1a\x1e.picontrol.PackageOperationLog0\x01\x12=\n" +
+	"\x16StreamPackageOperation\x12\x19.picontrol.PackageCommand\x1a\x1e.picontrol.PackageOperationLog0\x01\x12S\n" +
+	"\x18SimulatePackageOperation\x12\x19.picontrol.PackageCommand\x1a\x1c.picontrol.PackageSimulation\x12@\n" +
+	"\x10ListPackageHolds\x12\x10.picontrol.Empty\x1a\x1a.picontrol.PackageHoldList\x12H\n" +
+	"\x0eSetPackageHold\x12\x1d.picontrol.PackageHoldRequest\x1a\x17.picontrol.ActionStatus\x12>\n" +
+	"\x0fListPackagePins\x12\x10.picontrol.Empty\x1a\x19.picontrol.PackagePinList\x12?\n" +
+	"\rSetPackagePin\x12\x15.picontrol.PackagePin\x1a\x17.picontrol.ActionStatus\x12A\n" +
+	"\x0fClearPackagePin\x12\x15.picontrol.PackagePin\x1a\x17.picontrol.ActionStatus\x127\n" +
+	"\bListJobs\x12\x10.picontrol.Empty\x1a\x19.picontrol.PackageJobList\x128\n" +
+	"\x06GetJob\x12\x17.picontrol.PackageJobId\x1a\x15.picontrol.PackageJob\x12=\n" +
+	"\tCancelJob\x12\x17.picontrol.PackageJobId\x1a\x17.picontrol.ActionStatus\x12F\n" +
+	"\tAttachJob\x12\x17.picontrol.PackageJobId\x1a\x1e.picontrol.PackageOperationLog0\x01\x12=\n" +
 	"\bPingHost\x12\x16.picontrol.PingRequest\x1a\x17.picontrol.PingResponse0\x01\x12F\n" +
 	"\tScanPorts\x12\x1a.picontrol.PortScanRequest\x1a\x1b.picontrol.PortScanResponse0\x01\x12:\n" +
 	"\tDNSLookup\x12\x15.picontrol.DNSRequest\x1a\x16.picontrol.DNSResponse\x12K\n" +
 	"\n" +
 	"Traceroute\x12\x1c.picontrol.TracerouteRequest\x1a\x1d.picontrol.TracerouteResponse0\x01\x124\n" +
-	"\vGetWifiInfo\x12\x10.picontrol.Empty\x1a\x13.picontrol.WifiInfo\x12O\n" +
-	"\x10TestNetworkSpeed\x12\x1b.picontrol.SpeedTestRequest\x1a\x1c.picontrol.SpeedTestResponse0\x01\x12C\n" +
+	"\vGetWifiInfo\x12\x10.picontrol.Empty\x1a\x13.picontrol.WifiInfo\x12C\n" +
+	"\bScanWifi\x12\x1a.picontrol.ScanWifiRequest\x1a\x1b.picontrol.ScanWifiResponse\x12J\n" +
+	"\x15ListSavedWifiNetworks\x12\x10.picontrol.Empty\x1a\x1f.picontrol.SavedWifiNetworkList\x12K\n" +
+	"\x0eAddWifiNetwork\x12 .picontrol.AddWifiNetworkRequest\x1a\x17.picontrol.ActionStatus\x12L\n" +
+	"\x12ConnectWifiNetwork\x12\x1d.picontrol.WifiNetworkRequest\x1a\x17.picontrol.ActionStatus\x12K\n" +
+	"\x11ForgetWifiNetwork\x12\x1d.picontrol.WifiNetworkRequest\x1a\x17.picontrol.ActionStatus\x12P\n" +
+	"\x16SetWifiNetworkPriority\x12\x1d.picontrol.WifiNetworkRequest\x1a\x17.picontrol.ActionStatus\x12O\n" +
+	"\x10TestNetworkSpeed\x12\x1b.picontrol.SpeedTestRequest\x1a\x1c.picontrol.SpeedTestResponse0\x01\x12S\n" +
+	"\x0fDiscoverDevices\x12!.picontrol.DiscoverDevicesRequest\x1a\x1b.picontrol.DiscoveredDevice0\x01\x12C\n" +
 	"\n" +
 	"UploadFile\x12\x14.picontrol.FileChunk\x1a\x1d.picontrol.FileUploadResponse(\x01\x12F\n" +
 	"\fDownloadFile\x12\x1e.picontrol.FileDownloadRequest\x1a\x14.picontrol.FileChunk0\x01\x12I\n" +
 	"\n" +
 	"DeleteFile\x12\x1c.picontrol.FileDeleteRequest\x1a\x1d.picontrol.FileDeleteResponse\x12H\n" +
 	"\x15GetSystemUpdateStatus\x12\x10.picontrol.Empty\x1a\x1d.picontrol.SystemUpdateStatus\x12E\n" +
-	"\x13StreamSystemUpgrade\x12\x10.picontrol.Empty\x1a\x1a.picontrol.UpgradeProgress0\x012\xe0\x02\n" +
+	"\x13StreamSystemUpgrade\x12\x10.picontrol.Empty\x1a\x1a.picontrol.UpgradeProgress0\x01\x12>\n" +
+	"\x10GetUpgradePolicy\x12\x10.picontrol.Empty\x1a\x18.picontrol.UpgradePolicy\x12E\n" +
+	"\x10SetUpgradePolicy\x12\x18.picontrol.UpgradePolicy\x1a\x17.picontrol.ActionStatus\x128\n" +
+	"\rRunUpgradeNow\x12\x10.picontrol.Empty\x1a\x15.picontrol.UpgradeRun\x12>\n" +
+	"\x0fListUpgradeRuns\x12\x10.picontrol.Empty\x1a\x19.picontrol.UpgradeRunList\x12:\n" +
+	"\x06Reboot\x12\x17.picontrol.PowerRequest\x1a\x17.picontrol.ActionStatus\x12<\n" +
+	"\bShutdown\x12\x17.picontrol.PowerRequest\x1a\x17.picontrol.ActionStatus\x12;\n" +
+	"\x0eCancelShutdown\x12\x10.picontrol.Empty\x1a\x17.picontrol.ActionStatus2\xe6\n" +
+	"\n" +
 	"\rDockerService\x12C\n" +
 	"\x0eListContainers\x12\x17.picontrol.DockerFilter\x1a\x18.picontrol.ContainerList\x12A\n" +
 	"\x0eStartContainer\x12\x16.picontrol.ContainerId\x1a\x17.picontrol.ActionStatus\x12@\n" +
 	"\rStopContainer\x12\x16.picontrol.ContainerId\x1a\x17.picontrol.ActionStatus\x12C\n" +
 	"\x10RestartContainer\x12\x16.picontrol.ContainerId\x1a\x17.picontrol.ActionStatus\x12@\n" +
-	"\x10GetContainerLogs\x12\x15.picontrol.LogRequest\x1a\x13.picontrol.LogEntry0\x01B\x10Z\x0epi_agent/protob\x06proto3"
+	"\x10GetContainerLogs\x12\x15.picontrol.LogRequest\x1a\x13.picontrol.LogEntry0\x01\x12L\n" +
+	"\x11CopyFromContainer\x12\x1f.picontrol.ContainerCopyRequest\x1a\x14.picontrol.FileChunk0\x01\x12H\n" +
+	"\x0fCopyToContainer\x12\x14.picontrol.FileChunk\x1a\x1d.picontrol.FileUploadResponse(\x01\x12S\n" +
+	"\x10InspectContainer\x12\".picontrol.InspectContainerRequest\x1a\x1b.picontrol.ContainerDetails\x126\n" +
+	"\vListVolumes\x12\x10.picontrol.Empty\x1a\x15.picontrol.VolumeList\x12G\n" +
+	"\fCreateVolume\x12\x1e.picontrol.CreateVolumeRequest\x1a\x17.picontrol.ActionStatus\x12G\n" +
+	"\fRemoveVolume\x12\x1e.picontrol.RemoveVolumeRequest\x1a\x17.picontrol.ActionStatus\x12H\n" +
+	"\fPruneVolumes\x12\x1e.picontrol.PruneVolumesRequest\x1a\x18.picontrol.PruneResponse\x12>\n" +
+	"\fListNetworks\x12\x10.picontrol.Empty\x1a\x1c.picontrol.DockerNetworkList\x12I\n" +
+	"\rCreateNetwork\x12\x1f.picontrol.CreateNetworkRequest\x1a\x17.picontrol.ActionStatus\x12D\n" +
+	"\rRemoveNetwork\x12\x1a.picontrol.DockerNetworkId\x1a\x17.picontrol.ActionStatus\x12K\n" +
+	"\x0eConnectNetwork\x12 .picontrol.NetworkConnectRequest\x1a\x17.picontrol.ActionStatus\x12N\n" +
+	"\x11DisconnectNetwork\x12 .picontrol.NetworkConnectRequest\x1a\x17.picontrol.ActionStatus\x12F\n" +
+	"\x13ListComposeProjects\x12\x10.picontrol.Empty\x1a\x1d.picontrol.ComposeProjectList\x12M\n" +
+	"\x14ManageComposeProject\x12\x19.picontrol.ComposeCommand\x1a\x18.picontrol.ComposeOutput0\x01B\x10Z\x0epi_agent/protob\x06proto3"
 
 var (
 	file_pi_control_proto_rawDescOnce sync.Once
@@ -4491,159 +10428,371 @@ func file_pi_control_proto_rawDescGZIP() []byte {
 	return file_pi_control_proto_rawDescData
 }
 
-var file_pi_control_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pi_control_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
+var file_pi_control_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_pi_control_proto_msgTypes = make([]protoimpl.MessageInfo, 121)
 var file_pi_control_proto_goTypes = []any{
-	(ServiceAction)(0),            // 0: picontrol.ServiceAction
-	(*Empty)(nil),                 // 1: picontrol.Empty
-	(*LiveStats)(nil),             // 2: picontrol.LiveStats
-	(*ProcessInfo)(nil),           // 3: picontrol.ProcessInfo
-	(*ProcessList)(nil),           // 4: picontrol.ProcessList
-	(*ProcessId)(nil),             // 5: picontrol.ProcessId
-	(*ServiceInfo)(nil),           // 6: picontrol.ServiceInfo
-	(*ServiceList)(nil),           // 7: picontrol.ServiceList
-	(*ServiceCommand)(nil),        // 8: picontrol.ServiceCommand
-	(*ActionStatus)(nil),          // 9: picontrol.ActionStatus
-	(*LogFilter)(nil),             // 10: picontrol.LogFilter
-	(*LogEntry)(nil),              // 11: picontrol.LogEntry
-	(*DiskInfo)(nil),              // 12: picontrol.DiskInfo
-	(*DiskPartition)(nil),         // 13: picontrol.DiskPartition
-	(*NetworkInfo)(nil),           // 14: picontrol.NetworkInfo
-	(*NetworkInterface)(nil),      // 15: picontrol.NetworkInterface
-	(*NetworkConnectionList)(nil), // 16: picontrol.NetworkConnectionList
-	(*NetworkConnection)(nil),     // 17: picontrol.NetworkConnection
-	(*PackageFilter)(nil),         // 18: picontrol.PackageFilter
-	(*PackageInfo)(nil),           // 19: picontrol.PackageInfo
-	(*PackageList)(nil),           // 20: picontrol.PackageList
-	(*PackageCommand)(nil),        // 21: picontrol.PackageCommand
-	(*PackageDetailsRequest)(nil), // 22: picontrol.PackageDetailsRequest
-	(*PackageDetails)(nil),        // 23: picontrol.PackageDetails
-	(*PackageDependencies)(nil),   // 24: picontrol.PackageDependencies
-	(*PackageOperationLog)(nil),   // 25: picontrol.PackageOperationLog
-	(*DiskIOStat)(nil),            // 26: picontrol.DiskIOStat
-	(*VersionInfo)(nil),           // 27: picontrol.VersionInfo
-	(*PingRequest)(nil),           // 28: picontrol.PingRequest
-	(*PingResponse)(nil),          // 29: picontrol.PingResponse
-	(*PingStats)(nil),             // 30: picontrol.PingStats
-	(*PortScanRequest)(nil),       // 31: picontrol.PortScanRequest
-	(*PortScanResponse)(nil),      // 32: picontrol.PortScanResponse
-	(*DNSRequest)(nil),            // 33: picontrol.DNSRequest
-	(*DNSResponse)(nil),           // 34: picontrol.DNSResponse
-	(*DNSRecord)(nil),             // 35: picontrol.DNSRecord
-	(*TracerouteRequest)(nil),     // 36: picontrol.TracerouteRequest
-	(*TracerouteResponse)(nil),    // 37: picontrol.TracerouteResponse
-	(*WifiInfo)(nil),              // 38: picontrol.WifiInfo
-	(*WifiNetwork)(nil),           // 39: picontrol.WifiNetwork
-	(*SpeedTestRequest)(nil),      // 40: picontrol.SpeedTestRequest
-	(*SpeedTestResponse)(nil),     // 41: picontrol.SpeedTestResponse
-	(*FileChunk)(nil),             // 42: picontrol.FileChunk
-	(*FileUploadResponse)(nil),    // 43: picontrol.FileUploadResponse
-	(*FileDownloadRequest)(nil),   // 44: picontrol.FileDownloadRequest
-	(*FileDeleteRequest)(nil),     // 45: picontrol.FileDeleteRequest
-	(*FileDeleteResponse)(nil),    // 46: picontrol.FileDeleteResponse
-	(*DockerFilter)(nil),          // 47: picontrol.DockerFilter
-	(*ContainerId)(nil),           // 48: picontrol.ContainerId
-	(*ContainerList)(nil),         // 49: picontrol.ContainerList
-	(*ContainerInfo)(nil),         // 50: picontrol.ContainerInfo
-	(*LogRequest)(nil),            // 51: picontrol.LogRequest
-	(*SystemUpdateStatus)(nil),    // 52: picontrol.SystemUpdateStatus
-	(*UpgradablePackage)(nil),     // 53: picontrol.UpgradablePackage
-	(*UpgradeProgress)(nil),       // 54: picontrol.UpgradeProgress
+	(ServiceAction)(0),              // 0: picontrol.ServiceAction
+	(PackageOperation)(0),           // 1: picontrol.PackageOperation
+	(JobState)(0),                   // 2: picontrol.JobState
+	(PortScanProtocol)(0),           // 3: picontrol.PortScanProtocol
+	(TracerouteProbe)(0),            // 4: picontrol.TracerouteProbe
+	(LogStream)(0),                  // 5: picontrol.LogStream
+	(ComposeAction)(0),              // 6: picontrol.ComposeAction
+	(RebootPolicy)(0),               // 7: picontrol.RebootPolicy
+	(*Empty)(nil),                   // 8: picontrol.Empty
+	(*LiveStats)(nil),               // 9: picontrol.LiveStats
+	(*ProcessInfo)(nil),             // 10: picontrol.ProcessInfo
+	(*ProcessList)(nil),             // 11: picontrol.ProcessList
+	(*ProcessId)(nil),               // 12: picontrol.ProcessId
+	(*ServiceInfo)(nil),             // 13: picontrol.ServiceInfo
+	(*ServiceList)(nil),             // 14: picontrol.ServiceList
+	(*ServiceCommand)(nil),          // 15: picontrol.ServiceCommand
+	(*ActionStatus)(nil),            // 16: picontrol.ActionStatus
+	(*LogFilter)(nil),               // 17: picontrol.LogFilter
+	(*LogEntry)(nil),                // 18: picontrol.LogEntry
+	(*DiskInfo)(nil),                // 19: picontrol.DiskInfo
+	(*DiskPartition)(nil),           // 20: picontrol.DiskPartition
+	(*NetworkInfo)(nil),             // 21: picontrol.NetworkInfo
+	(*NetworkInterface)(nil),        // 22: picontrol.NetworkInterface
+	(*NetworkConnectionList)(nil),   // 23: picontrol.NetworkConnectionList
+	(*NetworkConnection)(nil),       // 24: picontrol.NetworkConnection
+	(*InterfaceConfigRequest)(nil),  // 25: picontrol.InterfaceConfigRequest
+	(*InterfaceConfig)(nil),         // 26: picontrol.InterfaceConfig
+	(*PackageFilter)(nil),           // 27: picontrol.PackageFilter
+	(*PackageInfo)(nil),             // 28: picontrol.PackageInfo
+	(*PackageList)(nil),             // 29: picontrol.PackageList
+	(*PackageCommand)(nil),          // 30: picontrol.PackageCommand
+	(*PackageDetailsRequest)(nil),   // 31: picontrol.PackageDetailsRequest
+	(*PackageDetails)(nil),          // 32: picontrol.PackageDetails
+	(*PackageDependencies)(nil),     // 33: picontrol.PackageDependencies
+	(*PackageSimulation)(nil),       // 34: picontrol.PackageSimulation
+	(*SimulatedPackage)(nil),        // 35: picontrol.SimulatedPackage
+	(*PackageSource)(nil),           // 36: picontrol.PackageSource
+	(*PackageSourceList)(nil),       // 37: picontrol.PackageSourceList
+	(*AddPackageSourceRequest)(nil), // 38: picontrol.AddPackageSourceRequest
+	(*PackageSourceToggle)(nil),     // 39: picontrol.PackageSourceToggle
+	(*PackageSourceId)(nil),         // 40: picontrol.PackageSourceId
+	(*SigningKeyRequest)(nil),       // 41: picontrol.SigningKeyRequest
+	(*PackageHistoryRequest)(nil),   // 42: picontrol.PackageHistoryRequest
+	(*PackageChange)(nil),           // 43: picontrol.PackageChange
+	(*PackageTransaction)(nil),      // 44: picontrol.PackageTransaction
+	(*PackageHistory)(nil),          // 45: picontrol.PackageHistory
+	(*PackageHold)(nil),             // 46: picontrol.PackageHold
+	(*PackageHoldList)(nil),         // 47: picontrol.PackageHoldList
+	(*PackageHoldRequest)(nil),      // 48: picontrol.PackageHoldRequest
+	(*PackagePin)(nil),              // 49: picontrol.PackagePin
+	(*PackagePinList)(nil),          // 50: picontrol.PackagePinList
+	(*PackageOperationLog)(nil),     // 51: picontrol.PackageOperationLog
+	(*PackageJob)(nil),              // 52: picontrol.PackageJob
+	(*PackageJobList)(nil),          // 53: picontrol.PackageJobList
+	(*PackageJobId)(nil),            // 54: picontrol.PackageJobId
+	(*DiskIOStat)(nil),              // 55: picontrol.DiskIOStat
+	(*InterfaceStats)(nil),          // 56: picontrol.InterfaceStats
+	(*VersionInfo)(nil),             // 57: picontrol.VersionInfo
+	(*PingRequest)(nil),             // 58: picontrol.PingRequest
+	(*PingResponse)(nil),            // 59: picontrol.PingResponse
+	(*PingStats)(nil),               // 60: picontrol.PingStats
+	(*PortScanRequest)(nil),         // 61: picontrol.PortScanRequest
+	(*PortScanResponse)(nil),        // 62: picontrol.PortScanResponse
+	(*DNSRequest)(nil),              // 63: picontrol.DNSRequest
+	(*DNSResponse)(nil),             // 64: picontrol.DNSResponse
+	(*DNSRecord)(nil),               // 65: picontrol.DNSRecord
+	(*TracerouteRequest)(nil),       // 66: picontrol.TracerouteRequest
+	(*TracerouteResponse)(nil),      // 67: picontrol.TracerouteResponse
+	(*TracerouteProbeResult)(nil),   // 68: picontrol.TracerouteProbeResult
+	(*WifiInfo)(nil),                // 69: picontrol.WifiInfo
+	(*WifiNetwork)(nil),             // 70: picontrol.WifiNetwork
+	(*ScanWifiRequest)(nil),         // 71: picontrol.ScanWifiRequest
+	(*ScanWifiResponse)(nil),        // 72: picontrol.ScanWifiResponse
+	(*SavedWifiNetwork)(nil),        // 73: picontrol.SavedWifiNetwork
+	(*SavedWifiNetworkList)(nil),    // 74: picontrol.SavedWifiNetworkList
+	(*AddWifiNetworkRequest)(nil),   // 75: picontrol.AddWifiNetworkRequest
+	(*WifiNetworkRequest)(nil),      // 76: picontrol.WifiNetworkRequest
+	(*WifiChannelStats)(nil),        // 77: picontrol.WifiChannelStats
+	(*SpeedTestRequest)(nil),        // 78: picontrol.SpeedTestRequest
+	(*SpeedTestResponse)(nil),       // 79: picontrol.SpeedTestResponse
+	(*DiscoverDevicesRequest)(nil),  // 80: picontrol.DiscoverDevicesRequest
+	(*DiscoveredDevice)(nil),        // 81: picontrol.DiscoveredDevice
+	(*FileChunk)(nil),               // 82: picontrol.FileChunk
+	(*FileUploadResponse)(nil),      // 83: picontrol.FileUploadResponse
+	(*FileDownloadRequest)(nil),     // 84: picontrol.FileDownloadRequest
+	(*FileDeleteRequest)(nil),       // 85: picontrol.FileDeleteRequest
+	(*FileDeleteResponse)(nil),      // 86: picontrol.FileDeleteResponse
+	(*DockerFilter)(nil),            // 87: picontrol.DockerFilter
+	(*ContainerId)(nil),             // 88: picontrol.ContainerId
+	(*ContainerList)(nil),           // 89: picontrol.ContainerList
+	(*ContainerInfo)(nil),           // 90: picontrol.ContainerInfo
+	(*LogRequest)(nil),              // 91: picontrol.LogRequest
+	(*ContainerCopyRequest)(nil),    // 92: picontrol.ContainerCopyRequest
+	(*InspectContainerRequest)(nil), // 93: picontrol.InspectContainerRequest
+	(*ContainerDetails)(nil),        // 94: picontrol.ContainerDetails
+	(*ContainerMount)(nil),          // 95: picontrol.ContainerMount
+	(*ContainerNetwork)(nil),        // 96: picontrol.ContainerNetwork
+	(*ContainerHealth)(nil),         // 97: picontrol.ContainerHealth
+	(*HealthCheckResult)(nil),       // 98: picontrol.HealthCheckResult
+	(*ContainerResources)(nil),      // 99: picontrol.ContainerResources
+	(*VolumeInfo)(nil),              // 100: picontrol.VolumeInfo
+	(*VolumeList)(nil),              // 101: picontrol.VolumeList
+	(*CreateVolumeRequest)(nil),     // 102: picontrol.CreateVolumeRequest
+	(*RemoveVolumeRequest)(nil),     // 103: picontrol.RemoveVolumeRequest
+	(*PruneVolumesRequest)(nil),     // 104: picontrol.PruneVolumesRequest
+	(*PruneResponse)(nil),           // 105: picontrol.PruneResponse
+	(*DockerNetworkInfo)(nil),       // 106: picontrol.DockerNetworkInfo
+	(*DockerNetworkList)(nil),       // 107: picontrol.DockerNetworkList
+	(*DockerNetworkId)(nil),         // 108: picontrol.DockerNetworkId
+	(*CreateNetworkRequest)(nil),    // 109: picontrol.CreateNetworkRequest
+	(*NetworkConnectRequest)(nil),   // 110: picontrol.NetworkConnectRequest
+	(*ComposeProject)(nil),          // 111: picontrol.ComposeProject
+	(*ComposeService)(nil),          // 112: picontrol.ComposeService
+	(*ComposeProjectList)(nil),      // 113: picontrol.ComposeProjectList
+	(*ComposeCommand)(nil),          // 114: picontrol.ComposeCommand
+	(*ComposeOutput)(nil),           // 115: picontrol.ComposeOutput
+	(*SystemUpdateStatus)(nil),      // 116: picontrol.SystemUpdateStatus
+	(*PowerRequest)(nil),            // 117: picontrol.PowerRequest
+	(*UpgradablePackage)(nil),       // 118: picontrol.UpgradablePackage
+	(*UpgradePolicy)(nil),           // 119: picontrol.UpgradePolicy
+	(*UpgradeRun)(nil),              // 120: picontrol.UpgradeRun
+	(*UpgradeRunList)(nil),          // 121: picontrol.UpgradeRunList
+	(*UpgradeProgress)(nil),         // 122: picontrol.UpgradeProgress
+	nil,                             // 123: picontrol.ContainerDetails.LabelsEntry
+	nil,                             // 124: picontrol.VolumeInfo.LabelsEntry
+	nil,                             // 125: picontrol.CreateVolumeRequest.LabelsEntry
+	nil,                             // 126: picontrol.CreateVolumeRequest.DriverOptsEntry
+	nil,                             // 127: picontrol.DockerNetworkInfo.LabelsEntry
+	nil,                             // 128: picontrol.CreateNetworkRequest.LabelsEntry
 }
 var file_pi_control_proto_depIdxs = []int32{
-	3,  // 0: picontrol.LiveStats.top_processes:type_name -> picontrol.ProcessInfo
-	26, // 1: picontrol.LiveStats.disk_io:type_name -> picontrol.DiskIOStat
-	3,  // 2: picontrol.ProcessList.processes:type_name -> picontrol.ProcessInfo
-	6,  // 3: picontrol.ServiceList.services:type_name -> picontrol.ServiceInfo
-	0,  // 4: picontrol.ServiceCommand.action:type_name -> picontrol.ServiceAction
-	13, // 5: picontrol.DiskInfo.partitions:type_name -> picontrol.DiskPartition
-	15, // 6: picontrol.NetworkInfo.interfaces:type_name -> picontrol.NetworkInterface
-	17, // 7: picontrol.NetworkConnectionList.connections:type_name -> picontrol.NetworkConnection
-	19, // 8: picontrol.PackageList.packages:type_name -> picontrol.PackageInfo
-	30, // 9: picontrol.PingResponse.statistics:type_name -> picontrol.PingStats
-	35, // 10: picontrol.DNSResponse.records:type_name -> picontrol.DNSRecord
-	39, // 11: picontrol.WifiInfo.available_networks:type_name -> picontrol.WifiNetwork
-	50, // 12: picontrol.ContainerList.containers:type_name -> picontrol.ContainerInfo
-	53, // 13: picontrol.SystemUpdateStatus.upgradable_packages:type_name -> picontrol.UpgradablePackage
-	1,  // 14: picontrol.SystemMonitor.StreamStats:input_type -> picontrol.Empty
-	1,  // 15: picontrol.SystemMonitor.ListProcesses:input_type -> picontrol.Empty
-	5,  // 16: picontrol.SystemMonitor.KillProcess:input_type -> picontrol.ProcessId
-	5,  // 17: picontrol.SystemMonitor.PauseProcess:input_type -> picontrol.ProcessId
-	5,  // 18: picontrol.SystemMonitor.ResumeProcess:input_type -> picontrol.ProcessId
-	1,  // 19: picontrol.SystemMonitor.ListServices:input_type -> picontrol.Empty
-	8,  // 20: picontrol.SystemMonitor.ManageService:input_type -> picontrol.ServiceCommand
-	10, // 21: picontrol.SystemMonitor.StreamLogs:input_type -> picontrol.LogFilter
-	1,  // 22: picontrol.SystemMonitor.GetDiskInfo:input_type -> picontrol.Empty
-	1,  // 23: picontrol.SystemMonitor.GetNetworkInfo:input_type -> picontrol.Empty
-	1,  // 24: picontrol.SystemMonitor.GetNetworkConnections:input_type -> picontrol.Empty
-	18, // 25: picontrol.SystemMonitor.ListPackages:input_type -> picontrol.PackageFilter
-	21, // 26: picontrol.SystemMonitor.InstallPackage:input_type -> picontrol.PackageCommand
-	21, // 27: picontrol.SystemMonitor.RemovePackage:input_type -> picontrol.PackageCommand
-	21, // 28: picontrol.SystemMonitor.UpdatePackage:input_type -> picontrol.PackageCommand
-	1,  // 29: picontrol.SystemMonitor.UpdatePackageList:input_type -> picontrol.Empty
-	1,  // 30: picontrol.SystemMonitor.UpgradePackages:input_type -> picontrol.Empty
-	1,  // 31: picontrol.SystemMonitor.GetVersion:input_type -> picontrol.Empty
-	22, // 32: picontrol.SystemMonitor.GetPackageDetails:input_type -> picontrol.PackageDetailsRequest
-	22, // 33: picontrol.SystemMonitor.GetPackageDependencies:input_type -> picontrol.PackageDetailsRequest
-	21, // 34: picontrol.SystemMonitor.StreamPackageOperation:input_type -> picontrol.PackageCommand
-	28, // 35: picontrol.SystemMonitor.PingHost:input_type -> picontrol.PingRequest
-	31, // 36: picontrol.SystemMonitor.ScanPorts:input_type -> picontrol.PortScanRequest
-	33, // 37: picontrol.SystemMonitor.DNSLookup:input_type -> picontrol.DNSRequest
-	36, // 38: picontrol.SystemMonitor.Traceroute:input_type -> picontrol.TracerouteRequest
-	1,  // 39: picontrol.SystemMonitor.GetWifiInfo:input_type -> picontrol.Empty
-	40, // 40: picontrol.SystemMonitor.TestNetworkSpeed:input_type -> picontrol.SpeedTestRequest
-	42, // 41: picontrol.SystemMonitor.UploadFile:input_type -> picontrol.FileChunk
-	44, // 42: picontrol.SystemMonitor.DownloadFile:input_type -> picontrol.FileDownloadRequest
-	45, // 43: picontrol.SystemMonitor.DeleteFile:input_type -> picontrol.FileDeleteRequest
-	1,  // 44: picontrol.SystemMonitor.GetSystemUpdateStatus:input_type -> picontrol.Empty
-	1,  // 45: picontrol.SystemMonitor.StreamSystemUpgrade:input_type -> picontrol.Empty
-	47, // 46: picontrol.DockerService.ListContainers:input_type -> picontrol.DockerFilter
-	48, // 47: picontrol.DockerService.StartContainer:input_type -> picontrol.ContainerId
-	48, // 48: picontrol.DockerService.StopContainer:input_type -> picontrol.ContainerId
-	48, // 49: picontrol.DockerService.RestartContainer:input_type -> picontrol.ContainerId
-	51, // 50: picontrol.DockerService.GetContainerLogs:input_type -> picontrol.LogRequest
-	2,  // 51: picontrol.SystemMonitor.StreamStats:output_type -> picontrol.LiveStats
-	4,  // 52: picontrol.SystemMonitor.ListProcesses:output_type -> picontrol.ProcessList
-	9,  // 53: picontrol.SystemMonitor.KillProcess:output_type -> picontrol.ActionStatus
-	9,  // 54: picontrol.SystemMonitor.PauseProcess:output_type -> picontrol.ActionStatus
-	9,  // 55: picontrol.SystemMonitor.ResumeProcess:output_type -> picontrol.ActionStatus
-	7,  // 56: picontrol.SystemMonitor.ListServices:output_type -> picontrol.ServiceList
-	9,  // 57: picontrol.SystemMonitor.ManageService:output_type -> picontrol.ActionStatus
-	11, // 58: picontrol.SystemMonitor.StreamLogs:output_type -> picontrol.LogEntry
-	12, // 59: picontrol.SystemMonitor.GetDiskInfo:output_type -> picontrol.DiskInfo
-	14, // 60: picontrol.SystemMonitor.GetNetworkInfo:output_type -> picontrol.NetworkInfo
-	16, // 61: picontrol.SystemMonitor.GetNetworkConnections:output_type -> picontrol.NetworkConnectionList
-	20, // 62: picontrol.SystemMonitor.ListPackages:output_type -> picontrol.PackageList
-	9,  // 63: picontrol.SystemMonitor.InstallPackage:output_type -> picontrol.ActionStatus
-	9,  // 64: picontrol.SystemMonitor.RemovePackage:output_type -> picontrol.ActionStatus
-	9,  // 65: picontrol.SystemMonitor.UpdatePackage:output_type -> picontrol.ActionStatus
-	9,  // 66: picontrol.SystemMonitor.UpdatePackageList:output_type -> picontrol.ActionStatus
-	9,  // 67: picontrol.SystemMonitor.UpgradePackages:output_type -> picontrol.ActionStatus
-	27, // 68: picontrol.SystemMonitor.GetVersion:output_type -> picontrol.VersionInfo
-	23, // 69: picontrol.SystemMonitor.GetPackageDetails:output_type -> picontrol.PackageDetails
-	24, // 70: picontrol.SystemMonitor.GetPackageDependencies:output_type -> picontrol.PackageDependencies
-	25, // 71: picontrol.SystemMonitor.StreamPackageOperation:output_type -> picontrol.PackageOperationLog
-	29, // 72: picontrol.SystemMonitor.PingHost:output_type -> picontrol.PingResponse
-	32, // 73: picontrol.SystemMonitor.ScanPorts:output_type -> picontrol.PortScanResponse
-	34, // 74: picontrol.SystemMonitor.DNSLookup:output_type -> picontrol.DNSResponse
-	37, // 75: picontrol.SystemMonitor.Traceroute:output_type -> picontrol.TracerouteResponse
-	38, // 76: picontrol.SystemMonitor.GetWifiInfo:output_type -> picontrol.WifiInfo
-	41, // 77: picontrol.SystemMonitor.TestNetworkSpeed:output_type -> picontrol.SpeedTestResponse
-	43, // 78: picontrol.SystemMonitor.UploadFile:output_type -> picontrol.FileUploadResponse
-	42, // 79: picontrol.SystemMonitor.DownloadFile:output_type -> picontrol.FileChunk
-	46, // 80: picontrol.SystemMonitor.DeleteFile:output_type -> picontrol.FileDeleteResponse
-	52, // 81: picontrol.SystemMonitor.GetSystemUpdateStatus:output_type -> picontrol.SystemUpdateStatus
-	54, // 82: picontrol.SystemMonitor.StreamSystemUpgrade:output_type -> picontrol.UpgradeProgress
-	49, // 83: picontrol.DockerService.ListContainers:output_type -> picontrol.ContainerList
-	9,  // 84: picontrol.DockerService.StartContainer:output_type -> picontrol.ActionStatus
-	9,  // 85: picontrol.DockerService.StopContainer:output_type -> picontrol.ActionStatus
-	9,  // 86: picontrol.DockerService.RestartContainer:output_type -> picontrol.ActionStatus
-	11, // 87: picontrol.DockerService.GetContainerLogs:output_type -> picontrol.LogEntry
-	51, // [51:88] is the sub-list for method output_type
-	14, // [14:51] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	10,  // 0: picontrol.LiveStats.top_processes:type_name -> picontrol.ProcessInfo
+	55,  // 1: picontrol.LiveStats.disk_io:type_name -> picontrol.DiskIOStat
+	56,  // 2: picontrol.LiveStats.interfaces:type_name -> picontrol.InterfaceStats
+	10,  // 3: picontrol.ProcessList.processes:type_name -> picontrol.ProcessInfo
+	13,  // 4: picontrol.ServiceList.services:type_name -> picontrol.ServiceInfo
+	0,   // 5: picontrol.ServiceCommand.action:type_name -> picontrol.ServiceAction
+	20,  // 6: picontrol.DiskInfo.partitions:type_name -> picontrol.DiskPartition
+	22,  // 7: picontrol.NetworkInfo.interfaces:type_name -> picontrol.NetworkInterface
+	24,  // 8: picontrol.NetworkConnectionList.connections:type_name -> picontrol.NetworkConnection
+	28,  // 9: picontrol.PackageList.packages:type_name -> picontrol.PackageInfo
+	1,   // 10: picontrol.PackageCommand.operation:type_name -> picontrol.PackageOperation
+	35,  // 11: picontrol.PackageSimulation.install:type_name -> picontrol.SimulatedPackage
+	35,  // 12: picontrol.PackageSimulation.upgrade:type_name -> picontrol.SimulatedPackage
+	35,  // 13: picontrol.PackageSimulation.remove:type_name -> picontrol.SimulatedPackage
+	35,  // 14: picontrol.PackageSimulation.held_back:type_name -> picontrol.SimulatedPackage
+	36,  // 15: picontrol.PackageSourceList.sources:type_name -> picontrol.PackageSource
+	36,  // 16: picontrol.AddPackageSourceRequest.source:type_name -> picontrol.PackageSource
+	43,  // 17: picontrol.PackageTransaction.changes:type_name -> picontrol.PackageChange
+	44,  // 18: picontrol.PackageHistory.transactions:type_name -> picontrol.PackageTransaction
+	46,  // 19: picontrol.PackageHoldList.holds:type_name -> picontrol.PackageHold
+	49,  // 20: picontrol.PackagePinList.pins:type_name -> picontrol.PackagePin
+	2,   // 21: picontrol.PackageJob.state:type_name -> picontrol.JobState
+	52,  // 22: picontrol.PackageJobList.jobs:type_name -> picontrol.PackageJob
+	60,  // 23: picontrol.PingResponse.statistics:type_name -> picontrol.PingStats
+	3,   // 24: picontrol.PortScanRequest.protocol:type_name -> picontrol.PortScanProtocol
+	65,  // 25: picontrol.DNSResponse.records:type_name -> picontrol.DNSRecord
+	4,   // 26: picontrol.TracerouteRequest.probe:type_name -> picontrol.TracerouteProbe
+	68,  // 27: picontrol.TracerouteResponse.probes:type_name -> picontrol.TracerouteProbeResult
+	70,  // 28: picontrol.WifiInfo.available_networks:type_name -> picontrol.WifiNetwork
+	77,  // 29: picontrol.WifiInfo.channels:type_name -> picontrol.WifiChannelStats
+	70,  // 30: picontrol.ScanWifiResponse.networks:type_name -> picontrol.WifiNetwork
+	77,  // 31: picontrol.ScanWifiResponse.channels:type_name -> picontrol.WifiChannelStats
+	73,  // 32: picontrol.SavedWifiNetworkList.networks:type_name -> picontrol.SavedWifiNetwork
+	90,  // 33: picontrol.ContainerList.containers:type_name -> picontrol.ContainerInfo
+	5,   // 34: picontrol.LogRequest.stream:type_name -> picontrol.LogStream
+	95,  // 35: picontrol.ContainerDetails.mounts:type_name -> picontrol.ContainerMount
+	96,  // 36: picontrol.ContainerDetails.networks:type_name -> picontrol.ContainerNetwork
+	123, // 37: picontrol.ContainerDetails.labels:type_name -> picontrol.ContainerDetails.LabelsEntry
+	97,  // 38: picontrol.ContainerDetails.health:type_name -> picontrol.ContainerHealth
+	99,  // 39: picontrol.ContainerDetails.resources:type_name -> picontrol.ContainerResources
+	98,  // 40: picontrol.ContainerHealth.log:type_name -> picontrol.HealthCheckResult
+	124, // 41: picontrol.VolumeInfo.labels:type_name -> picontrol.VolumeInfo.LabelsEntry
+	100, // 42: picontrol.VolumeList.volumes:type_name -> picontrol.VolumeInfo
+	125, // 43: picontrol.CreateVolumeRequest.labels:type_name -> picontrol.CreateVolumeRequest.LabelsEntry
+	126, // 44: picontrol.CreateVolumeRequest.driver_opts:type_name -> picontrol.CreateVolumeRequest.DriverOptsEntry
+	127, // 45: picontrol.DockerNetworkInfo.labels:type_name -> picontrol.DockerNetworkInfo.LabelsEntry
+	106, // 46: picontrol.DockerNetworkList.networks:type_name -> picontrol.DockerNetworkInfo
+	128, // 47: picontrol.CreateNetworkRequest.labels:type_name -> picontrol.CreateNetworkRequest.LabelsEntry
+	112, // 48: picontrol.ComposeProject.services:type_name -> picontrol.ComposeService
+	111, // 49: picontrol.ComposeProjectList.projects:type_name -> picontrol.ComposeProject
+	6,   // 50: picontrol.ComposeCommand.action:type_name -> picontrol.ComposeAction
+	118, // 51: picontrol.SystemUpdateStatus.upgradable_packages:type_name -> picontrol.UpgradablePackage
+	7,   // 52: picontrol.UpgradePolicy.reboot:type_name -> picontrol.RebootPolicy
+	118, // 53: picontrol.UpgradeRun.upgraded:type_name -> picontrol.UpgradablePackage
+	118, // 54: picontrol.UpgradeRun.held:type_name -> picontrol.UpgradablePackage
+	120, // 55: picontrol.UpgradeRunList.runs:type_name -> picontrol.UpgradeRun
+	8,   // 56: picontrol.SystemMonitor.StreamStats:input_type -> picontrol.Empty
+	8,   // 57: picontrol.SystemMonitor.ListProcesses:input_type -> picontrol.Empty
+	12,  // 58: picontrol.SystemMonitor.KillProcess:input_type -> picontrol.ProcessId
+	12,  // 59: picontrol.SystemMonitor.PauseProcess:input_type -> picontrol.ProcessId
+	12,  // 60: picontrol.SystemMonitor.ResumeProcess:input_type -> picontrol.ProcessId
+	8,   // 61: picontrol.SystemMonitor.ListServices:input_type -> picontrol.Empty
+	15,  // 62: picontrol.SystemMonitor.ManageService:input_type -> picontrol.ServiceCommand
+	17,  // 63: picontrol.SystemMonitor.StreamLogs:input_type -> picontrol.LogFilter
+	8,   // 64: picontrol.SystemMonitor.GetDiskInfo:input_type -> picontrol.Empty
+	8,   // 65: picontrol.SystemMonitor.GetNetworkInfo:input_type -> picontrol.Empty
+	8,   // 66: picontrol.SystemMonitor.GetNetworkConnections:input_type -> picontrol.Empty
+	25,  // 67: picontrol.SystemMonitor.GetInterfaceConfig:input_type -> picontrol.InterfaceConfigRequest
+	26,  // 68: picontrol.SystemMonitor.SetInterfaceConfig:input_type -> picontrol.InterfaceConfig
+	25,  // 69: picontrol.SystemMonitor.ConfirmInterfaceConfig:input_type -> picontrol.InterfaceConfigRequest
+	25,  // 70: picontrol.SystemMonitor.RevertInterfaceConfig:input_type -> picontrol.InterfaceConfigRequest
+	27,  // 71: picontrol.SystemMonitor.ListPackages:input_type -> picontrol.PackageFilter
+	30,  // 72: picontrol.SystemMonitor.InstallPackage:input_type -> picontrol.PackageCommand
+	30,  // 73: picontrol.SystemMonitor.RemovePackage:input_type -> picontrol.PackageCommand
+	30,  // 74: picontrol.SystemMonitor.UpdatePackage:input_type -> picontrol.PackageCommand
+	8,   // 75: picontrol.SystemMonitor.UpdatePackageList:input_type -> picontrol.Empty
+	8,   // 76: picontrol.SystemMonitor.UpgradePackages:input_type -> picontrol.Empty
+	8,   // 77: picontrol.SystemMonitor.ListPackageSources:input_type -> picontrol.Empty
+	38,  // 78: picontrol.SystemMonitor.AddPackageSource:input_type -> picontrol.AddPackageSourceRequest
+	39,  // 79: picontrol.SystemMonitor.SetPackageSourceEnabled:input_type -> picontrol.PackageSourceToggle
+	40,  // 80: picontrol.SystemMonitor.RemovePackageSource:input_type -> picontrol.PackageSourceId
+	41,  // 81: picontrol.SystemMonitor.ImportSigningKey:input_type -> picontrol.SigningKeyRequest
+	42,  // 82: picontrol.SystemMonitor.GetPackageHistory:input_type -> picontrol.PackageHistoryRequest
+	8,   // 83: picontrol.SystemMonitor.GetVersion:input_type -> picontrol.Empty
+	31,  // 84: picontrol.SystemMonitor.GetPackageDetails:input_type -> picontrol.PackageDetailsRequest
+	31,  // 85: picontrol.SystemMonitor.GetPackageDependencies:input_type -> picontrol.PackageDetailsRequest
+	30,  // 86: picontrol.SystemMonitor.StreamPackageOperation:input_type -> picontrol.PackageCommand
+	30,  // 87: picontrol.SystemMonitor.SimulatePackageOperation:input_type -> picontrol.PackageCommand
+	8,   // 88: picontrol.SystemMonitor.ListPackageHolds:input_type -> picontrol.Empty
+	48,  // 89: picontrol.SystemMonitor.SetPackageHold:input_type -> picontrol.PackageHoldRequest
+	8,   // 90: picontrol.SystemMonitor.ListPackagePins:input_type -> picontrol.Empty
+	49,  // 91: picontrol.SystemMonitor.SetPackagePin:input_type -> picontrol.PackagePin
+	49,  // 92: picontrol.SystemMonitor.ClearPackagePin:input_type -> picontrol.PackagePin
+	8,   // 93: picontrol.SystemMonitor.ListJobs:input_type -> picontrol.Empty
+	54,  // 94: picontrol.SystemMonitor.GetJob:input_type -> picontrol.PackageJobId
+	54,  // 95: picontrol.SystemMonitor.CancelJob:input_type -> picontrol.PackageJobId
+	54,  // 96: picontrol.SystemMonitor.AttachJob:input_type -> picontrol.PackageJobId
+	58,  // 97: picontrol.SystemMonitor.PingHost:input_type -> picontrol.PingRequest
+	61,  // 98: picontrol.SystemMonitor.ScanPorts:input_type -> picontrol.PortScanRequest
+	63,  // 99: picontrol.SystemMonitor.DNSLookup:input_type -> picontrol.DNSRequest
+	66,  // 100: picontrol.SystemMonitor.Traceroute:input_type -> picontrol.TracerouteRequest
+	8,   // 101: picontrol.SystemMonitor.GetWifiInfo:input_type -> picontrol.Empty
+	71,  // 102: picontrol.SystemMonitor.ScanWifi:input_type -> picontrol.ScanWifiRequest
+	8,   // 103: picontrol.SystemMonitor.ListSavedWifiNetworks:input_type -> picontrol.Empty
+	75,  // 104: picontrol.SystemMonitor.AddWifiNetwork:input_type -> picontrol.AddWifiNetworkRequest
+	76,  // 105: picontrol.SystemMonitor.ConnectWifiNetwork:input_type -> picontrol.WifiNetworkRequest
+	76,  // 106: picontrol.SystemMonitor.ForgetWifiNetwork:input_type -> picontrol.WifiNetworkRequest
+	76,  // 107: picontrol.SystemMonitor.SetWifiNetworkPriority:input_type -> picontrol.WifiNetworkRequest
+	78,  // 108: picontrol.SystemMonitor.TestNetworkSpeed:input_type -> picontrol.SpeedTestRequest
+	80,  // 109: picontrol.SystemMonitor.DiscoverDevices:input_type -> picontrol.DiscoverDevicesRequest
+	82,  // 110: picontrol.SystemMonitor.UploadFile:input_type -> picontrol.FileChunk
+	84,  // 111: picontrol.SystemMonitor.DownloadFile:input_type -> picontrol.FileDownloadRequest
+	85,  // 112: picontrol.SystemMonitor.DeleteFile:input_type -> picontrol.FileDeleteRequest
+	8,   // 113: picontrol.SystemMonitor.GetSystemUpdateStatus:input_type -> picontrol.Empty
+	8,   // 114: picontrol.SystemMonitor.StreamSystemUpgrade:input_type -> picontrol.Empty
+	8,   // 115: picontrol.SystemMonitor.GetUpgradePolicy:input_type -> picontrol.Empty
+	119, // 116: picontrol.SystemMonitor.SetUpgradePolicy:input_type -> picontrol.UpgradePolicy
+	8,   // 117: picontrol.SystemMonitor.RunUpgradeNow:input_type -> picontrol.Empty
+	8,   // 118: picontrol.SystemMonitor.ListUpgradeRuns:input_type -> picontrol.Empty
+	117, // 119: picontrol.SystemMonitor.Reboot:input_type -> picontrol.PowerRequest
+	117, // 120: picontrol.SystemMonitor.Shutdown:input_type -> picontrol.PowerRequest
+	8,   // 121: picontrol.SystemMonitor.CancelShutdown:input_type -> picontrol.Empty
+	87,  // 122: picontrol.DockerService.ListContainers:input_type -> picontrol.DockerFilter
+	88,  // 123: picontrol.DockerService.StartContainer:input_type -> picontrol.ContainerId
+	88,  // 124: picontrol.DockerService.StopContainer:input_type -> picontrol.ContainerId
+	88,  // 125: picontrol.DockerService.RestartContainer:input_type -> picontrol.ContainerId
+	91,  // 126: picontrol.DockerService.GetContainerLogs:input_type -> picontrol.LogRequest
+	92,  // 127: picontrol.DockerService.CopyFromContainer:input_type -> picontrol.ContainerCopyRequest
+	82,  // 128: picontrol.DockerService.CopyToContainer:input_type -> picontrol.FileChunk
+	93,  // 129: picontrol.DockerService.InspectContainer:input_type -> picontrol.InspectContainerRequest
+	8,   // 130: picontrol.DockerService.ListVolumes:input_type -> picontrol.Empty
+	102, // 131: picontrol.DockerService.CreateVolume:input_type -> picontrol.CreateVolumeRequest
+	103, // 132: picontrol.DockerService.RemoveVolume:input_type -> picontrol.RemoveVolumeRequest
+	104, // 133: picontrol.DockerService.PruneVolumes:input_type -> picontrol.PruneVolumesRequest
+	8,   // 134: picontrol.DockerService.ListNetworks:input_type -> picontrol.Empty
+	109, // 135: picontrol.DockerService.CreateNetwork:input_type -> picontrol.CreateNetworkRequest
+	108, // 136: picontrol.DockerService.RemoveNetwork:input_type -> picontrol.DockerNetworkId
+	110, // 137: picontrol.DockerService.ConnectNetwork:input_type -> picontrol.NetworkConnectRequest
+	110, // 138: picontrol.DockerService.DisconnectNetwork:input_type -> picontrol.NetworkConnectRequest
+	8,   // 139: picontrol.DockerService.ListComposeProjects:input_type -> picontrol.Empty
+	114, // 140: picontrol.DockerService.ManageComposeProject:input_type -> picontrol.ComposeCommand
+	9,   // 141: picontrol.SystemMonitor.StreamStats:output_type -> picontrol.LiveStats
+	11,  // 142: picontrol.SystemMonitor.ListProcesses:output_type -> picontrol.ProcessList
+	16,  // 143: picontrol.SystemMonitor.KillProcess:output_type -> picontrol.ActionStatus
+	16,  // 144: picontrol.SystemMonitor.PauseProcess:output_type -> picontrol.ActionStatus
+	16,  // 145: picontrol.SystemMonitor.ResumeProcess:output_type -> picontrol.ActionStatus
+	14,  // 146: picontrol.SystemMonitor.ListServices:output_type -> picontrol.ServiceList
+	16,  // 147: picontrol.SystemMonitor.ManageService:output_type -> picontrol.ActionStatus
+	18,  // 148: picontrol.SystemMonitor.StreamLogs:output_type -> picontrol.LogEntry
+	19,  // 149: picontrol.SystemMonitor.GetDiskInfo:output_type -> picontrol.DiskInfo
+	21,  // 150: picontrol.SystemMonitor.GetNetworkInfo:output_type -> picontrol.NetworkInfo
+	23,  // 151: picontrol.SystemMonitor.GetNetworkConnections:output_type -> picontrol.NetworkConnectionList
+	26,  // 152: picontrol.SystemMonitor.GetInterfaceConfig:output_type -> picontrol.InterfaceConfig
+	16,  // 153: picontrol.SystemMonitor.SetInterfaceConfig:output_type -> picontrol.ActionStatus
+	16,  // 154: picontrol.SystemMonitor.ConfirmInterfaceConfig:output_type -> picontrol.ActionStatus
+	16,  // 155: picontrol.SystemMonitor.RevertInterfaceConfig:output_type -> picontrol.ActionStatus
+	29,  // 156: picontrol.SystemMonitor.ListPackages:output_type -> picontrol.PackageList
+	16,  // 157: picontrol.SystemMonitor.InstallPackage:output_type -> picontrol.ActionStatus
+	16,  // 158: picontrol.SystemMonitor.RemovePackage:output_type -> picontrol.ActionStatus
+	16,  // 159: picontrol.SystemMonitor.UpdatePackage:output_type -> picontrol.ActionStatus
+	16,  // 160: picontrol.SystemMonitor.UpdatePackageList:output_type -> picontrol.ActionStatus
+	16,  // 161: picontrol.SystemMonitor.UpgradePackages:output_type -> picontrol.ActionStatus
+	37,  // 162: picontrol.SystemMonitor.ListPackageSources:output_type -> picontrol.PackageSourceList
+	16,  // 163: picontrol.SystemMonitor.AddPackageSource:output_type -> picontrol.ActionStatus
+	16,  // 164: picontrol.SystemMonitor.SetPackageSourceEnabled:output_type -> picontrol.ActionStatus
+	16,  // 165: picontrol.SystemMonitor.RemovePackageSource:output_type -> picontrol.ActionStatus
+	16,  // 166: picontrol.SystemMonitor.ImportSigningKey:output_type -> picontrol.ActionStatus
+	45,  // 167: picontrol.SystemMonitor.GetPackageHistory:output_type -> picontrol.PackageHistory
+	57,  // 168: picontrol.SystemMonitor.GetVersion:output_type -> picontrol.VersionInfo
+	32,  // 169: picontrol.SystemMonitor.GetPackageDetails:output_type -> picontrol.PackageDetails
+	33,  // 170: picontrol.SystemMonitor.GetPackageDependencies:output_type -> picontrol.PackageDependencies
+	51,  // 171: picontrol.SystemMonitor.StreamPackageOperation:output_type -> picontrol.PackageOperationLog
+	34,  // 172: picontrol.SystemMonitor.SimulatePackageOperation:output_type -> picontrol.PackageSimulation
+	47,  // 173: picontrol.SystemMonitor.ListPackageHolds:output_type -> picontrol.PackageHoldList
+	16,  // 174: picontrol.SystemMonitor.SetPackageHold:output_type -> picontrol.ActionStatus
+	50,  // 175: picontrol.SystemMonitor.ListPackagePins:output_type -> picontrol.PackagePinList
+	16,  // 176: picontrol.SystemMonitor.SetPackagePin:output_type -> picontrol.ActionStatus
+	16,  // 177: picontrol.SystemMonitor.ClearPackagePin:output_type -> picontrol.ActionStatus
+	53,  // 178: picontrol.SystemMonitor.ListJobs:output_type -> picontrol.PackageJobList
+	52,  // 179: picontrol.SystemMonitor.GetJob:output_type -> picontrol.PackageJob
+	16,  // 180: picontrol.SystemMonitor.CancelJob:output_type -> picontrol.ActionStatus
+	51,  // 181: picontrol.SystemMonitor.AttachJob:output_type -> picontrol.PackageOperationLog
+	59,  // 182: picontrol.SystemMonitor.PingHost:output_type -> picontrol.PingResponse
+	62,  // 183: picontrol.SystemMonitor.ScanPorts:output_type -> picontrol.PortScanResponse
+	64,  // 184: picontrol.SystemMonitor.DNSLookup:output_type -> picontrol.DNSResponse
+	67,  // 185: picontrol.SystemMonitor.Traceroute:output_type -> picontrol.TracerouteResponse
+	69,  // 186: picontrol.SystemMonitor.GetWifiInfo:output_type -> picontrol.WifiInfo
+	72,  // 187: picontrol.SystemMonitor.ScanWifi:output_type -> picontrol.ScanWifiResponse
+	74,  // 188: picontrol.SystemMonitor.ListSavedWifiNetworks:output_type -> picontrol.SavedWifiNetworkList
+	16,  // 189: picontrol.SystemMonitor.AddWifiNetwork:output_type -> picontrol.ActionStatus
+	16,  // 190: picontrol.SystemMonitor.ConnectWifiNetwork:output_type -> picontrol.ActionStatus
+	16,  // 191: picontrol.SystemMonitor.ForgetWifiNetwork:output_type -> picontrol.ActionStatus
+	16,  // 192: picontrol.SystemMonitor.SetWifiNetworkPriority:output_type -> picontrol.ActionStatus
+	79,  // 193: picontrol.SystemMonitor.TestNetworkSpeed:output_type -> picontrol.SpeedTestResponse
+	81,  // 194: picontrol.SystemMonitor.DiscoverDevices:output_type -> picontrol.DiscoveredDevice
+	83,  // 195: picontrol.SystemMonitor.UploadFile:output_type -> picontrol.FileUploadResponse
+	82,  // 196: picontrol.SystemMonitor.DownloadFile:output_type -> picontrol.FileChunk
+	86,  // 197: picontrol.SystemMonitor.DeleteFile:output_type -> picontrol.FileDeleteResponse
+	116, // 198: picontrol.SystemMonitor.GetSystemUpdateStatus:output_type -> picontrol.SystemUpdateStatus
+	122, // 199: picontrol.SystemMonitor.StreamSystemUpgrade:output_type -> picontrol.UpgradeProgress
+	119, // 200: picontrol.SystemMonitor.GetUpgradePolicy:output_type -> picontrol.UpgradePolicy
+	16,  // 201: picontrol.SystemMonitor.SetUpgradePolicy:output_type -> picontrol.ActionStatus
+	120, // 202: picontrol.SystemMonitor.RunUpgradeNow:output_type -> picontrol.UpgradeRun
+	121, // 203: picontrol.SystemMonitor.ListUpgradeRuns:output_type -> picontrol.UpgradeRunList
+	16,  // 204: picontrol.SystemMonitor.Reboot:output_type -> picontrol.ActionStatus
+	16,  // 205: picontrol.SystemMonitor.Shutdown:output_type -> picontrol.ActionStatus
+	16,  // 206: picontrol.SystemMonitor.CancelShutdown:output_type -> picontrol.ActionStatus
+	89,  // 207: picontrol.DockerService.ListContainers:output_type -> picontrol.ContainerList
+	16,  // 208: picontrol.DockerService.StartContainer:output_type -> picontrol.ActionStatus
+	16,  // 209: picontrol.DockerService.StopContainer:output_type -> picontrol.ActionStatus
+	16,  // 210: picontrol.DockerService.RestartContainer:output_type -> picontrol.ActionStatus
+	18,  // 211: picontrol.DockerService.GetContainerLogs:output_type -> picontrol.LogEntry
+	82,  // 212: picontrol.DockerService.CopyFromContainer:output_type -> picontrol.FileChunk
+	83,  // 213: picontrol.DockerService.CopyToContainer:output_type -> picontrol.FileUploadResponse
+	94,  // 214: picontrol.DockerService.InspectContainer:output_type -> picontrol.ContainerDetails
+	101, // 215: picontrol.DockerService.ListVolumes:output_type -> picontrol.VolumeList
+	16,  // 216: picontrol.DockerService.CreateVolume:output_type -> picontrol.ActionStatus
+	16,  // 217: picontrol.DockerService.RemoveVolume:output_type -> picontrol.ActionStatus
+	105, // 218: picontrol.DockerService.PruneVolumes:output_type -> picontrol.PruneResponse
+	107, // 219: picontrol.DockerService.ListNetworks:output_type -> picontrol.DockerNetworkList
+	16,  // 220: picontrol.DockerService.CreateNetwork:output_type -> picontrol.ActionStatus
+	16,  // 221: picontrol.DockerService.RemoveNetwork:output_type -> picontrol.ActionStatus
+	16,  // 222: picontrol.DockerService.ConnectNetwork:output_type -> picontrol.ActionStatus
+	16,  // 223: picontrol.DockerService.DisconnectNetwork:output_type -> picontrol.ActionStatus
+	113, // 224: picontrol.DockerService.ListComposeProjects:output_type -> picontrol.ComposeProjectList
+	115, // 225: picontrol.DockerService.ManageComposeProject:output_type -> picontrol.ComposeOutput
+	141, // [141:226] is the sub-list for method output_type
+	56,  // [56:141] is the sub-list for method input_type
+	56,  // [56:56] is the sub-list for extension type_name
+	56,  // [56:56] is the sub-list for extension extendee
+	0,   // [0:56] is the sub-list for field type_name
 }
 
 func init() { file_pi_control_proto_init() }
@@ -4656,8 +10805,8 @@ func file_pi_control_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pi_control_proto_rawDesc), len(file_pi_control_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   54,
+			NumEnums:      8,
+			NumMessages:   121,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -19,38 +19,72 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SystemMonitor_StreamStats_FullMethodName            = "/picontrol.SystemMonitor/StreamStats"
-	SystemMonitor_ListProcesses_FullMethodName          = "/picontrol.SystemMonitor/ListProcesses"
-	SystemMonitor_KillProcess_FullMethodName            = "/picontrol.SystemMonitor/KillProcess"
-	SystemMonitor_PauseProcess_FullMethodName           = "/picontrol.SystemMonitor/PauseProcess"
-	SystemMonitor_ResumeProcess_FullMethodName          = "/picontrol.SystemMonitor/ResumeProcess"
-	SystemMonitor_ListServices_FullMethodName           = "/picontrol.SystemMonitor/ListServices"
-	SystemMonitor_ManageService_FullMethodName          = "/picontrol.SystemMonitor/ManageService"
-	SystemMonitor_StreamLogs_FullMethodName             = "/picontrol.SystemMonitor/StreamLogs"
-	SystemMonitor_GetDiskInfo_FullMethodName            = "/picontrol.SystemMonitor/GetDiskInfo"
-	SystemMonitor_GetNetworkInfo_FullMethodName         = "/picontrol.SystemMonitor/GetNetworkInfo"
-	SystemMonitor_GetNetworkConnections_FullMethodName  = "/picontrol.SystemMonitor/GetNetworkConnections"
-	SystemMonitor_ListPackages_FullMethodName           = "/picontrol.SystemMonitor/ListPackages"
-	SystemMonitor_InstallPackage_FullMethodName         = "/picontrol.SystemMonitor/InstallPackage"
-	SystemMonitor_RemovePackage_FullMethodName          = "/picontrol.SystemMonitor/RemovePackage"
-	SystemMonitor_UpdatePackage_FullMethodName          = "/picontrol.SystemMonitor/UpdatePackage"
-	SystemMonitor_UpdatePackageList_FullMethodName      = "/picontrol.SystemMonitor/UpdatePackageList"
-	SystemMonitor_UpgradePackages_FullMethodName        = "/picontrol.SystemMonitor/UpgradePackages"
-	SystemMonitor_GetVersion_FullMethodName             = "/picontrol.SystemMonitor/GetVersion"
-	SystemMonitor_GetPackageDetails_FullMethodName      = "/picontrol.SystemMonitor/GetPackageDetails"
-	SystemMonitor_GetPackageDependencies_FullMethodName = "/picontrol.SystemMonitor/GetPackageDependencies"
-	SystemMonitor_StreamPackageOperation_FullMethodName = "/picontrol.SystemMonitor/StreamPackageOperation"
-	SystemMonitor_PingHost_FullMethodName               = "/picontrol.SystemMonitor/PingHost"
-	SystemMonitor_ScanPorts_FullMethodName              = "/picontrol.SystemMonitor/ScanPorts"
-	SystemMonitor_DNSLookup_FullMethodName              = "/picontrol.SystemMonitor/DNSLookup"
-	SystemMonitor_Traceroute_FullMethodName             = "/picontrol.SystemMonitor/Traceroute"
-	SystemMonitor_GetWifiInfo_FullMethodName            = "/picontrol.SystemMonitor/GetWifiInfo"
-	SystemMonitor_TestNetworkSpeed_FullMethodName       = "/picontrol.SystemMonitor/TestNetworkSpeed"
-	SystemMonitor_UploadFile_FullMethodName             = "/picontrol.SystemMonitor/UploadFile"
-	SystemMonitor_DownloadFile_FullMethodName           = "/picontrol.SystemMonitor/DownloadFile"
-	SystemMonitor_DeleteFile_FullMethodName             = "/picontrol.SystemMonitor/DeleteFile"
-	SystemMonitor_GetSystemUpdateStatus_FullMethodName  = "/picontrol.SystemMonitor/GetSystemUpdateStatus"
-	SystemMonitor_StreamSystemUpgrade_FullMethodName    = "/picontrol.SystemMonitor/StreamSystemUpgrade"
+	SystemMonitor_StreamStats_FullMethodName              = "/picontrol.SystemMonitor/StreamStats"
+	SystemMonitor_ListProcesses_FullMethodName            = "/picontrol.SystemMonitor/ListProcesses"
+	SystemMonitor_KillProcess_FullMethodName              = "/picontrol.SystemMonitor/KillProcess"
+	SystemMonitor_PauseProcess_FullMethodName             = "/picontrol.SystemMonitor/PauseProcess"
+	SystemMonitor_ResumeProcess_FullMethodName            = "/picontrol.SystemMonitor/ResumeProcess"
+	SystemMonitor_ListServices_FullMethodName             = "/picontrol.SystemMonitor/ListServices"
+	SystemMonitor_ManageService_FullMethodName            = "/picontrol.SystemMonitor/ManageService"
+	SystemMonitor_StreamLogs_FullMethodName               = "/picontrol.SystemMonitor/StreamLogs"
+	SystemMonitor_GetDiskInfo_FullMethodName              = "/picontrol.SystemMonitor/GetDiskInfo"
+	SystemMonitor_GetNetworkInfo_FullMethodName           = "/picontrol.SystemMonitor/GetNetworkInfo"
+	SystemMonitor_GetNetworkConnections_FullMethodName    = "/picontrol.SystemMonitor/GetNetworkConnections"
+	SystemMonitor_GetInterfaceConfig_FullMethodName       = "/picontrol.SystemMonitor/GetInterfaceConfig"
+	SystemMonitor_SetInterfaceConfig_FullMethodName       = "/picontrol.SystemMonitor/SetInterfaceConfig"
+	SystemMonitor_ConfirmInterfaceConfig_FullMethodName   = "/picontrol.SystemMonitor/ConfirmInterfaceConfig"
+	SystemMonitor_RevertInterfaceConfig_FullMethodName    = "/picontrol.SystemMonitor/RevertInterfaceConfig"
+	SystemMonitor_ListPackages_FullMethodName             = "/picontrol.SystemMonitor/ListPackages"
+	SystemMonitor_InstallPackage_FullMethodName           = "/picontrol.SystemMonitor/InstallPackage"
+	SystemMonitor_RemovePackage_FullMethodName            = "/picontrol.SystemMonitor/RemovePackage"
+	SystemMonitor_UpdatePackage_FullMethodName            = "/picontrol.SystemMonitor/UpdatePackage"
+	SystemMonitor_UpdatePackageList_FullMethodName        = "/picontrol.SystemMonitor/UpdatePackageList"
+	SystemMonitor_UpgradePackages_FullMethodName          = "/picontrol.SystemMonitor/UpgradePackages"
+	SystemMonitor_ListPackageSources_FullMethodName       = "/picontrol.SystemMonitor/ListPackageSources"
+	SystemMonitor_AddPackageSource_FullMethodName         = "/picontrol.SystemMonitor/AddPackageSource"
+	SystemMonitor_SetPackageSourceEnabled_FullMethodName  = "/picontrol.SystemMonitor/SetPackageSourceEnabled"
+	SystemMonitor_RemovePackageSource_FullMethodName      = "/picontrol.SystemMonitor/RemovePackageSource"
+	SystemMonitor_ImportSigningKey_FullMethodName         = "/picontrol.SystemMonitor/ImportSigningKey"
+	SystemMonitor_GetPackageHistory_FullMethodName        = "/picontrol.SystemMonitor/GetPackageHistory"
+	SystemMonitor_GetVersion_FullMethodName               = "/picontrol.SystemMonitor/GetVersion"
+	SystemMonitor_GetPackageDetails_FullMethodName        = "/picontrol.SystemMonitor/GetPackageDetails"
+	SystemMonitor_GetPackageDependencies_FullMethodName   = "/picontrol.SystemMonitor/GetPackageDependencies"
+	SystemMonitor_StreamPackageOperation_FullMethodName   = "/picontrol.SystemMonitor/StreamPackageOperation"
+	SystemMonitor_SimulatePackageOperation_FullMethodName = "/picontrol.SystemMonitor/SimulatePackageOperation"
+	SystemMonitor_ListPackageHolds_FullMethodName         = "/picontrol.SystemMonitor/ListPackageHolds"
+	SystemMonitor_SetPackageHold_FullMethodName           = "/picontrol.SystemMonitor/SetPackageHold"
+	SystemMonitor_ListPackagePins_FullMethodName          = "/picontrol.SystemMonitor/ListPackagePins"
+	SystemMonitor_SetPackagePin_FullMethodName            = "/picontrol.SystemMonitor/SetPackagePin"
+	SystemMonitor_ClearPackagePin_FullMethodName          = "/picontrol.SystemMonitor/ClearPackagePin"
+	SystemMonitor_ListJobs_FullMethodName                 = "/picontrol.SystemMonitor/ListJobs"
+	SystemMonitor_GetJob_FullMethodName                   = "/picontrol.SystemMonitor/GetJob"
+	SystemMonitor_CancelJob_FullMethodName                = "/picontrol.SystemMonitor/CancelJob"
+	SystemMonitor_AttachJob_FullMethodName                = "/picontrol.SystemMonitor/AttachJob"
+	SystemMonitor_PingHost_FullMethodName                 = "/picontrol.SystemMonitor/PingHost"
+	SystemMonitor_ScanPorts_FullMethodName                = "/picontrol.SystemMonitor/ScanPorts"
+	SystemMonitor_DNSLookup_FullMethodName                = "/picontrol.SystemMonitor/DNSLookup"
+	SystemMonitor_Traceroute_FullMethodName               = "/picontrol.SystemMonitor/Traceroute"
+	SystemMonitor_GetWifiInfo_FullMethodName              = "/picontrol.SystemMonitor/GetWifiInfo"
+	SystemMonitor_ScanWifi_FullMethodName                 = "/picontrol.SystemMonitor/ScanWifi"
+	SystemMonitor_ListSavedWifiNetworks_FullMethodName    = "/picontrol.SystemMonitor/ListSavedWifiNetworks"
+	SystemMonitor_AddWifiNetwork_FullMethodName           = "/picontrol.SystemMonitor/AddWifiNetwork"
+	SystemMonitor_ConnectWifiNetwork_FullMethodName       = "/picontrol.SystemMonitor/ConnectWifiNetwork"
+	SystemMonitor_ForgetWifiNetwork_FullMethodName        = "/picontrol.SystemMonitor/ForgetWifiNetwork"
+	SystemMonitor_SetWifiNetworkPriority_FullMethodName   = "/picontrol.SystemMonitor/SetWifiNetworkPriority"
+	SystemMonitor_TestNetworkSpeed_FullMethodName         = "/picontrol.SystemMonitor/TestNetworkSpeed"
+	SystemMonitor_DiscoverDevices_FullMethodName          = "/picontrol.SystemMonitor/DiscoverDevices"
+	SystemMonitor_UploadFile_FullMethodName               = "/picontrol.SystemMonitor/UploadFile"
+	SystemMonitor_DownloadFile_FullMethodName             = "/picontrol.SystemMonitor/DownloadFile"
+	SystemMonitor_DeleteFile_FullMethodName               = "/picontrol.SystemMonitor/DeleteFile"
+	SystemMonitor_GetSystemUpdateStatus_FullMethodName    = "/picontrol.SystemMonitor/GetSystemUpdateStatus"
+	SystemMonitor_StreamSystemUpgrade_FullMethodName      = "/picontrol.SystemMonitor/StreamSystemUpgrade"
+	SystemMonitor_GetUpgradePolicy_FullMethodName         = "/picontrol.SystemMonitor/GetUpgradePolicy"
+	SystemMonitor_SetUpgradePolicy_FullMethodName         = "/picontrol.SystemMonitor/SetUpgradePolicy"
+	SystemMonitor_RunUpgradeNow_FullMethodName            = "/picontrol.SystemMonitor/RunUpgradeNow"
+	SystemMonitor_ListUpgradeRuns_FullMethodName          = "/picontrol.SystemMonitor/ListUpgradeRuns"
+	SystemMonitor_Reboot_FullMethodName                   = "/picontrol.SystemMonitor/Reboot"
+	SystemMonitor_Shutdown_FullMethodName                 = "/picontrol.SystemMonitor/Shutdown"
+	SystemMonitor_CancelShutdown_FullMethodName           = "/picontrol.SystemMonitor/CancelShutdown"
 )
 
 // SystemMonitorClient is the client API for SystemMonitor service.
@@ -79,6 +113,14 @@ type SystemMonitorClient interface {
 	GetNetworkInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NetworkInfo, error)
 	// Get active network connections
 	GetNetworkConnections(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NetworkConnectionList, error)
+	// Get the configured addressing of an interface and any change awaiting confirmation
+	GetInterfaceConfig(ctx context.Context, in *InterfaceConfigRequest, opts ...grpc.CallOption) (*InterfaceConfig, error)
+	// Set static addresses or DHCP on an interface; the change is reverted unless confirmed in time
+	SetInterfaceConfig(ctx context.Context, in *InterfaceConfig, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Keep a change made by SetInterfaceConfig
+	ConfirmInterfaceConfig(ctx context.Context, in *InterfaceConfigRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Undo an unconfirmed change now
+	RevertInterfaceConfig(ctx context.Context, in *InterfaceConfigRequest, opts ...grpc.CallOption) (*ActionStatus, error)
 	// List installed packages (optionally filter)
 	ListPackages(ctx context.Context, in *PackageFilter, opts ...grpc.CallOption) (*PackageList, error)
 	// Install a package
@@ -91,6 +133,18 @@ type SystemMonitorClient interface {
 	UpdatePackageList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ActionStatus, error)
 	// Upgrade packages (apt upgrade)
 	UpgradePackages(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ActionStatus, error)
+	// List APT sources from sources.list, .list and deb822 .sources files
+	ListPackageSources(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PackageSourceList, error)
+	// Add an APT source after checking that apt-get update succeeds for it
+	AddPackageSource(ctx context.Context, in *AddPackageSourceRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Enable or disable an APT source
+	SetPackageSourceEnabled(ctx context.Context, in *PackageSourceToggle, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Remove an APT source
+	RemovePackageSource(ctx context.Context, in *PackageSourceId, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Import a repository signing key into /etc/apt/keyrings
+	ImportSigningKey(ctx context.Context, in *SigningKeyRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Package change history from apt and dpkg logs (newest first)
+	GetPackageHistory(ctx context.Context, in *PackageHistoryRequest, opts ...grpc.CallOption) (*PackageHistory, error)
 	// Get agent version
 	GetVersion(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VersionInfo, error)
 	// Get detailed package information
@@ -99,6 +153,27 @@ type SystemMonitorClient interface {
 	GetPackageDependencies(ctx context.Context, in *PackageDetailsRequest, opts ...grpc.CallOption) (*PackageDependencies, error)
 	// Stream package operation logs (install/remove/update)
 	StreamPackageOperation(ctx context.Context, in *PackageCommand, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PackageOperationLog], error)
+	// Preview what a package operation would change without applying it
+	SimulatePackageOperation(ctx context.Context, in *PackageCommand, opts ...grpc.CallOption) (*PackageSimulation, error)
+	// List packages held at their installed version
+	ListPackageHolds(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PackageHoldList, error)
+	// Hold or release packages (apt-mark hold/unhold)
+	SetPackageHold(ctx context.Context, in *PackageHoldRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// List apt pin priorities from /etc/apt/preferences(.d)
+	ListPackagePins(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PackagePinList, error)
+	// Create or replace an agent-managed pin for a package
+	SetPackagePin(ctx context.Context, in *PackagePin, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Remove the agent-managed pin for a package
+	ClearPackagePin(ctx context.Context, in *PackagePin, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Package job queue (all package manager work runs serially through it)
+	// List queued, running and recently finished package jobs
+	ListJobs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PackageJobList, error)
+	// Get a single package job
+	GetJob(ctx context.Context, in *PackageJobId, opts ...grpc.CallOption) (*PackageJob, error)
+	// Cancel a queued or running package job
+	CancelJob(ctx context.Context, in *PackageJobId, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Stream a job's output from the start, following it until it completes
+	AttachJob(ctx context.Context, in *PackageJobId, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PackageOperationLog], error)
 	// Network Tools
 	// Ping a host and stream results
 	PingHost(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PingResponse], error)
@@ -110,8 +185,22 @@ type SystemMonitorClient interface {
 	Traceroute(ctx context.Context, in *TracerouteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TracerouteResponse], error)
 	// Get WiFi information
 	GetWifiInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WifiInfo, error)
+	// Scan for nearby WiFi networks with per-channel congestion
+	ScanWifi(ctx context.Context, in *ScanWifiRequest, opts ...grpc.CallOption) (*ScanWifiResponse, error)
+	// List saved WiFi networks and the outcome of the last change
+	ListSavedWifiNetworks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SavedWifiNetworkList, error)
+	// Save a WiFi network (or update a saved one), optionally connecting to it
+	AddWifiNetwork(ctx context.Context, in *AddWifiNetworkRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Connect to a saved WiFi network; the previous one is restored if there is no connectivity
+	ConnectWifiNetwork(ctx context.Context, in *WifiNetworkRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Forget a saved WiFi network
+	ForgetWifiNetwork(ctx context.Context, in *WifiNetworkRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Set the autoconnect priority of a saved WiFi network
+	SetWifiNetworkPriority(ctx context.Context, in *WifiNetworkRequest, opts ...grpc.CallOption) (*ActionStatus, error)
 	// Test network speed (download/upload)
 	TestNetworkSpeed(ctx context.Context, in *SpeedTestRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SpeedTestResponse], error)
+	// Find devices on the local subnets; each device is streamed when found or updated
+	DiscoverDevices(ctx context.Context, in *DiscoverDevicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DiscoveredDevice], error)
 	// File Transfer
 	// Upload a file using streaming chunks
 	UploadFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileChunk, FileUploadResponse], error)
@@ -124,6 +213,21 @@ type SystemMonitorClient interface {
 	GetSystemUpdateStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SystemUpdateStatus, error)
 	// Stream system upgrade progress (apt update + apt upgrade)
 	StreamSystemUpgrade(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UpgradeProgress], error)
+	// Get the unattended upgrade policy and next scheduled run
+	GetUpgradePolicy(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UpgradePolicy, error)
+	// Set the unattended upgrade policy
+	SetUpgradePolicy(ctx context.Context, in *UpgradePolicy, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Start an upgrade with the configured policy now, outside the window.
+	// Returns the in-progress run at once; follow job_id with AttachJob.
+	RunUpgradeNow(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UpgradeRun, error)
+	// History of unattended and manually triggered upgrade runs (newest first)
+	ListUpgradeRuns(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UpgradeRunList, error)
+	// Reboot the system after a delay, broadcasting a message to logged-in users
+	Reboot(ctx context.Context, in *PowerRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Power off the system after a delay, broadcasting a message to logged-in users
+	Shutdown(ctx context.Context, in *PowerRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Cancel a pending delayed reboot or shutdown
+	CancelShutdown(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ActionStatus, error)
 }
 
 type systemMonitorClient struct {
@@ -262,6 +366,46 @@ func (c *systemMonitorClient) GetNetworkConnections(ctx context.Context, in *Emp
 	return out, nil
 }
 
+func (c *systemMonitorClient) GetInterfaceConfig(ctx context.Context, in *InterfaceConfigRequest, opts ...grpc.CallOption) (*InterfaceConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InterfaceConfig)
+	err := c.cc.Invoke(ctx, SystemMonitor_GetInterfaceConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) SetInterfaceConfig(ctx context.Context, in *InterfaceConfig, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_SetInterfaceConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) ConfirmInterfaceConfig(ctx context.Context, in *InterfaceConfigRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_ConfirmInterfaceConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) RevertInterfaceConfig(ctx context.Context, in *InterfaceConfigRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_RevertInterfaceConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *systemMonitorClient) ListPackages(ctx context.Context, in *PackageFilter, opts ...grpc.CallOption) (*PackageList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PackageList)
@@ -322,6 +466,66 @@ func (c *systemMonitorClient) UpgradePackages(ctx context.Context, in *Empty, op
 	return out, nil
 }
 
+func (c *systemMonitorClient) ListPackageSources(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PackageSourceList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PackageSourceList)
+	err := c.cc.Invoke(ctx, SystemMonitor_ListPackageSources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) AddPackageSource(ctx context.Context, in *AddPackageSourceRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_AddPackageSource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) SetPackageSourceEnabled(ctx context.Context, in *PackageSourceToggle, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_SetPackageSourceEnabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) RemovePackageSource(ctx context.Context, in *PackageSourceId, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_RemovePackageSource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) ImportSigningKey(ctx context.Context, in *SigningKeyRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_ImportSigningKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) GetPackageHistory(ctx context.Context, in *PackageHistoryRequest, opts ...grpc.CallOption) (*PackageHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PackageHistory)
+	err := c.cc.Invoke(ctx, SystemMonitor_GetPackageHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *systemMonitorClient) GetVersion(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VersionInfo, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(VersionInfo)
@@ -371,9 +575,118 @@ func (c *systemMonitorClient) StreamPackageOperation(ctx context.Context, in *Pa
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SystemMonitor_StreamPackageOperationClient = grpc.ServerStreamingClient[PackageOperationLog]
 
+func (c *systemMonitorClient) SimulatePackageOperation(ctx context.Context, in *PackageCommand, opts ...grpc.CallOption) (*PackageSimulation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PackageSimulation)
+	err := c.cc.Invoke(ctx, SystemMonitor_SimulatePackageOperation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) ListPackageHolds(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PackageHoldList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PackageHoldList)
+	err := c.cc.Invoke(ctx, SystemMonitor_ListPackageHolds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) SetPackageHold(ctx context.Context, in *PackageHoldRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_SetPackageHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) ListPackagePins(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PackagePinList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PackagePinList)
+	err := c.cc.Invoke(ctx, SystemMonitor_ListPackagePins_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) SetPackagePin(ctx context.Context, in *PackagePin, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_SetPackagePin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) ClearPackagePin(ctx context.Context, in *PackagePin, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_ClearPackagePin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) ListJobs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PackageJobList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PackageJobList)
+	err := c.cc.Invoke(ctx, SystemMonitor_ListJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) GetJob(ctx context.Context, in *PackageJobId, opts ...grpc.CallOption) (*PackageJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PackageJob)
+	err := c.cc.Invoke(ctx, SystemMonitor_GetJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) CancelJob(ctx context.Context, in *PackageJobId, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_CancelJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) AttachJob(ctx context.Context, in *PackageJobId, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PackageOperationLog], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[3], SystemMonitor_AttachJob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PackageJobId, PackageOperationLog]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SystemMonitor_AttachJobClient = grpc.ServerStreamingClient[PackageOperationLog]
+
 func (c *systemMonitorClient) PingHost(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PingResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[3], SystemMonitor_PingHost_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[4], SystemMonitor_PingHost_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -392,7 +705,7 @@ type SystemMonitor_PingHostClient = grpc.ServerStreamingClient[PingResponse]
 
 func (c *systemMonitorClient) ScanPorts(ctx context.Context, in *PortScanRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PortScanResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[4], SystemMonitor_ScanPorts_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[5], SystemMonitor_ScanPorts_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -421,7 +734,7 @@ func (c *systemMonitorClient) DNSLookup(ctx context.Context, in *DNSRequest, opt
 
 func (c *systemMonitorClient) Traceroute(ctx context.Context, in *TracerouteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TracerouteResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[5], SystemMonitor_Traceroute_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[6], SystemMonitor_Traceroute_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -448,9 +761,69 @@ func (c *systemMonitorClient) GetWifiInfo(ctx context.Context, in *Empty, opts .
 	return out, nil
 }
 
+func (c *systemMonitorClient) ScanWifi(ctx context.Context, in *ScanWifiRequest, opts ...grpc.CallOption) (*ScanWifiResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScanWifiResponse)
+	err := c.cc.Invoke(ctx, SystemMonitor_ScanWifi_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) ListSavedWifiNetworks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SavedWifiNetworkList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavedWifiNetworkList)
+	err := c.cc.Invoke(ctx, SystemMonitor_ListSavedWifiNetworks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) AddWifiNetwork(ctx context.Context, in *AddWifiNetworkRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_AddWifiNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) ConnectWifiNetwork(ctx context.Context, in *WifiNetworkRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_ConnectWifiNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) ForgetWifiNetwork(ctx context.Context, in *WifiNetworkRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_ForgetWifiNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) SetWifiNetworkPriority(ctx context.Context, in *WifiNetworkRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_SetWifiNetworkPriority_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *systemMonitorClient) TestNetworkSpeed(ctx context.Context, in *SpeedTestRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SpeedTestResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[6], SystemMonitor_TestNetworkSpeed_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[7], SystemMonitor_TestNetworkSpeed_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -467,9 +840,28 @@ func (c *systemMonitorClient) TestNetworkSpeed(ctx context.Context, in *SpeedTes
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SystemMonitor_TestNetworkSpeedClient = grpc.ServerStreamingClient[SpeedTestResponse]
 
+func (c *systemMonitorClient) DiscoverDevices(ctx context.Context, in *DiscoverDevicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DiscoveredDevice], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[8], SystemMonitor_DiscoverDevices_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DiscoverDevicesRequest, DiscoveredDevice]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SystemMonitor_DiscoverDevicesClient = grpc.ServerStreamingClient[DiscoveredDevice]
+
 func (c *systemMonitorClient) UploadFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileChunk, FileUploadResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[7], SystemMonitor_UploadFile_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[9], SystemMonitor_UploadFile_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -482,7 +874,7 @@ type SystemMonitor_UploadFileClient = grpc.ClientStreamingClient[FileChunk, File
 
 func (c *systemMonitorClient) DownloadFile(ctx context.Context, in *FileDownloadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[8], SystemMonitor_DownloadFile_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[10], SystemMonitor_DownloadFile_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -521,7 +913,7 @@ func (c *systemMonitorClient) GetSystemUpdateStatus(ctx context.Context, in *Emp
 
 func (c *systemMonitorClient) StreamSystemUpgrade(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UpgradeProgress], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[9], SystemMonitor_StreamSystemUpgrade_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &SystemMonitor_ServiceDesc.Streams[11], SystemMonitor_StreamSystemUpgrade_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -538,44 +930,134 @@ func (c *systemMonitorClient) StreamSystemUpgrade(ctx context.Context, in *Empty
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SystemMonitor_StreamSystemUpgradeClient = grpc.ServerStreamingClient[UpgradeProgress]
 
-// SystemMonitorServer is the server API for SystemMonitor service.
-// All implementations must embed UnimplementedSystemMonitorServer
-// for forward compatibility.
-type SystemMonitorServer interface {
-	// Stream real-time system statistics (CPU, RAM, temp, etc.)
-	StreamStats(*Empty, grpc.ServerStreamingServer[LiveStats]) error
-	// Get list of all running processes
-	ListProcesses(context.Context, *Empty) (*ProcessList, error)
-	// Kill a specific process by PID
-	KillProcess(context.Context, *ProcessId) (*ActionStatus, error)
-	// Pause a specific process by PID (SIGSTOP)
-	PauseProcess(context.Context, *ProcessId) (*ActionStatus, error)
-	// Resume a specific process by PID (SIGCONT)
-	ResumeProcess(context.Context, *ProcessId) (*ActionStatus, error)
-	// List all systemd services
-	ListServices(context.Context, *Empty) (*ServiceList, error)
-	// Control a systemd service (start/stop/restart/enable/disable)
-	ManageService(context.Context, *ServiceCommand) (*ActionStatus, error)
-	// Stream system logs in real-time
-	StreamLogs(*LogFilter, grpc.ServerStreamingServer[LogEntry]) error
-	// Get disk usage information
-	GetDiskInfo(context.Context, *Empty) (*DiskInfo, error)
-	// Get network interface information
-	GetNetworkInfo(context.Context, *Empty) (*NetworkInfo, error)
-	// Get active network connections
-	GetNetworkConnections(context.Context, *Empty) (*NetworkConnectionList, error)
-	// List installed packages (optionally filter)
-	ListPackages(context.Context, *PackageFilter) (*PackageList, error)
-	// Install a package
-	InstallPackage(context.Context, *PackageCommand) (*ActionStatus, error)
-	// Remove a package
-	RemovePackage(context.Context, *PackageCommand) (*ActionStatus, error)
-	// Update a specific package
-	UpdatePackage(context.Context, *PackageCommand) (*ActionStatus, error)
-	// Update package list (apt update)
-	UpdatePackageList(context.Context, *Empty) (*ActionStatus, error)
+func (c *systemMonitorClient) GetUpgradePolicy(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UpgradePolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpgradePolicy)
+	err := c.cc.Invoke(ctx, SystemMonitor_GetUpgradePolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) SetUpgradePolicy(ctx context.Context, in *UpgradePolicy, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_SetUpgradePolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) RunUpgradeNow(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UpgradeRun, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpgradeRun)
+	err := c.cc.Invoke(ctx, SystemMonitor_RunUpgradeNow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) ListUpgradeRuns(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UpgradeRunList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpgradeRunList)
+	err := c.cc.Invoke(ctx, SystemMonitor_ListUpgradeRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) Reboot(ctx context.Context, in *PowerRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_Reboot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) Shutdown(ctx context.Context, in *PowerRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_Shutdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemMonitorClient) CancelShutdown(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, SystemMonitor_CancelShutdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SystemMonitorServer is the server API for SystemMonitor service.
+// All implementations must embed UnimplementedSystemMonitorServer
+// for forward compatibility.
+type SystemMonitorServer interface {
+	// Stream real-time system statistics (CPU, RAM, temp, etc.)
+	StreamStats(*Empty, grpc.ServerStreamingServer[LiveStats]) error
+	// Get list of all running processes
+	ListProcesses(context.Context, *Empty) (*ProcessList, error)
+	// Kill a specific process by PID
+	KillProcess(context.Context, *ProcessId) (*ActionStatus, error)
+	// Pause a specific process by PID (SIGSTOP)
+	PauseProcess(context.Context, *ProcessId) (*ActionStatus, error)
+	// Resume a specific process by PID (SIGCONT)
+	ResumeProcess(context.Context, *ProcessId) (*ActionStatus, error)
+	// List all systemd services
+	ListServices(context.Context, *Empty) (*ServiceList, error)
+	// Control a systemd service (start/stop/restart/enable/disable)
+	ManageService(context.Context, *ServiceCommand) (*ActionStatus, error)
+	// Stream system logs in real-time
+	StreamLogs(*LogFilter, grpc.ServerStreamingServer[LogEntry]) error
+	// Get disk usage information
+	GetDiskInfo(context.Context, *Empty) (*DiskInfo, error)
+	// Get network interface information
+	GetNetworkInfo(context.Context, *Empty) (*NetworkInfo, error)
+	// Get active network connections
+	GetNetworkConnections(context.Context, *Empty) (*NetworkConnectionList, error)
+	// Get the configured addressing of an interface and any change awaiting confirmation
+	GetInterfaceConfig(context.Context, *InterfaceConfigRequest) (*InterfaceConfig, error)
+	// Set static addresses or DHCP on an interface; the change is reverted unless confirmed in time
+	SetInterfaceConfig(context.Context, *InterfaceConfig) (*ActionStatus, error)
+	// Keep a change made by SetInterfaceConfig
+	ConfirmInterfaceConfig(context.Context, *InterfaceConfigRequest) (*ActionStatus, error)
+	// Undo an unconfirmed change now
+	RevertInterfaceConfig(context.Context, *InterfaceConfigRequest) (*ActionStatus, error)
+	// List installed packages (optionally filter)
+	ListPackages(context.Context, *PackageFilter) (*PackageList, error)
+	// Install a package
+	InstallPackage(context.Context, *PackageCommand) (*ActionStatus, error)
+	// Remove a package
+	RemovePackage(context.Context, *PackageCommand) (*ActionStatus, error)
+	// Update a specific package
+	UpdatePackage(context.Context, *PackageCommand) (*ActionStatus, error)
+	// Update package list (apt update)
+	UpdatePackageList(context.Context, *Empty) (*ActionStatus, error)
 	// Upgrade packages (apt upgrade)
 	UpgradePackages(context.Context, *Empty) (*ActionStatus, error)
+	// List APT sources from sources.list, .list and deb822 .sources files
+	ListPackageSources(context.Context, *Empty) (*PackageSourceList, error)
+	// Add an APT source after checking that apt-get update succeeds for it
+	AddPackageSource(context.Context, *AddPackageSourceRequest) (*ActionStatus, error)
+	// Enable or disable an APT source
+	SetPackageSourceEnabled(context.Context, *PackageSourceToggle) (*ActionStatus, error)
+	// Remove an APT source
+	RemovePackageSource(context.Context, *PackageSourceId) (*ActionStatus, error)
+	// Import a repository signing key into /etc/apt/keyrings
+	ImportSigningKey(context.Context, *SigningKeyRequest) (*ActionStatus, error)
+	// Package change history from apt and dpkg logs (newest first)
+	GetPackageHistory(context.Context, *PackageHistoryRequest) (*PackageHistory, error)
 	// Get agent version
 	GetVersion(context.Context, *Empty) (*VersionInfo, error)
 	// Get detailed package information
@@ -584,6 +1066,27 @@ type SystemMonitorServer interface {
 	GetPackageDependencies(context.Context, *PackageDetailsRequest) (*PackageDependencies, error)
 	// Stream package operation logs (install/remove/update)
 	StreamPackageOperation(*PackageCommand, grpc.ServerStreamingServer[PackageOperationLog]) error
+	// Preview what a package operation would change without applying it
+	SimulatePackageOperation(context.Context, *PackageCommand) (*PackageSimulation, error)
+	// List packages held at their installed version
+	ListPackageHolds(context.Context, *Empty) (*PackageHoldList, error)
+	// Hold or release packages (apt-mark hold/unhold)
+	SetPackageHold(context.Context, *PackageHoldRequest) (*ActionStatus, error)
+	// List apt pin priorities from /etc/apt/preferences(.d)
+	ListPackagePins(context.Context, *Empty) (*PackagePinList, error)
+	// Create or replace an agent-managed pin for a package
+	SetPackagePin(context.Context, *PackagePin) (*ActionStatus, error)
+	// Remove the agent-managed pin for a package
+	ClearPackagePin(context.Context, *PackagePin) (*ActionStatus, error)
+	// Package job queue (all package manager work runs serially through it)
+	// List queued, running and recently finished package jobs
+	ListJobs(context.Context, *Empty) (*PackageJobList, error)
+	// Get a single package job
+	GetJob(context.Context, *PackageJobId) (*PackageJob, error)
+	// Cancel a queued or running package job
+	CancelJob(context.Context, *PackageJobId) (*ActionStatus, error)
+	// Stream a job's output from the start, following it until it completes
+	AttachJob(*PackageJobId, grpc.ServerStreamingServer[PackageOperationLog]) error
 	// Network Tools
 	// Ping a host and stream results
 	PingHost(*PingRequest, grpc.ServerStreamingServer[PingResponse]) error
@@ -595,8 +1098,22 @@ type SystemMonitorServer interface {
 	Traceroute(*TracerouteRequest, grpc.ServerStreamingServer[TracerouteResponse]) error
 	// Get WiFi information
 	GetWifiInfo(context.Context, *Empty) (*WifiInfo, error)
+	// Scan for nearby WiFi networks with per-channel congestion
+	ScanWifi(context.Context, *ScanWifiRequest) (*ScanWifiResponse, error)
+	// List saved WiFi networks and the outcome of the last change
+	ListSavedWifiNetworks(context.Context, *Empty) (*SavedWifiNetworkList, error)
+	// Save a WiFi network (or update a saved one), optionally connecting to it
+	AddWifiNetwork(context.Context, *AddWifiNetworkRequest) (*ActionStatus, error)
+	// Connect to a saved WiFi network; the previous one is restored if there is no connectivity
+	ConnectWifiNetwork(context.Context, *WifiNetworkRequest) (*ActionStatus, error)
+	// Forget a saved WiFi network
+	ForgetWifiNetwork(context.Context, *WifiNetworkRequest) (*ActionStatus, error)
+	// Set the autoconnect priority of a saved WiFi network
+	SetWifiNetworkPriority(context.Context, *WifiNetworkRequest) (*ActionStatus, error)
 	// Test network speed (download/upload)
 	TestNetworkSpeed(*SpeedTestRequest, grpc.ServerStreamingServer[SpeedTestResponse]) error
+	// Find devices on the local subnets; each device is streamed when found or updated
+	DiscoverDevices(*DiscoverDevicesRequest, grpc.ServerStreamingServer[DiscoveredDevice]) error
 	// File Transfer
 	// Upload a file using streaming chunks
 	UploadFile(grpc.ClientStreamingServer[FileChunk, FileUploadResponse]) error
@@ -609,6 +1126,21 @@ type SystemMonitorServer interface {
 	GetSystemUpdateStatus(context.Context, *Empty) (*SystemUpdateStatus, error)
 	// Stream system upgrade progress (apt update + apt upgrade)
 	StreamSystemUpgrade(*Empty, grpc.ServerStreamingServer[UpgradeProgress]) error
+	// Get the unattended upgrade policy and next scheduled run
+	GetUpgradePolicy(context.Context, *Empty) (*UpgradePolicy, error)
+	// Set the unattended upgrade policy
+	SetUpgradePolicy(context.Context, *UpgradePolicy) (*ActionStatus, error)
+	// Start an upgrade with the configured policy now, outside the window.
+	// Returns the in-progress run at once; follow job_id with AttachJob.
+	RunUpgradeNow(context.Context, *Empty) (*UpgradeRun, error)
+	// History of unattended and manually triggered upgrade runs (newest first)
+	ListUpgradeRuns(context.Context, *Empty) (*UpgradeRunList, error)
+	// Reboot the system after a delay, broadcasting a message to logged-in users
+	Reboot(context.Context, *PowerRequest) (*ActionStatus, error)
+	// Power off the system after a delay, broadcasting a message to logged-in users
+	Shutdown(context.Context, *PowerRequest) (*ActionStatus, error)
+	// Cancel a pending delayed reboot or shutdown
+	CancelShutdown(context.Context, *Empty) (*ActionStatus, error)
 	mustEmbedUnimplementedSystemMonitorServer()
 }
 
@@ -652,6 +1184,18 @@ func (UnimplementedSystemMonitorServer) GetNetworkInfo(context.Context, *Empty)
 func (UnimplementedSystemMonitorServer) GetNetworkConnections(context.Context, *Empty) (*NetworkConnectionList, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetNetworkConnections not implemented")
 }
+func (UnimplementedSystemMonitorServer) GetInterfaceConfig(context.Context, *InterfaceConfigRequest) (*InterfaceConfig, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetInterfaceConfig not implemented")
+}
+func (UnimplementedSystemMonitorServer) SetInterfaceConfig(context.Context, *InterfaceConfig) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetInterfaceConfig not implemented")
+}
+func (UnimplementedSystemMonitorServer) ConfirmInterfaceConfig(context.Context, *InterfaceConfigRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmInterfaceConfig not implemented")
+}
+func (UnimplementedSystemMonitorServer) RevertInterfaceConfig(context.Context, *InterfaceConfigRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevertInterfaceConfig not implemented")
+}
 func (UnimplementedSystemMonitorServer) ListPackages(context.Context, *PackageFilter) (*PackageList, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListPackages not implemented")
 }
@@ -670,6 +1214,24 @@ func (UnimplementedSystemMonitorServer) UpdatePackageList(context.Context, *Empt
 func (UnimplementedSystemMonitorServer) UpgradePackages(context.Context, *Empty) (*ActionStatus, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpgradePackages not implemented")
 }
+func (UnimplementedSystemMonitorServer) ListPackageSources(context.Context, *Empty) (*PackageSourceList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPackageSources not implemented")
+}
+func (UnimplementedSystemMonitorServer) AddPackageSource(context.Context, *AddPackageSourceRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddPackageSource not implemented")
+}
+func (UnimplementedSystemMonitorServer) SetPackageSourceEnabled(context.Context, *PackageSourceToggle) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPackageSourceEnabled not implemented")
+}
+func (UnimplementedSystemMonitorServer) RemovePackageSource(context.Context, *PackageSourceId) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemovePackageSource not implemented")
+}
+func (UnimplementedSystemMonitorServer) ImportSigningKey(context.Context, *SigningKeyRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportSigningKey not implemented")
+}
+func (UnimplementedSystemMonitorServer) GetPackageHistory(context.Context, *PackageHistoryRequest) (*PackageHistory, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPackageHistory not implemented")
+}
 func (UnimplementedSystemMonitorServer) GetVersion(context.Context, *Empty) (*VersionInfo, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetVersion not implemented")
 }
@@ -682,6 +1244,36 @@ func (UnimplementedSystemMonitorServer) GetPackageDependencies(context.Context,
 func (UnimplementedSystemMonitorServer) StreamPackageOperation(*PackageCommand, grpc.ServerStreamingServer[PackageOperationLog]) error {
 	return status.Error(codes.Unimplemented, "method StreamPackageOperation not implemented")
 }
+func (UnimplementedSystemMonitorServer) SimulatePackageOperation(context.Context, *PackageCommand) (*PackageSimulation, error) {
+	return nil, status.Error(codes.Unimplemented, "method SimulatePackageOperation not implemented")
+}
+func (UnimplementedSystemMonitorServer) ListPackageHolds(context.Context, *Empty) (*PackageHoldList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPackageHolds not implemented")
+}
+func (UnimplementedSystemMonitorServer) SetPackageHold(context.Context, *PackageHoldRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPackageHold not implemented")
+}
+func (UnimplementedSystemMonitorServer) ListPackagePins(context.Context, *Empty) (*PackagePinList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPackagePins not implemented")
+}
+func (UnimplementedSystemMonitorServer) SetPackagePin(context.Context, *PackagePin) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPackagePin not implemented")
+}
+func (UnimplementedSystemMonitorServer) ClearPackagePin(context.Context, *PackagePin) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearPackagePin not implemented")
+}
+func (UnimplementedSystemMonitorServer) ListJobs(context.Context, *Empty) (*PackageJobList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedSystemMonitorServer) GetJob(context.Context, *PackageJobId) (*PackageJob, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedSystemMonitorServer) CancelJob(context.Context, *PackageJobId) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (UnimplementedSystemMonitorServer) AttachJob(*PackageJobId, grpc.ServerStreamingServer[PackageOperationLog]) error {
+	return status.Error(codes.Unimplemented, "method AttachJob not implemented")
+}
 func (UnimplementedSystemMonitorServer) PingHost(*PingRequest, grpc.ServerStreamingServer[PingResponse]) error {
 	return status.Error(codes.Unimplemented, "method PingHost not implemented")
 }
@@ -697,9 +1289,30 @@ func (UnimplementedSystemMonitorServer) Traceroute(*TracerouteRequest, grpc.Serv
 func (UnimplementedSystemMonitorServer) GetWifiInfo(context.Context, *Empty) (*WifiInfo, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetWifiInfo not implemented")
 }
+func (UnimplementedSystemMonitorServer) ScanWifi(context.Context, *ScanWifiRequest) (*ScanWifiResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ScanWifi not implemented")
+}
+func (UnimplementedSystemMonitorServer) ListSavedWifiNetworks(context.Context, *Empty) (*SavedWifiNetworkList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSavedWifiNetworks not implemented")
+}
+func (UnimplementedSystemMonitorServer) AddWifiNetwork(context.Context, *AddWifiNetworkRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddWifiNetwork not implemented")
+}
+func (UnimplementedSystemMonitorServer) ConnectWifiNetwork(context.Context, *WifiNetworkRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConnectWifiNetwork not implemented")
+}
+func (UnimplementedSystemMonitorServer) ForgetWifiNetwork(context.Context, *WifiNetworkRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method ForgetWifiNetwork not implemented")
+}
+func (UnimplementedSystemMonitorServer) SetWifiNetworkPriority(context.Context, *WifiNetworkRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetWifiNetworkPriority not implemented")
+}
 func (UnimplementedSystemMonitorServer) TestNetworkSpeed(*SpeedTestRequest, grpc.ServerStreamingServer[SpeedTestResponse]) error {
 	return status.Error(codes.Unimplemented, "method TestNetworkSpeed not implemented")
 }
+func (UnimplementedSystemMonitorServer) DiscoverDevices(*DiscoverDevicesRequest, grpc.ServerStreamingServer[DiscoveredDevice]) error {
+	return status.Error(codes.Unimplemented, "method DiscoverDevices not implemented")
+}
 func (UnimplementedSystemMonitorServer) UploadFile(grpc.ClientStreamingServer[FileChunk, FileUploadResponse]) error {
 	return status.Error(codes.Unimplemented, "method UploadFile not implemented")
 }
@@ -715,6 +1328,27 @@ func (UnimplementedSystemMonitorServer) GetSystemUpdateStatus(context.Context, *
 func (UnimplementedSystemMonitorServer) StreamSystemUpgrade(*Empty, grpc.ServerStreamingServer[UpgradeProgress]) error {
 	return status.Error(codes.Unimplemented, "method StreamSystemUpgrade not implemented")
 }
+func (UnimplementedSystemMonitorServer) GetUpgradePolicy(context.Context, *Empty) (*UpgradePolicy, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUpgradePolicy not implemented")
+}
+func (UnimplementedSystemMonitorServer) SetUpgradePolicy(context.Context, *UpgradePolicy) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetUpgradePolicy not implemented")
+}
+func (UnimplementedSystemMonitorServer) RunUpgradeNow(context.Context, *Empty) (*UpgradeRun, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunUpgradeNow not implemented")
+}
+func (UnimplementedSystemMonitorServer) ListUpgradeRuns(context.Context, *Empty) (*UpgradeRunList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUpgradeRuns not implemented")
+}
+func (UnimplementedSystemMonitorServer) Reboot(context.Context, *PowerRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method Reboot not implemented")
+}
+func (UnimplementedSystemMonitorServer) Shutdown(context.Context, *PowerRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (UnimplementedSystemMonitorServer) CancelShutdown(context.Context, *Empty) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelShutdown not implemented")
+}
 func (UnimplementedSystemMonitorServer) mustEmbedUnimplementedSystemMonitorServer() {}
 func (UnimplementedSystemMonitorServer) testEmbeddedByValue()                       {}
 
@@ -920,6 +1554,78 @@ func _SystemMonitor_GetNetworkConnections_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SystemMonitor_GetInterfaceConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InterfaceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).GetInterfaceConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_GetInterfaceConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).GetInterfaceConfig(ctx, req.(*InterfaceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_SetInterfaceConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InterfaceConfig)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).SetInterfaceConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_SetInterfaceConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).SetInterfaceConfig(ctx, req.(*InterfaceConfig))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_ConfirmInterfaceConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InterfaceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).ConfirmInterfaceConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_ConfirmInterfaceConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).ConfirmInterfaceConfig(ctx, req.(*InterfaceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_RevertInterfaceConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InterfaceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).RevertInterfaceConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_RevertInterfaceConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).RevertInterfaceConfig(ctx, req.(*InterfaceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SystemMonitor_ListPackages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PackageFilter)
 	if err := dec(in); err != nil {
@@ -1028,136 +1734,525 @@ func _SystemMonitor_UpgradePackages_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SystemMonitor_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _SystemMonitor_ListPackageSources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SystemMonitorServer).GetVersion(ctx, in)
+		return srv.(SystemMonitorServer).ListPackageSources(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SystemMonitor_GetVersion_FullMethodName,
+		FullMethod: SystemMonitor_ListPackageSources_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SystemMonitorServer).GetVersion(ctx, req.(*Empty))
+		return srv.(SystemMonitorServer).ListPackageSources(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SystemMonitor_GetPackageDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PackageDetailsRequest)
+func _SystemMonitor_AddPackageSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPackageSourceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SystemMonitorServer).GetPackageDetails(ctx, in)
+		return srv.(SystemMonitorServer).AddPackageSource(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SystemMonitor_GetPackageDetails_FullMethodName,
+		FullMethod: SystemMonitor_AddPackageSource_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SystemMonitorServer).GetPackageDetails(ctx, req.(*PackageDetailsRequest))
+		return srv.(SystemMonitorServer).AddPackageSource(ctx, req.(*AddPackageSourceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SystemMonitor_GetPackageDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PackageDetailsRequest)
+func _SystemMonitor_SetPackageSourceEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackageSourceToggle)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SystemMonitorServer).GetPackageDependencies(ctx, in)
+		return srv.(SystemMonitorServer).SetPackageSourceEnabled(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SystemMonitor_GetPackageDependencies_FullMethodName,
+		FullMethod: SystemMonitor_SetPackageSourceEnabled_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SystemMonitorServer).GetPackageDependencies(ctx, req.(*PackageDetailsRequest))
+		return srv.(SystemMonitorServer).SetPackageSourceEnabled(ctx, req.(*PackageSourceToggle))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SystemMonitor_StreamPackageOperation_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PackageCommand)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _SystemMonitor_RemovePackageSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackageSourceId)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(SystemMonitorServer).StreamPackageOperation(m, &grpc.GenericServerStream[PackageCommand, PackageOperationLog]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type SystemMonitor_StreamPackageOperationServer = grpc.ServerStreamingServer[PackageOperationLog]
-
-func _SystemMonitor_PingHost_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PingRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).RemovePackageSource(ctx, in)
 	}
-	return srv.(SystemMonitorServer).PingHost(m, &grpc.GenericServerStream[PingRequest, PingResponse]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type SystemMonitor_PingHostServer = grpc.ServerStreamingServer[PingResponse]
-
-func _SystemMonitor_ScanPorts_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PortScanRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_RemovePackageSource_FullMethodName,
 	}
-	return srv.(SystemMonitorServer).ScanPorts(m, &grpc.GenericServerStream[PortScanRequest, PortScanResponse]{ServerStream: stream})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).RemovePackageSource(ctx, req.(*PackageSourceId))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type SystemMonitor_ScanPortsServer = grpc.ServerStreamingServer[PortScanResponse]
-
-func _SystemMonitor_DNSLookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DNSRequest)
+func _SystemMonitor_ImportSigningKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SigningKeyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SystemMonitorServer).DNSLookup(ctx, in)
+		return srv.(SystemMonitorServer).ImportSigningKey(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SystemMonitor_DNSLookup_FullMethodName,
+		FullMethod: SystemMonitor_ImportSigningKey_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SystemMonitorServer).DNSLookup(ctx, req.(*DNSRequest))
+		return srv.(SystemMonitorServer).ImportSigningKey(ctx, req.(*SigningKeyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SystemMonitor_Traceroute_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(TracerouteRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _SystemMonitor_GetPackageHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackageHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(SystemMonitorServer).Traceroute(m, &grpc.GenericServerStream[TracerouteRequest, TracerouteResponse]{ServerStream: stream})
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).GetPackageHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_GetPackageHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).GetPackageHistory(ctx, req.(*PackageHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type SystemMonitor_TracerouteServer = grpc.ServerStreamingServer[TracerouteResponse]
-
-func _SystemMonitor_GetWifiInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _SystemMonitor_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SystemMonitorServer).GetWifiInfo(ctx, in)
+		return srv.(SystemMonitorServer).GetVersion(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SystemMonitor_GetWifiInfo_FullMethodName,
+		FullMethod: SystemMonitor_GetVersion_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SystemMonitorServer).GetWifiInfo(ctx, req.(*Empty))
+		return srv.(SystemMonitorServer).GetVersion(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_GetPackageDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackageDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).GetPackageDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_GetPackageDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).GetPackageDetails(ctx, req.(*PackageDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_GetPackageDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackageDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).GetPackageDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_GetPackageDependencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).GetPackageDependencies(ctx, req.(*PackageDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_StreamPackageOperation_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PackageCommand)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemMonitorServer).StreamPackageOperation(m, &grpc.GenericServerStream[PackageCommand, PackageOperationLog]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SystemMonitor_StreamPackageOperationServer = grpc.ServerStreamingServer[PackageOperationLog]
+
+func _SystemMonitor_SimulatePackageOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackageCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).SimulatePackageOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_SimulatePackageOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).SimulatePackageOperation(ctx, req.(*PackageCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_ListPackageHolds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).ListPackageHolds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_ListPackageHolds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).ListPackageHolds(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_SetPackageHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackageHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).SetPackageHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_SetPackageHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).SetPackageHold(ctx, req.(*PackageHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_ListPackagePins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).ListPackagePins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_ListPackagePins_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).ListPackagePins(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_SetPackagePin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackagePin)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).SetPackagePin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_SetPackagePin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).SetPackagePin(ctx, req.(*PackagePin))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_ClearPackagePin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackagePin)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).ClearPackagePin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_ClearPackagePin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).ClearPackagePin(ctx, req.(*PackagePin))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).ListJobs(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackageJobId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).GetJob(ctx, req.(*PackageJobId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackageJobId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_CancelJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).CancelJob(ctx, req.(*PackageJobId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_AttachJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PackageJobId)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemMonitorServer).AttachJob(m, &grpc.GenericServerStream[PackageJobId, PackageOperationLog]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SystemMonitor_AttachJobServer = grpc.ServerStreamingServer[PackageOperationLog]
+
+func _SystemMonitor_PingHost_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemMonitorServer).PingHost(m, &grpc.GenericServerStream[PingRequest, PingResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SystemMonitor_PingHostServer = grpc.ServerStreamingServer[PingResponse]
+
+func _SystemMonitor_ScanPorts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PortScanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemMonitorServer).ScanPorts(m, &grpc.GenericServerStream[PortScanRequest, PortScanResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SystemMonitor_ScanPortsServer = grpc.ServerStreamingServer[PortScanResponse]
+
+func _SystemMonitor_DNSLookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DNSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).DNSLookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_DNSLookup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).DNSLookup(ctx, req.(*DNSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_Traceroute_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TracerouteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemMonitorServer).Traceroute(m, &grpc.GenericServerStream[TracerouteRequest, TracerouteResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SystemMonitor_TracerouteServer = grpc.ServerStreamingServer[TracerouteResponse]
+
+func _SystemMonitor_GetWifiInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).GetWifiInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_GetWifiInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).GetWifiInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_ScanWifi_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScanWifiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).ScanWifi(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_ScanWifi_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).ScanWifi(ctx, req.(*ScanWifiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_ListSavedWifiNetworks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).ListSavedWifiNetworks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_ListSavedWifiNetworks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).ListSavedWifiNetworks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_AddWifiNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWifiNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).AddWifiNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_AddWifiNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).AddWifiNetwork(ctx, req.(*AddWifiNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_ConnectWifiNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WifiNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).ConnectWifiNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_ConnectWifiNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).ConnectWifiNetwork(ctx, req.(*WifiNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_ForgetWifiNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WifiNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).ForgetWifiNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_ForgetWifiNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).ForgetWifiNetwork(ctx, req.(*WifiNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_SetWifiNetworkPriority_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WifiNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).SetWifiNetworkPriority(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_SetWifiNetworkPriority_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).SetWifiNetworkPriority(ctx, req.(*WifiNetworkRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1167,77 +2262,214 @@ func _SystemMonitor_TestNetworkSpeed_Handler(srv interface{}, stream grpc.Server
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(SystemMonitorServer).TestNetworkSpeed(m, &grpc.GenericServerStream[SpeedTestRequest, SpeedTestResponse]{ServerStream: stream})
+	return srv.(SystemMonitorServer).TestNetworkSpeed(m, &grpc.GenericServerStream[SpeedTestRequest, SpeedTestResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SystemMonitor_TestNetworkSpeedServer = grpc.ServerStreamingServer[SpeedTestResponse]
+
+func _SystemMonitor_DiscoverDevices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DiscoverDevicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemMonitorServer).DiscoverDevices(m, &grpc.GenericServerStream[DiscoverDevicesRequest, DiscoveredDevice]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SystemMonitor_DiscoverDevicesServer = grpc.ServerStreamingServer[DiscoveredDevice]
+
+func _SystemMonitor_UploadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SystemMonitorServer).UploadFile(&grpc.GenericServerStream[FileChunk, FileUploadResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SystemMonitor_UploadFileServer = grpc.ClientStreamingServer[FileChunk, FileUploadResponse]
+
+func _SystemMonitor_DownloadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FileDownloadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemMonitorServer).DownloadFile(m, &grpc.GenericServerStream[FileDownloadRequest, FileChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SystemMonitor_DownloadFileServer = grpc.ServerStreamingServer[FileChunk]
+
+func _SystemMonitor_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).DeleteFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_DeleteFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).DeleteFile(ctx, req.(*FileDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_GetSystemUpdateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).GetSystemUpdateStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_GetSystemUpdateStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).GetSystemUpdateStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_StreamSystemUpgrade_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemMonitorServer).StreamSystemUpgrade(m, &grpc.GenericServerStream[Empty, UpgradeProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SystemMonitor_StreamSystemUpgradeServer = grpc.ServerStreamingServer[UpgradeProgress]
+
+func _SystemMonitor_GetUpgradePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).GetUpgradePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_GetUpgradePolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).GetUpgradePolicy(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemMonitor_SetUpgradePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradePolicy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).SetUpgradePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_SetUpgradePolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).SetUpgradePolicy(ctx, req.(*UpgradePolicy))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type SystemMonitor_TestNetworkSpeedServer = grpc.ServerStreamingServer[SpeedTestResponse]
-
-func _SystemMonitor_UploadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(SystemMonitorServer).UploadFile(&grpc.GenericServerStream[FileChunk, FileUploadResponse]{ServerStream: stream})
+func _SystemMonitor_RunUpgradeNow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).RunUpgradeNow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_RunUpgradeNow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).RunUpgradeNow(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type SystemMonitor_UploadFileServer = grpc.ClientStreamingServer[FileChunk, FileUploadResponse]
-
-func _SystemMonitor_DownloadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(FileDownloadRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _SystemMonitor_ListUpgradeRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(SystemMonitorServer).DownloadFile(m, &grpc.GenericServerStream[FileDownloadRequest, FileChunk]{ServerStream: stream})
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).ListUpgradeRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_ListUpgradeRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).ListUpgradeRuns(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type SystemMonitor_DownloadFileServer = grpc.ServerStreamingServer[FileChunk]
-
-func _SystemMonitor_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileDeleteRequest)
+func _SystemMonitor_Reboot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PowerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SystemMonitorServer).DeleteFile(ctx, in)
+		return srv.(SystemMonitorServer).Reboot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SystemMonitor_DeleteFile_FullMethodName,
+		FullMethod: SystemMonitor_Reboot_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SystemMonitorServer).DeleteFile(ctx, req.(*FileDeleteRequest))
+		return srv.(SystemMonitorServer).Reboot(ctx, req.(*PowerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SystemMonitor_GetSystemUpdateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _SystemMonitor_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PowerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SystemMonitorServer).GetSystemUpdateStatus(ctx, in)
+		return srv.(SystemMonitorServer).Shutdown(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SystemMonitor_GetSystemUpdateStatus_FullMethodName,
+		FullMethod: SystemMonitor_Shutdown_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SystemMonitorServer).GetSystemUpdateStatus(ctx, req.(*Empty))
+		return srv.(SystemMonitorServer).Shutdown(ctx, req.(*PowerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SystemMonitor_StreamSystemUpgrade_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _SystemMonitor_CancelShutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(SystemMonitorServer).StreamSystemUpgrade(m, &grpc.GenericServerStream[Empty, UpgradeProgress]{ServerStream: stream})
+	if interceptor == nil {
+		return srv.(SystemMonitorServer).CancelShutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemMonitor_CancelShutdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemMonitorServer).CancelShutdown(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type SystemMonitor_StreamSystemUpgradeServer = grpc.ServerStreamingServer[UpgradeProgress]
-
 // SystemMonitor_ServiceDesc is the grpc.ServiceDesc for SystemMonitor service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1281,6 +2513,22 @@ var SystemMonitor_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNetworkConnections",
 			Handler:    _SystemMonitor_GetNetworkConnections_Handler,
 		},
+		{
+			MethodName: "GetInterfaceConfig",
+			Handler:    _SystemMonitor_GetInterfaceConfig_Handler,
+		},
+		{
+			MethodName: "SetInterfaceConfig",
+			Handler:    _SystemMonitor_SetInterfaceConfig_Handler,
+		},
+		{
+			MethodName: "ConfirmInterfaceConfig",
+			Handler:    _SystemMonitor_ConfirmInterfaceConfig_Handler,
+		},
+		{
+			MethodName: "RevertInterfaceConfig",
+			Handler:    _SystemMonitor_RevertInterfaceConfig_Handler,
+		},
 		{
 			MethodName: "ListPackages",
 			Handler:    _SystemMonitor_ListPackages_Handler,
@@ -1305,6 +2553,30 @@ var SystemMonitor_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpgradePackages",
 			Handler:    _SystemMonitor_UpgradePackages_Handler,
 		},
+		{
+			MethodName: "ListPackageSources",
+			Handler:    _SystemMonitor_ListPackageSources_Handler,
+		},
+		{
+			MethodName: "AddPackageSource",
+			Handler:    _SystemMonitor_AddPackageSource_Handler,
+		},
+		{
+			MethodName: "SetPackageSourceEnabled",
+			Handler:    _SystemMonitor_SetPackageSourceEnabled_Handler,
+		},
+		{
+			MethodName: "RemovePackageSource",
+			Handler:    _SystemMonitor_RemovePackageSource_Handler,
+		},
+		{
+			MethodName: "ImportSigningKey",
+			Handler:    _SystemMonitor_ImportSigningKey_Handler,
+		},
+		{
+			MethodName: "GetPackageHistory",
+			Handler:    _SystemMonitor_GetPackageHistory_Handler,
+		},
 		{
 			MethodName: "GetVersion",
 			Handler:    _SystemMonitor_GetVersion_Handler,
@@ -1317,6 +2589,42 @@ var SystemMonitor_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPackageDependencies",
 			Handler:    _SystemMonitor_GetPackageDependencies_Handler,
 		},
+		{
+			MethodName: "SimulatePackageOperation",
+			Handler:    _SystemMonitor_SimulatePackageOperation_Handler,
+		},
+		{
+			MethodName: "ListPackageHolds",
+			Handler:    _SystemMonitor_ListPackageHolds_Handler,
+		},
+		{
+			MethodName: "SetPackageHold",
+			Handler:    _SystemMonitor_SetPackageHold_Handler,
+		},
+		{
+			MethodName: "ListPackagePins",
+			Handler:    _SystemMonitor_ListPackagePins_Handler,
+		},
+		{
+			MethodName: "SetPackagePin",
+			Handler:    _SystemMonitor_SetPackagePin_Handler,
+		},
+		{
+			MethodName: "ClearPackagePin",
+			Handler:    _SystemMonitor_ClearPackagePin_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _SystemMonitor_ListJobs_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _SystemMonitor_GetJob_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _SystemMonitor_CancelJob_Handler,
+		},
 		{
 			MethodName: "DNSLookup",
 			Handler:    _SystemMonitor_DNSLookup_Handler,
@@ -1325,6 +2633,30 @@ var SystemMonitor_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetWifiInfo",
 			Handler:    _SystemMonitor_GetWifiInfo_Handler,
 		},
+		{
+			MethodName: "ScanWifi",
+			Handler:    _SystemMonitor_ScanWifi_Handler,
+		},
+		{
+			MethodName: "ListSavedWifiNetworks",
+			Handler:    _SystemMonitor_ListSavedWifiNetworks_Handler,
+		},
+		{
+			MethodName: "AddWifiNetwork",
+			Handler:    _SystemMonitor_AddWifiNetwork_Handler,
+		},
+		{
+			MethodName: "ConnectWifiNetwork",
+			Handler:    _SystemMonitor_ConnectWifiNetwork_Handler,
+		},
+		{
+			MethodName: "ForgetWifiNetwork",
+			Handler:    _SystemMonitor_ForgetWifiNetwork_Handler,
+		},
+		{
+			MethodName: "SetWifiNetworkPriority",
+			Handler:    _SystemMonitor_SetWifiNetworkPriority_Handler,
+		},
 		{
 			MethodName: "DeleteFile",
 			Handler:    _SystemMonitor_DeleteFile_Handler,
@@ -1333,6 +2665,34 @@ var SystemMonitor_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSystemUpdateStatus",
 			Handler:    _SystemMonitor_GetSystemUpdateStatus_Handler,
 		},
+		{
+			MethodName: "GetUpgradePolicy",
+			Handler:    _SystemMonitor_GetUpgradePolicy_Handler,
+		},
+		{
+			MethodName: "SetUpgradePolicy",
+			Handler:    _SystemMonitor_SetUpgradePolicy_Handler,
+		},
+		{
+			MethodName: "RunUpgradeNow",
+			Handler:    _SystemMonitor_RunUpgradeNow_Handler,
+		},
+		{
+			MethodName: "ListUpgradeRuns",
+			Handler:    _SystemMonitor_ListUpgradeRuns_Handler,
+		},
+		{
+			MethodName: "Reboot",
+			Handler:    _SystemMonitor_Reboot_Handler,
+		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _SystemMonitor_Shutdown_Handler,
+		},
+		{
+			MethodName: "CancelShutdown",
+			Handler:    _SystemMonitor_CancelShutdown_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1350,6 +2710,11 @@ var SystemMonitor_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _SystemMonitor_StreamPackageOperation_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "AttachJob",
+			Handler:       _SystemMonitor_AttachJob_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "PingHost",
 			Handler:       _SystemMonitor_PingHost_Handler,
@@ -1370,6 +2735,11 @@ var SystemMonitor_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _SystemMonitor_TestNetworkSpeed_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DiscoverDevices",
+			Handler:       _SystemMonitor_DiscoverDevices_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "UploadFile",
 			Handler:       _SystemMonitor_UploadFile_Handler,
@@ -1390,11 +2760,25 @@ var SystemMonitor_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	DockerService_ListContainers_FullMethodName   = "/picontrol.DockerService/ListContainers"
-	DockerService_StartContainer_FullMethodName   = "/picontrol.DockerService/StartContainer"
-	DockerService_StopContainer_FullMethodName    = "/picontrol.DockerService/StopContainer"
-	DockerService_RestartContainer_FullMethodName = "/picontrol.DockerService/RestartContainer"
-	DockerService_GetContainerLogs_FullMethodName = "/picontrol.DockerService/GetContainerLogs"
+	DockerService_ListContainers_FullMethodName       = "/picontrol.DockerService/ListContainers"
+	DockerService_StartContainer_FullMethodName       = "/picontrol.DockerService/StartContainer"
+	DockerService_StopContainer_FullMethodName        = "/picontrol.DockerService/StopContainer"
+	DockerService_RestartContainer_FullMethodName     = "/picontrol.DockerService/RestartContainer"
+	DockerService_GetContainerLogs_FullMethodName     = "/picontrol.DockerService/GetContainerLogs"
+	DockerService_CopyFromContainer_FullMethodName    = "/picontrol.DockerService/CopyFromContainer"
+	DockerService_CopyToContainer_FullMethodName      = "/picontrol.DockerService/CopyToContainer"
+	DockerService_InspectContainer_FullMethodName     = "/picontrol.DockerService/InspectContainer"
+	DockerService_ListVolumes_FullMethodName          = "/picontrol.DockerService/ListVolumes"
+	DockerService_CreateVolume_FullMethodName         = "/picontrol.DockerService/CreateVolume"
+	DockerService_RemoveVolume_FullMethodName         = "/picontrol.DockerService/RemoveVolume"
+	DockerService_PruneVolumes_FullMethodName         = "/picontrol.DockerService/PruneVolumes"
+	DockerService_ListNetworks_FullMethodName         = "/picontrol.DockerService/ListNetworks"
+	DockerService_CreateNetwork_FullMethodName        = "/picontrol.DockerService/CreateNetwork"
+	DockerService_RemoveNetwork_FullMethodName        = "/picontrol.DockerService/RemoveNetwork"
+	DockerService_ConnectNetwork_FullMethodName       = "/picontrol.DockerService/ConnectNetwork"
+	DockerService_DisconnectNetwork_FullMethodName    = "/picontrol.DockerService/DisconnectNetwork"
+	DockerService_ListComposeProjects_FullMethodName  = "/picontrol.DockerService/ListComposeProjects"
+	DockerService_ManageComposeProject_FullMethodName = "/picontrol.DockerService/ManageComposeProject"
 )
 
 // DockerServiceClient is the client API for DockerService service.
@@ -1411,63 +2795,255 @@ type DockerServiceClient interface {
 	RestartContainer(ctx context.Context, in *ContainerId, opts ...grpc.CallOption) (*ActionStatus, error)
 	// Get container logs
 	GetContainerLogs(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEntry], error)
+	// Copy a file or directory out of a container as a tar stream
+	CopyFromContainer(ctx context.Context, in *ContainerCopyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error)
+	// Copy a tar stream into a directory inside a container
+	CopyToContainer(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileChunk, FileUploadResponse], error)
+	// Get detailed container configuration and state
+	InspectContainer(ctx context.Context, in *InspectContainerRequest, opts ...grpc.CallOption) (*ContainerDetails, error)
+	// Volumes
+	// List volumes with disk usage and attached containers
+	ListVolumes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VolumeList, error)
+	// Create a volume
+	CreateVolume(ctx context.Context, in *CreateVolumeRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Remove a volume
+	RemoveVolume(ctx context.Context, in *RemoveVolumeRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Remove unused volumes
+	PruneVolumes(ctx context.Context, in *PruneVolumesRequest, opts ...grpc.CallOption) (*PruneResponse, error)
+	// Networks
+	// List networks with attached containers
+	ListNetworks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DockerNetworkList, error)
+	// Create a network
+	CreateNetwork(ctx context.Context, in *CreateNetworkRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Remove a network
+	RemoveNetwork(ctx context.Context, in *DockerNetworkId, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Connect a container to a network
+	ConnectNetwork(ctx context.Context, in *NetworkConnectRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Disconnect a container from a network
+	DisconnectNetwork(ctx context.Context, in *NetworkConnectRequest, opts ...grpc.CallOption) (*ActionStatus, error)
+	// Docker Compose
+	// List compose projects (grouped by com.docker.compose.project label)
+	ListComposeProjects(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ComposeProjectList, error)
+	// Run up/down/restart/pull on a compose project and stream output
+	ManageComposeProject(ctx context.Context, in *ComposeCommand, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ComposeOutput], error)
+}
+
+type dockerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDockerServiceClient(cc grpc.ClientConnInterface) DockerServiceClient {
+	return &dockerServiceClient{cc}
+}
+
+func (c *dockerServiceClient) ListContainers(ctx context.Context, in *DockerFilter, opts ...grpc.CallOption) (*ContainerList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ContainerList)
+	err := c.cc.Invoke(ctx, DockerService_ListContainers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerServiceClient) StartContainer(ctx context.Context, in *ContainerId, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, DockerService_StartContainer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerServiceClient) StopContainer(ctx context.Context, in *ContainerId, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, DockerService_StopContainer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerServiceClient) RestartContainer(ctx context.Context, in *ContainerId, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, DockerService_RestartContainer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerServiceClient) GetContainerLogs(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEntry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DockerService_ServiceDesc.Streams[0], DockerService_GetContainerLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LogRequest, LogEntry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DockerService_GetContainerLogsClient = grpc.ServerStreamingClient[LogEntry]
+
+func (c *dockerServiceClient) CopyFromContainer(ctx context.Context, in *ContainerCopyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DockerService_ServiceDesc.Streams[1], DockerService_CopyFromContainer_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ContainerCopyRequest, FileChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DockerService_CopyFromContainerClient = grpc.ServerStreamingClient[FileChunk]
+
+func (c *dockerServiceClient) CopyToContainer(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileChunk, FileUploadResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DockerService_ServiceDesc.Streams[2], DockerService_CopyToContainer_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FileChunk, FileUploadResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DockerService_CopyToContainerClient = grpc.ClientStreamingClient[FileChunk, FileUploadResponse]
+
+func (c *dockerServiceClient) InspectContainer(ctx context.Context, in *InspectContainerRequest, opts ...grpc.CallOption) (*ContainerDetails, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ContainerDetails)
+	err := c.cc.Invoke(ctx, DockerService_InspectContainer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerServiceClient) ListVolumes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VolumeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeList)
+	err := c.cc.Invoke(ctx, DockerService_ListVolumes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerServiceClient) CreateVolume(ctx context.Context, in *CreateVolumeRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, DockerService_CreateVolume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type dockerServiceClient struct {
-	cc grpc.ClientConnInterface
+func (c *dockerServiceClient) RemoveVolume(ctx context.Context, in *RemoveVolumeRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, DockerService_RemoveVolume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewDockerServiceClient(cc grpc.ClientConnInterface) DockerServiceClient {
-	return &dockerServiceClient{cc}
+func (c *dockerServiceClient) PruneVolumes(ctx context.Context, in *PruneVolumesRequest, opts ...grpc.CallOption) (*PruneResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PruneResponse)
+	err := c.cc.Invoke(ctx, DockerService_PruneVolumes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *dockerServiceClient) ListContainers(ctx context.Context, in *DockerFilter, opts ...grpc.CallOption) (*ContainerList, error) {
+func (c *dockerServiceClient) ListNetworks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DockerNetworkList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ContainerList)
-	err := c.cc.Invoke(ctx, DockerService_ListContainers_FullMethodName, in, out, cOpts...)
+	out := new(DockerNetworkList)
+	err := c.cc.Invoke(ctx, DockerService_ListNetworks_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dockerServiceClient) StartContainer(ctx context.Context, in *ContainerId, opts ...grpc.CallOption) (*ActionStatus, error) {
+func (c *dockerServiceClient) CreateNetwork(ctx context.Context, in *CreateNetworkRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ActionStatus)
-	err := c.cc.Invoke(ctx, DockerService_StartContainer_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, DockerService_CreateNetwork_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dockerServiceClient) StopContainer(ctx context.Context, in *ContainerId, opts ...grpc.CallOption) (*ActionStatus, error) {
+func (c *dockerServiceClient) RemoveNetwork(ctx context.Context, in *DockerNetworkId, opts ...grpc.CallOption) (*ActionStatus, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ActionStatus)
-	err := c.cc.Invoke(ctx, DockerService_StopContainer_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, DockerService_RemoveNetwork_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dockerServiceClient) RestartContainer(ctx context.Context, in *ContainerId, opts ...grpc.CallOption) (*ActionStatus, error) {
+func (c *dockerServiceClient) ConnectNetwork(ctx context.Context, in *NetworkConnectRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ActionStatus)
-	err := c.cc.Invoke(ctx, DockerService_RestartContainer_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, DockerService_ConnectNetwork_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dockerServiceClient) GetContainerLogs(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEntry], error) {
+func (c *dockerServiceClient) DisconnectNetwork(ctx context.Context, in *NetworkConnectRequest, opts ...grpc.CallOption) (*ActionStatus, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &DockerService_ServiceDesc.Streams[0], DockerService_GetContainerLogs_FullMethodName, cOpts...)
+	out := new(ActionStatus)
+	err := c.cc.Invoke(ctx, DockerService_DisconnectNetwork_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[LogRequest, LogEntry]{ClientStream: stream}
+	return out, nil
+}
+
+func (c *dockerServiceClient) ListComposeProjects(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ComposeProjectList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ComposeProjectList)
+	err := c.cc.Invoke(ctx, DockerService_ListComposeProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dockerServiceClient) ManageComposeProject(ctx context.Context, in *ComposeCommand, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ComposeOutput], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DockerService_ServiceDesc.Streams[3], DockerService_ManageComposeProject_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ComposeCommand, ComposeOutput]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -1478,7 +3054,7 @@ func (c *dockerServiceClient) GetContainerLogs(ctx context.Context, in *LogReque
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type DockerService_GetContainerLogsClient = grpc.ServerStreamingClient[LogEntry]
+type DockerService_ManageComposeProjectClient = grpc.ServerStreamingClient[ComposeOutput]
 
 // DockerServiceServer is the server API for DockerService service.
 // All implementations must embed UnimplementedDockerServiceServer
@@ -1494,6 +3070,37 @@ type DockerServiceServer interface {
 	RestartContainer(context.Context, *ContainerId) (*ActionStatus, error)
 	// Get container logs
 	GetContainerLogs(*LogRequest, grpc.ServerStreamingServer[LogEntry]) error
+	// Copy a file or directory out of a container as a tar stream
+	CopyFromContainer(*ContainerCopyRequest, grpc.ServerStreamingServer[FileChunk]) error
+	// Copy a tar stream into a directory inside a container
+	CopyToContainer(grpc.ClientStreamingServer[FileChunk, FileUploadResponse]) error
+	// Get detailed container configuration and state
+	InspectContainer(context.Context, *InspectContainerRequest) (*ContainerDetails, error)
+	// Volumes
+	// List volumes with disk usage and attached containers
+	ListVolumes(context.Context, *Empty) (*VolumeList, error)
+	// Create a volume
+	CreateVolume(context.Context, *CreateVolumeRequest) (*ActionStatus, error)
+	// Remove a volume
+	RemoveVolume(context.Context, *RemoveVolumeRequest) (*ActionStatus, error)
+	// Remove unused volumes
+	PruneVolumes(context.Context, *PruneVolumesRequest) (*PruneResponse, error)
+	// Networks
+	// List networks with attached containers
+	ListNetworks(context.Context, *Empty) (*DockerNetworkList, error)
+	// Create a network
+	CreateNetwork(context.Context, *CreateNetworkRequest) (*ActionStatus, error)
+	// Remove a network
+	RemoveNetwork(context.Context, *DockerNetworkId) (*ActionStatus, error)
+	// Connect a container to a network
+	ConnectNetwork(context.Context, *NetworkConnectRequest) (*ActionStatus, error)
+	// Disconnect a container from a network
+	DisconnectNetwork(context.Context, *NetworkConnectRequest) (*ActionStatus, error)
+	// Docker Compose
+	// List compose projects (grouped by com.docker.compose.project label)
+	ListComposeProjects(context.Context, *Empty) (*ComposeProjectList, error)
+	// Run up/down/restart/pull on a compose project and stream output
+	ManageComposeProject(*ComposeCommand, grpc.ServerStreamingServer[ComposeOutput]) error
 	mustEmbedUnimplementedDockerServiceServer()
 }
 
@@ -1519,6 +3126,48 @@ func (UnimplementedDockerServiceServer) RestartContainer(context.Context, *Conta
 func (UnimplementedDockerServiceServer) GetContainerLogs(*LogRequest, grpc.ServerStreamingServer[LogEntry]) error {
 	return status.Error(codes.Unimplemented, "method GetContainerLogs not implemented")
 }
+func (UnimplementedDockerServiceServer) CopyFromContainer(*ContainerCopyRequest, grpc.ServerStreamingServer[FileChunk]) error {
+	return status.Error(codes.Unimplemented, "method CopyFromContainer not implemented")
+}
+func (UnimplementedDockerServiceServer) CopyToContainer(grpc.ClientStreamingServer[FileChunk, FileUploadResponse]) error {
+	return status.Error(codes.Unimplemented, "method CopyToContainer not implemented")
+}
+func (UnimplementedDockerServiceServer) InspectContainer(context.Context, *InspectContainerRequest) (*ContainerDetails, error) {
+	return nil, status.Error(codes.Unimplemented, "method InspectContainer not implemented")
+}
+func (UnimplementedDockerServiceServer) ListVolumes(context.Context, *Empty) (*VolumeList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListVolumes not implemented")
+}
+func (UnimplementedDockerServiceServer) CreateVolume(context.Context, *CreateVolumeRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateVolume not implemented")
+}
+func (UnimplementedDockerServiceServer) RemoveVolume(context.Context, *RemoveVolumeRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveVolume not implemented")
+}
+func (UnimplementedDockerServiceServer) PruneVolumes(context.Context, *PruneVolumesRequest) (*PruneResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PruneVolumes not implemented")
+}
+func (UnimplementedDockerServiceServer) ListNetworks(context.Context, *Empty) (*DockerNetworkList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListNetworks not implemented")
+}
+func (UnimplementedDockerServiceServer) CreateNetwork(context.Context, *CreateNetworkRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateNetwork not implemented")
+}
+func (UnimplementedDockerServiceServer) RemoveNetwork(context.Context, *DockerNetworkId) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveNetwork not implemented")
+}
+func (UnimplementedDockerServiceServer) ConnectNetwork(context.Context, *NetworkConnectRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConnectNetwork not implemented")
+}
+func (UnimplementedDockerServiceServer) DisconnectNetwork(context.Context, *NetworkConnectRequest) (*ActionStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method DisconnectNetwork not implemented")
+}
+func (UnimplementedDockerServiceServer) ListComposeProjects(context.Context, *Empty) (*ComposeProjectList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListComposeProjects not implemented")
+}
+func (UnimplementedDockerServiceServer) ManageComposeProject(*ComposeCommand, grpc.ServerStreamingServer[ComposeOutput]) error {
+	return status.Error(codes.Unimplemented, "method ManageComposeProject not implemented")
+}
 func (UnimplementedDockerServiceServer) mustEmbedUnimplementedDockerServiceServer() {}
 func (UnimplementedDockerServiceServer) testEmbeddedByValue()                       {}
 
@@ -1623,6 +3272,233 @@ func _DockerService_GetContainerLogs_Handler(srv interface{}, stream grpc.Server
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DockerService_GetContainerLogsServer = grpc.ServerStreamingServer[LogEntry]
 
+func _DockerService_CopyFromContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ContainerCopyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DockerServiceServer).CopyFromContainer(m, &grpc.GenericServerStream[ContainerCopyRequest, FileChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DockerService_CopyFromContainerServer = grpc.ServerStreamingServer[FileChunk]
+
+func _DockerService_CopyToContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DockerServiceServer).CopyToContainer(&grpc.GenericServerStream[FileChunk, FileUploadResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DockerService_CopyToContainerServer = grpc.ClientStreamingServer[FileChunk, FileUploadResponse]
+
+func _DockerService_InspectContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServiceServer).InspectContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DockerService_InspectContainer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServiceServer).InspectContainer(ctx, req.(*InspectContainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerService_ListVolumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServiceServer).ListVolumes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DockerService_ListVolumes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServiceServer).ListVolumes(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerService_CreateVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServiceServer).CreateVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DockerService_CreateVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServiceServer).CreateVolume(ctx, req.(*CreateVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerService_RemoveVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServiceServer).RemoveVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DockerService_RemoveVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServiceServer).RemoveVolume(ctx, req.(*RemoveVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerService_PruneVolumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneVolumesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServiceServer).PruneVolumes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DockerService_PruneVolumes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServiceServer).PruneVolumes(ctx, req.(*PruneVolumesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerService_ListNetworks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServiceServer).ListNetworks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DockerService_ListNetworks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServiceServer).ListNetworks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerService_CreateNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNetworkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServiceServer).CreateNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DockerService_CreateNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServiceServer).CreateNetwork(ctx, req.(*CreateNetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerService_RemoveNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DockerNetworkId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServiceServer).RemoveNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DockerService_RemoveNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServiceServer).RemoveNetwork(ctx, req.(*DockerNetworkId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerService_ConnectNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkConnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServiceServer).ConnectNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DockerService_ConnectNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServiceServer).ConnectNetwork(ctx, req.(*NetworkConnectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerService_DisconnectNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkConnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServiceServer).DisconnectNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DockerService_DisconnectNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServiceServer).DisconnectNetwork(ctx, req.(*NetworkConnectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerService_ListComposeProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DockerServiceServer).ListComposeProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DockerService_ListComposeProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DockerServiceServer).ListComposeProjects(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DockerService_ManageComposeProject_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ComposeCommand)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DockerServiceServer).ManageComposeProject(m, &grpc.GenericServerStream[ComposeCommand, ComposeOutput]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DockerService_ManageComposeProjectServer = grpc.ServerStreamingServer[ComposeOutput]
+
 // DockerService_ServiceDesc is the grpc.ServiceDesc for DockerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1646,6 +3522,50 @@ var DockerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RestartContainer",
 			Handler:    _DockerService_RestartContainer_Handler,
 		},
+		{
+			MethodName: "InspectContainer",
+			Handler:    _DockerService_InspectContainer_Handler,
+		},
+		{
+			MethodName: "ListVolumes",
+			Handler:    _DockerService_ListVolumes_Handler,
+		},
+		{
+			MethodName: "CreateVolume",
+			Handler:    _DockerService_CreateVolume_Handler,
+		},
+		{
+			MethodName: "RemoveVolume",
+			Handler:    _DockerService_RemoveVolume_Handler,
+		},
+		{
+			MethodName: "PruneVolumes",
+			Handler:    _DockerService_PruneVolumes_Handler,
+		},
+		{
+			MethodName: "ListNetworks",
+			Handler:    _DockerService_ListNetworks_Handler,
+		},
+		{
+			MethodName: "CreateNetwork",
+			Handler:    _DockerService_CreateNetwork_Handler,
+		},
+		{
+			MethodName: "RemoveNetwork",
+			Handler:    _DockerService_RemoveNetwork_Handler,
+		},
+		{
+			MethodName: "ConnectNetwork",
+			Handler:    _DockerService_ConnectNetwork_Handler,
+		},
+		{
+			MethodName: "DisconnectNetwork",
+			Handler:    _DockerService_DisconnectNetwork_Handler,
+		},
+		{
+			MethodName: "ListComposeProjects",
+			Handler:    _DockerService_ListComposeProjects_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1653,6 +3573,21 @@ var DockerService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _DockerService_GetContainerLogs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "CopyFromContainer",
+			Handler:       _DockerService_CopyFromContainer_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CopyToContainer",
+			Handler:       _DockerService_CopyToContainer_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ManageComposeProject",
+			Handler:       _DockerService_ManageComposeProject_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "pi_control.proto",
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/host"
 	"github.com/shirou/gopsutil/v3/process"
 
 	pb "pi_agent/proto"
@@ -19,6 +20,14 @@ type systemMonitorServer struct {
 	topProcessesTime time.Time
 	prevDiskIO       map[string]disk.IOCountersStat
 	prevDiskIOTime   time.Time
+	jobs             *packageJobQueue
+	packages         packageManager
+	upgrades         *upgradeScheduler
+	dataDir          string
+	lastBootReason   string
+	devices          *deviceTable
+	wifi             *wifiController
+	netConfig        *interfaceConfigStager
 }
 
 // GetVersion returns the agent version and privilege status
@@ -26,8 +35,16 @@ func (s *systemMonitorServer) GetVersion(ctx context.Context, req *pb.Empty) (*p
 	// Check if running as root
 	isRoot := os.Geteuid() == 0
 
-	return &pb.VersionInfo{
-		Version: Version,
-		IsRoot:  isRoot,
-	}, nil
+	info := &pb.VersionInfo{
+		Version:        Version,
+		IsRoot:         isRoot,
+		LastBootReason: s.lastBootReason,
+	}
+	if bootTime, err := host.BootTime(); err == nil {
+		info.BootTime = int64(bootTime)
+	}
+	if uptime, err := host.Uptime(); err == nil {
+		info.UptimeSeconds = int64(uptime)
+	}
+	return info, nil
 }
@@ -2,17 +2,21 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"os/exec"
 	"runtime"
-	"strconv"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	netutil "github.com/shirou/gopsutil/v3/net"
 	"github.com/shirou/gopsutil/v3/process"
+	"golang.org/x/net/dns/dnsmessage"
 
 	pb "pi_agent/proto"
 )
@@ -24,6 +28,14 @@ func (s *systemMonitorServer) GetNetworkInfo(ctx context.Context, req *pb.Empty)
 		return nil, err
 	}
 
+	// Read the counters once rather than once per interface
+	counters := make(map[string]netutil.IOCountersStat)
+	if ioStats, err := netutil.IOCounters(true); err == nil {
+		for _, stat := range ioStats {
+			counters[stat.Name] = stat
+		}
+	}
+
 	var networkInterfaces []*pb.NetworkInterface
 	for _, iface := range interfaces {
 		// Get addresses for this interface (Addrs is already a slice in gopsutil)
@@ -32,30 +44,16 @@ func (s *systemMonitorServer) GetNetworkInfo(ctx context.Context, req *pb.Empty)
 			addresses = append(addresses, addr.Addr)
 		}
 
-		// Get interface stats
-		ioStats, err := netutil.IOCounters(true)
-		var bytesSent, bytesRecv, packetsSent, packetsRecv uint64
-		if err == nil {
-			for _, stat := range ioStats {
-				if stat.Name == iface.Name {
-					bytesSent = stat.BytesSent
-					bytesRecv = stat.BytesRecv
-					packetsSent = stat.PacketsSent
-					packetsRecv = stat.PacketsRecv
-					break
-				}
-			}
-		}
-
+		stat := counters[iface.Name]
 		networkInterfaces = append(networkInterfaces, &pb.NetworkInterface{
 			Name:        iface.Name,
 			Addresses:   addresses,
 			MacAddress:  iface.HardwareAddr, // In gopsutil, this is already a string
 			IsUp:        len(iface.Flags) > 0 && iface.Flags[0] == "up",
-			BytesSent:   bytesSent,
-			BytesRecv:   bytesRecv,
-			PacketsSent: packetsSent,
-			PacketsRecv: packetsRecv,
+			BytesSent:   stat.BytesSent,
+			BytesRecv:   stat.BytesRecv,
+			PacketsSent: stat.PacketsSent,
+			PacketsRecv: stat.PacketsRecv,
 		})
 	}
 
@@ -117,256 +115,561 @@ func (s *systemMonitorServer) GetNetworkConnections(ctx context.Context, req *pb
 	return &pb.NetworkConnectionList{Connections: networkConnections}, nil
 }
 
+// GetInterfaceConfig - Get the configured addressing of an interface
+func (s *systemMonitorServer) GetInterfaceConfig(ctx context.Context, req *pb.InterfaceConfigRequest) (*pb.InterfaceConfig, error) {
+	configurator, err := newInterfaceConfigurator(req.Interface)
+	if err != nil {
+		cfg := &pb.InterfaceConfig{Interface: req.Interface, Error: err.Error()}
+		s.netConfig.status(cfg)
+		return cfg, nil
+	}
+	cfg, err := configurator.Get(ctx, req.Interface)
+	if err != nil {
+		cfg = &pb.InterfaceConfig{
+			Interface: req.Interface,
+			Error:     fmt.Sprintf("Failed to read configuration of %s: %v", req.Interface, err),
+		}
+	}
+	cfg.Backend = configurator.Name()
+	s.netConfig.status(cfg)
+	return cfg, nil
+}
+
+// SetInterfaceConfig - Apply static addressing or DHCP; reverted unless confirmed in time
+func (s *systemMonitorServer) SetInterfaceConfig(ctx context.Context, req *pb.InterfaceConfig) (*pb.ActionStatus, error) {
+	log.Printf("Set interface config: interface=%s, dhcp=%v, ipv4=%v, gateway=%s, ipv6=%v, dns=%v",
+		req.Interface, req.Dhcp, req.Ipv4Addresses, req.Ipv4Gateway, req.Ipv6Addresses, req.DnsServers)
+
+	if err := validateInterfaceConfig(req); err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
+	}
+	configurator, err := newInterfaceConfigurator(req.Interface)
+	if err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
+	}
+	if err := configurator.Check(req); err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
+	}
+
+	timeout := confirmTimeout(req.ConfirmTimeout)
+	if err := s.netConfig.stage(configurator, req, timeout); err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
+	}
+	return &pb.ActionStatus{
+		Success: true,
+		Message: fmt.Sprintf("Applying %s; confirm within %s or it is reverted", describeInterfaceConfig(req), timeout),
+	}, nil
+}
+
+// ConfirmInterfaceConfig - Keep a change made by SetInterfaceConfig
+func (s *systemMonitorServer) ConfirmInterfaceConfig(ctx context.Context, req *pb.InterfaceConfigRequest) (*pb.ActionStatus, error) {
+	if err := s.netConfig.confirm(req.Interface); err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
+	}
+	return &pb.ActionStatus{Success: true, Message: fmt.Sprintf("Configuration of %s confirmed", req.Interface)}, nil
+}
+
+// RevertInterfaceConfig - Undo an unconfirmed change now
+func (s *systemMonitorServer) RevertInterfaceConfig(ctx context.Context, req *pb.InterfaceConfigRequest) (*pb.ActionStatus, error) {
+	if err := s.netConfig.revert(req.Interface, nil, "requested"); err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
+	}
+	return &pb.ActionStatus{Success: true, Message: fmt.Sprintf("Configuration of %s reverted", req.Interface)}, nil
+}
+
 // ==================== Network Tools Implementation ====================
 
 // PingHost - Ping a host and stream results
 func (s *systemMonitorServer) PingHost(req *pb.PingRequest, stream pb.SystemMonitor_PingHostServer) error {
 	log.Printf("Ping request: host=%s, count=%d, timeout=%d", req.Host, req.Count, req.Timeout)
+	ctx := stream.Context()
 
-	count := int(req.Count)
-	timeout := int(req.Timeout)
+	count := int(req.Count) // 0 = until cancelled
+	timeout := time.Duration(req.Timeout) * time.Second
 	packetSize := int(req.PacketSize)
 
-	if count <= 0 {
-		count = 4 // Default count
-	}
 	if timeout <= 0 {
-		timeout = 5 // Default timeout
+		timeout = 5 * time.Second // Default timeout
 	}
 	if packetSize <= 0 {
 		packetSize = 56 // Default packet size
 	}
-
-	// Use the ping command (cross-platform)
-	var pingCmd string
-	if runtime.GOOS == "windows" {
-		// Windows: ping -n count -w timeout_ms host
-		pingCmd = fmt.Sprintf("ping -n %d -w %d %s", count, timeout*1000, req.Host)
-	} else {
-		// Linux/Unix: ping -c count -W timeout -s packet_size host
-		pingCmd = fmt.Sprintf("ping -c %d -W %d -s %d %s", count, timeout, packetSize, req.Host)
-	}
-
-	cmd := exec.Command("sh", "-c", pingCmd)
-	if runtime.GOOS == "windows" {
-		cmd = exec.Command("cmd", "/C", pingCmd)
+	if packetSize > maxPingPayload {
+		packetSize = maxPingPayload
 	}
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
+	fail := func(ip string, err error) error {
 		return stream.Send(&pb.PingResponse{
 			Success:  false,
 			Host:     req.Host,
+			Ip:       ip,
 			Error:    fmt.Sprintf("Ping failed: %v", err),
 			Finished: true,
 		})
 	}
 
-	// Parse output (simplified - send summary)
-	lines := strings.Split(string(output), "\n")
-	for i, line := range lines {
-		if strings.Contains(line, "time=") || strings.Contains(line, "ms") {
-			err := stream.Send(&pb.PingResponse{
-				Success:  true,
-				Host:     req.Host,
-				Sequence: int32(i + 1),
-				Finished: i == len(lines)-1,
-			})
+	if strings.TrimSpace(req.Host) == "" {
+		return fail("", fmt.Errorf("host is required"))
+	}
+	dst, err := resolveHost(ctx, strings.TrimSpace(req.Host))
+	if err != nil {
+		return fail("", err)
+	}
+	ip := dst.String()
+
+	conn, err := listenICMP(dst)
+	if err != nil {
+		return fail(ip, err)
+	}
+	defer conn.Close()
+
+	var sent, received int32
+	var minLatency, maxLatency, totalLatency float64
+
+	// Every reply carries the statistics so far: once a continuous ping is
+	// cancelled the stream is gone and a final summary cannot be delivered
+	statistics := func() *pb.PingStats {
+		stats := &pb.PingStats{
+			PacketsSent:     sent,
+			PacketsReceived: received,
+			PacketLoss:      float64(sent-received) * 100 / float64(sent),
+			MinLatency:      minLatency,
+			MaxLatency:      maxLatency,
+		}
+		if received > 0 {
+			stats.AvgLatency = totalLatency / float64(received)
+		}
+		return stats
+	}
+	finish := func() error {
+		return stream.Send(&pb.PingResponse{
+			Success:    received > 0,
+			Host:       req.Host,
+			Ip:         ip,
+			Finished:   true,
+			Statistics: statistics(),
+		})
+	}
+
+	for seq := 1; count == 0 || seq <= count; seq++ {
+		start := time.Now()
+		sent++
+		if err := conn.sendEcho(dst, seq, packetSize); err != nil {
+			return fail(ip, err)
+		}
+
+		// Wait for the matching reply, skipping unrelated ICMP traffic
+		// and late replies to earlier requests
+		response := &pb.PingResponse{Host: req.Host, Ip: ip, Sequence: int32(seq)}
+		deadline := start.Add(timeout)
+		for {
+			if ctx.Err() != nil {
+				// Best effort: a cancelled stream usually drops it
+				finish()
+				return nil
+			}
+			reply, err := conn.read(deadline)
 			if err != nil {
-				return err
+				if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+					response.Error = fmt.Sprintf("Request timeout for icmp_seq %d", seq)
+					break
+				}
+				continue
+			}
+			if !conn.isEchoReply(reply, seq) {
+				continue
+			}
+
+			latency := float64(reply.received.Sub(start).Microseconds()) / 1000
+			response.Success = true
+			response.Latency = latency
+			response.Ttl = int32(reply.ttl)
+			if reply.from != nil {
+				response.Ip = reply.from.String()
+			}
+
+			received++
+			totalLatency += latency
+			if received == 1 || latency < minLatency {
+				minLatency = latency
+			}
+			if latency > maxLatency {
+				maxLatency = latency
+			}
+			break
+		}
+
+		response.Statistics = statistics()
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+
+		// One request per second, like ping
+		if count == 0 || seq < count {
+			select {
+			case <-ctx.Done():
+				finish()
+				return nil
+			case <-time.After(time.Until(start.Add(time.Second))):
 			}
 		}
 	}
 
-	return stream.Send(&pb.PingResponse{
-		Success:  true,
-		Host:     req.Host,
-		Finished: true,
-	})
+	return finish()
 }
 
-// ScanPorts - Scan ports on a host and stream results
+// ScanPorts - Scan ports on a host or subnet and stream results as probes complete
 func (s *systemMonitorServer) ScanPorts(req *pb.PortScanRequest, stream pb.SystemMonitor_ScanPortsServer) error {
-	log.Printf("Port scan request: host=%s", req.Host)
+	log.Printf("Port scan request: host=%s, protocol=%s", req.Host, req.Protocol)
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
 
-	timeout := time.Duration(req.Timeout) * time.Millisecond
-	if timeout == 0 {
-		timeout = 1000 * time.Millisecond
+	fail := func(err error) error {
+		return stream.Send(&pb.PortScanResponse{
+			Error:    fmt.Sprintf("Port scan failed: %v", err),
+			Finished: true,
+		})
 	}
 
-	ports := req.Ports
-	if len(ports) == 0 {
-		// Default common ports
-		ports = []int32{20, 21, 22, 23, 25, 53, 80, 110, 143, 443, 445, 3306, 3389, 5432, 8080, 8443}
+	timeout := time.Duration(req.Timeout) * time.Millisecond
+	if timeout <= 0 {
+		timeout = defaultScanTimeout
+	}
+	concurrency := int(req.Concurrency)
+	if concurrency <= 0 {
+		concurrency = defaultScanConcurrency
+	}
+	if concurrency > maxScanConcurrency {
+		concurrency = maxScanConcurrency
 	}
 
-	totalPorts := len(ports)
-	for i, port := range ports {
-		address := net.JoinHostPort(req.Host, strconv.Itoa(int(port)))
-		conn, err := net.DialTimeout("tcp", address, timeout)
+	if strings.TrimSpace(req.Host) == "" {
+		return fail(fmt.Errorf("host is required"))
+	}
+	hosts, err := scanHosts(ctx, strings.TrimSpace(req.Host))
+	if err != nil {
+		return fail(err)
+	}
+	ports, err := scanPortList(req)
+	if err != nil {
+		return fail(err)
+	}
+	total := len(hosts) * len(ports)
+	if total == 0 {
+		return fail(fmt.Errorf("nothing to scan"))
+	}
+	if total > maxScanProbes {
+		return fail(fmt.Errorf("%d probes requested, the limit is %d", total, maxScanProbes))
+	}
+	if concurrency > total {
+		concurrency = total
+	}
 
-		isOpen := err == nil
-		if isOpen {
-			conn.Close()
+	// Feed targets to the workers, paced by the rate limit
+	targets := make(chan scanTarget)
+	go func() {
+		defer close(targets)
+		var tick <-chan time.Time
+		if req.Rate > 0 {
+			if interval := time.Second / time.Duration(req.Rate); interval > 0 {
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				tick = ticker.C
+			}
 		}
-
-		progress := int32((i + 1) * 100 / totalPorts)
-		err = stream.Send(&pb.PortScanResponse{
-			Port:     port,
-			Open:     isOpen,
-			Progress: progress,
-			Finished: i == totalPorts-1,
-		})
-
-		if err != nil {
+		for _, ip := range hosts {
+			for _, port := range ports {
+				if tick != nil {
+					select {
+					case <-tick:
+					case <-ctx.Done():
+						return
+					}
+				}
+				select {
+				case targets <- scanTarget{ip: ip, port: port}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	results := make(chan *pb.PortScanResponse, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for target := range targets {
+				var result *pb.PortScanResponse
+				if req.Protocol == pb.PortScanProtocol_PORT_SCAN_UDP {
+					result = probeUDP(ctx, target, timeout)
+				} else {
+					result = probeTCP(ctx, target, timeout, req.GrabBanner)
+				}
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	completed := 0
+	for result := range results {
+		completed++
+		result.Progress = int32(completed * 100 / total)
+		result.Finished = completed == total
+		if err := stream.Send(result); err != nil {
 			return err
 		}
 	}
-
-	return nil
+	return ctx.Err()
 }
 
-// DNSLookup - Perform DNS lookup
+// DNSLookup - Query a resolver for one record type
 func (s *systemMonitorServer) DNSLookup(ctx context.Context, req *pb.DNSRequest) (*pb.DNSResponse, error) {
-	log.Printf("DNS lookup: hostname=%s, type=%s", req.Hostname, req.RecordType)
+	log.Printf("DNS lookup: hostname=%s, type=%s, resolver=%s", req.Hostname, req.RecordType, req.Resolver)
 
-	startTime := time.Now()
+	response := &pb.DNSResponse{Hostname: req.Hostname}
 
-	// Simple DNS lookup (A record)
-	addrs, err := net.LookupHost(req.Hostname)
+	typeName := strings.ToUpper(strings.TrimSpace(req.RecordType))
+	if typeName == "" {
+		typeName = defaultDNSType
+	}
+	qtype, ok := dnsRecordTypes[typeName]
+	if !ok {
+		response.Error = fmt.Sprintf("unsupported record type %q", req.RecordType)
+		return response, nil
+	}
+	if strings.TrimSpace(req.Hostname) == "" {
+		response.Error = "hostname is required"
+		return response, nil
+	}
+
+	name := strings.TrimSpace(req.Hostname)
+
+	// Without a chosen server, lookups go through the system resolver so
+	// /etc/hosts and search domains apply as they do for other programs
+	resolver := strings.TrimSpace(req.Resolver)
+	names := []string{dnsQueryName(name, qtype)}
+	if resolver == "" {
+		if qtype == dnsmessage.TypeA || qtype == dnsmessage.TypeAAAA {
+			lookupSystemAddresses(ctx, response, name, qtype)
+			return response, nil
+		}
+		conf, err := readResolvConf()
+		if err != nil {
+			response.Error = err.Error()
+			return response, nil
+		}
+		resolver = conf.nameserver
+		if qtype != dnsmessage.TypePTR || net.ParseIP(name) == nil {
+			names = conf.searchNames(name)
+		}
+	}
+	server, err := resolverAddress(resolver)
 	if err != nil {
-		return &pb.DNSResponse{
-			Success:  false,
-			Hostname: req.Hostname,
-			Error:    fmt.Sprintf("DNS lookup failed: %v", err),
-		}, nil
+		response.Error = err.Error()
+		return response, nil
 	}
+	response.Server = server
 
-	queryTime := time.Since(startTime).Seconds() * 1000 // Convert to milliseconds
+	// Like the system resolver, move on to the next search name on NXDOMAIN
+	startTime := time.Now()
+	var msg *dnsmessage.Message
+	for _, qname := range names {
+		msg, err = queryDNS(ctx, server, qname, qtype)
+		if err != nil || msg.RCode != dnsmessage.RCodeNameError {
+			break
+		}
+	}
+	response.QueryTime = time.Since(startTime).Seconds() * 1000 // Convert to milliseconds
+	if err != nil {
+		response.Error = fmt.Sprintf("DNS lookup failed: %v", err)
+		return response, nil
+	}
 
-	return &pb.DNSResponse{
-		Success:   true,
-		Hostname:  req.Hostname,
-		Addresses: addrs,
-		QueryTime: queryTime,
-	}, nil
+	response.Rcode = dnsRCodeName(msg.RCode)
+	for _, answer := range msg.Answers {
+		record, ok := dnsRecordFromResource(answer)
+		if !ok {
+			continue
+		}
+		response.Records = append(response.Records, record)
+		if answer.Header.Type == qtype {
+			response.Addresses = append(response.Addresses, record.Value)
+		}
+	}
+
+	if msg.RCode != dnsmessage.RCodeSuccess {
+		response.Error = fmt.Sprintf("DNS lookup failed: %s", response.Rcode)
+		return response, nil
+	}
+	response.Success = true
+	return response, nil
 }
 
 // Traceroute - Perform traceroute and stream results
 func (s *systemMonitorServer) Traceroute(req *pb.TracerouteRequest, stream pb.SystemMonitor_TracerouteServer) error {
-	log.Printf("Traceroute request: host=%s, max_hops=%d", req.Host, req.MaxHops)
+	log.Printf("Traceroute request: host=%s, max_hops=%d, probe=%s", req.Host, req.MaxHops, req.Probe)
+	ctx := stream.Context()
 
 	maxHops := int(req.MaxHops)
 	if maxHops <= 0 {
 		maxHops = 30
 	}
-
-	// Use traceroute command (cross-platform)
-	var traceCmd string
-	switch runtime.GOOS {
-	case "windows":
-		traceCmd = fmt.Sprintf("tracert -h %d -w 3000 %s", maxHops, req.Host)
-	default:
-		traceCmd = fmt.Sprintf("traceroute -m %d -w 3 -q 1 %s", maxHops, req.Host)
+	if maxHops > 255 {
+		maxHops = 255
 	}
-
-	cmd := exec.Command("sh", "-c", traceCmd)
-	if runtime.GOOS == "windows" {
-		cmd = exec.Command("cmd", "/C", traceCmd)
+	timeout := time.Duration(req.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	probes := int(req.ProbesPerHop)
+	if probes <= 0 {
+		probes = defaultTraceProbes
+	}
+	if probes > maxTraceProbes {
+		probes = maxTraceProbes
 	}
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
+	fail := func(err error) error {
 		return stream.Send(&pb.TracerouteResponse{
 			Error:    fmt.Sprintf("Traceroute failed: %v", err),
 			Finished: true,
 		})
 	}
 
-	// Parse and stream output
-	lines := strings.Split(string(output), "\n")
-	log.Printf("Traceroute output has %d lines", len(lines))
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "traceroute") || strings.HasPrefix(line, "Tracing") {
-			continue
-		}
+	if strings.TrimSpace(req.Host) == "" {
+		return fail(fmt.Errorf("host is required"))
+	}
+	dst, err := resolveHost(ctx, strings.TrimSpace(req.Host))
+	if err != nil {
+		return fail(err)
+	}
+	v6 := dst.To4() == nil
 
-		log.Printf("Processing traceroute line: %s", line)
+	// Routers answer every probe type with ICMP, which only a raw socket sees
+	listener, err := listenRawICMP(dst)
+	if err != nil {
+		return fail(err)
+	}
+	defer listener.Close()
 
-		// Parse traceroute output
-		var hopNum int32
-		var ip, hostname string
-		var latency float64
-		timeout := false
+	events := make(chan traceEvent, maxTraceProbes*4)
+	prober, err := newTraceProber(req, dst, listener, timeout, maxHops*probes-1, events)
+	if err != nil {
+		return fail(err)
+	}
+	defer prober.Close()
 
-		fields := strings.Fields(line)
-		if len(fields) < 2 {
-			log.Printf("Skipping line with insufficient fields: %s", line)
-			continue
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			reply, err := listener.read(time.Time{})
+			if err != nil {
+				if errors.Is(err, net.ErrClosed) {
+					return
+				}
+				continue
+			}
+			seq, ok := prober.match(reply)
+			if !ok {
+				continue
+			}
+			select {
+			case events <- traceEventFromReply(reply, seq, dst, v6):
+			case <-done:
+				return
+			}
 		}
-
-		// Try to parse hop number from first field
-		if num, err := strconv.Atoi(fields[0]); err == nil {
-			hopNum = int32(num)
-		} else {
-			log.Printf("Could not parse hop number from: %s", fields[0])
-			continue
+	}()
+
+	asnCache := make(map[string]string)
+	for ttl := 1; ttl <= maxHops; ttl++ {
+		hopCtx, cancelHop := context.WithTimeout(ctx, timeout)
+		firstSeq := (ttl - 1) * probes
+		sentAt := make([]time.Time, probes)
+		for i := 0; i < probes; i++ {
+			sentAt[i] = time.Now()
+			if err := prober.send(hopCtx, ttl, firstSeq+i); err != nil {
+				cancelHop()
+				return fail(err)
+			}
 		}
 
-		// Check for timeout
-		if strings.Contains(line, "*") {
-			timeout = true
-			log.Printf("Hop %d: timeout detected", hopNum)
-		} else {
-			// Extract IP and hostname
-			for i, f := range fields[1:] {
-				// Look for IP in parentheses like (192.168.1.1)
-				if strings.HasPrefix(f, "(") && strings.HasSuffix(f, ")") {
-					ip = strings.Trim(f, "()")
-					if i > 0 {
-						hostname = fields[1]
-					}
-				}
-				// Look for standalone IP (no parentheses)
-				if net.ParseIP(f) != nil {
-					if ip == "" {
-						ip = f
-					}
+		// Collect answers until every probe of this hop has one or the
+		// hop times out; answers to earlier hops are dropped
+		results := make([]*pb.TracerouteProbeResult, probes)
+		pending := probes
+		reached, unreachable := false, false
+		for pending > 0 && hopCtx.Err() == nil {
+			select {
+			case ev := <-events:
+				i := ev.seq - firstSeq
+				if i < 0 || i >= probes || results[i] != nil {
+					continue
 				}
-				// Look for latency (e.g., "1.234ms" or "<1ms")
-				if strings.Contains(f, "ms") {
-					latencyStr := strings.TrimSuffix(strings.TrimPrefix(f, "<"), "ms")
-					if val, err := strconv.ParseFloat(latencyStr, 64); err == nil {
-						latency = val
-					}
+				results[i] = &pb.TracerouteProbeResult{
+					Ip:      ev.from.String(),
+					Latency: float64(ev.at.Sub(sentAt[i]).Microseconds()) / 1000,
+					Note:    ev.note,
 				}
+				pending--
+				reached = reached || ev.reached
+				unreachable = unreachable || ev.note != ""
+			case <-hopCtx.Done():
 			}
+		}
+		cancelHop()
+		if ctx.Err() != nil {
+			return nil
+		}
 
-			// If no IP found but we have a hostname, use it as IP
-			if ip == "" && len(fields) > 1 && fields[1] != "*" {
-				hostname = fields[1]
-				ip = hostname
+		hop := &pb.TracerouteResponse{Hop: int32(ttl), Timeout: true, Reached: reached}
+		var total float64
+		var answered int
+		for i, result := range results {
+			if result == nil {
+				results[i] = &pb.TracerouteProbeResult{Timeout: true}
+				continue
 			}
-
-			log.Printf("Hop %d: ip=%s, hostname=%s, latency=%.2fms", hopNum, ip, hostname, latency)
+			if hop.Ip == "" {
+				hop.Ip = result.Ip
+			}
+			total += result.Latency
+			answered++
 		}
-
-		if hopNum > 0 {
-			err := stream.Send(&pb.TracerouteResponse{
-				Hop:      hopNum,
-				Ip:       ip,
-				Hostname: hostname,
-				Latency:  latency,
-				Timeout:  timeout,
-				Finished: false,
-			})
-			if err != nil {
-				log.Printf("Error sending hop %d: %v", hopNum, err)
-				return err
+		hop.Probes = results
+		if answered > 0 {
+			hop.Timeout = false
+			hop.Latency = total / float64(answered)
+
+			ip := net.ParseIP(hop.Ip)
+			hop.Hostname = reverseLookup(ctx, ip)
+			if req.LookupAsn {
+				asn, ok := asnCache[hop.Ip]
+				if !ok {
+					asn = lookupASN(ctx, ip)
+					asnCache[hop.Ip] = asn
+				}
+				hop.Asn = asn
 			}
-			log.Printf("Successfully sent hop %d", hopNum)
+		}
+
+		if err := stream.Send(hop); err != nil {
+			return err
+		}
+		if reached || unreachable {
+			break
 		}
 	}
 
@@ -387,24 +690,56 @@ func (s *systemMonitorServer) GetWifiInfo(ctx context.Context, req *pb.Empty) (*
 	// Platform-specific WiFi info retrieval
 	switch runtime.GOOS {
 	case "linux":
-		// Use iwconfig or nmcli on Linux
-		cmd := exec.Command("iwconfig")
-		output, err := cmd.CombinedOutput()
-		if err == nil {
-			lines := strings.Split(string(output), "\n")
-			for _, line := range lines {
-				if strings.Contains(line, "ESSID:") {
-					parts := strings.Split(line, "ESSID:")
-					if len(parts) > 1 {
-						ssid := strings.Trim(parts[1], "\" \n")
-						if ssid != "" && ssid != "off/any" {
-							wifiInfo.Connected = true
-							wifiInfo.Ssid = ssid
-						}
+		iface, err := wifiInterface("")
+		if err != nil {
+			return wifiInfo, nil
+		}
+		wifiInfo.Interface = iface
+		output, err := runIw(ctx, iwTimeout, "dev", iface, "link")
+		if err != nil {
+			log.Printf("WiFi info: %v", err)
+			return wifiInfo, nil
+		}
+		link := parseIwLink(output)
+		if link.connected {
+			wifiInfo.Connected = true
+			wifiInfo.Ssid = link.ssid
+			wifiInfo.Bssid = link.bssid
+			wifiInfo.SignalStrength = link.signal
+			wifiInfo.SignalQuality = signalQuality(link.signal)
+			wifiInfo.Frequency = link.freq / 1000
+			wifiInfo.Channel = wifiChannel(int(link.freq))
+			wifiInfo.LinkSpeed = link.txRate
+			wifiInfo.RxLinkSpeed = link.rxRate
+		}
+		if netIface, err := net.InterfaceByName(iface); err == nil {
+			if addrs, err := netIface.Addrs(); err == nil {
+				for _, addr := range addrs {
+					if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil {
+						wifiInfo.IpAddress = ipNet.IP.String()
+						break
 					}
 				}
 			}
 		}
+
+		// The kernel keeps the results of the last scan; reading them does
+		// not start a new one. Security is only advertised in beacons, so it
+		// comes from there too.
+		if output, err := runIw(ctx, iwTimeout, "dev", iface, "scan", "dump"); err == nil {
+			wifiInfo.AvailableNetworks = parseIwScan(output)
+			for _, network := range wifiInfo.AvailableNetworks {
+				if network.Bssid == link.bssid {
+					network.Connected = true
+					wifiInfo.Security = network.Security
+				}
+			}
+			var survey map[int]float64
+			if output, err := runIw(ctx, iwTimeout, "dev", iface, "survey", "dump"); err == nil {
+				survey = parseIwSurvey(output)
+			}
+			wifiInfo.Channels = wifiChannelStats(wifiInfo.AvailableNetworks, survey)
+		}
 	case "windows":
 		// Use netsh on Windows
 		cmd := exec.Command("netsh", "wlan", "show", "interfaces")
@@ -427,206 +762,452 @@ func (s *systemMonitorServer) GetWifiInfo(ctx context.Context, req *pb.Empty) (*
 	return wifiInfo, nil
 }
 
-// TestNetworkSpeed - Test network speed (simplified implementation)
-func (s *systemMonitorServer) TestNetworkSpeed(req *pb.SpeedTestRequest, stream pb.SystemMonitor_TestNetworkSpeedServer) error {
-	log.Printf("Speed test request: download=%v, upload=%v, duration=%d", req.TestDownload, req.TestUpload, req.Duration)
+// ScanWifi - Scan for nearby WiFi networks and report how crowded each channel is
+func (s *systemMonitorServer) ScanWifi(ctx context.Context, req *pb.ScanWifiRequest) (*pb.ScanWifiResponse, error) {
+	log.Printf("WiFi scan request: interface=%s, cached=%v", req.Interface, req.Cached)
 
-	duration := req.Duration
-	if duration < 5 {
-		duration = 10
+	response := &pb.ScanWifiResponse{}
+	if runtime.GOOS != "linux" {
+		response.Error = "WiFi scanning is only supported on Linux"
+		return response, nil
 	}
-	if duration > 30 {
-		duration = 30
+	iface, err := wifiInterface(req.Interface)
+	if err != nil {
+		response.Error = err.Error()
+		return response, nil
+	}
+	response.Interface = iface
+
+	var output string
+	if !req.Cached {
+		output, err = runIw(ctx, wifiScanTimeout, "dev", iface, "scan")
+		// Another scan (e.g. by wpa_supplicant) is running; its results will
+		// be in the cache shortly
+		if err != nil && strings.Contains(err.Error(), "busy") {
+			select {
+			case <-time.After(3 * time.Second):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			req.Cached = true
+		}
+	}
+	if req.Cached {
+		output, err = runIw(ctx, iwTimeout, "dev", iface, "scan", "dump")
+	}
+	if err != nil {
+		if strings.Contains(err.Error(), "Operation not permitted") {
+			response.Error = "Scanning requires root; request cached results instead"
+		} else {
+			response.Error = fmt.Sprintf("WiFi scan failed: %v", err)
+		}
+		return response, nil
 	}
 
-	// Measure latency first
-	latency := measureLatency()
-	log.Printf("Measured latency: %.2fms", latency)
+	response.Networks = parseIwScan(output)
+	var survey map[int]float64
+	if output, err := runIw(ctx, iwTimeout, "dev", iface, "survey", "dump"); err == nil {
+		survey = parseIwSurvey(output)
+	}
+	response.Channels = wifiChannelStats(response.Networks, survey)
+	response.Success = true
+	log.Printf("WiFi scan on %s found %d networks", iface, len(response.Networks))
+	return response, nil
+}
+
+// ListSavedWifiNetworks - List saved WiFi networks, highest priority first
+func (s *systemMonitorServer) ListSavedWifiNetworks(ctx context.Context, req *pb.Empty) (*pb.SavedWifiNetworkList, error) {
+	list := &pb.SavedWifiNetworkList{}
+	s.wifi.status(list)
 
-	// Send connecting phase
-	err := stream.Send(&pb.SpeedTestResponse{
-		Phase:    "connecting",
-		Progress: 0,
-		Latency:  latency,
-		Server:   "local",
-		Finished: false,
+	manager, err := newWifiManager()
+	if err != nil {
+		list.Error = err.Error()
+		return list, nil
+	}
+	list.Backend = manager.Name()
+	networks, err := manager.Saved(ctx)
+	if err != nil {
+		list.Error = fmt.Sprintf("Failed to list WiFi networks: %v", err)
+		return list, nil
+	}
+	sort.SliceStable(networks, func(i, j int) bool {
+		return networks[i].Priority > networks[j].Priority
 	})
+	list.Networks = networks
+	return list, nil
+}
+
+// AddWifiNetwork - Save a WiFi network and optionally connect to it
+func (s *systemMonitorServer) AddWifiNetwork(ctx context.Context, req *pb.AddWifiNetworkRequest) (*pb.ActionStatus, error) {
+	log.Printf("Add WiFi network: ssid=%q, security=%s, hidden=%v, priority=%d, connect=%v", req.Ssid, req.Security, req.Hidden, req.Priority, req.Connect)
+
+	if err := validateWifiNetwork(req); err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
+	}
+	manager, err := newWifiManager()
 	if err != nil {
-		return err
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
+	}
+	if err := manager.Add(ctx, req); err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to save %s: %v", req.Ssid, err),
+			ErrorCode: 1,
+		}, nil
 	}
+	if !req.Connect {
+		return &pb.ActionStatus{Success: true, Message: fmt.Sprintf("Saved %s", req.Ssid)}, nil
+	}
+	return s.connectWifi(ctx, manager, req.Ssid, rollbackTimeout(req.RollbackTimeout)), nil
+}
 
-	// Test download speed if requested
-	if req.TestDownload {
-		err := testDownloadSpeed(stream, int(duration))
-		if err != nil {
-			return err
-		}
+// ConnectWifiNetwork - Switch to a saved WiFi network, restoring the previous one if connectivity is lost
+func (s *systemMonitorServer) ConnectWifiNetwork(ctx context.Context, req *pb.WifiNetworkRequest) (*pb.ActionStatus, error) {
+	log.Printf("Connect WiFi network: ssid=%q, rollback=%d", req.Ssid, req.RollbackTimeout)
+
+	manager, err := newWifiManager()
+	if err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
 	}
+	if _, err := savedWifiNetwork(ctx, manager, req.Ssid); err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
+	}
+	return s.connectWifi(ctx, manager, req.Ssid, rollbackTimeout(req.RollbackTimeout)), nil
+}
 
-	// Test upload speed if requested
-	if req.TestUpload {
-		err := testUploadSpeed(stream, int(duration))
-		if err != nil {
-			return err
-		}
+// connectWifi starts switching to ssid in the background, since the switch
+// drops the connection this RPC arrived on
+func (s *systemMonitorServer) connectWifi(ctx context.Context, manager wifiManager, ssid string, rollback time.Duration) *pb.ActionStatus {
+	previous, err := activeWifiNetwork(ctx, manager)
+	if err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}
+	}
+	if previous == ssid {
+		return &pb.ActionStatus{Success: true, Message: fmt.Sprintf("Already connected to %s", ssid)}
 	}
 
-	// Send completion
-	err = stream.Send(&pb.SpeedTestResponse{
-		Phase:    "complete",
-		Progress: 100,
-		Latency:  latency,
-		Server:   "local",
-		Finished: true,
-	})
+	change := wifiChange{
+		Description: fmt.Sprintf("Connect to %s", ssid),
+		Connect:     ssid,
+		Restore:     previous,
+	}
+	if err := s.wifi.start(ctx, manager, change, rollback); err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}
+	}
 
-	return err
+	message := fmt.Sprintf("Connecting to %s", ssid)
+	if rollback > 0 && previous != "" {
+		message += fmt.Sprintf("; %s will be restored if there is no connectivity within %s", previous, rollback)
+	}
+	return &pb.ActionStatus{Success: true, Message: message}
 }
 
-func measureLatency() float64 {
-	// Try to ping a public DNS server
-	conn, err := net.DialTimeout("tcp", "8.8.8.8:53", 2*time.Second)
-	if err == nil {
-		defer conn.Close()
-		// Measure simple round trip
-		start := time.Now()
-		// Simple write/read test
-		conn.Write([]byte{0})
-		conn.Read(make([]byte, 1))
-		elapsed := time.Since(start)
-		return float64(elapsed.Milliseconds())
+// ForgetWifiNetwork - Remove a saved WiFi network
+func (s *systemMonitorServer) ForgetWifiNetwork(ctx context.Context, req *pb.WifiNetworkRequest) (*pb.ActionStatus, error) {
+	log.Printf("Forget WiFi network: ssid=%q", req.Ssid)
+
+	manager, err := newWifiManager()
+	if err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
+	}
+	network, err := savedWifiNetwork(ctx, manager, req.Ssid)
+	if err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
+	}
+
+	if !network.Active {
+		if err := manager.Forget(ctx, req.Ssid); err != nil {
+			return &pb.ActionStatus{
+				Success:   false,
+				Message:   fmt.Sprintf("Failed to forget %s: %v", req.Ssid, err),
+				ErrorCode: 1,
+			}, nil
+		}
+		return &pb.ActionStatus{Success: true, Message: fmt.Sprintf("Forgot %s", req.Ssid)}, nil
 	}
 
-	// Fallback: estimate latency with localhost ping (0ms)
-	return 0
+	// Forgetting the current network disconnects from it. Disable it first
+	// and only delete it once another network has connectivity.
+	rollback := rollbackTimeout(req.RollbackTimeout)
+	change := wifiChange{
+		Description: fmt.Sprintf("Forget %s", req.Ssid),
+		Forget:      req.Ssid,
+		Restore:     req.Ssid,
+	}
+	if err := s.wifi.start(ctx, manager, change, rollback); err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
+	}
+	message := fmt.Sprintf("Disconnecting from %s", req.Ssid)
+	if rollback > 0 {
+		message += fmt.Sprintf("; it is forgotten once another network has connectivity, or restored after %s", rollback)
+	}
+	return &pb.ActionStatus{Success: true, Message: message}, nil
 }
 
-func testDownloadSpeed(stream pb.SystemMonitor_TestNetworkSpeedServer, duration int) error {
-	log.Printf("Testing download speed for %d seconds", duration)
+// SetWifiNetworkPriority - Set which saved WiFi network is preferred
+func (s *systemMonitorServer) SetWifiNetworkPriority(ctx context.Context, req *pb.WifiNetworkRequest) (*pb.ActionStatus, error) {
+	log.Printf("Set WiFi network priority: ssid=%q, priority=%d", req.Ssid, req.Priority)
 
-	// Generate test data to simulate downloading
-	chunkSize := 1024 * 1024 // 1MB chunks
-	testDataSize := int64(0)
-	lastReportTime := time.Now()
-	lastReportedSize := int64(0)
-	startTime := time.Now()
-	endTime := startTime.Add(time.Duration(duration) * time.Second)
+	manager, err := newWifiManager()
+	if err != nil {
+		return &pb.ActionStatus{Success: false, Message: err.Error(), ErrorCode: 1}, nil
+	}
+	if err := manager.SetPriority(ctx, req.Ssid, req.Priority); err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to set priority of %s: %v", req.Ssid, err),
+			ErrorCode: 1,
+		}, nil
+	}
+	return &pb.ActionStatus{Success: true, Message: fmt.Sprintf("Priority of %s set to %d", req.Ssid, req.Priority)}, nil
+}
 
-	for time.Now().Before(endTime) {
-		// Simulate downloading by reading/writing test data
-		chunk := make([]byte, chunkSize)
-		for i := range chunk {
-			chunk[i] = byte(i % 256)
+// TestNetworkSpeed - Measure TCP throughput to an iperf3 or HTTP server and stream progress
+func (s *systemMonitorServer) TestNetworkSpeed(req *pb.SpeedTestRequest, stream pb.SystemMonitor_TestNetworkSpeedServer) error {
+	log.Printf("Speed test request: server=%s, download=%v, upload=%v, duration=%d", req.Server, req.TestDownload, req.TestUpload, req.Duration)
+	ctx := stream.Context()
+
+	duration := req.Duration
+	if duration < 5 {
+		duration = 10
+	}
+	if duration > 30 {
+		duration = 30
+	}
+	testDuration := time.Duration(duration) * time.Second
+	parallel := int(req.Parallel)
+	if parallel <= 0 {
+		parallel = 1
+	}
+	if parallel > maxSpeedTestStreams {
+		parallel = maxSpeedTestStreams
+	}
+
+	server := strings.TrimSpace(req.Server)
+	fail := func(err error) error {
+		return stream.Send(&pb.SpeedTestResponse{
+			Phase:    "complete",
+			Server:   server,
+			Error:    fmt.Sprintf("Speed test failed: %v", err),
+			Finished: true,
+		})
+	}
+	if server == "" {
+		server = defaultSpeedTestServer
+	}
+	address, isHTTP, err := parseSpeedTestServer(server)
+	if err != nil {
+		return fail(err)
+	}
+
+	// iperf3 servers take every connection for a client, so their latency
+	// comes from the connections the test opens anyway
+	var latencies []time.Duration
+	if isHTTP {
+		if latencies, err = probeLatency(ctx, address, speedTestLatencySamples); err != nil {
+			return fail(err)
 		}
+	}
+	latency, jitter := latencyStats(latencies)
+	err = stream.Send(&pb.SpeedTestResponse{
+		Phase:   "connecting",
+		Latency: latency,
+		Jitter:  jitter,
+		Server:  server,
+	})
+	if err != nil {
+		return err
+	}
 
-		testDataSize += int64(len(chunk))
+	var directions []bool // true for download
+	if req.TestDownload || !req.TestUpload {
+		directions = append(directions, true)
+	}
+	if req.TestUpload || !req.TestDownload {
+		directions = append(directions, false)
+	}
 
-		// Calculate speed based on the amount transferred since last report
-		timeSinceLastReport := time.Since(lastReportTime).Seconds()
-		if timeSinceLastReport >= 0.5 { // Report every 0.5 seconds
-			dataSinceLastReport := testDataSize - lastReportedSize
-			var downloadSpeed float64
-			if timeSinceLastReport > 0 {
-				downloadSpeed = float64(dataSinceLastReport) / (timeSinceLastReport * 1024 * 1024) // Mbps
-			}
+	complete := &pb.SpeedTestResponse{Phase: "complete", Progress: 100, Server: server, Finished: true, Retransmits: -1}
+	for i, download := range directions {
+		name := "upload"
+		if download {
+			name = "download"
+		}
 
-			elapsed := time.Since(startTime).Seconds()
-			progress := float64((elapsed / float64(duration)) * 100)
-			if progress > 100 {
-				progress = 100
+		var counter atomic.Int64
+		type outcome struct {
+			phase *speedPhase
+			err   error
+		}
+		done := make(chan outcome, 1)
+		go func() {
+			var o outcome
+			if isHTTP {
+				o.phase, o.err = runHTTPTest(ctx, speedTestURL(server, download), download, testDuration, &counter)
+			} else {
+				o.phase, o.err = runIperfTest(ctx, address, download, testDuration, parallel, &counter)
 			}
-
-			err := stream.Send(&pb.SpeedTestResponse{
-				Phase:         "download",
-				Progress:      progress,
-				DownloadSpeed: downloadSpeed,
-				Finished:      false,
-			})
-			if err != nil {
-				return err
+			done <- o
+		}()
+
+		// Report the rate of each interval while the test runs
+		ticker := time.NewTicker(speedTestReportInterval)
+		start, last := time.Now(), time.Now()
+		var lastBytes int64
+		var result outcome
+	running:
+		for {
+			select {
+			case result = <-done:
+				break running
+			case now := <-ticker.C:
+				bytes := counter.Load()
+				rate := float64(bytes-lastBytes) * 8 / 1e6 / now.Sub(last).Seconds()
+				last, lastBytes = now, bytes
+				elapsed := min(now.Sub(start).Seconds()/testDuration.Seconds(), 1)
+				progress := &pb.SpeedTestResponse{
+					Phase:    name,
+					Progress: (float64(i) + elapsed) / float64(len(directions)) * 100,
+					Bytes:    bytes,
+					Server:   server,
+				}
+				if download {
+					progress.DownloadSpeed = rate
+				} else {
+					progress.UploadSpeed = rate
+				}
+				if err := stream.Send(progress); err != nil {
+					ticker.Stop()
+					return err
+				}
 			}
-
-			lastReportTime = time.Now()
-			lastReportedSize = testDataSize
+		}
+		ticker.Stop()
+		if result.err != nil {
+			return fail(fmt.Errorf("%s: %v", name, result.err))
 		}
 
-		// Add small delay to prevent CPU spinning
-		time.Sleep(50 * time.Millisecond)
+		phase := result.phase
+		latencies = append(latencies, phase.latencies...)
+		latency, jitter = latencyStats(latencies)
+		summary := &pb.SpeedTestResponse{
+			Phase:       name,
+			Progress:    float64(i+1) / float64(len(directions)) * 100,
+			Latency:     latency,
+			Jitter:      jitter,
+			Retransmits: phase.retransmits,
+			Bytes:       phase.bytes,
+			Server:      server,
+		}
+		if download {
+			summary.DownloadSpeed = phase.mbps()
+			complete.DownloadSpeed = summary.DownloadSpeed
+		} else {
+			summary.UploadSpeed = phase.mbps()
+			complete.UploadSpeed = summary.UploadSpeed
+		}
+		if phase.retransmits >= 0 {
+			complete.Retransmits = max(complete.Retransmits, 0) + phase.retransmits
+		}
+		complete.Bytes += phase.bytes
+		if err := stream.Send(summary); err != nil {
+			return err
+		}
 	}
 
-	// Send final download result with total average
-	elapsed := time.Since(startTime).Seconds()
-	finalSpeed := float64(testDataSize) / (elapsed * 1024 * 1024)
-
-	return stream.Send(&pb.SpeedTestResponse{
-		Phase:         "download",
-		Progress:      100,
-		DownloadSpeed: finalSpeed,
-		Finished:      false,
-	})
+	complete.Latency, complete.Jitter = latency, jitter
+	return stream.Send(complete)
 }
 
-func testUploadSpeed(stream pb.SystemMonitor_TestNetworkSpeedServer, duration int) error {
-	log.Printf("Testing upload speed for %d seconds", duration)
+// DiscoverDevices - Find devices on the local subnets from the neighbor table, a ping sweep, mDNS and SSDP
+func (s *systemMonitorServer) DiscoverDevices(req *pb.DiscoverDevicesRequest, stream pb.SystemMonitor_DiscoverDevicesServer) error {
+	log.Printf("Device discovery request: interface=%s, timeout=%d, passive=%v", req.Interface, req.Timeout, req.Passive)
 
-	// Generate test data to simulate uploading
-	chunkSize := 1024 * 1024 // 1MB chunks
-	testDataSize := int64(0)
-	lastReportTime := time.Now()
-	lastReportedSize := int64(0)
-	startTime := time.Now()
-	endTime := startTime.Add(time.Duration(duration) * time.Second)
+	timeout := time.Duration(req.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultDiscoveryTimeout
+	}
+	if timeout > maxDiscoveryTimeout {
+		timeout = maxDiscoveryTimeout
+	}
+	ctx, cancel := context.WithTimeout(stream.Context(), timeout)
+	defer cancel()
 
-	for time.Now().Before(endTime) {
-		// Simulate uploading by creating and writing test data
-		chunk := make([]byte, chunkSize)
-		for i := range chunk {
-			chunk[i] = byte(i % 256)
+	ifaces, err := discoveryInterfaces(req.Interface)
+	if err != nil {
+		return stream.Send(&pb.DiscoveredDevice{
+			Error:    fmt.Sprintf("Discovery failed: %v", err),
+			Finished: true,
+		})
+	}
+	local := make(map[string]bool)
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, addr := range addrs {
+			if ipnet, ok := addr.(*net.IPNet); ok {
+				local[ipnet.IP.String()] = true
+			}
 		}
+	}
 
-		testDataSize += int64(len(chunk))
+	// Senders give up once ctx is done, so the channel is never closed
+	sightings := make(chan deviceSighting, 64)
+	var wg sync.WaitGroup
+	run := func(f func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f()
+		}()
+	}
+	run(func() { pollNeighbors(ctx, sightings) })
+	run(func() { listenMDNS(ctx, ifaces, sightings) })
+	run(func() { listenSSDP(ctx, ifaces, sightings) })
+	if !req.Passive {
+		run(func() { sweepICMP(ctx, sweepTargets(ifaces), sightings) })
+	}
 
-		// Calculate speed based on the amount transferred since last report
-		timeSinceLastReport := time.Since(lastReportTime).Seconds()
-		if timeSinceLastReport >= 0.5 { // Report every 0.5 seconds
-			dataSinceLastReport := testDataSize - lastReportedSize
-			var uploadSpeed float64
-			if timeSinceLastReport > 0 {
-				uploadSpeed = float64(dataSinceLastReport) / (timeSinceLastReport * 1024 * 1024) // Mbps
+	reported := make(map[string]bool)
+	count := 0
+discovering:
+	for {
+		select {
+		case sighting := <-sightings:
+			ip := net.ParseIP(sighting.ip)
+			if ip == nil || local[sighting.ip] {
+				continue
 			}
-
-			elapsed := time.Since(startTime).Seconds()
-			progress := float64((elapsed / float64(duration)) * 100)
-			if progress > 100 {
-				progress = 100
+			iface := interfaceFor(ifaces, ip)
+			if iface == "" {
+				continue
 			}
-
-			err := stream.Send(&pb.SpeedTestResponse{
-				Phase:       "upload",
-				Progress:    progress,
-				UploadSpeed: uploadSpeed,
-				Finished:    false,
-			})
-			if err != nil {
+			device, changed := s.devices.record(sighting, iface, time.Now())
+			if reported[sighting.ip] && !changed {
+				continue
+			}
+			if !reported[sighting.ip] {
+				count++
+				if device.Hostname == "" {
+					go func() {
+						if name := reverseLookup(ctx, ip); name != "" {
+							sendSighting(ctx, sightings, deviceSighting{ip: device.Ip, hostname: name})
+						}
+					}()
+				}
+			}
+			reported[sighting.ip] = true
+			if err := stream.Send(device); err != nil {
 				return err
 			}
-
-			lastReportTime = time.Now()
-			lastReportedSize = testDataSize
+		case <-ctx.Done():
+			break discovering
 		}
-
-		// Add small delay to prevent CPU spinning
-		time.Sleep(50 * time.Millisecond)
 	}
+	wg.Wait()
 
-	// Send final upload result with total average
-	elapsed := time.Since(startTime).Seconds()
-	finalSpeed := float64(testDataSize) / (elapsed * 1024 * 1024)
-
-	return stream.Send(&pb.SpeedTestResponse{
-		Phase:       "upload",
-		Progress:    100,
-		UploadSpeed: finalSpeed,
-		Finished:    false,
-	})
+	if err := stream.Context().Err(); err != nil {
+		return err
+	}
+	log.Printf("Device discovery found %d devices", count)
+	return stream.Send(&pb.DiscoveredDevice{Finished: true})
 }
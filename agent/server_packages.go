@@ -1,14 +1,10 @@
 package main
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"fmt"
-	"os"
 	"os/exec"
 	"runtime"
-	"strconv"
 	"strings"
 	"time"
 
@@ -19,124 +15,33 @@ import (
 
 // ListPackages returns a list of packages (installed or searchable)
 func (s *systemMonitorServer) ListPackages(ctx context.Context, req *pb.PackageFilter) (*pb.PackageList, error) {
-	var cmd *exec.Cmd
-
 	if req.InstalledOnly || req.SearchTerm == "" {
-		// List installed packages with size and section info
-		cmd = exec.Command("dpkg-query", "-W", "-f=${Package}\t${Version}\t${Architecture}\t${Status}\t${binary:Summary}\t${Installed-Size}\t${Section}\n")
-	} else {
-		// Search for packages
-		cmd = exec.Command("apt-cache", "search", req.SearchTerm)
+		packages, err := s.packages.ListInstalled()
+		if err != nil {
+			return nil, err
+		}
+		return &pb.PackageList{Packages: filterPackages(packages, req.SearchTerm)}, nil
 	}
 
-	output, err := cmd.Output()
+	// Limit to 100 results to prevent high CPU usage
+	packages, err := s.packages.Search(req.SearchTerm, 100)
 	if err != nil {
 		return nil, err
 	}
-
-	var packages []*pb.PackageInfo
-	scanner := bufio.NewScanner(bytes.NewReader(output))
-
-	if req.InstalledOnly || req.SearchTerm == "" {
-		// Parse dpkg-query output
-		for scanner.Scan() {
-			line := scanner.Text()
-			fields := strings.Split(line, "\t")
-			if len(fields) < 7 {
-				continue
-			}
-
-			name := fields[0]
-			version := fields[1]
-			arch := fields[2]
-			status := fields[3]
-			description := fields[4]
-			sizeStr := fields[5]
-			section := fields[6]
-
-			// Only include installed packages
-			if !strings.Contains(status, "install ok installed") {
-				continue
-			}
-
-			// Apply search filter if provided
-			if req.SearchTerm != "" && !strings.Contains(strings.ToLower(name), strings.ToLower(req.SearchTerm)) &&
-				!strings.Contains(strings.ToLower(description), strings.ToLower(req.SearchTerm)) {
-				continue
-			}
-
-			// Parse installed size (convert KB to bytes)
-			var installedSize uint64
-			if size, err := strconv.ParseUint(sizeStr, 10, 64); err == nil {
-				installedSize = size * 1024
-			}
-
-			packages = append(packages, &pb.PackageInfo{
-				Name:          name,
-				Version:       version,
-				Architecture:  arch,
-				Description:   description,
-				Installed:     true,
-				Status:        "installed",
-				InstalledSize: installedSize,
-				Section:       section,
-			})
-		}
-	} else {
-		// Parse apt-cache search output
-		// Limit to 100 results to prevent high CPU usage
-		count := 0
-		maxResults := 100
-
-		for scanner.Scan() && count < maxResults {
-			line := scanner.Text()
-			parts := strings.SplitN(line, " - ", 2)
-			if len(parts) != 2 {
-				continue
-			}
-
-			name := parts[0]
-			description := parts[1]
-
-			// Check if package is installed (this is expensive, so we limit results)
-			installed := false
-			version := ""
-			checkCmd := exec.Command("dpkg-query", "-W", "-f=${Version}\t${Status}", name)
-			if out, err := checkCmd.Output(); err == nil {
-				fields := strings.Split(string(out), "\t")
-				if len(fields) >= 2 && strings.Contains(fields[1], "install ok installed") {
-					installed = true
-					version = fields[0]
-				}
-			}
-
-			status := "not-installed"
-			if installed {
-				status = "installed"
-			}
-
-			packages = append(packages, &pb.PackageInfo{
-				Name:        name,
-				Version:     version,
-				Description: description,
-				Installed:   installed,
-				Status:      status,
-			})
-			count++
-		}
-	}
-
 	return &pb.PackageList{Packages: packages}, nil
 }
 
-// InstallPackage installs a package using apt
+// InstallPackage installs a package
 func (s *systemMonitorServer) InstallPackage(ctx context.Context, req *pb.PackageCommand) (*pb.ActionStatus, error) {
-	cmd := exec.Command("apt-get", "install", "-y", req.PackageName)
-	output, err := cmd.CombinedOutput()
+	step, err := packageOperationStep(s.packages, pb.PackageOperation_PACKAGE_INSTALL, req.PackageName)
+	var output string
+	if err == nil {
+		output, err = s.runPackageJob(ctx, "install "+req.PackageName, step)
+	}
 	if err != nil {
 		return &pb.ActionStatus{
 			Success:   false,
-			Message:   fmt.Sprintf("Failed to install package %s: %v\n%s", req.PackageName, err, string(output)),
+			Message:   fmt.Sprintf("Failed to install package %s: %v\n%s", req.PackageName, err, output),
 			ErrorCode: 1,
 		}, nil
 	}
@@ -147,14 +52,17 @@ func (s *systemMonitorServer) InstallPackage(ctx context.Context, req *pb.Packag
 	}, nil
 }
 
-// RemovePackage removes a package using apt
+// RemovePackage removes a package
 func (s *systemMonitorServer) RemovePackage(ctx context.Context, req *pb.PackageCommand) (*pb.ActionStatus, error) {
-	cmd := exec.Command("apt-get", "remove", "-y", req.PackageName)
-	output, err := cmd.CombinedOutput()
+	step, err := packageOperationStep(s.packages, pb.PackageOperation_PACKAGE_REMOVE, req.PackageName)
+	var output string
+	if err == nil {
+		output, err = s.runPackageJob(ctx, "remove "+req.PackageName, step)
+	}
 	if err != nil {
 		return &pb.ActionStatus{
 			Success:   false,
-			Message:   fmt.Sprintf("Failed to remove package %s: %v\n%s", req.PackageName, err, string(output)),
+			Message:   fmt.Sprintf("Failed to remove package %s: %v\n%s", req.PackageName, err, output),
 			ErrorCode: 1,
 		}, nil
 	}
@@ -165,14 +73,17 @@ func (s *systemMonitorServer) RemovePackage(ctx context.Context, req *pb.Package
 	}, nil
 }
 
-// UpdatePackage updates a specific package using apt
+// UpdatePackage updates a specific package
 func (s *systemMonitorServer) UpdatePackage(ctx context.Context, req *pb.PackageCommand) (*pb.ActionStatus, error) {
-	cmd := exec.Command("apt-get", "install", "--only-upgrade", "-y", req.PackageName)
-	output, err := cmd.CombinedOutput()
+	step, err := packageOperationStep(s.packages, pb.PackageOperation_PACKAGE_UPGRADE, req.PackageName)
+	var output string
+	if err == nil {
+		output, err = s.runPackageJob(ctx, "upgrade "+req.PackageName, step)
+	}
 	if err != nil {
 		return &pb.ActionStatus{
 			Success:   false,
-			Message:   fmt.Sprintf("Failed to update package %s: %v\n%s", req.PackageName, err, string(output)),
+			Message:   fmt.Sprintf("Failed to update package %s: %v\n%s", req.PackageName, err, output),
 			ErrorCode: 1,
 		}, nil
 	}
@@ -183,14 +94,13 @@ func (s *systemMonitorServer) UpdatePackage(ctx context.Context, req *pb.Package
 	}, nil
 }
 
-// UpdatePackageList updates the package list (apt update)
+// UpdatePackageList refreshes the package index (apt update, apk update, dnf makecache)
 func (s *systemMonitorServer) UpdatePackageList(ctx context.Context, req *pb.Empty) (*pb.ActionStatus, error) {
-	cmd := exec.Command("apt-get", "update")
-	output, err := cmd.CombinedOutput()
+	output, err := s.runPackageJob(ctx, "update package list", s.packages.RefreshStep())
 	if err != nil {
 		return &pb.ActionStatus{
 			Success:   false,
-			Message:   fmt.Sprintf("Failed to update package list: %v\n%s", err, string(output)),
+			Message:   fmt.Sprintf("Failed to update package list: %v\n%s", err, output),
 			ErrorCode: 1,
 		}, nil
 	}
@@ -201,14 +111,13 @@ func (s *systemMonitorServer) UpdatePackageList(ctx context.Context, req *pb.Emp
 	}, nil
 }
 
-// UpgradePackages upgrades all packages (apt upgrade)
+// UpgradePackages upgrades all packages
 func (s *systemMonitorServer) UpgradePackages(ctx context.Context, req *pb.Empty) (*pb.ActionStatus, error) {
-	cmd := exec.Command("apt-get", "upgrade", "-y")
-	output, err := cmd.CombinedOutput()
+	output, err := s.runPackageJob(ctx, "upgrade all packages", s.packages.UpgradeStep(""))
 	if err != nil {
 		return &pb.ActionStatus{
 			Success:   false,
-			Message:   fmt.Sprintf("Failed to upgrade packages: %v\n%s", err, string(output)),
+			Message:   fmt.Sprintf("Failed to upgrade packages: %v\n%s", err, output),
 			ErrorCode: 1,
 		}, nil
 	}
@@ -219,179 +128,365 @@ func (s *systemMonitorServer) UpgradePackages(ctx context.Context, req *pb.Empty
 	}, nil
 }
 
-// GetPackageDetails returns detailed information about a specific package
-func (s *systemMonitorServer) GetPackageDetails(ctx context.Context, req *pb.PackageDetailsRequest) (*pb.PackageDetails, error) {
-	// Get detailed package information using apt-cache show
-	cmd := exec.Command("apt-cache", "show", req.PackageName)
-	output, err := cmd.Output()
+// ListPackageSources returns the configured package repositories
+func (s *systemMonitorServer) ListPackageSources(ctx context.Context, req *pb.Empty) (*pb.PackageSourceList, error) {
+	sources, ok := s.packages.(sourceManager)
+	if !ok {
+		return nil, fmt.Errorf("source management is not supported by %s", s.packages.Name())
+	}
+
+	list, err := sources.Sources()
 	if err != nil {
-		return nil, fmt.Errorf("package not found: %v", err)
+		return nil, fmt.Errorf("failed to list sources: %v", err)
 	}
+	return &pb.PackageSourceList{Sources: list}, nil
+}
 
-	details := &pb.PackageDetails{
-		Name: req.PackageName,
-	}
-
-	scanner := bufio.NewScanner(bytes.NewReader(output))
-	var longDesc strings.Builder
-	inDescription := false
-
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		if strings.HasPrefix(line, "Version:") {
-			details.Version = strings.TrimSpace(strings.TrimPrefix(line, "Version:"))
-		} else if strings.HasPrefix(line, "Architecture:") {
-			details.Architecture = strings.TrimSpace(strings.TrimPrefix(line, "Architecture:"))
-		} else if strings.HasPrefix(line, "Description:") {
-			details.Description = strings.TrimSpace(strings.TrimPrefix(line, "Description:"))
-			inDescription = true
-		} else if strings.HasPrefix(line, "Maintainer:") {
-			details.Maintainer = strings.TrimSpace(strings.TrimPrefix(line, "Maintainer:"))
-		} else if strings.HasPrefix(line, "Homepage:") {
-			details.Homepage = strings.TrimSpace(strings.TrimPrefix(line, "Homepage:"))
-		} else if strings.HasPrefix(line, "Section:") {
-			details.Section = strings.TrimSpace(strings.TrimPrefix(line, "Section:"))
-		} else if strings.HasPrefix(line, "Installed-Size:") {
-			sizeStr := strings.TrimSpace(strings.TrimPrefix(line, "Installed-Size:"))
-			if size, err := strconv.ParseUint(sizeStr, 10, 64); err == nil {
-				details.InstalledSize = size * 1024 // Convert KB to bytes
-			}
-		} else if strings.HasPrefix(line, "Source:") {
-			details.Source = strings.TrimSpace(strings.TrimPrefix(line, "Source:"))
-		} else if strings.HasPrefix(line, "Tag:") {
-			tagsStr := strings.TrimSpace(strings.TrimPrefix(line, "Tag:"))
-			details.Tags = strings.Split(tagsStr, ",")
-			for i := range details.Tags {
-				details.Tags[i] = strings.TrimSpace(details.Tags[i])
-			}
-		} else if inDescription && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
-			longDesc.WriteString(strings.TrimSpace(line))
-			longDesc.WriteString("\n")
-		} else if inDescription && line == "" {
-			continue
-		} else if inDescription {
-			inDescription = false
-		}
+// AddPackageSource adds a repository after updating the package lists from
+// it alone, so a broken source is reported before it affects other updates
+func (s *systemMonitorServer) AddPackageSource(ctx context.Context, req *pb.AddPackageSourceRequest) (*pb.ActionStatus, error) {
+	sources, ok := s.packages.(sourceManager)
+	if !ok {
+		return unsupportedSourceStatus(s.packages), nil
+	}
+
+	staged, step, err := sources.StageSource(req.Name, req.Source, req.Deb822)
+	if err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Invalid source: %v", err),
+			ErrorCode: 1,
+		}, nil
 	}
 
-	details.LongDescription = strings.TrimSpace(longDesc.String())
-
-	// Check if installed and get install date
-	dpkgCmd := exec.Command("dpkg-query", "-W", "-f=${db:Status-Status}\t${Installed-Size}", req.PackageName)
-	if dpkgOutput, err := dpkgCmd.Output(); err == nil {
-		fields := strings.Fields(string(dpkgOutput))
-		if len(fields) > 0 && fields[0] == "installed" {
-			details.Installed = true
-			details.Status = "installed"
-
-			// Try to get install date from dpkg logs
-			logCmd := exec.Command("grep", "-m1", fmt.Sprintf("install %s", req.PackageName), "/var/log/dpkg.log")
-			if logOutput, err := logCmd.Output(); err == nil {
-				logLine := string(logOutput)
-				if len(logLine) > 19 {
-					// Parse timestamp from dpkg log (format: YYYY-MM-DD HH:MM:SS)
-					dateStr := logLine[:19]
-					if t, err := time.Parse("2006-01-02 15:04:05", dateStr); err == nil {
-						details.InstallDate = t.Unix()
-					}
-				}
+	var warnings []string
+	if !req.SkipValidation {
+		output, err := s.runPackageJob(ctx, "validate source "+req.Name, step)
+		warnings = sourceValidationWarnings(output)
+		problem := sourceValidationProblem(output)
+		if err != nil || problem != "" {
+			staged.cleanup()
+			if problem == "" {
+				problem = err.Error()
 			}
-		} else {
-			details.Installed = false
-			details.Status = "not-installed"
+			return &pb.ActionStatus{
+				Success:   false,
+				Message:   fmt.Sprintf("Source %s failed validation: %s\n%s", req.Name, problem, output),
+				ErrorCode: 1,
+			}, nil
 		}
 	}
 
+	if err := staged.commit(); err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to add source %s: %v", req.Name, err),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	message := fmt.Sprintf("Added source %s", staged.target)
+	if len(warnings) > 0 {
+		message += "\n" + strings.Join(warnings, "\n")
+	}
+	return &pb.ActionStatus{
+		Success: true,
+		Message: message,
+	}, nil
+}
+
+// SetPackageSourceEnabled enables or disables a repository entry
+func (s *systemMonitorServer) SetPackageSourceEnabled(ctx context.Context, req *pb.PackageSourceToggle) (*pb.ActionStatus, error) {
+	sources, ok := s.packages.(sourceManager)
+	if !ok {
+		return unsupportedSourceStatus(s.packages), nil
+	}
+
+	action := "disable"
+	if req.Enabled {
+		action = "enable"
+	}
+	if err := sources.SetSourceEnabled(req.Id, req.Enabled); err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to %s source: %v", action, err),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	return &pb.ActionStatus{
+		Success: true,
+		Message: fmt.Sprintf("Source %sd", action),
+	}, nil
+}
+
+// RemovePackageSource removes a repository entry
+func (s *systemMonitorServer) RemovePackageSource(ctx context.Context, req *pb.PackageSourceId) (*pb.ActionStatus, error) {
+	sources, ok := s.packages.(sourceManager)
+	if !ok {
+		return unsupportedSourceStatus(s.packages), nil
+	}
+
+	if err := sources.RemoveSource(req.Id); err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to remove source: %v", err),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	return &pb.ActionStatus{
+		Success: true,
+		Message: "Source removed",
+	}, nil
+}
+
+// ImportSigningKey stores a repository signing key for use with signed-by.
+// The keyring path is returned as the message.
+func (s *systemMonitorServer) ImportSigningKey(ctx context.Context, req *pb.SigningKeyRequest) (*pb.ActionStatus, error) {
+	sources, ok := s.packages.(sourceManager)
+	if !ok {
+		return unsupportedSourceStatus(s.packages), nil
+	}
+
+	path, err := sources.ImportSigningKey(req.Name, req.KeyData, req.Url, req.Replace)
+	if err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to import key: %v", err),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	return &pb.ActionStatus{
+		Success: true,
+		Message: path,
+	}, nil
+}
+
+func unsupportedSourceStatus(m packageManager) *pb.ActionStatus {
+	return &pb.ActionStatus{
+		Success:   false,
+		Message:   fmt.Sprintf("Source management is not supported by %s", m.Name()),
+		ErrorCode: 1,
+	}
+}
+
+// GetPackageDetails returns detailed information about a specific package
+func (s *systemMonitorServer) GetPackageDetails(ctx context.Context, req *pb.PackageDetailsRequest) (*pb.PackageDetails, error) {
+	if err := s.packages.CheckName(req.PackageName); err != nil {
+		return nil, err
+	}
+	details, err := s.packages.Details(req.PackageName)
+	if err != nil {
+		return nil, fmt.Errorf("package not found: %v", err)
+	}
 	return details, nil
 }
 
 // GetPackageDependencies returns dependency information for a package
 func (s *systemMonitorServer) GetPackageDependencies(ctx context.Context, req *pb.PackageDetailsRequest) (*pb.PackageDependencies, error) {
-	deps := &pb.PackageDependencies{
-		PackageName: req.PackageName,
-		Depends:     []string{},
-		RequiredBy:  []string{},
-		Recommends:  []string{},
-		Suggests:    []string{},
-		Conflicts:   []string{},
-	}
-
-	// Get dependencies using apt-cache depends
-	depsCmd := exec.Command("apt-cache", "depends", req.PackageName)
-	if output, err := depsCmd.Output(); err == nil {
-		scanner := bufio.NewScanner(bytes.NewReader(output))
-		for scanner.Scan() {
-			line := strings.TrimSpace(scanner.Text())
-
-			if strings.HasPrefix(line, "Depends:") {
-				pkg := strings.TrimSpace(strings.TrimPrefix(line, "Depends:"))
-				pkg = strings.Split(pkg, " ")[0] // Remove version constraints
-				deps.Depends = append(deps.Depends, pkg)
-			} else if strings.HasPrefix(line, "Recommends:") {
-				pkg := strings.TrimSpace(strings.TrimPrefix(line, "Recommends:"))
-				pkg = strings.Split(pkg, " ")[0]
-				deps.Recommends = append(deps.Recommends, pkg)
-			} else if strings.HasPrefix(line, "Suggests:") {
-				pkg := strings.TrimSpace(strings.TrimPrefix(line, "Suggests:"))
-				pkg = strings.Split(pkg, " ")[0]
-				deps.Suggests = append(deps.Suggests, pkg)
-			} else if strings.HasPrefix(line, "Conflicts:") {
-				pkg := strings.TrimSpace(strings.TrimPrefix(line, "Conflicts:"))
-				pkg = strings.Split(pkg, " ")[0]
-				deps.Conflicts = append(deps.Conflicts, pkg)
-			}
+	if err := s.packages.CheckName(req.PackageName); err != nil {
+		return nil, err
+	}
+	return s.packages.Dependencies(req.PackageName)
+}
+
+// StreamPackageOperation queues a package operation and streams its output and progress
+func (s *systemMonitorServer) StreamPackageOperation(req *pb.PackageCommand, stream pb.SystemMonitor_StreamPackageOperationServer) error {
+	step, err := packageOperationStep(s.packages, req.Operation, req.PackageName)
+	if err != nil {
+		return stream.Send(&pb.PackageOperationLog{
+			Timestamp: time.Now().Unix(),
+			Level:     "error",
+			Message:   err.Error(),
+			Completed: true,
+			ExitCode:  -1,
+		})
+	}
+
+	description := strings.TrimSpace(step.phase + " " + req.PackageName)
+	return s.streamPackageJob(stream.Context(), func(entry jobLogEntry) error {
+		return stream.Send(entry.log)
+	}, description, step)
+}
+
+// SimulatePackageOperation previews the packages an operation would install,
+// upgrade or remove without changing the system
+func (s *systemMonitorServer) SimulatePackageOperation(ctx context.Context, req *pb.PackageCommand) (*pb.PackageSimulation, error) {
+	sim, err := s.packages.Simulate(req.Operation, req.PackageName)
+	if err != nil {
+		return &pb.PackageSimulation{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+	return sim, nil
+}
+
+// ListPackageHolds returns packages held at their installed version
+func (s *systemMonitorServer) ListPackageHolds(ctx context.Context, req *pb.Empty) (*pb.PackageHoldList, error) {
+	holds, err := s.packages.Holds()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list held packages: %v", err)
+	}
+	return &pb.PackageHoldList{Holds: holds}, nil
+}
+
+// SetPackageHold holds or releases packages
+func (s *systemMonitorServer) SetPackageHold(ctx context.Context, req *pb.PackageHoldRequest) (*pb.ActionStatus, error) {
+	var names []string
+	for _, name := range req.PackageNames {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if err := s.packages.CheckName(name); err != nil {
+			return &pb.ActionStatus{
+				Success:   false,
+				Message:   err.Error(),
+				ErrorCode: 1,
+			}, nil
 		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   "No packages specified",
+			ErrorCode: 1,
+		}, nil
 	}
 
-	// Get reverse dependencies (what requires this package)
-	rdepsCmd := exec.Command("apt-cache", "rdepends", req.PackageName)
-	if output, err := rdepsCmd.Output(); err == nil {
-		scanner := bufio.NewScanner(bytes.NewReader(output))
-		scanner.Scan() // Skip first line (package name)
-		scanner.Scan() // Skip "Reverse Depends:" line
+	verb, done := "release", "Released"
+	if req.Hold {
+		verb, done = "hold", "Held"
+	}
 
-		for scanner.Scan() {
-			line := strings.TrimSpace(scanner.Text())
-			if line != "" && !strings.HasPrefix(line, "|") {
-				deps.RequiredBy = append(deps.RequiredBy, line)
-			}
+	step, err := s.packages.HoldStep(names, req.Hold)
+	if err == nil {
+		var output string
+		output, err = s.runPackageJob(ctx, verb+" "+strings.Join(names, " "), step)
+		if err != nil {
+			err = fmt.Errorf("%v\n%s", err, output)
 		}
 	}
+	if err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to %s %s: %v", verb, strings.Join(names, ", "), err),
+			ErrorCode: 1,
+		}, nil
+	}
 
-	return deps, nil
+	return &pb.ActionStatus{
+		Success: true,
+		Message: fmt.Sprintf("%s %s", done, strings.Join(names, ", ")),
+	}, nil
 }
 
-// StreamPackageOperation streams package installation/removal logs in real-time
-func (s *systemMonitorServer) StreamPackageOperation(req *pb.PackageCommand, stream pb.SystemMonitor_StreamPackageOperationServer) error {
-	// This is a placeholder - in a real implementation, you would:
-	// 1. Start the apt-get command with a pseudo-terminal
-	// 2. Parse its output for progress information
-	// 3. Stream progress updates to the client
-
-	// For now, just return a simple completion message
-	err := stream.Send(&pb.PackageOperationLog{
-		Timestamp: time.Now().Unix(),
-		Level:     "info",
-		Message:   fmt.Sprintf("Starting operation for package: %s", req.PackageName),
-		Progress:  0,
-		Completed: false,
-	})
+// ListPackagePins returns configured pin priorities
+func (s *systemMonitorServer) ListPackagePins(ctx context.Context, req *pb.Empty) (*pb.PackagePinList, error) {
+	pins, ok := s.packages.(pinManager)
+	if !ok {
+		return nil, fmt.Errorf("pin priorities are not supported by %s", s.packages.Name())
+	}
+
+	list, err := pins.Pins()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pins: %v", err)
+	}
+	return &pb.PackagePinList{Pins: list}, nil
+}
+
+// SetPackagePin creates or replaces the agent-managed pin for a package
+func (s *systemMonitorServer) SetPackagePin(ctx context.Context, req *pb.PackagePin) (*pb.ActionStatus, error) {
+	pins, ok := s.packages.(pinManager)
+	if !ok {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Pin priorities are not supported by %s", s.packages.Name()),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	if err := pins.SetPin(req); err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to pin %s: %v", req.Package, err),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	return &pb.ActionStatus{
+		Success: true,
+		Message: fmt.Sprintf("Pinned %s (%s) at priority %d", req.Package, req.Pin, req.Priority),
+	}, nil
+}
+
+// ClearPackagePin removes the agent-managed pin for a package
+func (s *systemMonitorServer) ClearPackagePin(ctx context.Context, req *pb.PackagePin) (*pb.ActionStatus, error) {
+	pins, ok := s.packages.(pinManager)
+	if !ok {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Pin priorities are not supported by %s", s.packages.Name()),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	if err := pins.ClearPin(req.Package); err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to clear pin for %s: %v", req.Package, err),
+			ErrorCode: 1,
+		}, nil
+	}
+
+	return &pb.ActionStatus{
+		Success: true,
+		Message: fmt.Sprintf("Cleared pin for %s", req.Package),
+	}, nil
+}
+
+// GetPackageHistory returns past package transactions, newest first
+func (s *systemMonitorServer) GetPackageHistory(ctx context.Context, req *pb.PackageHistoryRequest) (*pb.PackageHistory, error) {
+	history, ok := s.packages.(historyProvider)
+	if !ok {
+		return nil, fmt.Errorf("package history is not supported by %s", s.packages.Name())
+	}
+
+	transactions, err := history.History()
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to read package history: %v", err)
 	}
 
-	// Simulate operation completion
-	time.Sleep(1 * time.Second)
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 100
+	}
 
-	return stream.Send(&pb.PackageOperationLog{
-		Timestamp: time.Now().Unix(),
-		Level:     "info",
-		Message:   "Operation completed",
-		Progress:  100,
-		Completed: true,
-		Success:   true,
-	})
+	result := &pb.PackageHistory{}
+	for i := len(transactions) - 1; i >= 0 && len(result.Transactions) < limit; i-- {
+		t := transactions[i]
+		if t.StartTime < req.Since {
+			break
+		}
+		if req.Package != "" && !transactionTouches(t, req.Package) {
+			continue
+		}
+		result.Transactions = append(result.Transactions, t)
+	}
+	return result, nil
+}
+
+// transactionTouches reports whether a transaction changed the named package
+func transactionTouches(t *pb.PackageTransaction, name string) bool {
+	for _, change := range t.Changes {
+		if change.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 // GetSystemUpdateStatus returns OS info, kernel version, and list of upgradable packages
@@ -403,14 +498,7 @@ func (s *systemMonitorServer) GetSystemUpdateStatus(ctx context.Context, req *pb
 		status.OsName = strings.TrimSpace(string(output))
 	} else {
 		// Fallback: read /etc/os-release
-		if data, err := os.ReadFile("/etc/os-release"); err == nil {
-			for _, line := range strings.Split(string(data), "\n") {
-				if strings.HasPrefix(line, "PRETTY_NAME=") {
-					status.OsName = strings.Trim(strings.TrimPrefix(line, "PRETTY_NAME="), "\"")
-					break
-				}
-			}
-		}
+		status.OsName = readOSRelease()["PRETTY_NAME"]
 	}
 
 	// Get kernel version
@@ -435,140 +523,96 @@ func (s *systemMonitorServer) GetSystemUpdateStatus(ctx context.Context, req *pb
 		}
 	}
 
-	// Get last update time from apt cache
-	if info, err := os.Stat("/var/lib/apt/lists/partial"); err == nil {
-		status.LastUpdate = info.ModTime().Format("2006-01-02 15:04:05")
-	} else if info, err := os.Stat("/var/cache/apt/pkgcache.bin"); err == nil {
-		status.LastUpdate = info.ModTime().Format("2006-01-02 15:04:05")
+	// Check whether installed updates need a reboot
+	status.RebootRequired, status.RebootRequiredPackages = rebootRequired()
+
+	// Get last package index refresh time
+	if lastRefresh, ok := s.packages.LastRefresh(); ok {
+		status.LastUpdate = lastRefresh.Format("2006-01-02 15:04:05")
 	}
 
-	// Get upgradable packages
-	cmd := exec.Command("apt", "list", "--upgradable")
-	cmd.Env = append(os.Environ(), "LANG=C")
-	output, err := cmd.Output()
-	if err == nil {
-		lines := strings.Split(string(output), "\n")
-		for _, line := range lines {
-			line = strings.TrimSpace(line)
-			if line == "" || strings.Contains(line, "Listing...") {
-				continue
+	// Get upgradable packages, marking held ones which upgrades skip
+	if packages, err := s.packages.Upgradable(); err == nil {
+		held := make(map[string]bool)
+		if holds, err := s.packages.Holds(); err == nil {
+			for _, hold := range holds {
+				held[hold.Name] = true
 			}
-			// Format: package/source new_version arch [upgradable from: old_version]
-			pkg := parseUpgradableLine(line)
-			if pkg != nil {
-				status.UpgradablePackages = append(status.UpgradablePackages, pkg)
+		}
+
+		for _, pkg := range packages {
+			pkg.Held = held[pkg.Name]
+			if !pkg.Held {
+				status.UpgradableCount++
 			}
 		}
-		status.UpgradableCount = int32(len(status.UpgradablePackages))
+		status.UpgradablePackages = packages
 	}
 
 	return status, nil
 }
 
-// parseUpgradableLine parses a line from "apt list --upgradable"
-// Format: name/source version arch [upgradable from: old_version]
-func parseUpgradableLine(line string) *pb.UpgradablePackage {
-	// Split on "/" to get package name
-	slashIdx := strings.Index(line, "/")
-	if slashIdx < 0 {
-		return nil
-	}
-	name := line[:slashIdx]
-	rest := line[slashIdx+1:]
-
-	// Split remaining by whitespace
-	parts := strings.Fields(rest)
-	if len(parts) < 3 {
-		return nil
+// StreamSystemUpgrade refreshes the package index, upgrades all packages and streams output
+func (s *systemMonitorServer) StreamSystemUpgrade(req *pb.Empty, stream pb.SystemMonitor_StreamSystemUpgradeServer) error {
+	send := func(entry jobLogEntry) error {
+		progress := &pb.UpgradeProgress{
+			Line:    entry.log.Message,
+			Phase:   entry.phase,
+			Percent: int32(entry.log.Progress),
+			JobId:   entry.log.JobId,
+		}
+		if entry.log.Completed {
+			progress.IsComplete = true
+			progress.Success = entry.log.Success
+			if entry.log.Success {
+				progress.Line = "System upgrade completed successfully."
+			}
+		}
+		return stream.Send(progress)
 	}
 
-	newVersion := parts[1]
-	arch := parts[2]
-
-	// Extract old version from "[upgradable from: x.y.z]"
-	oldVersion := ""
-	fromIdx := strings.Index(line, "upgradable from: ")
-	if fromIdx >= 0 {
-		tail := line[fromIdx+len("upgradable from: "):]
-		oldVersion = strings.TrimSuffix(strings.TrimSpace(tail), "]")
-	}
+	return s.streamPackageJob(stream.Context(), send, "system upgrade",
+		s.packages.RefreshStep(),
+		s.packages.UpgradeStep(""),
+	)
+}
 
-	return &pb.UpgradablePackage{
-		Name:           name,
-		CurrentVersion: oldVersion,
-		NewVersion:     newVersion,
-		Architecture:   arch,
-	}
+// GetUpgradePolicy returns the unattended upgrade policy and its next run time
+func (s *systemMonitorServer) GetUpgradePolicy(ctx context.Context, req *pb.Empty) (*pb.UpgradePolicy, error) {
+	return s.upgrades.getPolicy(), nil
 }
 
-// StreamSystemUpgrade runs apt update then apt upgrade and streams output
-func (s *systemMonitorServer) StreamSystemUpgrade(req *pb.Empty, stream pb.SystemMonitor_StreamSystemUpgradeServer) error {
-	// Phase 1: apt update
-	if err := streamCommand(stream, "update", exec.Command("apt-get", "update")); err != nil {
-		return err
+// SetUpgradePolicy validates and saves the unattended upgrade policy
+func (s *systemMonitorServer) SetUpgradePolicy(ctx context.Context, req *pb.UpgradePolicy) (*pb.ActionStatus, error) {
+	if err := s.upgrades.setPolicy(req); err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to save upgrade policy: %v", err),
+			ErrorCode: 1,
+		}, nil
 	}
 
-	// Phase 2: apt upgrade
-	if err := streamCommand(stream, "upgrade", exec.Command("apt-get", "upgrade", "-y")); err != nil {
-		return err
+	message := "Unattended upgrades disabled"
+	if req.Enabled {
+		message = fmt.Sprintf("Unattended upgrades scheduled at %s", req.WindowStart)
 	}
-
-	// Send completion
-	return stream.Send(&pb.UpgradeProgress{
-		Line:       "System upgrade completed successfully.",
-		Phase:      "done",
-		Percent:    100,
-		IsComplete: true,
-		Success:    true,
-	})
+	return &pb.ActionStatus{
+		Success: true,
+		Message: message,
+	}, nil
 }
 
-func streamCommand(stream pb.SystemMonitor_StreamSystemUpgradeServer, phase string, cmd *exec.Cmd) error {
-	cmd.Env = append(os.Environ(), "DEBIAN_FRONTEND=noninteractive", "LANG=C")
-
-	stdout, err := cmd.StdoutPipe()
+// RunUpgradeNow starts an upgrade with the current policy and returns its
+// run and job ID without waiting; follow it with AttachJob
+func (s *systemMonitorServer) RunUpgradeNow(ctx context.Context, req *pb.Empty) (*pb.UpgradeRun, error) {
+	run, err := s.upgrades.run("manual")
 	if err != nil {
-		return err
-	}
-	cmd.Stderr = cmd.Stdout // merge stderr into stdout
-
-	if err := cmd.Start(); err != nil {
-		stream.Send(&pb.UpgradeProgress{
-			Line:       fmt.Sprintf("Failed to start %s: %v", phase, err),
-			Phase:      "error",
-			IsComplete: true,
-			Success:    false,
-		})
-		return err
-	}
-
-	scanner := bufio.NewScanner(stdout)
-	for scanner.Scan() {
-		select {
-		case <-stream.Context().Done():
-			cmd.Process.Kill()
-			return stream.Context().Err()
-		default:
-		}
-
-		line := scanner.Text()
-		if err := stream.Send(&pb.UpgradeProgress{
-			Line:  line,
-			Phase: phase,
-		}); err != nil {
-			return err
-		}
-	}
-
-	if err := cmd.Wait(); err != nil {
-		stream.Send(&pb.UpgradeProgress{
-			Line:       fmt.Sprintf("%s failed: %v", phase, err),
-			Phase:      "error",
-			IsComplete: true,
-			Success:    false,
-		})
-		return err
+		return nil, err
 	}
+	return run, nil
+}
 
-	return nil
+// ListUpgradeRuns returns the history of unattended and manual upgrade runs
+func (s *systemMonitorServer) ListUpgradeRuns(ctx context.Context, req *pb.Empty) (*pb.UpgradeRunList, error) {
+	return &pb.UpgradeRunList{Runs: s.upgrades.listRuns()}, nil
 }
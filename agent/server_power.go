@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+
+	pb "pi_agent/proto"
+)
+
+// Files in the data directory used to remember agent-initiated power actions
+const (
+	powerRequestFile  = "power-request.json"
+	lastBootFile      = "last-boot.json"
+	maxPowerDelayMins = 24 * 60
+	// Boot times read from /proc/stat can drift by a second or two
+	bootTimeSlack = 60
+)
+
+// powerRequest is written before the agent reboots or shuts down the system
+// so the reason can be reported once it is back up
+type powerRequest struct {
+	Action      string `json:"action"` // "reboot" or "shutdown"
+	Message     string `json:"message"`
+	RequestedAt int64  `json:"requested_at"`
+	BootTime    int64  `json:"boot_time"` // Boot the request was made in
+}
+
+// bootRecord is the reason determined for a boot, kept so agent restarts
+// within the same boot report the same reason
+type bootRecord struct {
+	BootTime int64  `json:"boot_time"`
+	Reason   string `json:"reason"`
+}
+
+// rebootRequiredPaths are checked in order; Debian writes /var/run, which is
+// a symlink to /run on current releases
+var rebootRequiredPaths = []string{"/run/reboot-required", "/var/run/reboot-required"}
+
+// rebootRequired reports whether installed updates need a reboot and which
+// packages asked for it
+func rebootRequired() (bool, []string) {
+	for _, path := range rebootRequiredPaths {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		var packages []string
+		if data, err := os.ReadFile(path + ".pkgs"); err == nil {
+			seen := make(map[string]bool)
+			for _, line := range splitLines(string(data)) {
+				name := strings.TrimSpace(line)
+				if name != "" && !seen[name] {
+					seen[name] = true
+					packages = append(packages, name)
+				}
+			}
+		}
+		return true, packages
+	}
+
+	// dnf-utils: needs-restarting -r exits 1 when a reboot is needed
+	if path, err := exec.LookPath("needs-restarting"); err == nil {
+		var exitErr *exec.ExitError
+		if err := exec.Command(path, "-r").Run(); err != nil && errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// schedulePower records the request and runs shutdown with the given delay.
+// action is "reboot" or "shutdown".
+func (s *systemMonitorServer) schedulePower(action string, delayMinutes int32, message string) error {
+	if delayMinutes < 0 || delayMinutes > maxPowerDelayMins {
+		return fmt.Errorf("delay must be between 0 and %d minutes", maxPowerDelayMins)
+	}
+	if strings.ContainsAny(message, "\r\n") {
+		return fmt.Errorf("message must be a single line")
+	}
+
+	flag := "-r"
+	if action == "shutdown" {
+		flag = "-P"
+	}
+	when := "now"
+	if delayMinutes > 0 {
+		when = fmt.Sprintf("+%d", delayMinutes)
+	}
+
+	bootTime, _ := host.BootTime()
+	request := powerRequest{
+		Action:      action,
+		Message:     message,
+		RequestedAt: time.Now().Unix(),
+		BootTime:    int64(bootTime),
+	}
+	if err := writeJSONFile(filepath.Join(s.dataDir, powerRequestFile), request); err != nil {
+		log.Printf("Warning: failed to record %s request: %v", action, err)
+	}
+
+	args := []string{flag, when}
+	if message != "" {
+		args = append(args, message)
+	}
+	output, err := exec.Command("shutdown", args...).CombinedOutput()
+	if err != nil {
+		os.Remove(filepath.Join(s.dataDir, powerRequestFile))
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+	log.Printf("Scheduled %s %s: %s", action, when, message)
+	return nil
+}
+
+// loadLastBootReason works out why the system last started, using a power
+// request left by a previous boot if there is one
+func loadLastBootReason(dataDir string) string {
+	bootTime, err := host.BootTime()
+	if err != nil {
+		return ""
+	}
+	current := int64(bootTime)
+
+	var record bootRecord
+	lastBootPath := filepath.Join(dataDir, lastBootFile)
+	if readJSONFile(lastBootPath, &record) == nil && abs64(record.BootTime-current) <= bootTimeSlack {
+		return record.Reason
+	}
+
+	reason := "not initiated by the agent (power loss, crash or manual restart)"
+	var request powerRequest
+	requestPath := filepath.Join(dataDir, powerRequestFile)
+	if readJSONFile(requestPath, &request) == nil && request.BootTime < current-bootTimeSlack {
+		verb := "reboot"
+		if request.Action == "shutdown" {
+			verb = "shutdown"
+		}
+		reason = fmt.Sprintf("%s requested via agent at %s", verb, time.Unix(request.RequestedAt, 0).Format("2006-01-02 15:04:05"))
+		if request.Message != "" {
+			reason += ": " + request.Message
+		}
+		os.Remove(requestPath)
+	}
+
+	if err := writeJSONFile(lastBootPath, bootRecord{BootTime: current, Reason: reason}); err != nil {
+		log.Printf("Warning: failed to record boot reason: %v", err)
+	}
+	return reason
+}
+
+// Reboot reboots the system after the requested delay
+func (s *systemMonitorServer) Reboot(ctx context.Context, req *pb.PowerRequest) (*pb.ActionStatus, error) {
+	return s.powerAction("reboot", req), nil
+}
+
+// Shutdown powers off the system after the requested delay
+func (s *systemMonitorServer) Shutdown(ctx context.Context, req *pb.PowerRequest) (*pb.ActionStatus, error) {
+	return s.powerAction("shutdown", req), nil
+}
+
+func (s *systemMonitorServer) powerAction(action string, req *pb.PowerRequest) *pb.ActionStatus {
+	if err := s.schedulePower(action, req.DelayMinutes, req.Message); err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to schedule %s: %v", action, err),
+			ErrorCode: 1,
+		}
+	}
+
+	message := fmt.Sprintf("System %s in %d minute(s)", action, req.DelayMinutes)
+	if req.DelayMinutes == 0 {
+		message = fmt.Sprintf("System %s started", action)
+	}
+	return &pb.ActionStatus{
+		Success: true,
+		Message: message,
+	}
+}
+
+// CancelShutdown cancels a pending delayed reboot or shutdown
+func (s *systemMonitorServer) CancelShutdown(ctx context.Context, req *pb.Empty) (*pb.ActionStatus, error) {
+	output, err := exec.Command("shutdown", "-c").CombinedOutput()
+	if err != nil {
+		return &pb.ActionStatus{
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to cancel shutdown: %v: %s", err, strings.TrimSpace(string(output))),
+			ErrorCode: 1,
+		}, nil
+	}
+	os.Remove(filepath.Join(s.dataDir, powerRequestFile))
+
+	return &pb.ActionStatus{
+		Success: true,
+		Message: "Pending shutdown cancelled",
+	}, nil
+}
+
+// readJSONFile decodes a JSON file from the data directory
+func readJSONFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// writeJSONFile writes a JSON file atomically, creating its directory
+func writeJSONFile(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data, 0644)
+}
+
+// writeFileAtomic replaces path through a temporary file so readers never
+// see it half written, creating its directory
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
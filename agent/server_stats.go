@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"math"
 	"os/exec"
 	"strconv"
 	"strings"
@@ -23,8 +24,8 @@ func (s *systemMonitorServer) StreamStats(req *pb.Empty, stream pb.SystemMonitor
 	ticker := time.NewTicker(1000 * time.Millisecond)
 	defer ticker.Stop()
 
-	var prevNet netStats
-	firstRun := true
+	var prevNet []netutil.IOCountersStat
+	var prevNetTime time.Time
 
 	// Initialize disk I/O tracking
 	s.prevDiskIO = make(map[string]disk.IOCountersStat)
@@ -41,31 +42,17 @@ func (s *systemMonitorServer) StreamStats(req *pb.Empty, stream pb.SystemMonitor
 				continue
 			}
 
-			// Calculate network speed (bytes per second)
-			if !firstRun {
-				// Store the raw counters before calculating the rate
-				currentSent := stats.NetBytesSent
-				currentRecv := stats.NetBytesRecv
-
-				// Calculate rate (already per second since interval is 1s)
-				stats.NetBytesSent = currentSent - prevNet.sent
-				stats.NetBytesRecv = currentRecv - prevNet.recv
-
-				// Prevent negative values from counter resets
-				if stats.NetBytesSent > 1e12 { // Unreasonably large, likely overflow
-					stats.NetBytesSent = 0
-				}
-				if stats.NetBytesRecv > 1e12 {
-					stats.NetBytesRecv = 0
+			// Calculate network rates from one read of the per-interface counters
+			if netIO, err := netutil.IOCounters(true); err == nil {
+				now := time.Now()
+				if prevNet != nil {
+					stats.Interfaces = interfaceRates(prevNet, netIO, now.Sub(prevNetTime).Seconds())
+					for _, iface := range stats.Interfaces {
+						stats.NetBytesSent += iface.TxBytes
+						stats.NetBytesRecv += iface.RxBytes
+					}
 				}
-
-				// Update previous counters with raw values
-				prevNet = netStats{sent: currentSent, recv: currentRecv}
-			} else {
-				firstRun = false
-				prevNet = netStats{sent: stats.NetBytesSent, recv: stats.NetBytesRecv}
-				stats.NetBytesSent = 0
-				stats.NetBytesRecv = 0
+				prevNet, prevNetTime = netIO, now
 			}
 
 			// Calculate disk I/O rates
@@ -92,9 +79,62 @@ func (s *systemMonitorServer) StreamStats(req *pb.Empty, stream pb.SystemMonitor
 	}
 }
 
-type netStats struct {
-	sent uint64
-	recv uint64
+// interfaceRates converts two reads of the per-interface counters taken
+// elapsed seconds apart into rates. Interfaces that were not in the previous
+// read are left out until the next one.
+func interfaceRates(prev, current []netutil.IOCountersStat, elapsed float64) []*pb.InterfaceStats {
+	if elapsed <= 0 {
+		elapsed = 1
+	}
+	previous := make(map[string]netutil.IOCountersStat, len(prev))
+	for _, p := range prev {
+		previous[p.Name] = p
+	}
+	rate := func(prev, current uint64) uint64 {
+		delta, ok := counterDelta(prev, current)
+		if !ok {
+			return 0
+		}
+		return uint64(float64(delta) / elapsed)
+	}
+
+	result := make([]*pb.InterfaceStats, 0, len(current))
+	for _, c := range current {
+		p, ok := previous[c.Name]
+		if !ok {
+			continue
+		}
+		result = append(result, &pb.InterfaceStats{
+			Name:      c.Name,
+			RxBytes:   rate(p.BytesRecv, c.BytesRecv),
+			TxBytes:   rate(p.BytesSent, c.BytesSent),
+			RxPackets: rate(p.PacketsRecv, c.PacketsRecv),
+			TxPackets: rate(p.PacketsSent, c.PacketsSent),
+			RxErrors:  c.Errin,
+			TxErrors:  c.Errout,
+			RxDropped: c.Dropin,
+			TxDropped: c.Dropout,
+		})
+	}
+	return result
+}
+
+// counterDelta returns how far a counter advanced between two reads. A
+// counter that went backwards either wrapped at 32 bits (drivers and 32-bit
+// kernels that keep unsigned long counters) or was reset because the
+// interface was recreated; ok is false for a reset.
+func counterDelta(prev, current uint64) (delta uint64, ok bool) {
+	if current >= prev {
+		return current - prev, true
+	}
+	if prev <= math.MaxUint32 {
+		// Only a wrap if the counter advanced by less than half its range;
+		// anything larger is far more likely a reset to a small value
+		if wrapped := math.MaxUint32 - prev + current + 1; wrapped < 1<<31 {
+			return wrapped, true
+		}
+	}
+	return 0, false
 }
 
 // collectStats gathers all system statistics
@@ -151,13 +191,6 @@ func collectStats() (*pb.LiveStats, error) {
 		stats.Load_15Min = loadAvg.Load15
 	}
 
-	// Network
-	netIO, err := netutil.IOCounters(false)
-	if err == nil && len(netIO) > 0 {
-		stats.NetBytesSent = netIO[0].BytesSent
-		stats.NetBytesRecv = netIO[0].BytesRecv
-	}
-
 	// Top processes - only update every 2 seconds to reduce CPU load
 	// This is called from StreamStats which runs every 1s, so we alternate
 	return stats, nil
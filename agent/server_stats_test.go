@@ -0,0 +1,83 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	netutil "github.com/shirou/gopsutil/v3/net"
+	"google.golang.org/protobuf/proto"
+
+	pb "pi_agent/proto"
+)
+
+func TestCounterDelta(t *testing.T) {
+	tests := []struct {
+		name          string
+		prev, current uint64
+		delta         uint64
+		ok            bool
+	}{
+		{"advanced", 1000, 1500, 500, true},
+		{"unchanged", 1000, 1000, 0, true},
+		{"32-bit wrap", math.MaxUint32 - 99, 400, 500, true},
+		{"32-bit wrap at zero", math.MaxUint32, 0, 1, true},
+		{"reset to a small value", 1_500_000_000, 1200, 0, false},
+		{"wrap by less than half the range", 3_000_000_000, 1200, 1_294_968_496, true},
+		{"reset below a small counter", 5000, 1200, 0, false},
+		{"64-bit counter went backwards", 1 << 40, 1<<40 - 1, 0, false},
+		{"64-bit counter reset", 1 << 40, 100, 0, false},
+	}
+	for _, tt := range tests {
+		delta, ok := counterDelta(tt.prev, tt.current)
+		if delta != tt.delta || ok != tt.ok {
+			t.Errorf("%s: counterDelta(%d, %d) = %d, %v; want %d, %v", tt.name, tt.prev, tt.current, delta, ok, tt.delta, tt.ok)
+		}
+	}
+}
+
+func TestInterfaceRates(t *testing.T) {
+	prev := []netutil.IOCountersStat{
+		{Name: "eth0", BytesRecv: 10_000, BytesSent: 4_000, PacketsRecv: 100, PacketsSent: 40},
+		{Name: "wlan0", BytesRecv: math.MaxUint32 - 999, BytesSent: 1_500_000_000, PacketsRecv: 50, PacketsSent: 60},
+		{Name: "usb0", BytesRecv: 1},
+	}
+	current := []netutil.IOCountersStat{
+		{Name: "eth0", BytesRecv: 30_000, BytesSent: 8_000, PacketsRecv: 300, PacketsSent: 80, Errin: 2, Dropout: 3},
+		// Received bytes wrapped; sent bytes were reset when it was recreated
+		{Name: "wlan0", BytesRecv: 1000, BytesSent: 500, PacketsRecv: 70, PacketsSent: 60},
+		// No previous sample yet
+		{Name: "docker0", BytesRecv: 5_000, BytesSent: 5_000},
+	}
+
+	tests := []struct {
+		name    string
+		elapsed float64
+		want    []*pb.InterfaceStats
+	}{
+		{"two seconds", 2, []*pb.InterfaceStats{
+			{Name: "eth0", RxBytes: 10_000, TxBytes: 2_000, RxPackets: 100, TxPackets: 20, RxErrors: 2, TxDropped: 3},
+			{Name: "wlan0", RxBytes: 1000, RxPackets: 10},
+		}},
+		// A non-positive interval counts as one second
+		{"zero elapsed", 0, []*pb.InterfaceStats{
+			{Name: "eth0", RxBytes: 20_000, TxBytes: 4_000, RxPackets: 200, TxPackets: 40, RxErrors: 2, TxDropped: 3},
+			{Name: "wlan0", RxBytes: 2000, RxPackets: 20},
+		}},
+		{"negative elapsed", -3, []*pb.InterfaceStats{
+			{Name: "eth0", RxBytes: 20_000, TxBytes: 4_000, RxPackets: 200, TxPackets: 40, RxErrors: 2, TxDropped: 3},
+			{Name: "wlan0", RxBytes: 2000, RxPackets: 20},
+		}},
+	}
+	for _, tt := range tests {
+		got := interfaceRates(prev, current, tt.elapsed)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %d interfaces, want %d: %v", tt.name, len(got), len(tt.want), got)
+			continue
+		}
+		for i := range tt.want {
+			if !proto.Equal(got[i], tt.want[i]) {
+				t.Errorf("%s: interface %d:\n got %v\nwant %v", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
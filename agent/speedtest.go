@@ -0,0 +1,584 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Speed test defaults and limits
+const (
+	defaultIperfPort        = 5201
+	maxSpeedTestStreams     = 8
+	speedTestReportInterval = 500 * time.Millisecond
+	speedTestDialTimeout    = 5 * time.Second
+	speedTestLatencySamples = 5
+)
+
+// defaultSpeedTestServer is used when a request names no server. It serves
+// downloads of a requested size and accepts uploads on separate paths.
+const (
+	defaultSpeedTestServer      = "https://speed.cloudflare.com"
+	defaultSpeedTestDownloadURL = defaultSpeedTestServer + "/__down?bytes=100000000"
+	defaultSpeedTestUploadURL   = defaultSpeedTestServer + "/__up"
+)
+
+// speedTestURL returns the URL an HTTP test fetches from or posts to
+func speedTestURL(server string, download bool) string {
+	switch {
+	case server != defaultSpeedTestServer:
+		return server
+	case download:
+		return defaultSpeedTestDownloadURL
+	default:
+		return defaultSpeedTestUploadURL
+	}
+}
+
+// iperf3 protocol settings
+const (
+	iperfCookieSize    = 37 // 36 characters and a NUL
+	iperfCookieChars   = "abcdefghijklmnopqrstuvwxyz234567"
+	iperfDefaultLen    = 128 * 1024
+	iperfMaxLen        = 1024 * 1024
+	iperfMaxJSON       = 1024 * 1024
+	iperfStateTimeout  = 10 * time.Second
+	iperfClientVersion = "3.12"
+)
+
+// iperf3 control states, sent as one signed byte on the control connection
+const (
+	iperfTestStart       = 1
+	iperfTestRunning     = 2
+	iperfTestEnd         = 4
+	iperfParamExchange   = 9
+	iperfCreateStreams   = 10
+	iperfServerTerminate = 11
+	iperfClientTerminate = 12
+	iperfExchangeResults = 13
+	iperfDisplayResults  = 14
+	iperfDone            = 16
+	iperfAccessDenied    = -1
+	iperfServerError     = -2
+)
+
+// iperfParams is the test description an iperf3 client sends
+type iperfParams struct {
+	TCP           bool   `json:"tcp,omitempty"`
+	UDP           bool   `json:"udp,omitempty"`
+	Omit          int    `json:"omit"`
+	Time          int    `json:"time"`
+	Num           int64  `json:"num"`
+	Blockcount    int64  `json:"blockcount"`
+	Parallel      int    `json:"parallel"`
+	Reverse       bool   `json:"reverse,omitempty"`
+	Bidirectional bool   `json:"bidirectional,omitempty"`
+	Len           int    `json:"len"`
+	PacingTimer   int    `json:"pacing_timer"`
+	ClientVersion string `json:"client_version,omitempty"`
+}
+
+// iperfResults is what each side reports once the test ends
+type iperfResults struct {
+	CPUUtilTotal         float64             `json:"cpu_util_total"`
+	CPUUtilUser          float64             `json:"cpu_util_user"`
+	CPUUtilSystem        float64             `json:"cpu_util_system"`
+	SenderHasRetransmits int                 `json:"sender_has_retransmits"`
+	Streams              []iperfStreamResult `json:"streams"`
+}
+
+type iperfStreamResult struct {
+	ID          int     `json:"id"`
+	Bytes       int64   `json:"bytes"`
+	Retransmits int64   `json:"retransmits"`
+	Jitter      float64 `json:"jitter"`
+	Errors      int64   `json:"errors"`
+	Packets     int64   `json:"packets"`
+	StartTime   float64 `json:"start_time"`
+	EndTime     float64 `json:"end_time"`
+}
+
+// iperfStreamID numbers streams the way iperf3 does: 1, 3, 4, ...
+func iperfStreamID(index int) int {
+	if index == 0 {
+		return 1
+	}
+	return index + 2
+}
+
+// newIperfCookie returns a random session cookie
+func newIperfCookie() string {
+	b := make([]byte, iperfCookieSize-1)
+	rand.Read(b)
+	for i := range b {
+		b[i] = iperfCookieChars[int(b[i])%len(iperfCookieChars)]
+	}
+	return string(b) + "\x00"
+}
+
+func writeIperfState(conn net.Conn, state int) error {
+	_, err := conn.Write([]byte{byte(int8(state))})
+	return err
+}
+
+func readIperfState(conn net.Conn) (int, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(conn, b[:]); err != nil {
+		return 0, err
+	}
+	return int(int8(b[0])), nil
+}
+
+// waitIperfState reads control states until want arrives. Other progress
+// states are skipped; refusals and terminations end the wait.
+func waitIperfState(conn net.Conn, want int) error {
+	for {
+		state, err := readIperfState(conn)
+		if err != nil {
+			return err
+		}
+		switch state {
+		case want:
+			return nil
+		case iperfAccessDenied:
+			return fmt.Errorf("server is busy or refused the test")
+		case iperfServerError:
+			var codes [8]byte
+			if _, err := io.ReadFull(conn, codes[:]); err != nil {
+				return fmt.Errorf("server error")
+			}
+			return fmt.Errorf("server error %d (errno %d)",
+				int32(binary.BigEndian.Uint32(codes[0:4])), int32(binary.BigEndian.Uint32(codes[4:8])))
+		case iperfServerTerminate, iperfClientTerminate, iperfDone:
+			return fmt.Errorf("test ended by the other side")
+		}
+	}
+}
+
+// writeIperfJSON sends a length-prefixed JSON message
+func writeIperfJSON(conn net.Conn, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	msg := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(msg, uint32(len(data)))
+	copy(msg[4:], data)
+	_, err = conn.Write(msg)
+	return err
+}
+
+func readIperfJSON(conn net.Conn, v interface{}) error {
+	var length [4]byte
+	if _, err := io.ReadFull(conn, length[:]); err != nil {
+		return err
+	}
+	n := binary.BigEndian.Uint32(length[:])
+	if n > iperfMaxJSON {
+		return fmt.Errorf("JSON message of %d bytes is too large", n)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(conn, data); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// runTransfer sends or receives on every connection until stop is closed
+// or all connections fail, and returns the bytes moved per connection
+func runTransfer(conns []net.Conn, send bool, blockSize int, counter *atomic.Int64, stop <-chan struct{}) []int64 {
+	counts := make([]int64, len(conns))
+	block := make([]byte, blockSize)
+	rand.Read(block)
+
+	var wg sync.WaitGroup
+	for i, conn := range conns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := block
+			if !send {
+				buf = make([]byte, blockSize)
+			}
+			for {
+				var n int
+				var err error
+				if send {
+					n, err = conn.Write(buf)
+				} else {
+					n, err = conn.Read(buf)
+				}
+				counts[i] += int64(n)
+				counter.Add(int64(n))
+				if err != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-stop:
+		// Unblock reads and writes in progress
+		for _, conn := range conns {
+			conn.SetDeadline(time.Now())
+		}
+		<-done
+	case <-done:
+	}
+	return counts
+}
+
+// speedPhase is the outcome of one direction of a speed test
+type speedPhase struct {
+	bytes       int64
+	seconds     float64
+	retransmits int64           // -1 if unknown
+	latencies   []time.Duration // TCP connection setup times
+}
+
+// mbps returns the average throughput in megabits per second
+func (p *speedPhase) mbps() float64 {
+	if p.seconds <= 0 {
+		return 0
+	}
+	return float64(p.bytes) * 8 / 1e6 / p.seconds
+}
+
+// dial connects to address and records how long the handshake took
+func (p *speedPhase) dial(ctx context.Context, address string) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: speedTestDialTimeout}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	p.latencies = append(p.latencies, time.Since(start))
+	return conn, nil
+}
+
+// latencyStats returns the mean of the samples and the mean difference
+// between consecutive samples, in milliseconds
+func latencyStats(samples []time.Duration) (latency, jitter float64) {
+	if len(samples) == 0 {
+		return 0, 0
+	}
+	var sum, diffs time.Duration
+	for i, sample := range samples {
+		sum += sample
+		if i > 0 {
+			diff := sample - samples[i-1]
+			if diff < 0 {
+				diff = -diff
+			}
+			diffs += diff
+		}
+	}
+	latency = float64(sum) / float64(len(samples)) / float64(time.Millisecond)
+	if len(samples) > 1 {
+		jitter = float64(diffs) / float64(len(samples)-1) / float64(time.Millisecond)
+	}
+	return latency, jitter
+}
+
+// probeLatency times a few TCP connections to address. Only used for HTTP
+// servers: iperf3 servers take every connection for a test client.
+func probeLatency(ctx context.Context, address string, samples int) ([]time.Duration, error) {
+	phase := &speedPhase{}
+	for i := 0; i < samples; i++ {
+		conn, err := phase.dial(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+		conn.Close()
+	}
+	return phase.latencies, nil
+}
+
+// parseSpeedTestServer returns the address to connect to and whether server
+// is an HTTP URL rather than an iperf3 server
+func parseSpeedTestServer(server string) (string, bool, error) {
+	if strings.HasPrefix(server, "http://") || strings.HasPrefix(server, "https://") {
+		u, err := url.Parse(server)
+		if err != nil {
+			return "", false, err
+		}
+		if u.Hostname() == "" {
+			return "", false, fmt.Errorf("URL %q has no host", server)
+		}
+		port := u.Port()
+		if port == "" {
+			port = "80"
+			if u.Scheme == "https" {
+				port = "443"
+			}
+		}
+		return net.JoinHostPort(u.Hostname(), port), true, nil
+	}
+
+	address := strings.TrimPrefix(server, "iperf3://")
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(strings.Trim(address, "[]"), strconv.Itoa(defaultIperfPort))
+	}
+	return address, false, nil
+}
+
+// runIperfTest runs one TCP test against an iperf3 server. download makes
+// the server send (iperf3 -R). The receiver's byte count is reported, as the
+// sender's includes data still buffered when the test ended.
+func runIperfTest(ctx context.Context, address string, download bool, duration time.Duration, parallel int, counter *atomic.Int64) (*speedPhase, error) {
+	phase := &speedPhase{retransmits: -1}
+	ctrl, err := phase.dial(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	defer ctrl.Close()
+	stopClose := context.AfterFunc(ctx, func() { ctrl.Close() })
+	defer stopClose()
+
+	cookie := newIperfCookie()
+	ctrl.SetDeadline(time.Now().Add(iperfStateTimeout))
+	if _, err := io.WriteString(ctrl, cookie); err != nil {
+		return nil, err
+	}
+	if err := waitIperfState(ctrl, iperfParamExchange); err != nil {
+		return nil, err
+	}
+	params := iperfParams{
+		TCP:           true,
+		Time:          int(duration / time.Second),
+		Parallel:      parallel,
+		Reverse:       download,
+		Len:           iperfDefaultLen,
+		PacingTimer:   1000,
+		ClientVersion: iperfClientVersion,
+	}
+	if err := writeIperfJSON(ctrl, params); err != nil {
+		return nil, err
+	}
+	if err := waitIperfState(ctrl, iperfCreateStreams); err != nil {
+		return nil, err
+	}
+
+	var conns []net.Conn
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+	for i := 0; i < parallel; i++ {
+		conn, err := phase.dial(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+		conns = append(conns, conn)
+		if _, err := io.WriteString(conn, cookie); err != nil {
+			return nil, err
+		}
+	}
+	if err := waitIperfState(ctrl, iperfTestRunning); err != nil {
+		return nil, err
+	}
+	ctrl.SetDeadline(time.Time{})
+
+	transferCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	start := time.Now()
+	counts := runTransfer(conns, !download, iperfDefaultLen, counter, transferCtx.Done())
+	phase.seconds = time.Since(start).Seconds()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	ours := iperfResults{SenderHasRetransmits: -1}
+	if !download {
+		ours.SenderHasRetransmits = 0
+	}
+	var sent int64
+	for i, conn := range conns {
+		stream := iperfStreamResult{ID: iperfStreamID(i), Bytes: counts[i], Retransmits: -1, EndTime: phase.seconds}
+		if !download {
+			if n, ok := tcpRetransmits(conn.(syscall.Conn)); ok {
+				stream.Retransmits = n
+				ours.SenderHasRetransmits = 1
+				phase.retransmits = max(phase.retransmits, 0) + n
+			}
+		}
+		sent += counts[i]
+		ours.Streams = append(ours.Streams, stream)
+	}
+
+	ctrl.SetDeadline(time.Now().Add(iperfStateTimeout))
+	if err := writeIperfState(ctrl, iperfTestEnd); err != nil {
+		return nil, err
+	}
+	if err := waitIperfState(ctrl, iperfExchangeResults); err != nil {
+		return nil, err
+	}
+	if err := writeIperfJSON(ctrl, ours); err != nil {
+		return nil, err
+	}
+	var theirs iperfResults
+	if err := readIperfJSON(ctrl, &theirs); err != nil {
+		return nil, err
+	}
+	if err := waitIperfState(ctrl, iperfDisplayResults); err != nil {
+		return nil, err
+	}
+	writeIperfState(ctrl, iperfDone)
+	// Wait for the server to close the connection, so a following test does
+	// not find it busy
+	io.Copy(io.Discard, ctrl)
+
+	if download {
+		phase.bytes = sent
+		if theirs.SenderHasRetransmits == 1 {
+			phase.retransmits = 0
+			for _, stream := range theirs.Streams {
+				phase.retransmits += max(stream.Retransmits, 0)
+			}
+		}
+	} else {
+		for _, stream := range theirs.Streams {
+			phase.bytes += stream.Bytes
+		}
+		if phase.bytes == 0 {
+			phase.bytes = sent
+		}
+	}
+	return phase, nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r       io.Reader
+	counter *atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.counter.Add(int64(n))
+	return n, err
+}
+
+// speedTestBody is an upload body that ends when ctx is done
+type speedTestBody struct {
+	ctx     context.Context
+	block   []byte
+	counter *atomic.Int64
+	sent    atomic.Int64
+}
+
+func (b *speedTestBody) Read(p []byte) (int, error) {
+	if b.ctx.Err() != nil {
+		return 0, io.EOF
+	}
+	n := copy(p, b.block)
+	b.sent.Add(int64(n))
+	b.counter.Add(int64(n))
+	return n, nil
+}
+
+// runHTTPTest downloads rawURL or posts data to it for duration. A download
+// that ends early is measured over the time it took.
+func runHTTPTest(ctx context.Context, rawURL string, download bool, duration time.Duration, counter *atomic.Int64) (*speedPhase, error) {
+	phase := &speedPhase{retransmits: -1}
+	var mu sync.Mutex
+	var conns []syscall.Conn
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			conn, err := phase.dial(ctx, address)
+			if err != nil {
+				return nil, err
+			}
+			if sc, ok := conn.(syscall.Conn); ok {
+				conns = append(conns, sc)
+			}
+			return conn, nil
+		},
+		// Count bytes as they cross the network
+		DisableCompression: true,
+	}
+	defer transport.CloseIdleConnections()
+	client := &http.Client{Transport: transport}
+
+	transferCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	start := time.Now()
+
+	if download {
+		req, err := http.NewRequestWithContext(transferCtx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("server answered %s", resp.Status)
+		}
+		phase.bytes, err = io.Copy(io.Discard, &countingReader{r: resp.Body, counter: counter})
+		phase.seconds = time.Since(start).Seconds()
+		if err != nil && transferCtx.Err() == nil {
+			return nil, err
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return phase, nil
+	}
+
+	body := &speedTestBody{ctx: transferCtx, block: make([]byte, 64*1024), counter: counter}
+	rand.Read(body.block)
+	// The response may arrive after the transfer time is up
+	requestCtx, cancelRequest := context.WithTimeout(ctx, duration+iperfStateTimeout)
+	defer cancelRequest()
+	req, err := http.NewRequestWithContext(requestCtx, http.MethodPost, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := client.Do(req)
+	// The server may answer before the upload time is up
+	phase.seconds = min(time.Since(start), duration).Seconds()
+	if err != nil {
+		return nil, err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("server answered %s", resp.Status)
+	}
+	phase.bytes = body.sent.Load()
+
+	mu.Lock()
+	for _, conn := range conns {
+		if n, ok := tcpRetransmits(conn); ok {
+			phase.retransmits = max(phase.retransmits, 0) + n
+		}
+	}
+	mu.Unlock()
+	return phase, nil
+}
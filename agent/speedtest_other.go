@@ -0,0 +1,10 @@
+//go:build !linux
+
+package main
+
+import "syscall"
+
+// tcpRetransmits is only implemented on Linux
+func tcpRetransmits(conn syscall.Conn) (int64, bool) {
+	return 0, false
+}
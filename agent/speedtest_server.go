@@ -0,0 +1,214 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// maxSpeedTestServerDuration bounds tests run against the built-in server
+const maxSpeedTestServerDuration = 5 * time.Minute
+
+// speedTestServer is an iperf3-compatible TCP throughput server, so the app
+// or any iperf3 client can measure its link to the agent. Like iperf3 it runs
+// one test at a time.
+type speedTestServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	active   *iperfSession
+}
+
+// iperfSession is the test in progress; data connections carrying its
+// cookie are handed to it
+type iperfSession struct {
+	cookie  string
+	streams chan net.Conn
+}
+
+func newSpeedTestServer(address string) (*speedTestServer, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	return &speedTestServer{listener: listener}, nil
+}
+
+func (s *speedTestServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle reads the cookie a connection starts with and either passes it to
+// the running test or starts a new one
+func (s *speedTestServer) handle(conn net.Conn) {
+	conn.SetReadDeadline(time.Now().Add(iperfStateTimeout))
+	cookie := make([]byte, iperfCookieSize)
+	if _, err := io.ReadFull(conn, cookie); err != nil {
+		conn.Close()
+		return
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	s.mu.Lock()
+	if s.active != nil {
+		if s.active.cookie == string(cookie) {
+			select {
+			case s.active.streams <- conn:
+			default:
+				conn.Close()
+			}
+		} else {
+			writeIperfState(conn, iperfAccessDenied)
+			conn.Close()
+		}
+		s.mu.Unlock()
+		return
+	}
+	session := &iperfSession{cookie: string(cookie), streams: make(chan net.Conn, maxSpeedTestStreams)}
+	s.active = session
+	s.mu.Unlock()
+
+	// Deferred calls run last first: the next client may connect as soon as
+	// the control connection closes
+	defer conn.Close()
+	defer func() {
+		s.mu.Lock()
+		s.active = nil
+		s.mu.Unlock()
+		// Close streams that arrived too late
+		for {
+			select {
+			case extra := <-session.streams:
+				extra.Close()
+			default:
+				return
+			}
+		}
+	}()
+	if err := session.run(conn); err != nil {
+		log.Printf("Speed test from %s failed: %v", conn.RemoteAddr(), err)
+	}
+}
+
+// run drives the server side of the iperf3 control protocol
+func (session *iperfSession) run(ctrl net.Conn) error {
+	ctrl.SetDeadline(time.Now().Add(iperfStateTimeout))
+	if err := writeIperfState(ctrl, iperfParamExchange); err != nil {
+		return err
+	}
+	var params iperfParams
+	if err := readIperfJSON(ctrl, &params); err != nil {
+		return err
+	}
+	if params.UDP || params.Bidirectional || params.Parallel > maxSpeedTestStreams {
+		writeIperfState(ctrl, iperfAccessDenied)
+		return fmt.Errorf("only one-way TCP tests with up to %d streams are supported", maxSpeedTestStreams)
+	}
+	parallel := max(params.Parallel, 1)
+	blockSize := params.Len
+	if blockSize <= 0 {
+		blockSize = iperfDefaultLen
+	}
+	blockSize = min(blockSize, iperfMaxLen)
+
+	if err := writeIperfState(ctrl, iperfCreateStreams); err != nil {
+		return err
+	}
+	var conns []net.Conn
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+	timeout := time.After(iperfStateTimeout)
+	for len(conns) < parallel {
+		select {
+		case conn := <-session.streams:
+			conns = append(conns, conn)
+		case <-timeout:
+			return fmt.Errorf("client opened %d of %d streams", len(conns), parallel)
+		}
+	}
+
+	if err := writeIperfState(ctrl, iperfTestStart); err != nil {
+		return err
+	}
+	if err := writeIperfState(ctrl, iperfTestRunning); err != nil {
+		return err
+	}
+
+	// The client times the test and ends it with TEST_END
+	duration := time.Duration(params.Time+params.Omit) * time.Second
+	if duration <= 0 || duration > maxSpeedTestServerDuration {
+		duration = maxSpeedTestServerDuration
+	}
+	ctrl.SetDeadline(time.Now().Add(duration + iperfStateTimeout))
+
+	stop := make(chan struct{})
+	countsCh := make(chan []int64, 1)
+	var counter atomic.Int64
+	start := time.Now()
+	go func() {
+		countsCh <- runTransfer(conns, params.Reverse, blockSize, &counter, stop)
+	}()
+	err := waitIperfState(ctrl, iperfTestEnd)
+	close(stop)
+	counts := <-countsCh
+	elapsed := time.Since(start).Seconds()
+	if err != nil {
+		return err
+	}
+
+	results := iperfResults{SenderHasRetransmits: -1}
+	if params.Reverse {
+		results.SenderHasRetransmits = 0
+	}
+	for i, conn := range conns {
+		stream := iperfStreamResult{ID: iperfStreamID(i), Bytes: counts[i], Retransmits: -1, EndTime: elapsed}
+		if params.Reverse {
+			if n, ok := tcpRetransmits(conn.(syscall.Conn)); ok {
+				stream.Retransmits = n
+				results.SenderHasRetransmits = 1
+			}
+		}
+		results.Streams = append(results.Streams, stream)
+	}
+
+	ctrl.SetDeadline(time.Now().Add(iperfStateTimeout))
+	if err := writeIperfState(ctrl, iperfExchangeResults); err != nil {
+		return err
+	}
+	var clientResults iperfResults
+	if err := readIperfJSON(ctrl, &clientResults); err != nil {
+		return err
+	}
+	if err := writeIperfJSON(ctrl, results); err != nil {
+		return err
+	}
+	if err := writeIperfState(ctrl, iperfDisplayResults); err != nil {
+		return err
+	}
+	// The client answers with IPERF_DONE before closing
+	readIperfState(ctrl)
+
+	direction := "upload"
+	if params.Reverse {
+		direction = "download"
+	}
+	log.Printf("Speed test from %s: %s of %d bytes in %.1fs", ctrl.RemoteAddr(), direction, counter.Load(), elapsed)
+	return nil
+}
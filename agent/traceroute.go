@@ -0,0 +1,377 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	pb "pi_agent/proto"
+)
+
+// Traceroute defaults and limits
+const (
+	defaultTraceUDPPort = 33434
+	defaultTraceTCPPort = 80
+	defaultTraceProbes  = 3
+	maxTraceProbes      = 10
+	traceLookupTimeout  = 2 * time.Second
+
+	protocolTCP = 6
+	protocolUDP = 17
+)
+
+// traceEvent is the answer to one probe: an ICMP message quoting it, an echo
+// reply, or a completed TCP handshake
+type traceEvent struct {
+	seq     int
+	from    net.IP
+	at      time.Time
+	reached bool   // The destination answered
+	note    string // Unreachable code, ends the trace
+}
+
+// traceProber sends probes of one type with a given TTL. seq is unique for
+// the whole trace so late answers can be told apart.
+type traceProber interface {
+	send(ctx context.Context, ttl, seq int) error
+	// match maps an ICMP message from the raw listener to the probe it answers
+	match(reply *icmpReply) (seq int, ok bool)
+	Close() error
+}
+
+// quotedProbe holds the fields of a probe packet quoted in an ICMP error
+type quotedProbe struct {
+	protocol int
+	dst      net.IP
+	srcPort  int // UDP and TCP
+	dstPort  int
+	id       int // ICMP echo
+	seq      int
+}
+
+// parseQuotedPacket parses the IP header and first 8 transport bytes that
+// ICMP time exceeded and unreachable messages carry
+func parseQuotedPacket(data []byte, v6 bool) (*quotedProbe, error) {
+	q := &quotedProbe{}
+	var transport []byte
+	if v6 {
+		if len(data) < 40 || data[0]>>4 != 6 {
+			return nil, fmt.Errorf("short or invalid IPv6 header")
+		}
+		q.protocol = int(data[6])
+		q.dst = net.IP(append([]byte(nil), data[24:40]...))
+		transport = data[40:]
+	} else {
+		if len(data) < 20 || data[0]>>4 != 4 {
+			return nil, fmt.Errorf("short or invalid IPv4 header")
+		}
+		headerLen := int(data[0]&0x0f) * 4
+		if headerLen < 20 || len(data) < headerLen {
+			return nil, fmt.Errorf("invalid IPv4 header length %d", headerLen)
+		}
+		q.protocol = int(data[9])
+		q.dst = net.IP(append([]byte(nil), data[16:20]...))
+		transport = data[headerLen:]
+	}
+	if len(transport) < 8 {
+		return nil, fmt.Errorf("quoted packet too short")
+	}
+
+	switch q.protocol {
+	case protocolICMP, protocolICMPv6:
+		q.id = int(binary.BigEndian.Uint16(transport[4:6]))
+		q.seq = int(binary.BigEndian.Uint16(transport[6:8]))
+	case protocolTCP, protocolUDP:
+		q.srcPort = int(binary.BigEndian.Uint16(transport[0:2]))
+		q.dstPort = int(binary.BigEndian.Uint16(transport[2:4]))
+	}
+	return q, nil
+}
+
+// unreachableNote returns traceroute's annotation for an ICMP destination
+// unreachable code; port unreachable (the normal UDP end) has none
+func unreachableNote(code int, v6 bool) string {
+	if v6 {
+		switch code {
+		case 0:
+			return "!N"
+		case 1:
+			return "!X"
+		case 3:
+			return "!H"
+		case 4:
+			return ""
+		}
+		return "!" + strconv.Itoa(code)
+	}
+
+	switch code {
+	case 0:
+		return "!N"
+	case 1:
+		return "!H"
+	case 2:
+		return "!P"
+	case 3:
+		return ""
+	case 4:
+		return "!F"
+	case 9, 10, 13:
+		return "!X"
+	}
+	return "!" + strconv.Itoa(code)
+}
+
+// traceEventFromReply converts a matched ICMP message into a traceEvent
+func traceEventFromReply(reply *icmpReply, seq int, dst net.IP, v6 bool) traceEvent {
+	ev := traceEvent{seq: seq, from: reply.from, at: reply.received}
+	if reply.from.Equal(dst) {
+		ev.reached = true
+	}
+	if reply.msgType == ipv4.ICMPTypeDestinationUnreachable || reply.msgType == ipv6.ICMPTypeDestinationUnreachable {
+		ev.note = unreachableNote(reply.code, v6)
+		if ev.note == "" {
+			ev.reached = true
+		}
+	}
+	return ev
+}
+
+// icmpTraceProber sends echo requests on the raw listener itself
+type icmpTraceProber struct {
+	conn *icmpConn
+	dst  net.IP
+}
+
+func (p *icmpTraceProber) send(ctx context.Context, ttl, seq int) error {
+	if err := p.conn.setTTL(ttl); err != nil {
+		return err
+	}
+	return p.conn.sendEcho(p.dst, seq, 32)
+}
+
+func (p *icmpTraceProber) match(reply *icmpReply) (int, bool) {
+	if reply.msgType == ipv4.ICMPTypeEchoReply || reply.msgType == ipv6.ICMPTypeEchoReply {
+		return reply.seq, reply.id == p.conn.id
+	}
+	if reply.quoted == nil {
+		return 0, false
+	}
+	q, err := parseQuotedPacket(reply.quoted, p.conn.v6)
+	if err != nil || (q.protocol != protocolICMP && q.protocol != protocolICMPv6) || q.id != p.conn.id || !q.dst.Equal(p.dst) {
+		return 0, false
+	}
+	return q.seq, true
+}
+
+// Close leaves the shared listener to the caller
+func (p *icmpTraceProber) Close() error {
+	return nil
+}
+
+// udpTraceProber sends datagrams to basePort+seq from one local port
+type udpTraceProber struct {
+	conn      net.PacketConn
+	dst       net.IP
+	basePort  int
+	localPort int
+}
+
+func newUDPTraceProber(dst net.IP, basePort int) (*udpTraceProber, error) {
+	network := "udp4"
+	if dst.To4() == nil {
+		network = "udp6"
+	}
+	conn, err := net.ListenPacket(network, ":0")
+	if err != nil {
+		return nil, err
+	}
+	return &udpTraceProber{
+		conn:      conn,
+		dst:       dst,
+		basePort:  basePort,
+		localPort: conn.LocalAddr().(*net.UDPAddr).Port,
+	}, nil
+}
+
+func (p *udpTraceProber) send(ctx context.Context, ttl, seq int) error {
+	var err error
+	if p.dst.To4() == nil {
+		err = ipv6.NewPacketConn(p.conn).SetHopLimit(ttl)
+	} else {
+		err = ipv4.NewPacketConn(p.conn).SetTTL(ttl)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = p.conn.WriteTo(make([]byte, 32), &net.UDPAddr{IP: p.dst, Port: p.basePort + seq})
+	return err
+}
+
+func (p *udpTraceProber) match(reply *icmpReply) (int, bool) {
+	if reply.quoted == nil {
+		return 0, false
+	}
+	q, err := parseQuotedPacket(reply.quoted, p.dst.To4() == nil)
+	if err != nil || q.protocol != protocolUDP || q.srcPort != p.localPort || !q.dst.Equal(p.dst) || q.dstPort < p.basePort {
+		return 0, false
+	}
+	return q.dstPort - p.basePort, true
+}
+
+func (p *udpTraceProber) Close() error {
+	return p.conn.Close()
+}
+
+// tcpTraceProber starts a connection per probe from localBase+seq. The kernel
+// sends the SYN; a completed or refused handshake means the destination
+// answered.
+type tcpTraceProber struct {
+	dst       net.IP
+	port      int
+	localBase int
+	timeout   time.Duration
+	events    chan<- traceEvent
+}
+
+func (p *tcpTraceProber) send(ctx context.Context, ttl, seq int) error {
+	v6 := p.dst.To4() == nil
+	dialer := net.Dialer{
+		LocalAddr: &net.TCPAddr{Port: p.localBase + seq},
+		Timeout:   p.timeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			var sockErr error
+			err := c.Control(func(fd uintptr) {
+				sockErr = setSocketTTL(fd, ttl, v6)
+			})
+			if err != nil {
+				return err
+			}
+			return sockErr
+		},
+	}
+
+	go func() {
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(p.dst.String(), strconv.Itoa(p.port)))
+		if err == nil {
+			conn.Close()
+		} else if !errors.Is(err, syscall.ECONNREFUSED) {
+			return
+		}
+		select {
+		case p.events <- traceEvent{seq: seq, from: p.dst, at: time.Now(), reached: true}:
+		case <-ctx.Done():
+		}
+	}()
+	return nil
+}
+
+func (p *tcpTraceProber) match(reply *icmpReply) (int, bool) {
+	if reply.quoted == nil {
+		return 0, false
+	}
+	q, err := parseQuotedPacket(reply.quoted, p.dst.To4() == nil)
+	if err != nil || q.protocol != protocolTCP || q.dstPort != p.port || !q.dst.Equal(p.dst) || q.srcPort < p.localBase {
+		return 0, false
+	}
+	return q.srcPort - p.localBase, true
+}
+
+func (p *tcpTraceProber) Close() error {
+	return nil
+}
+
+// reverseLookup returns the first PTR name of ip without the trailing dot
+func reverseLookup(ctx context.Context, ip net.IP) string {
+	ctx, cancel := context.WithTimeout(ctx, traceLookupTimeout)
+	defer cancel()
+	names, err := net.DefaultResolver.LookupAddr(ctx, ip.String())
+	if err != nil || len(names) == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(names[0], ".")
+}
+
+// lookupASN returns "AS<number> <name>" for a public address using Team
+// Cymru's DNS service
+func lookupASN(ctx context.Context, ip net.IP) string {
+	if ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(ctx, traceLookupTimeout)
+	defer cancel()
+
+	var query string
+	if ip4 := ip.To4(); ip4 != nil {
+		query = fmt.Sprintf("%d.%d.%d.%d.origin.asn.cymru.com", ip4[3], ip4[2], ip4[1], ip4[0])
+	} else {
+		var nibbles []string
+		for i := len(ip) - 1; i >= 0; i-- {
+			nibbles = append(nibbles, fmt.Sprintf("%x.%x", ip[i]&0x0f, ip[i]>>4))
+		}
+		query = strings.Join(nibbles, ".") + ".origin6.asn.cymru.com"
+	}
+
+	// "13335 | 1.1.1.0/24 | US | arin | 2010-07-14"
+	records, err := net.DefaultResolver.LookupTXT(ctx, query)
+	if err != nil || len(records) == 0 {
+		return ""
+	}
+	asn := strings.Fields(strings.Split(records[0], "|")[0])
+	if len(asn) == 0 {
+		return ""
+	}
+	result := "AS" + asn[0]
+
+	// "13335 | US | arin | 2010-07-14 | CLOUDFLARENET - Cloudflare, Inc., US"
+	if records, err := net.DefaultResolver.LookupTXT(ctx, result+".asn.cymru.com"); err == nil && len(records) > 0 {
+		fields := strings.Split(records[0], "|")
+		if name := strings.TrimSpace(fields[len(fields)-1]); len(fields) >= 5 && name != "" {
+			result += " " + name
+		}
+	}
+	return result
+}
+
+// newTraceProber creates the prober for the requested probe type. maxSeq is
+// the highest sequence number the trace will use.
+func newTraceProber(req *pb.TracerouteRequest, dst net.IP, listener *icmpConn, timeout time.Duration, maxSeq int, events chan<- traceEvent) (traceProber, error) {
+	switch req.Probe {
+	case pb.TracerouteProbe_TRACEROUTE_ICMP:
+		return &icmpTraceProber{conn: listener, dst: dst}, nil
+	case pb.TracerouteProbe_TRACEROUTE_TCP:
+		if !tcpTracerouteSupported {
+			return nil, fmt.Errorf("TCP traceroute is not supported on this platform")
+		}
+		port := int(req.Port)
+		if port <= 0 || port > 65535 {
+			port = defaultTraceTCPPort
+		}
+		return &tcpTraceProber{
+			dst:       dst,
+			port:      port,
+			localBase: 40000 + rand.Intn(20000),
+			timeout:   timeout,
+			events:    events,
+		}, nil
+	default:
+		port := int(req.Port)
+		if port <= 0 {
+			port = defaultTraceUDPPort
+		}
+		if port+maxSeq > 65535 {
+			return nil, fmt.Errorf("port %d leaves no room for %d probes", port, maxSeq+1)
+		}
+		return newUDPTraceProber(dst, port)
+	}
+}
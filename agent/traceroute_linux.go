@@ -0,0 +1,16 @@
+//go:build linux
+
+package main
+
+import "golang.org/x/sys/unix"
+
+// tcpTracerouteSupported reports whether setSocketTTL works here
+const tcpTracerouteSupported = true
+
+// setSocketTTL sets the TTL (hop limit) on a socket before it connects
+func setSocketTTL(fd uintptr, ttl int, v6 bool) error {
+	if v6 {
+		return unix.SetsockoptInt(int(fd), unix.IPPROTO_IPV6, unix.IPV6_UNICAST_HOPS, ttl)
+	}
+	return unix.SetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_TTL, ttl)
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package main
+
+import "errors"
+
+// tcpTracerouteSupported reports whether setSocketTTL works here
+const tcpTracerouteSupported = false
+
+// setSocketTTL is only implemented on Linux
+func setSocketTTL(fd uintptr, ttl int, v6 bool) error {
+	return errors.ErrUnsupported
+}
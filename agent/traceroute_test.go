@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/hex"
+	"net"
+	"strings"
+	"testing"
+)
+
+// hexFixture decodes a hex dump in testdata, skipping # comment lines
+func hexFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	var digits strings.Builder
+	for _, line := range fixtureLines(t, name) {
+		if !strings.HasPrefix(line, "#") {
+			digits.WriteString(strings.Join(strings.Fields(line), ""))
+		}
+	}
+	data, err := hex.DecodeString(digits.String())
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return data
+}
+
+// The fixtures were captured on a client, router and destination joined by
+// network namespaces, with the client at 10.1.0.2 and 2001:db8:1::2
+func TestTraceReplies(t *testing.T) {
+	dst4 := net.ParseIP("10.2.0.2")
+	dst6 := net.ParseIP("2001:db8:2::2")
+	udp4 := &udpTraceProber{dst: dst4, basePort: defaultTraceUDPPort, localPort: 54321}
+	udp6 := &udpTraceProber{dst: dst6, basePort: defaultTraceUDPPort, localPort: 54321}
+
+	tests := []struct {
+		fixture string
+		from    string
+		prober  traceProber
+		quoted  quotedProbe
+		seq     int
+		reached bool
+		note    string
+	}{
+		{"icmp4-time-exceeded-udp", "10.1.0.1", udp4,
+			quotedProbe{protocol: protocolUDP, dst: dst4, srcPort: 54321, dstPort: 33437}, 3, false, ""},
+		{"icmp4-time-exceeded-options", "10.1.0.1", udp4,
+			quotedProbe{protocol: protocolUDP, dst: dst4, srcPort: 54321, dstPort: 33438}, 4, false, ""},
+		{"icmp4-time-exceeded-echo", "10.1.0.1",
+			&icmpTraceProber{conn: &icmpConn{id: 0x1234}, dst: dst4},
+			quotedProbe{protocol: protocolICMP, dst: dst4, id: 0x1234, seq: 7}, 7, false, ""},
+		{"icmp4-time-exceeded-tcp", "10.1.0.1",
+			&tcpTraceProber{dst: dst4, port: 443, localBase: 40000},
+			quotedProbe{protocol: protocolTCP, dst: dst4, srcPort: 40007, dstPort: 443}, 7, false, ""},
+		{"icmp4-port-unreachable", "10.2.0.2", udp4,
+			quotedProbe{protocol: protocolUDP, dst: dst4, srcPort: 54321, dstPort: 33446}, 12, true, ""},
+		{"icmp4-host-unreachable", "10.1.0.1",
+			&icmpTraceProber{conn: &icmpConn{id: 0x1234}, dst: net.ParseIP("10.4.0.7")},
+			quotedProbe{protocol: protocolICMP, dst: net.ParseIP("10.4.0.7"), id: 0x1234, seq: 9}, 9, false, "!H"},
+		{"icmp4-filtered", "10.1.0.1",
+			&udpTraceProber{dst: net.ParseIP("10.3.0.7"), basePort: defaultTraceUDPPort, localPort: 54321},
+			quotedProbe{protocol: protocolUDP, dst: net.ParseIP("10.3.0.7"), srcPort: 54321, dstPort: 33440}, 6, false, "!X"},
+		{"icmp6-time-exceeded-udp", "2001:db8:1::1", udp6,
+			quotedProbe{protocol: protocolUDP, dst: dst6, srcPort: 54321, dstPort: 33435}, 1, false, ""},
+		{"icmp6-time-exceeded-tcp", "2001:db8:1::1",
+			&tcpTraceProber{dst: dst6, port: 443, localBase: 40000},
+			quotedProbe{protocol: protocolTCP, dst: dst6, srcPort: 40011, dstPort: 443}, 11, false, ""},
+		{"icmp6-port-unreachable", "2001:db8:2::2", udp6,
+			quotedProbe{protocol: protocolUDP, dst: dst6, srcPort: 54321, dstPort: 33440}, 6, true, ""},
+		{"icmp6-admin-prohibited", "2001:db8:1::1",
+			&icmpTraceProber{conn: &icmpConn{id: 0x1234, v6: true}, dst: net.ParseIP("2001:db8:3::80")},
+			quotedProbe{protocol: protocolICMPv6, dst: net.ParseIP("2001:db8:3::80"), id: 0x1234, seq: 12}, 12, false, "!X"},
+	}
+	for _, tt := range tests {
+		v6 := strings.HasPrefix(tt.fixture, "icmp6")
+		protocol := protocolICMP
+		if v6 {
+			protocol = protocolICMPv6
+		}
+		reply, err := parseICMPReply(protocol, hexFixture(t, tt.fixture))
+		if err != nil {
+			t.Errorf("%s: %v", tt.fixture, err)
+			continue
+		}
+		reply.from = net.ParseIP(tt.from)
+
+		q, err := parseQuotedPacket(reply.quoted, v6)
+		if err != nil {
+			t.Errorf("%s: %v", tt.fixture, err)
+			continue
+		}
+		if q.protocol != tt.quoted.protocol || !q.dst.Equal(tt.quoted.dst) || q.srcPort != tt.quoted.srcPort ||
+			q.dstPort != tt.quoted.dstPort || q.id != tt.quoted.id || q.seq != tt.quoted.seq {
+			t.Errorf("%s: quoted %+v, want %+v", tt.fixture, *q, tt.quoted)
+		}
+
+		seq, ok := tt.prober.match(reply)
+		if !ok || seq != tt.seq {
+			t.Errorf("%s: match = %d, %v; want %d, true", tt.fixture, seq, ok, tt.seq)
+		}
+
+		ev := traceEventFromReply(reply, seq, tt.quoted.dst, v6)
+		if ev.reached != tt.reached || ev.note != tt.note {
+			t.Errorf("%s: event reached %v note %q, want %v %q", tt.fixture, ev.reached, ev.note, tt.reached, tt.note)
+		}
+	}
+
+	// Replies to another destination or from another socket are not ours
+	reply, _ := parseICMPReply(protocolICMP, hexFixture(t, "icmp4-time-exceeded-udp"))
+	if _, ok := (&udpTraceProber{dst: dst4, basePort: defaultTraceUDPPort, localPort: 54322}).match(reply); ok {
+		t.Error("reply matched a prober with another local port")
+	}
+	if _, ok := (&udpTraceProber{dst: net.ParseIP("10.2.0.3"), basePort: defaultTraceUDPPort, localPort: 54321}).match(reply); ok {
+		t.Error("reply matched a prober with another destination")
+	}
+	reply, _ = parseICMPReply(protocolICMP, hexFixture(t, "icmp4-time-exceeded-echo"))
+	if _, ok := (&icmpTraceProber{conn: &icmpConn{id: 0x4321}, dst: dst4}).match(reply); ok {
+		t.Error("reply matched a prober with another echo ID")
+	}
+}
+
+func TestParseQuotedPacketInvalid(t *testing.T) {
+	reply, err := parseICMPReply(protocolICMP, hexFixture(t, "icmp4-time-exceeded-options"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	withOptions := reply.quoted
+
+	tests := []struct {
+		name string
+		data []byte
+		v6   bool
+	}{
+		{"empty", nil, false},
+		{"header cut inside the options", withOptions[:22], false},
+		{"no transport bytes after the options", withOptions[:28], false},
+		{"IHL below 5", append([]byte{0x44}, withOptions[1:]...), false},
+		{"IPv4 header read as IPv6", withOptions, true},
+		{"short IPv6 header", make([]byte, 39), true},
+	}
+	for _, tt := range tests {
+		if q, err := parseQuotedPacket(tt.data, tt.v6); err == nil {
+			t.Errorf("%s: parsed %+v, want an error", tt.name, *q)
+		}
+	}
+}
@@ -0,0 +1,425 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "pi_agent/proto"
+)
+
+// Limits for the unattended upgrade scheduler
+const (
+	upgradePolicyFile       = "upgrade-policy.json"
+	upgradeRunsFile         = "upgrade-runs.json"
+	maxUpgradeRuns          = 50
+	maxUpgradeRunOutput     = 64 * 1024
+	defaultUpgradeWindowMin = 120
+	upgradeCheckInterval    = time.Minute
+)
+
+// upgradeScheduler runs refresh and upgrade jobs inside a maintenance window
+// and keeps a history of runs in the agent's data directory
+type upgradeScheduler struct {
+	server  *systemMonitorServer
+	dataDir string
+
+	mu      sync.Mutex
+	policy  *pb.UpgradePolicy
+	runs    []*pb.UpgradeRun // Newest first
+	running bool
+	nextID  int
+}
+
+func newUpgradeScheduler(server *systemMonitorServer, dataDir string) *upgradeScheduler {
+	u := &upgradeScheduler{
+		server:  server,
+		dataDir: dataDir,
+		policy:  &pb.UpgradePolicy{},
+	}
+
+	policy := &pb.UpgradePolicy{}
+	if err := u.load(upgradePolicyFile, policy); err == nil {
+		u.policy = policy
+	} else if !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Warning: failed to load upgrade policy: %v", err)
+	}
+
+	runs := &pb.UpgradeRunList{}
+	if err := u.load(upgradeRunsFile, runs); err == nil {
+		u.runs = runs.Runs
+	} else if !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Warning: failed to load upgrade history: %v", err)
+	}
+	for _, run := range u.runs {
+		if run.FinishedAt == 0 {
+			run.Error = "the agent stopped before the run finished"
+		}
+		if id, err := strconv.Atoi(strings.TrimPrefix(run.Id, "run-")); err == nil && id > u.nextID {
+			u.nextID = id
+		}
+	}
+
+	return u
+}
+
+// start checks the maintenance window once a minute
+func (u *upgradeScheduler) start() {
+	go func() {
+		ticker := time.NewTicker(upgradeCheckInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			u.checkWindow(time.Now())
+		}
+	}()
+}
+
+// checkWindow starts a scheduled run if now is inside a window that has not
+// had a scheduled run yet
+func (u *upgradeScheduler) checkWindow(now time.Time) {
+	u.mu.Lock()
+	policy := proto.Clone(u.policy).(*pb.UpgradePolicy)
+	var lastScheduled time.Time
+	for _, run := range u.runs {
+		if run.Trigger == "schedule" {
+			lastScheduled = time.Unix(run.StartedAt, 0)
+			break
+		}
+	}
+	u.mu.Unlock()
+
+	if !policy.Enabled {
+		return
+	}
+	windowStart, ok := currentUpgradeWindow(policy, now)
+	if !ok || !lastScheduled.Before(windowStart) {
+		return
+	}
+
+	log.Printf("Maintenance window opened at %s, starting unattended upgrade", windowStart.Format("2006-01-02 15:04"))
+	if _, err := u.run("schedule"); err != nil {
+		log.Printf("Unattended upgrade not started: %v", err)
+	}
+}
+
+// parseWindowStart parses "HH:MM"
+func parseWindowStart(s string) (hour, minute int, err error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid window start %q (expected HH:MM)", s)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// windowDuration returns the policy's window length, applying the default
+func windowDuration(policy *pb.UpgradePolicy) time.Duration {
+	minutes := policy.WindowMinutes
+	if minutes <= 0 {
+		minutes = defaultUpgradeWindowMin
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// windowOpensOn reports whether the window opens on the given weekday
+func windowOpensOn(policy *pb.UpgradePolicy, day time.Weekday) bool {
+	if len(policy.Days) == 0 {
+		return true
+	}
+	for _, d := range policy.Days {
+		if time.Weekday(d) == day {
+			return true
+		}
+	}
+	return false
+}
+
+// currentUpgradeWindow returns the start of the window containing now.
+// Windows that opened yesterday and run past midnight are included.
+func currentUpgradeWindow(policy *pb.UpgradePolicy, now time.Time) (time.Time, bool) {
+	hour, minute, err := parseWindowStart(policy.WindowStart)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	for _, daysAgo := range []int{0, 1} {
+		day := now.AddDate(0, 0, -daysAgo)
+		start := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location())
+		if windowOpensOn(policy, start.Weekday()) && !now.Before(start) && now.Before(start.Add(windowDuration(policy))) {
+			return start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// nextUpgradeWindow returns the start of the next window after now
+func nextUpgradeWindow(policy *pb.UpgradePolicy, now time.Time) (time.Time, bool) {
+	hour, minute, err := parseWindowStart(policy.WindowStart)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	for daysAhead := 0; daysAhead <= 7; daysAhead++ {
+		day := now.AddDate(0, 0, daysAhead)
+		start := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location())
+		if start.After(now) && windowOpensOn(policy, start.Weekday()) {
+			return start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// validateUpgradePolicy checks a policy before it is saved
+func validateUpgradePolicy(policy *pb.UpgradePolicy) error {
+	if _, _, err := parseWindowStart(policy.WindowStart); err != nil {
+		return err
+	}
+	for _, d := range policy.Days {
+		if d < 0 || d > 6 {
+			return fmt.Errorf("invalid day %d (0 = Sunday ... 6 = Saturday)", d)
+		}
+	}
+	if policy.WindowMinutes < 0 || policy.WindowMinutes > 24*60 {
+		return fmt.Errorf("window length must be between 0 and 1440 minutes")
+	}
+	return nil
+}
+
+// getPolicy returns a copy of the policy with next_run filled in
+func (u *upgradeScheduler) getPolicy() *pb.UpgradePolicy {
+	u.mu.Lock()
+	policy := proto.Clone(u.policy).(*pb.UpgradePolicy)
+	u.mu.Unlock()
+
+	policy.NextRun = 0
+	if policy.Enabled {
+		if next, ok := nextUpgradeWindow(policy, time.Now()); ok {
+			policy.NextRun = next.Unix()
+		}
+	}
+	return policy
+}
+
+// setPolicy validates and stores a new policy
+func (u *upgradeScheduler) setPolicy(policy *pb.UpgradePolicy) error {
+	policy = proto.Clone(policy).(*pb.UpgradePolicy)
+	policy.NextRun = 0
+	if policy.Enabled || policy.WindowStart != "" {
+		if err := validateUpgradePolicy(policy); err != nil {
+			return err
+		}
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if err := u.save(upgradePolicyFile, policy); err != nil {
+		return err
+	}
+	u.policy = policy
+	return nil
+}
+
+// listRuns returns the run history, newest first
+func (u *upgradeScheduler) listRuns() []*pb.UpgradeRun {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	runs := make([]*pb.UpgradeRun, len(u.runs))
+	for i, run := range u.runs {
+		runs[i] = proto.Clone(run).(*pb.UpgradeRun)
+	}
+	return runs
+}
+
+// run starts one upgrade according to the current policy. It returns once
+// the package job is queued, with the run's ID and job ID; the run is
+// recorded in the history while it is in progress and updated when it
+// finishes. Runs are not tied to the caller and only CancelJob stops them.
+// Only one run happens at a time.
+func (u *upgradeScheduler) run(trigger string) (*pb.UpgradeRun, error) {
+	u.mu.Lock()
+	if u.running {
+		u.mu.Unlock()
+		return nil, fmt.Errorf("an upgrade run is already in progress")
+	}
+	u.running = true
+	u.nextID++
+	policy := proto.Clone(u.policy).(*pb.UpgradePolicy)
+	record := &pb.UpgradeRun{
+		Id:           fmt.Sprintf("run-%d", u.nextID),
+		Trigger:      trigger,
+		StartedAt:    time.Now().Unix(),
+		SecurityOnly: policy.SecurityOnly,
+	}
+	u.mu.Unlock()
+
+	wait, err := u.execute(policy, record)
+	if err != nil {
+		u.finish(record, err)
+		return proto.Clone(record).(*pb.UpgradeRun), nil
+	}
+
+	started := proto.Clone(record).(*pb.UpgradeRun)
+	u.mu.Lock()
+	u.recordLocked(started)
+	u.mu.Unlock()
+
+	go func() {
+		u.finish(record, wait())
+	}()
+	return proto.Clone(started).(*pb.UpgradeRun), nil
+}
+
+// finish completes the record of a run and saves the history
+func (u *upgradeScheduler) finish(record *pb.UpgradeRun, err error) {
+	record.FinishedAt = time.Now().Unix()
+	record.Success = err == nil
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	u.mu.Lock()
+	u.recordLocked(record)
+	u.running = false
+	u.mu.Unlock()
+
+	log.Printf("Upgrade %s (%s) finished: success=%v, %d upgraded", record.Id, record.Trigger, record.Success, len(record.Upgraded))
+}
+
+// recordLocked adds or replaces a run in the history and saves it; u.mu
+// must be held
+func (u *upgradeScheduler) recordLocked(record *pb.UpgradeRun) {
+	replaced := false
+	for i, run := range u.runs {
+		if run.Id == record.Id {
+			u.runs[i] = record
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		u.runs = append([]*pb.UpgradeRun{record}, u.runs...)
+		if len(u.runs) > maxUpgradeRuns {
+			u.runs = u.runs[:maxUpgradeRuns]
+		}
+	}
+	if err := u.save(upgradeRunsFile, &pb.UpgradeRunList{Runs: u.runs}); err != nil {
+		log.Printf("Warning: failed to save upgrade history: %v", err)
+	}
+}
+
+// execute queues the refresh and upgrade steps. The returned function waits
+// for them and fills in the record.
+func (u *upgradeScheduler) execute(policy *pb.UpgradePolicy, record *pb.UpgradeRun) (wait func() error, err error) {
+	packages := u.server.packages
+
+	steps := []packageStep{packages.RefreshStep(), packages.UpgradeStep("")}
+	cleanup := func() {}
+	if policy.SecurityOnly {
+		steps, cleanup, err = packages.SecurityUpgradeSteps()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	held := make(map[string]bool)
+	if holds, err := packages.Holds(); err == nil {
+		for _, hold := range holds {
+			held[hold.Name] = true
+		}
+	}
+
+	installedBefore := make(map[string]string)
+	if installed, err := packages.ListInstalled(); err == nil {
+		for _, pkg := range installed {
+			installedBefore[pkg.Name] = pkg.Version
+		}
+	}
+
+	job, err := u.server.jobs.submit("unattended upgrade ("+record.Trigger+")", steps...)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	record.JobId = job.id
+
+	return func() error {
+		<-job.done
+		cleanup()
+		return u.complete(job, policy, record, installedBefore, held)
+	}, nil
+}
+
+// complete records the outcome of a finished upgrade job and reboots if the
+// policy asks for it
+func (u *upgradeScheduler) complete(job *packageJob, policy *pb.UpgradePolicy, record *pb.UpgradeRun, installedBefore map[string]string, held map[string]bool) error {
+	packages := u.server.packages
+
+	output := job.output()
+	if len(output) > maxUpgradeRunOutput {
+		output = "...\n" + output[len(output)-maxUpgradeRunOutput:]
+	}
+	record.Output = output
+	if job.err != nil {
+		return job.err
+	}
+
+	// Compare installed versions so packages that only became upgradable
+	// after the refresh are recorded too
+	if after, err := packages.ListInstalled(); err == nil {
+		for _, pkg := range after {
+			previous, ok := installedBefore[pkg.Name]
+			if ok && previous != pkg.Version {
+				record.Upgraded = append(record.Upgraded, &pb.UpgradablePackage{
+					Name:           pkg.Name,
+					CurrentVersion: previous,
+					NewVersion:     pkg.Version,
+					Architecture:   pkg.Architecture,
+				})
+			}
+		}
+	}
+	if remaining, err := packages.Upgradable(); err == nil {
+		for _, pkg := range remaining {
+			if held[pkg.Name] {
+				pkg.Held = true
+				record.Held = append(record.Held, pkg)
+			}
+		}
+	}
+
+	record.RebootRequired, _ = rebootRequired()
+	if policy.Reboot == pb.RebootPolicy_REBOOT_ALWAYS ||
+		(policy.Reboot == pb.RebootPolicy_REBOOT_IF_REQUIRED && record.RebootRequired) {
+		if err := u.server.schedulePower("reboot", 1, "Rebooting after unattended upgrade"); err != nil {
+			return fmt.Errorf("upgrade succeeded but reboot failed: %w", err)
+		}
+		record.RebootScheduled = true
+	}
+	return nil
+}
+
+// load reads a protojson file from the data directory
+func (u *upgradeScheduler) load(name string, msg proto.Message) error {
+	data, err := os.ReadFile(filepath.Join(u.dataDir, name))
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(data, msg)
+}
+
+// save writes a protojson file to the data directory atomically
+func (u *upgradeScheduler) save(name string, msg proto.Message) error {
+	data, err := protojson.MarshalOptions{Indent: "  "}.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(u.dataDir, name), data, 0644)
+}
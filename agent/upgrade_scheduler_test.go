@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+// fakeUpgradePackages runs slow no-op steps in place of a package manager
+type fakeUpgradePackages struct {
+	packageManager
+}
+
+func (fakeUpgradePackages) RefreshStep() packageStep {
+	return packageStep{phase: "update", command: "true"}
+}
+
+func (fakeUpgradePackages) UpgradeStep(name string) packageStep {
+	return packageStep{phase: "upgrade", command: "sleep", args: []string{"0.5"}}
+}
+
+func (fakeUpgradePackages) Holds() ([]*pb.PackageHold, error)            { return nil, nil }
+func (fakeUpgradePackages) ListInstalled() ([]*pb.PackageInfo, error)    { return nil, nil }
+func (fakeUpgradePackages) Upgradable() ([]*pb.UpgradablePackage, error) { return nil, nil }
+
+// A run returns once its job is queued and finishes on its own
+func TestUpgradeRunDetached(t *testing.T) {
+	server := &systemMonitorServer{jobs: newPackageJobQueue(), packages: fakeUpgradePackages{}}
+	u := newUpgradeScheduler(server, t.TempDir())
+
+	run, err := u.run("manual")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.JobId == "" || run.FinishedAt != 0 {
+		t.Fatalf("run = %v, want an unfinished run with a job ID", run)
+	}
+	if runs := u.listRuns(); len(runs) != 1 || runs[0].Id != run.Id {
+		t.Fatalf("history = %v, want the in-progress run", runs)
+	}
+	if _, err := u.run("manual"); err == nil {
+		t.Error("second run started while the first was in progress")
+	}
+
+	job := server.jobs.get(run.JobId)
+	select {
+	case <-job.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job did not finish")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		runs := u.listRuns()
+		if runs[0].FinishedAt != 0 {
+			if !runs[0].Success {
+				t.Errorf("run = %v, want success", runs[0])
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("run was not recorded as finished")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// An unfinished run left by a restart is reported as interrupted
+	u.mu.Lock()
+	u.runs[0].FinishedAt = 0
+	u.recordLocked(u.runs[0])
+	u.mu.Unlock()
+	if runs := newUpgradeScheduler(server, u.dataDir).listRuns(); runs[0].Error == "" {
+		t.Errorf("reloaded run = %v, want an error", runs[0])
+	}
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+const (
+	iwTimeout       = 5 * time.Second
+	wifiScanTimeout = 30 * time.Second
+	sysClassNet     = "/sys/class/net"
+)
+
+// wifiLink is the association state reported by "iw dev <if> link"
+type wifiLink struct {
+	connected bool
+	bssid     string
+	ssid      string
+	freq      float64 // MHz
+	signal    int32   // dBm
+	rxRate    float64 // Mbps
+	txRate    float64 // Mbps
+}
+
+// wifiInterfaces lists the wireless interfaces, which have a "wireless" or
+// "phy80211" entry in sysfs
+func wifiInterfaces() []string {
+	entries, err := os.ReadDir(sysClassNet)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		dir := filepath.Join(sysClassNet, entry.Name())
+		if _, err := os.Stat(filepath.Join(dir, "wireless")); err == nil {
+			names = append(names, entry.Name())
+		} else if _, err := os.Stat(filepath.Join(dir, "phy80211")); err == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// wifiInterface validates the requested interface, defaulting to the first
+// wireless one
+func wifiInterface(name string) (string, error) {
+	names := wifiInterfaces()
+	if name == "" {
+		if len(names) == 0 {
+			return "", fmt.Errorf("no wireless interface found")
+		}
+		return names[0], nil
+	}
+	for _, n := range names {
+		if n == name {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not a wireless interface", name)
+}
+
+// runIw runs iw and returns its output, folding the output into the error
+// since iw prints the reason for failures there
+func runIw(ctx context.Context, timeout time.Duration, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, "iw", args...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return "", fmt.Errorf("iw %s: %s", strings.Join(args, " "), msg)
+		}
+		return "", fmt.Errorf("iw %s: %v", strings.Join(args, " "), err)
+	}
+	return string(output), nil
+}
+
+// parseIwLink parses "iw dev <if> link":
+//
+//	Connected to 11:22:33:44:55:66 (on wlan0)
+//		SSID: HomeNet
+//		freq: 5180
+//		signal: -52 dBm
+//		rx bitrate: 390.0 MBit/s VHT-MCS 8 80MHz VHT-NSS 1
+//		tx bitrate: 433.3 MBit/s VHT-MCS 9 80MHz short GI VHT-NSS 1
+func parseIwLink(output string) wifiLink {
+	var link wifiLink
+	for _, line := range strings.Split(output, "\n") {
+		if rest, ok := strings.CutPrefix(line, "Connected to "); ok {
+			link.connected = true
+			link.bssid = strings.ToLower(strings.Fields(rest)[0])
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "SSID":
+			link.ssid = unescapeIwSSID(value)
+		case "freq":
+			link.freq = leadingFloat(value)
+		case "signal":
+			link.signal = int32(leadingFloat(value))
+		case "rx bitrate":
+			link.rxRate = leadingFloat(value)
+		case "tx bitrate":
+			link.txRate = leadingFloat(value)
+		}
+	}
+	return link
+}
+
+// parseIwScan parses "iw dev <if> scan" or "scan dump" output. Each BSS
+// starts at column 0 and its fields are indented:
+//
+//	BSS 11:22:33:44:55:66(on wlan0) -- associated
+//		freq: 2437
+//		signal: -45.00 dBm
+//		last seen: 120 ms ago
+//		SSID: HomeNet
+//		capability: ESS Privacy ShortSlotTime (0x0411)
+//		RSN:	 * Version: 1
+//			 * Authentication suites: PSK SAE
+func parseIwScan(output string) []*pb.WifiNetwork {
+	var networks []*pb.WifiNetwork
+	var current *pb.WifiNetwork
+	var sec wifiSecurity
+	section := ""
+
+	finish := func() {
+		if current != nil {
+			current.Security = sec.String()
+			networks = append(networks, current)
+		}
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if rest, ok := strings.CutPrefix(line, "BSS "); ok {
+			finish()
+			bssid, _, _ := strings.Cut(rest, "(")
+			current = &pb.WifiNetwork{
+				Bssid:     strings.ToLower(strings.TrimSpace(bssid)),
+				Connected: strings.HasSuffix(strings.TrimSpace(rest), "-- associated"),
+			}
+			sec = wifiSecurity{}
+			section = ""
+			continue
+		}
+		if current == nil || strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// Fields of the BSS are indented by one tab; deeper lines belong to
+		// the last section (RSN, WPA, HT operation, ...)
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "\t\t") {
+			key, value, _ := strings.Cut(trimmed, ":")
+			section = key
+			value = strings.TrimSpace(value)
+			switch key {
+			case "freq":
+				current.Frequency = leadingFloat(value) / 1000
+				current.Channel = wifiChannel(int(leadingFloat(value)))
+			case "signal":
+				current.SignalStrength = int32(leadingFloat(value))
+				current.SignalQuality = signalQuality(current.SignalStrength)
+			case "last seen":
+				// Newer iw also prints "last seen: 1362.474s [boottime]"
+				if strings.HasSuffix(value, "ms ago") {
+					current.LastSeen = int64(leadingFloat(value))
+				}
+			case "SSID":
+				// Hidden networks send an empty SSID or one of NUL bytes
+				if ssid := unescapeIwSSID(value); strings.Trim(ssid, "\x00") != "" {
+					current.Ssid = ssid
+				}
+			case "capability":
+				sec.privacy = strings.Contains(value, "Privacy")
+			case "RSN":
+				sec.rsn = true
+			case "WPA":
+				sec.wpa = true
+			}
+			// Section headers may carry their first item on the same line
+			trimmed = value
+		}
+		if suites, ok := strings.CutPrefix(strings.TrimSpace(strings.TrimPrefix(trimmed, "*")), "Authentication suites:"); ok && section == "RSN" {
+			sec.addSuites(suites)
+		}
+	}
+	finish()
+
+	sort.SliceStable(networks, func(i, j int) bool {
+		return networks[i].SignalStrength > networks[j].SignalStrength
+	})
+	return networks
+}
+
+// wifiSecurity collects the security elements advertised by a BSS
+type wifiSecurity struct {
+	privacy, wpa, rsn         bool
+	psk, sae, owe, enterprise bool
+}
+
+func (s *wifiSecurity) addSuites(suites string) {
+	for _, suite := range strings.Fields(suites) {
+		suite = strings.TrimSuffix(strings.TrimPrefix(suite, "FT/"), "/SHA-256")
+		switch suite {
+		case "PSK":
+			s.psk = true
+		case "SAE":
+			s.sae = true
+		case "OWE":
+			s.owe = true
+		case "802.1X", "802.1X/SUITE-B", "802.1X/SUITE-B-192":
+			s.enterprise = true
+		}
+	}
+}
+
+func (s wifiSecurity) String() string {
+	switch {
+	case s.rsn && s.enterprise:
+		return "WPA2-Enterprise"
+	case s.rsn && s.sae && s.psk:
+		return "WPA2/WPA3"
+	case s.rsn && s.sae:
+		return "WPA3"
+	case s.rsn && s.owe:
+		return "OWE"
+	case s.rsn && s.wpa:
+		return "WPA/WPA2"
+	case s.rsn:
+		return "WPA2"
+	case s.wpa:
+		return "WPA"
+	case s.privacy:
+		return "WEP"
+	}
+	return "Open"
+}
+
+// parseIwSurvey parses "iw dev <if> survey dump" into the percentage of time
+// each frequency (MHz) was busy. Frequencies without timing data are left out.
+func parseIwSurvey(output string) map[int]float64 {
+	busy := make(map[int]float64)
+	freq := 0
+	var active, busyTime float64
+	finish := func() {
+		if freq != 0 && active > 0 {
+			busy[freq] = min(busyTime/active*100, 100)
+		}
+	}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "frequency":
+			finish()
+			freq = int(leadingFloat(value))
+			active, busyTime = 0, 0
+		case "channel active time":
+			active = leadingFloat(value)
+		case "channel busy time":
+			busyTime = leadingFloat(value)
+		}
+	}
+	finish()
+	return busy
+}
+
+// wifiChannelStats summarizes how crowded each channel is from the networks
+// heard on it and the driver's survey of busy time. Channels are keyed by
+// frequency, as channel numbers repeat across bands.
+func wifiChannelStats(networks []*pb.WifiNetwork, survey map[int]float64) []*pb.WifiChannelStats {
+	byFreq := make(map[int]*pb.WifiChannelStats)
+	stats := func(freq int) *pb.WifiChannelStats {
+		c, ok := byFreq[freq]
+		if !ok {
+			c = &pb.WifiChannelStats{Channel: wifiChannel(freq), Frequency: float64(freq) / 1000, StrongestSignal: -100, Utilization: -1}
+			byFreq[freq] = c
+		}
+		return c
+	}
+	for _, n := range networks {
+		freq := int(math.Round(n.Frequency * 1000))
+		if wifiChannel(freq) == 0 {
+			continue
+		}
+		c := stats(freq)
+		c.Networks++
+		c.StrongestSignal = max(c.StrongestSignal, n.SignalStrength)
+	}
+	for freq, utilization := range survey {
+		if wifiChannel(freq) != 0 {
+			stats(freq).Utilization = utilization
+		}
+	}
+
+	channels := make([]*pb.WifiChannelStats, 0, len(byFreq))
+	for _, c := range byFreq {
+		if c.Networks == 0 {
+			c.StrongestSignal = 0
+		}
+		channels = append(channels, c)
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].Frequency < channels[j].Frequency
+	})
+	return channels
+}
+
+// wifiChannel converts a frequency in MHz to its 802.11 channel number
+func wifiChannel(freq int) int32 {
+	switch {
+	case freq == 2484:
+		return 14
+	case freq >= 2412 && freq <= 2472:
+		return int32((freq - 2407) / 5)
+	case freq == 5935:
+		return 2
+	case freq >= 5955 && freq <= 7115:
+		return int32((freq - 5950) / 5)
+	case freq >= 5000 && freq <= 5900:
+		return int32((freq - 5000) / 5)
+	}
+	return 0
+}
+
+// signalQuality maps dBm to a 0-100 percentage: -100 dBm or less is 0,
+// -50 dBm or more is 100
+func signalQuality(dBm int32) int32 {
+	return min(max(2*(dBm+100), 0), 100)
+}
+
+// unescapeIwSSID decodes the \xNN escapes iw uses for spaces at the ends and
+// for non-printable or non-ASCII bytes
+func unescapeIwSSID(s string) string {
+	if !strings.Contains(s, `\x`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) && s[i+1] == 'x' {
+			if v, err := strconv.ParseUint(s[i+2:i+4], 16, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// leadingFloat parses the number at the start of values like "-45.00 dBm"
+// or "390.0 MBit/s", returning 0 if there is none
+func leadingFloat(s string) float64 {
+	field, _, _ := strings.Cut(strings.TrimSpace(s), " ")
+	v, _ := strconv.ParseFloat(field, 64)
+	return v
+}
@@ -0,0 +1,452 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	pb "pi_agent/proto"
+)
+
+// Timing of WiFi changes
+const (
+	defaultWifiRollback = 60 * time.Second
+	maxWifiRollback     = 10 * time.Minute
+	wifiApplyDelay      = time.Second // Lets the RPC reply go out before the link drops
+	wifiCheckInterval   = 2 * time.Second
+	wifiConnectTimeout  = 45 * time.Second
+	wifiRestoreDelay    = 10 * time.Second // Lets the WiFi service start after a reboot
+	procNetRoute        = "/proc/net/route"
+)
+
+// pendingWifiChangeFile in the data directory holds a change waiting for
+// connectivity
+const pendingWifiChangeFile = "wifi-change-pending.json"
+
+// wifiManager abstracts the service that owns the WiFi configuration
+type wifiManager interface {
+	// Name returns the backend name (NetworkManager, wpa_supplicant)
+	Name() string
+
+	// Interface returns the wireless interface the backend manages
+	Interface(ctx context.Context) (string, error)
+
+	// Saved returns the configured networks
+	Saved(ctx context.Context) ([]*pb.SavedWifiNetwork, error)
+
+	// Add saves a network, replacing the settings of one with the same SSID
+	Add(ctx context.Context, req *pb.AddWifiNetworkRequest) error
+
+	// Connect activates a saved network and waits until it is associated
+	Connect(ctx context.Context, ssid string) error
+
+	// SetEnabled allows or prevents automatic connection to a network and
+	// disconnects from it when disabled
+	SetEnabled(ctx context.Context, ssid string, enabled bool) error
+
+	// Forget removes a saved network
+	Forget(ctx context.Context, ssid string) error
+
+	// SetPriority sets the autoconnect priority of a saved network
+	SetPriority(ctx context.Context, ssid string, priority int32) error
+}
+
+// newWifiManager returns the backend in use, preferring NetworkManager. It is
+// detected on every call since either service may start after the agent.
+func newWifiManager() (wifiManager, error) {
+	if nm := (nmWifiManager{}); nm.running() {
+		return nm, nil
+	}
+	if iface, err := wifiInterface(""); err == nil {
+		if wpa := (wpaWifiManager{iface: iface}); wpa.running() {
+			return wpa, nil
+		}
+	}
+	return nil, fmt.Errorf("neither NetworkManager nor wpa_supplicant is managing WiFi")
+}
+
+// validateWifiNetwork checks an AddWifiNetworkRequest and fills in the
+// default security
+func validateWifiNetwork(req *pb.AddWifiNetworkRequest) error {
+	if len(req.Ssid) == 0 || len(req.Ssid) > 32 {
+		return fmt.Errorf("SSID must be 1 to 32 bytes")
+	}
+	if strings.ContainsFunc(req.Ssid+req.Password, unicode.IsControl) {
+		return fmt.Errorf("SSID and password must not contain control characters")
+	}
+
+	req.Security = strings.ToUpper(strings.TrimSpace(req.Security))
+	if req.Security == "" {
+		req.Security = "OPEN"
+		if req.Password != "" {
+			req.Security = "WPA2"
+		}
+	}
+	switch req.Security {
+	case "OPEN":
+		req.Security = "Open"
+		if req.Password != "" {
+			return fmt.Errorf("open networks have no password")
+		}
+	case "WPA2":
+		// A passphrase, or the 256-bit key itself in hex
+		if _, err := hex.DecodeString(req.Password); len(req.Password) == 64 && err != nil {
+			return fmt.Errorf("a 64 character WPA2 key must be hex")
+		}
+		if len(req.Password) < 8 || len(req.Password) > 64 {
+			return fmt.Errorf("WPA2 passwords are 8 to 63 characters")
+		}
+	case "WPA3":
+		if len(req.Password) < 8 {
+			return fmt.Errorf("WPA3 passwords are at least 8 characters")
+		}
+	default:
+		return fmt.Errorf("unsupported security %q; use WPA2, WPA3 or Open", req.Security)
+	}
+	return nil
+}
+
+// savedWifiNetwork finds a saved network by SSID
+func savedWifiNetwork(ctx context.Context, m wifiManager, ssid string) (*pb.SavedWifiNetwork, error) {
+	networks, err := m.Saved(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range networks {
+		if n.Ssid == ssid {
+			return n, nil
+		}
+	}
+	return nil, fmt.Errorf("no saved network named %q", ssid)
+}
+
+// activeWifiNetwork returns the SSID of the connected network, "" if none
+func activeWifiNetwork(ctx context.Context, m wifiManager) (string, error) {
+	networks, err := m.Saved(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, n := range networks {
+		if n.Active {
+			return n.Ssid, nil
+		}
+	}
+	return "", nil
+}
+
+// wifiChange is a change that may cut the agent off the network: connecting
+// to Connect or, to forget the active network, disabling Forget so another
+// one takes over. Once Interface has connectivity again a forgotten network
+// is deleted; otherwise Restore is enabled again and reconnected.
+type wifiChange struct {
+	Description string `json:"description"`
+	Backend     string `json:"backend"`
+	Interface   string `json:"interface"`
+	Connect     string `json:"connect,omitempty"`
+	Forget      string `json:"forget,omitempty"`
+	Restore     string `json:"restore,omitempty"`
+	RevertAt    int64  `json:"revert_at,omitempty"`
+}
+
+func (change wifiChange) apply(ctx context.Context, m wifiManager) error {
+	if change.Forget != "" {
+		return m.SetEnabled(ctx, change.Forget, false)
+	}
+	return m.Connect(ctx, change.Connect)
+}
+
+func (change wifiChange) commit(ctx context.Context, m wifiManager) error {
+	if change.Forget != "" {
+		return m.Forget(ctx, change.Forget)
+	}
+	return nil
+}
+
+func (change wifiChange) revert(ctx context.Context, m wifiManager) error {
+	if change.Restore == change.Forget {
+		if err := m.SetEnabled(ctx, change.Restore, true); err != nil {
+			return err
+		}
+	}
+	return m.Connect(ctx, change.Restore)
+}
+
+// wifiChangeManager returns the backend that made change
+func wifiChangeManager(change wifiChange) (wifiManager, error) {
+	switch change.Backend {
+	case nmWifiManager{}.Name():
+		return nmWifiManager{}, nil
+	case wpaWifiManager{}.Name():
+		return wpaWifiManager{iface: change.Interface}, nil
+	}
+	return nil, fmt.Errorf("unknown backend %q", change.Backend)
+}
+
+// wifiController applies one WiFi change at a time in the background and
+// keeps the outcome of the last one. A change waiting for connectivity is
+// saved in the data directory, so one left pending when the agent or the
+// system restarts is still finished or reverted.
+type wifiController struct {
+	mu         sync.Mutex
+	path       string
+	pending    bool
+	lastChange string
+	lastTime   time.Time
+}
+
+func newWifiController(dataDir string) *wifiController {
+	c := &wifiController{path: filepath.Join(dataDir, pendingWifiChangeFile)}
+	c.restore()
+	return c
+}
+
+// restore picks up the change a previous run left pending. It keeps its
+// deadline; one past it is reverted once the WiFi service had time to start.
+func (c *wifiController) restore() {
+	var change wifiChange
+	if err := readJSONFile(c.path, &change); err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Warning: failed to read pending WiFi change: %v", err)
+		}
+		return
+	}
+	manager, err := wifiChangeManager(change)
+	if err != nil {
+		log.Printf("Warning: cannot finish %s: %v", change.Description, err)
+		c.save(nil)
+		return
+	}
+
+	c.pending = true
+	log.Printf("WiFi change: %s is waiting for connectivity until %s", change.Description, time.Unix(change.RevertAt, 0).Format("15:04:05"))
+	go func() {
+		ctx := context.Background()
+		var err error
+		if timeout := time.Until(time.Unix(change.RevertAt, 0)); timeout > 0 {
+			err = waitWifiConnectivity(ctx, change.Interface, timeout)
+		} else {
+			time.Sleep(wifiRestoreDelay)
+			err = fmt.Errorf("not finished before the agent restarted")
+		}
+		c.complete(ctx, manager, change, err, true)
+	}()
+}
+
+// rollbackTimeout converts the request field: 0 means the default and a
+// negative value disables rollback
+func rollbackTimeout(seconds int32) time.Duration {
+	switch {
+	case seconds == 0:
+		return defaultWifiRollback
+	case seconds < 0:
+		return 0
+	}
+	return min(time.Duration(seconds)*time.Second, maxWifiRollback)
+}
+
+// start runs change through manager in the background unless another change
+// is pending
+func (c *wifiController) start(ctx context.Context, manager wifiManager, change wifiChange, rollback time.Duration) error {
+	iface, err := manager.Interface(ctx)
+	if err != nil {
+		return err
+	}
+	change.Backend = manager.Name()
+	change.Interface = iface
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pending {
+		return fmt.Errorf("another WiFi change is in progress")
+	}
+	c.pending = true
+	go c.run(manager, change, rollback)
+	return nil
+}
+
+func (c *wifiController) run(manager wifiManager, change wifiChange, rollback time.Duration) {
+	ctx := context.Background()
+	time.Sleep(wifiApplyDelay)
+	log.Printf("WiFi change: %s", change.Description)
+
+	if rollback > 0 {
+		// Saved before applying, since connecting alone may take
+		// wifiConnectTimeout
+		change.RevertAt = time.Now().Add(wifiConnectTimeout + rollback).Unix()
+		c.save(&change)
+	}
+	err := change.apply(ctx, manager)
+	if err == nil && rollback > 0 {
+		change.RevertAt = time.Now().Add(rollback).Unix()
+		c.save(&change)
+		err = waitWifiConnectivity(ctx, change.Interface, rollback)
+	}
+	c.complete(ctx, manager, change, err, rollback > 0)
+}
+
+// complete commits change when err is nil, meaning it was applied and has
+// connectivity, and reverts it otherwise. A network disabled to forget it is
+// always enabled again; switching back to the previous one needs rollback.
+func (c *wifiController) complete(ctx context.Context, manager wifiManager, change wifiChange, err error, rollback bool) {
+	if err == nil {
+		if err = change.commit(ctx, manager); err == nil {
+			c.finish(change.Description + " succeeded")
+			return
+		}
+	}
+	result := fmt.Sprintf("%s failed: %v", change.Description, err)
+	if change.Restore != "" && (rollback || change.Forget != "") {
+		if rerr := change.revert(ctx, manager); rerr != nil {
+			result += fmt.Sprintf("; rollback failed: %v", rerr)
+		} else {
+			result += "; rolled back"
+		}
+	}
+	c.finish(result)
+}
+
+func (c *wifiController) finish(result string) {
+	log.Printf("WiFi change: %s", result)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.save(nil)
+	c.pending = false
+	c.lastChange = result
+	c.lastTime = time.Now()
+}
+
+// save writes the pending change to the data directory, or removes the
+// file when change is nil
+func (c *wifiController) save(change *wifiChange) {
+	var err error
+	if change != nil {
+		err = writeJSONFile(c.path, change)
+	} else if err = os.Remove(c.path); os.IsNotExist(err) {
+		err = nil
+	}
+	if err != nil {
+		log.Printf("Warning: failed to save pending WiFi change: %v", err)
+	}
+}
+
+// status fills in the pending flag and last outcome
+func (c *wifiController) status(list *pb.SavedWifiNetworkList) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	list.ChangePending = c.pending
+	list.LastChange = c.lastChange
+	if !c.lastTime.IsZero() {
+		list.LastChangeTime = c.lastTime.Unix()
+	}
+}
+
+// waitWifiConnectivity polls until iface has connectivity, giving up after
+// timeout
+func waitWifiConnectivity(ctx context.Context, iface string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if wifiHasConnectivity(ctx, iface) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("no connectivity on %s for %s", iface, timeout)
+		}
+		time.Sleep(wifiCheckInterval)
+	}
+}
+
+// wifiHasConnectivity reports whether iface is associated, has an IPv4
+// address and, if it has a default route, its gateway answers a ping
+func wifiHasConnectivity(ctx context.Context, iface string) bool {
+	output, err := runIw(ctx, iwTimeout, "dev", iface, "link")
+	if err != nil || !parseIwLink(output).connected {
+		return false
+	}
+	netIface, err := net.InterfaceByName(iface)
+	if err != nil {
+		return false
+	}
+	addrs, err := netIface.Addrs()
+	if err != nil {
+		return false
+	}
+	hasIPv4 := false
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil {
+			hasIPv4 = true
+		}
+	}
+	if !hasIPv4 {
+		return false
+	}
+	gateway := defaultGateway(iface)
+	if gateway == nil {
+		return true
+	}
+	return pingOnce(gateway, iwTimeout)
+}
+
+// defaultGateway returns the IPv4 default gateway through iface, nil if
+// there is none
+func defaultGateway(iface string) net.IP {
+	f, err := os.Open(procNetRoute)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	// Iface Destination Gateway Flags ... with addresses in little-endian hex
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[0] != iface || fields[1] != "00000000" {
+			continue
+		}
+		raw, err := hex.DecodeString(fields[2])
+		if err != nil || len(raw) != 4 {
+			continue
+		}
+		gateway := make(net.IP, 4)
+		binary.BigEndian.PutUint32(gateway, binary.LittleEndian.Uint32(raw))
+		if !gateway.IsUnspecified() {
+			return gateway
+		}
+	}
+	return nil
+}
+
+// pingOnce sends a single echo request and waits for the reply
+func pingOnce(dst net.IP, timeout time.Duration) bool {
+	conn, err := listenICMP(dst)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(timeout)
+	if err := conn.sendEcho(dst, 1, 32); err != nil {
+		return false
+	}
+	for {
+		reply, err := conn.read(deadline)
+		if err != nil {
+			if netErr, ok := err.(net.Error); (ok && netErr.Timeout()) || time.Now().After(deadline) {
+				return false
+			}
+			continue
+		}
+		if conn.isEchoReply(reply, 1) && reply.from.Equal(dst) {
+			return true
+		}
+	}
+}
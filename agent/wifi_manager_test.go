@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+// fakeWifiManager records the calls a change makes and fails those listed
+// in errs
+type fakeWifiManager struct {
+	calls []string
+	errs  map[string]error
+}
+
+func (m *fakeWifiManager) call(name string) error {
+	m.calls = append(m.calls, name)
+	return m.errs[name]
+}
+
+func (m *fakeWifiManager) Name() string { return "fake" }
+func (m *fakeWifiManager) Interface(ctx context.Context) (string, error) {
+	return "wlan1", nil
+}
+func (m *fakeWifiManager) Saved(ctx context.Context) ([]*pb.SavedWifiNetwork, error) {
+	return nil, nil
+}
+func (m *fakeWifiManager) Add(ctx context.Context, req *pb.AddWifiNetworkRequest) error {
+	return m.call("add " + req.Ssid)
+}
+func (m *fakeWifiManager) Connect(ctx context.Context, ssid string) error {
+	return m.call("connect " + ssid)
+}
+func (m *fakeWifiManager) SetEnabled(ctx context.Context, ssid string, enabled bool) error {
+	if enabled {
+		return m.call("enable " + ssid)
+	}
+	return m.call("disable " + ssid)
+}
+func (m *fakeWifiManager) Forget(ctx context.Context, ssid string) error {
+	return m.call("forget " + ssid)
+}
+func (m *fakeWifiManager) SetPriority(ctx context.Context, ssid string, priority int32) error {
+	return m.call("priority " + ssid)
+}
+
+func TestWifiControllerComplete(t *testing.T) {
+	connect := wifiChange{Description: "Connect to Office", Connect: "Office", Restore: "Home"}
+	forget := wifiChange{Description: "Forget Home", Forget: "Home", Restore: "Home"}
+	errLost := errors.New("no connectivity")
+	errBusy := errors.New("busy")
+
+	tests := []struct {
+		name     string
+		change   wifiChange
+		err      error
+		rollback bool
+		errs     map[string]error
+		calls    []string
+		result   string
+	}{
+		{"connected", connect, nil, true, nil, nil, "Connect to Office succeeded"},
+		{"connection lost", connect, errLost, true, nil, []string{"connect Home"}, "Connect to Office failed: no connectivity; rolled back"},
+		{"no rollback", connect, errLost, false, nil, nil, "Connect to Office failed: no connectivity"},
+		{"no previous network", wifiChange{Description: "Connect to Office", Connect: "Office"}, errLost, true, nil, nil,
+			"Connect to Office failed: no connectivity"},
+		{"forgotten", forget, nil, true, nil, []string{"forget Home"}, "Forget Home succeeded"},
+		{"forget lost connectivity", forget, errLost, true, nil, []string{"enable Home", "connect Home"},
+			"Forget Home failed: no connectivity; rolled back"},
+		// The network must not stay disabled when deleting it fails
+		{"forget failed", forget, nil, false, map[string]error{"forget Home": errBusy},
+			[]string{"forget Home", "enable Home", "connect Home"}, "Forget Home failed: busy; rolled back"},
+		{"rollback failed", forget, errLost, true, map[string]error{"enable Home": errBusy}, []string{"enable Home"},
+			"Forget Home failed: no connectivity; rollback failed: busy"},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		path := filepath.Join(dir, pendingWifiChangeFile)
+		c := newWifiController(dir)
+		change := tt.change
+		c.pending = true
+		c.save(&change)
+
+		m := &fakeWifiManager{errs: tt.errs}
+		c.complete(context.Background(), m, tt.change, tt.err, tt.rollback)
+		if !reflect.DeepEqual(m.calls, tt.calls) {
+			t.Errorf("%s: calls %q, want %q", tt.name, m.calls, tt.calls)
+		}
+		list := &pb.SavedWifiNetworkList{}
+		c.status(list)
+		if list.ChangePending || list.LastChange != tt.result {
+			t.Errorf("%s: pending %v, result %q; want %q", tt.name, list.ChangePending, list.LastChange, tt.result)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s: %s still exists", tt.name, pendingWifiChangeFile)
+		}
+	}
+}
+
+func TestWifiControllerRestore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, pendingWifiChangeFile)
+	change := wifiChange{Description: "Connect to Office", Backend: "wpa_supplicant", Interface: "wlan1",
+		Connect: "Office", Restore: "Home", RevertAt: time.Now().Add(time.Hour).Unix()}
+	if err := writeJSONFile(path, change); err != nil {
+		t.Fatal(err)
+	}
+	c := newWifiController(dir)
+	list := &pb.SavedWifiNetworkList{}
+	c.status(list)
+	if !list.ChangePending {
+		t.Error("a saved change was not restored")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("a restored change is no longer saved: %v", err)
+	}
+
+	// A change from a backend this agent does not know is dropped
+	change.Backend = "iwd"
+	if err := writeJSONFile(path, change); err != nil {
+		t.Fatal(err)
+	}
+	c = newWifiController(dir)
+	c.status(list)
+	if list.ChangePending {
+		t.Error("a change from an unknown backend was restored")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("%s still exists", pendingWifiChangeFile)
+	}
+}
+
+func TestWifiControllerStart(t *testing.T) {
+	c := newWifiController(t.TempDir())
+	m := &fakeWifiManager{}
+	if err := c.start(context.Background(), m, wifiChange{Description: "Connect to Office", Connect: "Office"}, 0); err != nil {
+		t.Fatal(err)
+	}
+	err := c.start(context.Background(), m, wifiChange{Description: "Connect to Home", Connect: "Home"}, 0)
+	if err == nil || !strings.Contains(err.Error(), "in progress") {
+		t.Errorf("second change: error %v, want one in progress", err)
+	}
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	pb "pi_agent/proto"
+)
+
+// nmConnectionDir holds NetworkManager keyfiles. Connections are written
+// there directly and loaded with "nmcli connection load" so passwords never
+// appear on a command line.
+const nmConnectionDir = "/etc/NetworkManager/system-connections"
+
+// nmWifiManager manages WiFi through NetworkManager's nmcli
+type nmWifiManager struct{}
+
+// nmConnection is a WiFi connection profile
+type nmConnection struct {
+	uuid     string
+	filename string
+	network  *pb.SavedWifiNetwork
+}
+
+func (m nmWifiManager) Name() string {
+	return "NetworkManager"
+}
+
+// running reports whether NetworkManager is running and manages a WiFi device
+func (m nmWifiManager) running() bool {
+	if !commandExists("nmcli") {
+		return false
+	}
+	_, err := m.Interface(context.Background())
+	return err == nil
+}
+
+// Interface returns the first WiFi device NetworkManager manages
+func (m nmWifiManager) Interface(ctx context.Context) (string, error) {
+	output, err := runNmcli(ctx, "-t", "-f", "DEVICE,TYPE,STATE", "device")
+	if err != nil {
+		return "", err
+	}
+	for _, line := range splitLines(output) {
+		fields := splitNmcliFields(line)
+		if len(fields) == 3 && fields[1] == "wifi" && fields[2] != "unmanaged" {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("NetworkManager manages no WiFi device")
+}
+
+// runNmcli runs nmcli and returns its output, using nmcli's error message
+// (e.g. "Error: unknown connection 'x'.") when it fails
+func runNmcli(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "nmcli", args...)
+	cmd.Env = append(os.Environ(), "LANG=C")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s", strings.TrimPrefix(msg, "Error: "))
+		}
+		return "", err
+	}
+	return string(output), nil
+}
+
+// splitNmcliFields splits a line of terse nmcli output, in which ':' and '\'
+// inside values are escaped with '\'
+func splitNmcliFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line):
+			i++
+			field.WriteByte(line[i])
+		case line[i] == ':':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(line[i])
+		}
+	}
+	return append(fields, field.String())
+}
+
+// connections returns the WiFi client profiles; access point (hotspot)
+// profiles are left out
+func (m nmWifiManager) connections(ctx context.Context) ([]nmConnection, error) {
+	output, err := runNmcli(ctx, "-t", "-f", "NAME,UUID,TYPE,AUTOCONNECT,AUTOCONNECT-PRIORITY,ACTIVE,FILENAME", "connection", "show")
+	if err != nil {
+		return nil, err
+	}
+	var conns []nmConnection
+	for _, line := range splitLines(output) {
+		fields := splitNmcliFields(line)
+		if len(fields) != 7 || (fields[2] != "802-11-wireless" && fields[2] != "wifi") {
+			continue
+		}
+		priority, _ := strconv.Atoi(fields[4])
+		conn := nmConnection{
+			uuid:     fields[1],
+			filename: fields[6],
+			network: &pb.SavedWifiNetwork{
+				Id:       fields[1],
+				Enabled:  fields[3] == "yes",
+				Priority: int32(priority),
+				Active:   fields[5] == "yes",
+				Security: "Open",
+			},
+		}
+
+		details, err := runNmcli(ctx, "-t", "--escape", "no", "connection", "show", "uuid", conn.uuid)
+		if err != nil {
+			return nil, err
+		}
+		mode := ""
+		for _, line := range splitLines(details) {
+			key, value, _ := strings.Cut(line, ":")
+			switch key {
+			case "802-11-wireless.ssid":
+				conn.network.Ssid = value
+			case "802-11-wireless.hidden":
+				conn.network.Hidden = value == "yes"
+			case "802-11-wireless.mode":
+				mode = value
+			case "802-11-wireless-security.key-mgmt":
+				conn.network.Security = nmSecurityName(value)
+			}
+		}
+		if mode != "ap" {
+			conns = append(conns, conn)
+		}
+	}
+	return conns, nil
+}
+
+// nmSecurityName maps key-mgmt to the names used in scan results
+func nmSecurityName(keyMgmt string) string {
+	switch keyMgmt {
+	case "wpa-psk":
+		return "WPA2"
+	case "sae":
+		return "WPA3"
+	case "owe":
+		return "OWE"
+	case "wpa-eap", "wpa-eap-suite-b-192":
+		return "WPA2-Enterprise"
+	case "none", "ieee8021x":
+		return "WEP"
+	}
+	return "Open"
+}
+
+func (m nmWifiManager) connection(ctx context.Context, ssid string) (nmConnection, error) {
+	conns, err := m.connections(ctx)
+	if err != nil {
+		return nmConnection{}, err
+	}
+	for _, conn := range conns {
+		if conn.network.Ssid == ssid {
+			return conn, nil
+		}
+	}
+	return nmConnection{}, fmt.Errorf("no saved network named %q", ssid)
+}
+
+func (m nmWifiManager) Saved(ctx context.Context) ([]*pb.SavedWifiNetwork, error) {
+	conns, err := m.connections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	networks := make([]*pb.SavedWifiNetwork, len(conns))
+	for i, conn := range conns {
+		networks[i] = conn.network
+	}
+	return networks, nil
+}
+
+func (m nmWifiManager) Add(ctx context.Context, req *pb.AddWifiNetworkRequest) error {
+	uuid := newUUID()
+	path := filepath.Join(nmConnectionDir, keyfileName(req.Ssid)+".nmconnection")
+	if conn, err := m.connection(ctx, req.Ssid); err == nil {
+		// Rewrite the existing profile in place
+		if !strings.HasSuffix(conn.filename, ".nmconnection") {
+			return fmt.Errorf("%s is stored in %s, which is not a keyfile; forget it first", req.Ssid, conn.filename)
+		}
+		uuid, path = conn.uuid, conn.filename
+	} else if _, err := os.Stat(path); err == nil {
+		path = filepath.Join(nmConnectionDir, keyfileName(req.Ssid)+"-"+uuid[:8]+".nmconnection")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[connection]\nid=%s\nuuid=%s\ntype=wifi\nautoconnect-priority=%d\n\n", keyfileString(req.Ssid), uuid, req.Priority)
+	fmt.Fprintf(&b, "[wifi]\nmode=infrastructure\nssid=%s\n", keyfileSSID(req.Ssid))
+	if req.Hidden {
+		b.WriteString("hidden=true\n")
+	}
+	switch req.Security {
+	case "WPA2":
+		fmt.Fprintf(&b, "\n[wifi-security]\nkey-mgmt=wpa-psk\npsk=%s\n", keyfileString(req.Password))
+	case "WPA3":
+		fmt.Fprintf(&b, "\n[wifi-security]\nkey-mgmt=sae\npsk=%s\n", keyfileString(req.Password))
+	}
+	b.WriteString("\n[ipv4]\nmethod=auto\n\n[ipv6]\nmethod=auto\n")
+
+	if err := os.MkdirAll(nmConnectionDir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+		return err
+	}
+	if _, err := runNmcli(ctx, "connection", "load", path); err != nil {
+		return fmt.Errorf("NetworkManager rejected the profile: %v", err)
+	}
+	return nil
+}
+
+func (m nmWifiManager) Connect(ctx context.Context, ssid string) error {
+	conn, err := m.connection(ctx, ssid)
+	if err != nil {
+		return err
+	}
+	_, err = runNmcli(ctx, "--wait", strconv.Itoa(int(wifiConnectTimeout.Seconds())), "connection", "up", "uuid", conn.uuid)
+	return err
+}
+
+func (m nmWifiManager) SetEnabled(ctx context.Context, ssid string, enabled bool) error {
+	conn, err := m.connection(ctx, ssid)
+	if err != nil {
+		return err
+	}
+	autoconnect := "no"
+	if enabled {
+		autoconnect = "yes"
+	}
+	if _, err := runNmcli(ctx, "connection", "modify", "uuid", conn.uuid, "connection.autoconnect", autoconnect); err != nil {
+		return err
+	}
+	if !enabled && conn.network.Active {
+		_, err = runNmcli(ctx, "connection", "down", "uuid", conn.uuid)
+	}
+	return err
+}
+
+func (m nmWifiManager) Forget(ctx context.Context, ssid string) error {
+	conn, err := m.connection(ctx, ssid)
+	if err != nil {
+		return err
+	}
+	_, err = runNmcli(ctx, "connection", "delete", "uuid", conn.uuid)
+	return err
+}
+
+func (m nmWifiManager) SetPriority(ctx context.Context, ssid string, priority int32) error {
+	conn, err := m.connection(ctx, ssid)
+	if err != nil {
+		return err
+	}
+	_, err = runNmcli(ctx, "connection", "modify", "uuid", conn.uuid, "connection.autoconnect-priority", strconv.Itoa(int(priority)))
+	return err
+}
+
+// keyfileString escapes a keyfile string value
+func keyfileString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	if strings.HasPrefix(s, " ") {
+		s = `\s` + s[1:]
+	}
+	return s
+}
+
+// keyfileSSID writes plain SSIDs as text and anything that could be
+// misread (list separators, escapes, spaces at the ends) as a byte list
+func keyfileSSID(ssid string) string {
+	if !strings.ContainsAny(ssid, `;\`) && strings.TrimSpace(ssid) == ssid {
+		return ssid
+	}
+	var b strings.Builder
+	for i := 0; i < len(ssid); i++ {
+		fmt.Fprintf(&b, "%d;", ssid[i])
+	}
+	return b.String()
+}
+
+// keyfileName derives a file name from an SSID
+func keyfileName(ssid string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, ssid)
+	if name = strings.TrimLeft(name, "."); name == "" {
+		return "wifi"
+	}
+	return name
+}
+
+// newUUID returns a random (version 4) UUID
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
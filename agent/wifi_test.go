@@ -0,0 +1,135 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "pi_agent/proto"
+)
+
+func TestParseIwLink(t *testing.T) {
+	got := parseIwLink(string(fixture(t, "iw-link")))
+	want := wifiLink{
+		connected: true,
+		bssid:     "3c:37:86:5e:a1:10",
+		ssid:      "HomeNet",
+		freq:      5180,
+		signal:    -52,
+		rxRate:    390,
+		txRate:    433.3,
+	}
+	if got != want {
+		t.Errorf("link = %+v, want %+v", got, want)
+	}
+	if got := parseIwLink("Not connected.\n"); got != (wifiLink{}) {
+		t.Errorf("not connected: link = %+v, want the zero value", got)
+	}
+}
+
+// The scan dump has a WPA2/WPA3 transition network on both bands, a hidden
+// network with an empty SSID and one with a NUL-filled SSID
+func TestParseIwScan(t *testing.T) {
+	want := []*pb.WifiNetwork{
+		{Ssid: "HomeNet", Bssid: "3c:37:86:5e:a1:0f", SignalStrength: -41, SignalQuality: 100,
+			Frequency: 2.437, Security: "WPA2/WPA3", Channel: 6, LastSeen: 600},
+		{Ssid: "HomeNet", Bssid: "3c:37:86:5e:a1:10", SignalStrength: -52, SignalQuality: 96,
+			Frequency: 5.18, Security: "WPA2/WPA3", Channel: 36, Connected: true, LastSeen: 36},
+		{Bssid: "a4:2b:b0:11:22:33", SignalStrength: -67, SignalQuality: 66,
+			Frequency: 2.437, Security: "WPA2", Channel: 6, LastSeen: 2408},
+		{Bssid: "c8:3a:35:c0:ff:ee", SignalStrength: -71, SignalQuality: 58,
+			Frequency: 2.462, Security: "WPA3", Channel: 11, LastSeen: 990},
+		{Ssid: "Office Guest\u00e9", Bssid: "00:1d:aa:40:50:60", SignalStrength: -78, SignalQuality: 44,
+			Frequency: 5.5, Security: "WPA2-Enterprise", Channel: 100, LastSeen: 3121},
+		{Ssid: "OldRouter", Bssid: "00:1d:aa:40:50:61", SignalStrength: -83, SignalQuality: 34,
+			Frequency: 2.412, Security: "WPA/WPA2", Channel: 1, LastSeen: 3120},
+		{Ssid: "CafeFreeWiFi", Bssid: "02:0f:b5:aa:bb:cc", SignalStrength: -88, SignalQuality: 24,
+			Frequency: 2.412, Security: "Open", Channel: 1, LastSeen: 4890},
+	}
+	got := parseIwScan(string(fixture(t, "iw-scan-dump")))
+	if len(got) != len(want) {
+		t.Fatalf("got %d networks, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("network %d:\n got %v\nwant %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseIwSurvey(t *testing.T) {
+	got := parseIwSurvey(string(fixture(t, "iw-survey-dump")))
+	want := map[int]float64{
+		2412: 28.79,
+		2437: 50,
+		5180: 12,
+	}
+	if len(got) != len(want) {
+		t.Errorf("survey = %v, want %v", got, want)
+	}
+	for freq, busy := range want {
+		if math.Abs(got[freq]-busy) > 0.01 {
+			t.Errorf("%d MHz busy = %v, want %v", freq, got[freq], busy)
+		}
+	}
+}
+
+func TestWifiChannelStats(t *testing.T) {
+	networks := parseIwScan(string(fixture(t, "iw-scan-dump")))
+	survey := parseIwSurvey(string(fixture(t, "iw-survey-dump")))
+
+	want := []struct {
+		channel, networks, strongest int32
+		utilization                  float64
+	}{
+		{1, 2, -83, survey[2412]},
+		{6, 2, -41, 50},
+		{11, 1, -71, -1},
+		{36, 1, -52, survey[5180]},
+		{100, 1, -78, -1},
+	}
+	got := wifiChannelStats(networks, survey)
+	if len(got) != len(want) {
+		t.Fatalf("got %d channels, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		c := got[i]
+		if c.Channel != w.channel || c.Networks != w.networks || c.StrongestSignal != w.strongest || c.Utilization != w.utilization {
+			t.Errorf("channel %d: got %v, want %+v", w.channel, c, w)
+		}
+	}
+}
+
+// Channel numbers repeat across bands: 2.4 GHz channel 1 and 5 with 6 GHz
+// channel 1 and 5, and 5 GHz channel 149 with 6 GHz channel 149
+func TestWifiChannelStatsAcrossBands(t *testing.T) {
+	networks := []*pb.WifiNetwork{
+		{Frequency: 2.412, Channel: 1, SignalStrength: -60},
+		{Frequency: 5.955, Channel: 1, SignalStrength: -70},
+		{Frequency: 2.432, Channel: 5, SignalStrength: -55},
+		{Frequency: 5.975, Channel: 5, SignalStrength: -45},
+		{Frequency: 5.745, Channel: 149, SignalStrength: -65},
+		{Frequency: 6.695, Channel: 149, SignalStrength: -75},
+		{Frequency: 6.695, Channel: 149, SignalStrength: -80},
+	}
+	survey := map[int]float64{2412: 20, 5955: 5, 5745: 30}
+
+	want := []*pb.WifiChannelStats{
+		{Channel: 1, Frequency: 2.412, Networks: 1, StrongestSignal: -60, Utilization: 20},
+		{Channel: 5, Frequency: 2.432, Networks: 1, StrongestSignal: -55, Utilization: -1},
+		{Channel: 149, Frequency: 5.745, Networks: 1, StrongestSignal: -65, Utilization: 30},
+		{Channel: 1, Frequency: 5.955, Networks: 1, StrongestSignal: -70, Utilization: 5},
+		{Channel: 5, Frequency: 5.975, Networks: 1, StrongestSignal: -45, Utilization: -1},
+		{Channel: 149, Frequency: 6.695, Networks: 2, StrongestSignal: -75, Utilization: -1},
+	}
+	got := wifiChannelStats(networks, survey)
+	if len(got) != len(want) {
+		t.Fatalf("got %d channels, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("channel %d:\n got %v\nwant %v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"context"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	pb "pi_agent/proto"
+)
+
+const (
+	wpaCtrlDir     = "/var/run/wpa_supplicant"
+	wpaCtrlTimeout = 5 * time.Second
+)
+
+// wpaCtrlSeq numbers the client sockets bound for control requests
+var wpaCtrlSeq atomic.Int64
+
+// wpaWifiManager manages WiFi through wpa_supplicant's control socket, the
+// interface wpa_cli uses. Changes are saved to wpa_supplicant.conf, which
+// needs update_config=1.
+type wpaWifiManager struct {
+	iface string
+}
+
+// wpaNetwork is a network block from LIST_NETWORKS
+type wpaNetwork struct {
+	id       string
+	ssid     string
+	current  bool
+	disabled bool
+}
+
+func (m wpaWifiManager) Name() string {
+	return "wpa_supplicant"
+}
+
+func (m wpaWifiManager) Interface(ctx context.Context) (string, error) {
+	return m.iface, nil
+}
+
+func (m wpaWifiManager) running() bool {
+	reply, err := m.request("PING")
+	return err == nil && reply == "PONG"
+}
+
+// request sends one command over the control socket and returns the reply.
+// Errors name only the command since arguments may hold a password.
+func (m wpaWifiManager) request(command string) (string, error) {
+	name, _, _ := strings.Cut(command, " ")
+	remote := &net.UnixAddr{Name: filepath.Join(wpaCtrlDir, m.iface), Net: "unixgram"}
+	local := &net.UnixAddr{
+		Name: filepath.Join(os.TempDir(), fmt.Sprintf("pi-agent-wpa-%d-%d", os.Getpid(), wpaCtrlSeq.Add(1))),
+		Net:  "unixgram",
+	}
+	conn, err := net.DialUnix("unixgram", local, remote)
+	if err != nil {
+		return "", fmt.Errorf("wpa_supplicant control socket: %v", err)
+	}
+	defer os.Remove(local.Name)
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(wpaCtrlTimeout))
+	if _, err := conn.Write([]byte(command)); err != nil {
+		return "", fmt.Errorf("wpa_supplicant %s: %v", name, err)
+	}
+	buf := make([]byte, 16384)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return "", fmt.Errorf("wpa_supplicant %s: %v", name, err)
+	}
+	reply := strings.TrimSpace(string(buf[:n]))
+	if reply == "FAIL" || strings.HasPrefix(reply, "UNKNOWN COMMAND") {
+		return "", fmt.Errorf("wpa_supplicant rejected %s", name)
+	}
+	return reply, nil
+}
+
+// requests sends commands in order, stopping at the first failure
+func (m wpaWifiManager) requests(commands ...string) error {
+	for _, command := range commands {
+		if _, err := m.request(command); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// save writes the configuration back to wpa_supplicant.conf
+func (m wpaWifiManager) save() error {
+	if _, err := m.request("SAVE_CONFIG"); err != nil {
+		return fmt.Errorf("wpa_supplicant could not save its configuration; it needs update_config=1")
+	}
+	return nil
+}
+
+// networks parses LIST_NETWORKS:
+//
+//	network id / ssid / bssid / flags
+//	0	HomeNet	any	[CURRENT]
+//	1	Office	any	[DISABLED]
+func (m wpaWifiManager) networks() ([]wpaNetwork, error) {
+	reply, err := m.request("LIST_NETWORKS")
+	if err != nil {
+		return nil, err
+	}
+	var networks []wpaNetwork
+	lines := splitLines(reply)
+	if len(lines) > 0 {
+		lines = lines[1:] // Header
+	}
+	for _, line := range lines {
+		// Flags are empty for enabled networks that are not in use
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			continue
+		}
+		flags := ""
+		if len(fields) > 3 {
+			flags = fields[3]
+		}
+		network := wpaNetwork{
+			id:       fields[0],
+			current:  strings.Contains(flags, "[CURRENT]"),
+			disabled: strings.Contains(flags, "[DISABLED]"),
+		}
+		// The listed SSID is escaped for display; the network block has
+		// the exact bytes
+		network.ssid = fields[1]
+		if raw, err := m.request("GET_NETWORK " + network.id + " ssid"); err == nil {
+			network.ssid = wpaSSID(raw)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// wpaSSID decodes an ssid value, which is either quoted text or hex
+func wpaSSID(value string) string {
+	if unquoted, ok := strings.CutPrefix(value, `"`); ok {
+		return strings.TrimSuffix(unquoted, `"`)
+	}
+	if raw, err := hex.DecodeString(value); err == nil {
+		return string(raw)
+	}
+	return value
+}
+
+func (m wpaWifiManager) network(ssid string) (wpaNetwork, error) {
+	networks, err := m.networks()
+	if err != nil {
+		return wpaNetwork{}, err
+	}
+	for _, network := range networks {
+		if network.ssid == ssid {
+			return network, nil
+		}
+	}
+	return wpaNetwork{}, fmt.Errorf("no saved network named %q", ssid)
+}
+
+func (m wpaWifiManager) Saved(ctx context.Context) ([]*pb.SavedWifiNetwork, error) {
+	networks, err := m.networks()
+	if err != nil {
+		return nil, err
+	}
+	saved := make([]*pb.SavedWifiNetwork, 0, len(networks))
+	for _, network := range networks {
+		s := &pb.SavedWifiNetwork{
+			Ssid:     network.ssid,
+			Id:       network.id,
+			Enabled:  !network.disabled,
+			Active:   network.current,
+			Security: "Open",
+		}
+		if value, err := m.request("GET_NETWORK " + network.id + " priority"); err == nil {
+			priority, _ := strconv.Atoi(value)
+			s.Priority = int32(priority)
+		}
+		if value, err := m.request("GET_NETWORK " + network.id + " scan_ssid"); err == nil {
+			s.Hidden = value == "1"
+		}
+		if value, err := m.request("GET_NETWORK " + network.id + " key_mgmt"); err == nil {
+			s.Security = wpaSecurityName(value)
+		}
+		saved = append(saved, s)
+	}
+	return saved, nil
+}
+
+// wpaSecurityName maps key_mgmt to the names used in scan results
+func wpaSecurityName(keyMgmt string) string {
+	fields := strings.Fields(keyMgmt)
+	has := func(name string) bool {
+		for _, f := range fields {
+			if f == name {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has("WPA-EAP"):
+		return "WPA2-Enterprise"
+	case has("SAE") && has("WPA-PSK"):
+		return "WPA2/WPA3"
+	case has("SAE"):
+		return "WPA3"
+	case has("WPA-PSK"):
+		return "WPA2"
+	case has("OWE"):
+		return "OWE"
+	}
+	return "Open"
+}
+
+func (m wpaWifiManager) Add(ctx context.Context, req *pb.AddWifiNetworkRequest) error {
+	id, added := "", false
+	if network, err := m.network(req.Ssid); err == nil {
+		id = network.id
+	} else {
+		reply, err := m.request("ADD_NETWORK")
+		if err != nil {
+			return err
+		}
+		id, added = reply, true
+	}
+
+	set := func(field, value string) string {
+		return fmt.Sprintf("SET_NETWORK %s %s %s", id, field, value)
+	}
+	scanSSID := "0"
+	if req.Hidden {
+		scanSSID = "1"
+	}
+	commands := []string{
+		set("ssid", hex.EncodeToString([]byte(req.Ssid))),
+		set("scan_ssid", scanSSID),
+		set("priority", strconv.Itoa(int(req.Priority))),
+	}
+	switch req.Security {
+	case "WPA2":
+		// Store the derived key rather than the passphrase
+		psk := req.Password
+		if len(psk) != 64 {
+			key, err := pbkdf2.Key(sha1.New, req.Password, []byte(req.Ssid), 4096, 32)
+			if err != nil {
+				return err
+			}
+			psk = hex.EncodeToString(key)
+		}
+		commands = append(commands, set("key_mgmt", "WPA-PSK"), set("psk", psk))
+	case "WPA3":
+		commands = append(commands, set("key_mgmt", "SAE"), set("sae_password", `"`+req.Password+`"`), set("ieee80211w", "2"))
+	default:
+		commands = append(commands, set("key_mgmt", "NONE"))
+	}
+	commands = append(commands, "ENABLE_NETWORK "+id)
+	if err := m.requests(commands...); err != nil {
+		if added {
+			m.request("REMOVE_NETWORK " + id)
+		}
+		return err
+	}
+	return m.save()
+}
+
+// Connect selects the network, which disables the others while it
+// associates, then enables those that were enabled before
+func (m wpaWifiManager) Connect(ctx context.Context, ssid string) error {
+	networks, err := m.networks()
+	if err != nil {
+		return err
+	}
+	var target *wpaNetwork
+	for i := range networks {
+		if networks[i].ssid == ssid {
+			target = &networks[i]
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("no saved network named %q", ssid)
+	}
+	if _, err := m.request("SELECT_NETWORK " + target.id); err != nil {
+		return err
+	}
+
+	err = m.waitAssociated(ctx, target.id)
+	for _, network := range networks {
+		if network.id != target.id && !network.disabled {
+			m.request("ENABLE_NETWORK " + network.id)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return m.save()
+}
+
+// waitAssociated polls STATUS until the network with id is connected
+func (m wpaWifiManager) waitAssociated(ctx context.Context, id string) error {
+	deadline := time.Now().Add(wifiConnectTimeout)
+	for {
+		status, err := m.request("STATUS")
+		if err != nil {
+			return err
+		}
+		values := make(map[string]string)
+		for _, line := range splitLines(status) {
+			if key, value, ok := strings.Cut(line, "="); ok {
+				values[key] = value
+			}
+		}
+		if values["wpa_state"] == "COMPLETED" && values["id"] == id {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("not associated after %s (state %s)", wifiConnectTimeout, values["wpa_state"])
+		}
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m wpaWifiManager) SetEnabled(ctx context.Context, ssid string, enabled bool) error {
+	network, err := m.network(ssid)
+	if err != nil {
+		return err
+	}
+	command := "DISABLE_NETWORK "
+	if enabled {
+		command = "ENABLE_NETWORK "
+	}
+	if _, err := m.request(command + network.id); err != nil {
+		return err
+	}
+	return m.save()
+}
+
+func (m wpaWifiManager) Forget(ctx context.Context, ssid string) error {
+	network, err := m.network(ssid)
+	if err != nil {
+		return err
+	}
+	if _, err := m.request("REMOVE_NETWORK " + network.id); err != nil {
+		return err
+	}
+	return m.save()
+}
+
+func (m wpaWifiManager) SetPriority(ctx context.Context, ssid string, priority int32) error {
+	network, err := m.network(ssid)
+	if err != nil {
+		return err
+	}
+	if _, err := m.request(fmt.Sprintf("SET_NETWORK %s priority %d", network.id, priority)); err != nil {
+		return err
+	}
+	return m.save()
+}
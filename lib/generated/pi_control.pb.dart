@@ -3912,11 +3912,15 @@ class SpeedTestRequest extends $pb.GeneratedMessage {
     $core.bool? testDownload,
     $core.bool? testUpload,
     $core.int? duration,
+    $core.String? server,
+    $core.int? parallel,
   }) {
     final result = create();
     if (testDownload != null) result.testDownload = testDownload;
     if (testUpload != null) result.testUpload = testUpload;
     if (duration != null) result.duration = duration;
+    if (server != null) result.server = server;
+    if (parallel != null) result.parallel = parallel;
     return result;
   }
 
@@ -3936,6 +3940,8 @@ class SpeedTestRequest extends $pb.GeneratedMessage {
     ..aOB(1, _omitFieldNames ? '' : 'testDownload')
     ..aOB(2, _omitFieldNames ? '' : 'testUpload')
     ..aI(3, _omitFieldNames ? '' : 'duration')
+    ..aOS(4, _omitFieldNames ? '' : 'server')
+    ..aI(5, _omitFieldNames ? '' : 'parallel')
     ..hasRequiredFields = false;
 
   @$core.Deprecated('See https://github.com/google/protobuf.dart/issues/998.')
@@ -3983,6 +3989,24 @@ class SpeedTestRequest extends $pb.GeneratedMessage {
   $core.bool hasDuration() => $_has(2);
   @$pb.TagNumber(3)
   void clearDuration() => $_clearField(3);
+
+  @$pb.TagNumber(4)
+  $core.String get server => $_getSZ(3);
+  @$pb.TagNumber(4)
+  set server($core.String value) => $_setString(3, value);
+  @$pb.TagNumber(4)
+  $core.bool hasServer() => $_has(3);
+  @$pb.TagNumber(4)
+  void clearServer() => $_clearField(4);
+
+  @$pb.TagNumber(5)
+  $core.int get parallel => $_getIZ(4);
+  @$pb.TagNumber(5)
+  set parallel($core.int value) => $_setSignedInt32(4, value);
+  @$pb.TagNumber(5)
+  $core.bool hasParallel() => $_has(4);
+  @$pb.TagNumber(5)
+  void clearParallel() => $_clearField(5);
 }
 
 /// Speed test response (streamed)
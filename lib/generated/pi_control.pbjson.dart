@@ -835,6 +835,8 @@ const SpeedTestRequest$json = {
     {'1': 'test_download', '3': 1, '4': 1, '5': 8, '10': 'testDownload'},
     {'1': 'test_upload', '3': 2, '4': 1, '5': 8, '10': 'testUpload'},
     {'1': 'duration', '3': 3, '4': 1, '5': 5, '10': 'duration'},
+    {'1': 'server', '3': 4, '4': 1, '5': 9, '10': 'server'},
+    {'1': 'parallel', '3': 5, '4': 1, '5': 5, '10': 'parallel'},
   ],
 };
 
@@ -842,7 +844,8 @@ const SpeedTestRequest$json = {
 final $typed_data.Uint8List speedTestRequestDescriptor = $convert.base64Decode(
     'ChBTcGVlZFRlc3RSZXF1ZXN0EiMKDXRlc3RfZG93bmxvYWQYASABKAhSDHRlc3REb3dubG9hZB'
     'IfCgt0ZXN0X3VwbG9hZBgCIAEoCFIKdGVzdFVwbG9hZBIaCghkdXJhdGlvbhgDIAEoBVIIZHVy'
-    'YXRpb24=');
+    'YXRpb24SFgoGc2VydmVyGAQgASgJUgZzZXJ2ZXISGgoIcGFyYWxsZWwYBSABKAVSCHBhcmFsbG'
+    'Vs');
 
 @$core.Deprecated('Use speedTestResponseDescriptor instead')
 const SpeedTestResponse$json = {
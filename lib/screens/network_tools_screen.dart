@@ -164,7 +164,7 @@ class _PingTabState extends ConsumerState<_PingTab> {
   Widget build(BuildContext context) {
     final statsResponse = _pingResults
         .where((r) => r.hasStatistics())
-        .firstOrNull;
+        .lastOrNull;
     final stats = statsResponse?.statistics;
 
     return SingleChildScrollView(
@@ -1115,6 +1115,7 @@ class _SpeedTestTab extends ConsumerStatefulWidget {
 }
 
 class _SpeedTestTabState extends ConsumerState<_SpeedTestTab> {
+  final _serverController = TextEditingController();
   bool _testDownload = true;
   bool _testUpload = true;
   int _duration = 10;
@@ -1129,6 +1130,12 @@ class _SpeedTestTabState extends ConsumerState<_SpeedTestTab> {
   final List<double> _uploadHistory = [];
   String? _error;
 
+  @override
+  void dispose() {
+    _serverController.dispose();
+    super.dispose();
+  }
+
   Future<void> _startSpeedTest() async {
     setState(() {
       _isTesting = true;
@@ -1150,6 +1157,8 @@ class _SpeedTestTabState extends ConsumerState<_SpeedTestTab> {
         testDownload: _testDownload,
         testUpload: _testUpload,
         duration: _duration,
+        // Empty lets the agent use its default server
+        server: _serverController.text.trim(),
       );
 
       final stream = grpcService.testNetworkSpeed(request);
@@ -1217,6 +1226,25 @@ class _SpeedTestTabState extends ConsumerState<_SpeedTestTab> {
                   style: Theme.of(context).textTheme.titleMedium,
                 ),
                 const Gap(12),
+                TextField(
+                  controller: _serverController,
+                  decoration: InputDecoration(
+                    hintText:
+                        'iperf3 host or http(s) URL (default: speed.cloudflare.com)',
+                    prefixIcon: const Icon(
+                      Icons.dns,
+                      color: AppTheme.primaryIndigo,
+                    ),
+                    filled: true,
+                    fillColor: AppTheme.glassLight,
+                    border: OutlineInputBorder(
+                      borderRadius: BorderRadius.circular(12),
+                      borderSide: const BorderSide(color: AppTheme.glassBorder),
+                    ),
+                  ),
+                  enabled: !_isTesting,
+                ),
+                const Gap(12),
                 // Test Type Selection
                 Column(
                   crossAxisAlignment: CrossAxisAlignment.start,